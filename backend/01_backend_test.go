@@ -61,3 +61,79 @@ func TestNotify(t *testing.T) {
 			err.Error())
 	}
 } // func TestNotify(t *testing.T)
+
+// TestNotifyPending checks the bookkeeping notify does before it posts
+// anything, which does not need a session bus.
+func TestNotifyPending(t *testing.T) {
+	if back == nil {
+		t.SkipNow()
+	}
+
+	var (
+		err     error
+		db      *database.Database
+		pending []objects.Notification
+		rem     = &objects.Reminder{
+			Title:     "Pending",
+			Timestamp: time.Now().Add(-time.Hour).Truncate(time.Minute),
+			UUID:      common.GetUUID(),
+		}
+	)
+
+	db = back.pool.Get()
+	defer back.pool.Put(db)
+
+	if err = db.ReminderAdd(rem); err != nil {
+		t.Fatalf("Cannot add Reminder: %s", err.Error())
+	} else if pending, err = back.pendingNotifications(db, rem); err != nil {
+		t.Fatalf("Cannot fetch pending Notifications: %s", err.Error())
+	} else if pending, err = back.addNext(db, rem, pending); err != nil {
+		t.Fatalf("Cannot add next Notification: %s", err.Error())
+	} else if pending, err = back.addPrev(db, rem, pending); err != nil {
+		t.Fatalf("Cannot add previous Notification: %s", err.Error())
+	} else if len(pending) != 1 {
+		t.Errorf("Expected 1 pending Notification, got %d", len(pending))
+	} else if !pending[0].Timestamp.Equal(rem.Timestamp) {
+		t.Errorf("Notification is due at %s, expected %s",
+			pending[0].Timestamp.Format(common.TimestampFormat),
+			rem.Timestamp.Format(common.TimestampFormat))
+	}
+} // func TestNotifyPending(t *testing.T)
+
+func TestEscalationStep(t *testing.T) {
+	type testCase struct {
+		esc       objects.Escalation
+		n         objects.Notification
+		onDisplay bool
+		step      int
+		post      bool
+	}
+
+	var (
+		now   = time.Now().Truncate(time.Minute)
+		esc   = objects.Escalation{Interval: 300}
+		cases = []testCase{
+			{onDisplay: true},
+			{post: true},
+			{esc: esc, n: objects.Notification{Displayed: now.Add(-time.Minute)}, onDisplay: true},
+			{esc: esc, n: objects.Notification{Displayed: now.Add(-time.Hour), Step: 1}, onDisplay: true, step: 2, post: true},
+			{esc: esc, onDisplay: true, post: true},
+		}
+	)
+
+	for i, c := range cases {
+		var (
+			r          = objects.Reminder{Escalation: c.esc}
+			step, post = escalationStep(&r, &c.n, c.onDisplay, now)
+		)
+
+		if step != c.step || post != c.post {
+			t.Errorf("Case %d: Expected step %d (post %t), got step %d (post %t)",
+				i,
+				c.step,
+				c.post,
+				step,
+				post)
+		}
+	}
+} // func TestEscalationStep(t *testing.T)
@@ -0,0 +1,190 @@
+// /home/krylon/go/src/github.com/blicero/theseus/backend/02_sync_test.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 17. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <>
+
+package backend
+
+import (
+	"testing"
+	"time"
+
+	"github.com/blicero/theseus/common"
+	"github.com/blicero/theseus/database"
+	"github.com/blicero/theseus/objects"
+	"github.com/blicero/theseus/objects/repeat"
+)
+
+func TestReminderMergeTombstone(t *testing.T) {
+	if back == nil {
+		t.SkipNow()
+	}
+
+	var (
+		err   error
+		db    *database.Database
+		r     *objects.Reminder
+		tomb  *objects.Tombstone
+		now   = time.Now().Truncate(time.Second)
+		gone  = &objects.Reminder{Title: "Deleted on Peer", Timestamp: now.Add(time.Hour), UUID: common.GetUUID()}
+		kept  = &objects.Reminder{Title: "Changed after deletion", Timestamp: now.Add(time.Hour), UUID: common.GetUUID()}
+		tombs = []objects.Tombstone{
+			{UUID: gone.UUID, Deleted: now},
+			{UUID: kept.UUID, Deleted: now.Add(-time.Hour)},
+		}
+	)
+
+	db = back.pool.Get()
+	defer back.pool.Put(db)
+
+	for _, rem := range []*objects.Reminder{gone, kept} {
+		if err = db.ReminderAdd(rem); err != nil {
+			t.Fatalf("Cannot add Reminder %q: %s", rem.Title, err.Error())
+		} else if err = db.ReminderSetChanged(rem, now.Add(-time.Minute)); err != nil {
+			t.Fatalf("Cannot set change time of Reminder %q: %s", rem.Title, err.Error())
+		}
+	}
+
+	if err = back.reminderMerge("peer", nil, tombs); err != nil {
+		t.Fatalf("Cannot merge Tombstones: %s", err.Error())
+	} else if r, err = db.ReminderGetByUUID(gone.UUID); err != nil {
+		t.Fatalf("Cannot look up Reminder: %s", err.Error())
+	} else if r != nil {
+		t.Errorf("Reminder %q should have been deleted", r.Title)
+	} else if r, err = db.ReminderGetByUUID(kept.UUID); err != nil {
+		t.Fatalf("Cannot look up Reminder: %s", err.Error())
+	} else if r == nil {
+		t.Errorf("Reminder %q should have survived an older deletion", kept.Title)
+	} else if tomb, err = db.TombstoneGetByUUID(kept.UUID); err != nil {
+		t.Fatalf("Cannot look up Tombstone: %s", err.Error())
+	} else if tomb != nil {
+		t.Errorf("There should be no Tombstone for Reminder %q", kept.Title)
+	}
+
+	// A stale copy from another Peer must not bring the Reminder back ...
+	gone.Changed = now.Add(-time.Minute)
+	if err = back.reminderMerge("peer", []objects.Reminder{*gone}, nil); err != nil {
+		t.Fatalf("Cannot merge Reminder: %s", err.Error())
+	} else if r, err = db.ReminderGetByUUID(gone.UUID); err != nil {
+		t.Fatalf("Cannot look up Reminder: %s", err.Error())
+	} else if r != nil {
+		t.Errorf("Deleted Reminder %q came back", r.Title)
+	}
+
+	// ... but a change made after the deletion does.
+	gone.Changed = now.Add(time.Minute)
+	if err = back.reminderMerge("peer", []objects.Reminder{*gone}, nil); err != nil {
+		t.Fatalf("Cannot merge Reminder: %s", err.Error())
+	} else if r, err = db.ReminderGetByUUID(gone.UUID); err != nil {
+		t.Fatalf("Cannot look up Reminder: %s", err.Error())
+	} else if r == nil {
+		t.Errorf("Reminder %q changed after its deletion was not restored", gone.Title)
+	} else if tomb, err = db.TombstoneGetByUUID(gone.UUID); err != nil {
+		t.Fatalf("Cannot look up Tombstone: %s", err.Error())
+	} else if tomb != nil {
+		t.Errorf("Tombstone of restored Reminder %q is still there", gone.Title)
+	}
+} // func TestReminderMergeTombstone(t *testing.T)
+
+func TestReminderMergeDependCycle(t *testing.T) {
+	if back == nil {
+		t.SkipNow()
+	}
+
+	var (
+		err    error
+		db     *database.Database
+		r      *objects.Reminder
+		now    = time.Now().Truncate(time.Second)
+		remote = objects.Reminder{
+			Title:     "New on Peer",
+			Timestamp: now.Add(time.Hour),
+			UUID:      common.GetUUID(),
+			Changed:   now,
+		}
+		local = &objects.Reminder{
+			Title:     "Waiting for Peer",
+			Timestamp: now.Add(time.Hour),
+			UUID:      common.GetUUID(),
+			Depends:   []objects.Dependency{{Prereq: remote.UUID, Kind: objects.DepAfter}},
+		}
+	)
+
+	remote.Depends = []objects.Dependency{{Prereq: local.UUID, Kind: objects.DepAfter}}
+
+	db = back.pool.Get()
+	defer back.pool.Put(db)
+
+	if err = db.ReminderAdd(local); err != nil {
+		t.Fatalf("Cannot add Reminder: %s", err.Error())
+	} else if err = back.reminderMerge("peer", []objects.Reminder{remote}, nil); err != nil {
+		t.Fatalf("Cannot merge Reminder: %s", err.Error())
+	} else if r, err = db.ReminderGetByUUID(remote.UUID); err != nil {
+		t.Fatalf("Cannot look up Reminder: %s", err.Error())
+	} else if r == nil {
+		t.Fatalf("Reminder %q was not added", remote.Title)
+	} else if len(r.Depends) != 0 {
+		t.Errorf("Dependencies of Reminder %q close a cycle, they should have been dropped: %v",
+			r.Title,
+			r.Depends)
+	}
+} // func TestReminderMergeDependCycle(t *testing.T)
+
+func TestReminderMergeInvalidRecur(t *testing.T) {
+	if back == nil {
+		t.SkipNow()
+	}
+
+	var (
+		err   error
+		db    *database.Database
+		r     *objects.Reminder
+		now   = time.Now().Truncate(time.Second)
+		stamp = time.Unix(3600*9, 0)
+		added = objects.Reminder{
+			Title:     "February 30th",
+			Timestamp: stamp,
+			Recur:     objects.Recurrence{Repeat: repeat.Yearly, Month: time.February, Day: 30},
+			UUID:      common.GetUUID(),
+			Changed:   now,
+		}
+		local = &objects.Reminder{
+			Title:     "Every day",
+			Timestamp: stamp,
+			Recur:     objects.Recurrence{Repeat: repeat.Daily},
+			UUID:      common.GetUUID(),
+		}
+		changed objects.Reminder
+	)
+
+	db = back.pool.Get()
+	defer back.pool.Put(db)
+
+	if err = db.ReminderAdd(local); err != nil {
+		t.Fatalf("Cannot add Reminder: %s", err.Error())
+	}
+
+	changed = *local
+	changed.Title = "April 31st"
+	changed.Recur = objects.Recurrence{Repeat: repeat.Yearly, Month: time.April, Day: 31}
+	changed.Timestamp = time.Unix(3600*10, 0)
+	changed.Changed = now.Add(time.Minute)
+
+	if err = back.reminderMerge("peer", []objects.Reminder{added, changed}, nil); err != nil {
+		t.Fatalf("Cannot merge Reminders: %s", err.Error())
+	} else if r, err = db.ReminderGetByUUID(added.UUID); err != nil {
+		t.Fatalf("Cannot look up Reminder: %s", err.Error())
+	} else if r != nil {
+		t.Errorf("Reminder %q should have been rejected", added.Title)
+	} else if r, err = db.ReminderGetByUUID(local.UUID); err != nil {
+		t.Fatalf("Cannot look up Reminder: %s", err.Error())
+	} else if r.Title != changed.Title {
+		t.Errorf("Title of Reminder %q was not updated: %q", local.Title, r.Title)
+	} else if r.Recur.Repeat != repeat.Daily || !r.Timestamp.Equal(stamp) {
+		t.Errorf("Invalid Recurrence of Reminder %q was merged: %s at %s",
+			r.Title,
+			&r.Recur,
+			r.Timestamp.Format(common.TimestampFormat))
+	}
+} // func TestReminderMergeInvalidRecur(t *testing.T)
@@ -0,0 +1,192 @@
+// /home/krylon/go/src/github.com/blicero/theseus/backend/03_revision_test.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 17. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <>
+
+package backend
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/blicero/theseus/common"
+	"github.com/blicero/theseus/database"
+	"github.com/blicero/theseus/objects"
+	"github.com/pquerna/ffjson/ffjson"
+)
+
+// request sends a request to the Daemon's web interface and decodes the
+// Response.
+func request(t *testing.T, method, uri string, form url.Values) objects.Response {
+	var (
+		err error
+		req *http.Request
+		res objects.Response
+		rec = httptest.NewRecorder()
+	)
+
+	if form == nil {
+		req = httptest.NewRequest(method, uri, nil)
+	} else {
+		req = httptest.NewRequest(method, uri, strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+
+	back.router.ServeHTTP(rec, req)
+
+	if err = ffjson.Unmarshal(rec.Body.Bytes(), &res); err != nil {
+		t.Fatalf("Cannot parse Response to %s: %s\n%s",
+			uri,
+			err.Error(),
+			rec.Body.Bytes())
+	} else if !res.Status {
+		t.Fatalf("Request %s failed: %s", uri, res.Message)
+	}
+
+	return res
+} // func request(t *testing.T, method, uri string, form url.Values) objects.Response
+
+func TestRevisionUndo(t *testing.T) {
+	if back == nil {
+		t.SkipNow()
+	}
+
+	var (
+		err  error
+		db   *database.Database
+		r    *objects.Reminder
+		buf  []byte
+		revs []objects.Revision
+		rem  = &objects.Reminder{
+			Title:     "Call the plumber",
+			Timestamp: time.Now().Add(time.Hour).Truncate(time.Minute),
+			UUID:      common.GetUUID(),
+		}
+	)
+
+	db = back.pool.Get()
+	defer back.pool.Put(db)
+
+	if err = db.ReminderAdd(rem); err != nil {
+		t.Fatalf("Cannot add Reminder: %s", err.Error())
+	}
+
+	var edit = *rem
+	edit.Title = "Call the electrician"
+
+	if buf, err = ffjson.Marshal(&edit); err != nil {
+		t.Fatalf("Cannot serialize Reminder: %s", err.Error())
+	}
+
+	request(t, "POST", fmt.Sprintf("/reminder/%d/update", rem.ID), url.Values{"reminder": []string{string(buf)}})
+	request(t, "GET", fmt.Sprintf("/reminder/%d/set_finished/true", rem.ID), nil)
+	request(t, "GET", fmt.Sprintf("/reminder/%d/delete", rem.ID), nil)
+
+	if revs, err = db.RevisionGetByUUID(rem.UUID); err != nil {
+		t.Fatalf("Cannot load Revisions: %s", err.Error())
+	} else if len(revs) != 3 {
+		t.Fatalf("Expected 3 Revisions, got %d: %v", len(revs), revs)
+	} else if revs[0].Field != "Title" || revs[1].Field != "Finished" || revs[2].Field != objects.FieldReminder {
+		t.Fatalf("Unexpected Revisions: %v", revs)
+	}
+
+	// Undoing the deletion brings the Reminder back, as it was.
+	request(t, "GET", "/revision/undo", nil)
+
+	if r, err = db.ReminderGetByUUID(rem.UUID); err != nil {
+		t.Fatalf("Cannot look up Reminder: %s", err.Error())
+	} else if r == nil {
+		t.Fatal("Undoing the deletion did not restore the Reminder")
+	} else if r.Title != edit.Title || !r.Finished {
+		t.Errorf("Restored Reminder is not what it was before the deletion: %q, %t",
+			r.Title,
+			r.Finished)
+	}
+
+	request(t, "GET", "/revision/undo?n=2", nil)
+
+	if r, err = db.ReminderGetByUUID(rem.UUID); err != nil {
+		t.Fatalf("Cannot look up Reminder: %s", err.Error())
+	} else if r.Title != rem.Title || r.Finished {
+		t.Errorf("Undo did not restore the original Reminder: %q, %t",
+			r.Title,
+			r.Finished)
+	}
+
+	// Reverting to the first Revision re-applies the edit.
+	request(t, "GET", fmt.Sprintf("/revision/%d/revert", revs[0].ID), nil)
+
+	if r, err = db.ReminderGetByUUID(rem.UUID); err != nil {
+		t.Fatalf("Cannot look up Reminder: %s", err.Error())
+	} else if r.Title != edit.Title || r.Finished {
+		t.Errorf("Reverting did not bring back the state after the first edit: %q, %t",
+			r.Title,
+			r.Finished)
+	} else if revs, err = db.RevisionGetByUUID(rem.UUID); err != nil {
+		t.Fatalf("Cannot load Revisions: %s", err.Error())
+	} else if last := revs[len(revs)-1]; last.Field != "Title" || last.Undoes != 0 {
+		t.Errorf("Revert was not recorded: %v", last)
+	}
+} // func TestRevisionUndo(t *testing.T)
+
+func TestRevisionCreate(t *testing.T) {
+	if back == nil {
+		t.SkipNow()
+	}
+
+	var (
+		err    error
+		db     *database.Database
+		r      *objects.Reminder
+		buf    []byte
+		res    objects.Response
+		revs   []objects.Revision
+		remote = objects.Reminder{
+			Title:     "Created on Peer",
+			Timestamp: time.Now().Add(time.Hour).Truncate(time.Minute),
+			UUID:      common.GetUUID(),
+			Changed:   time.Now().Truncate(time.Second),
+		}
+		rem = &objects.Reminder{
+			Title:     "Water the plants",
+			Timestamp: time.Now().Add(time.Hour).Truncate(time.Minute),
+		}
+	)
+
+	if buf, err = ffjson.Marshal(rem); err != nil {
+		t.Fatalf("Cannot serialize Reminder: %s", err.Error())
+	}
+
+	res = request(t, "POST", "/reminder/add", url.Values{"reminder": []string{string(buf)}})
+
+	db = back.pool.Get()
+	defer back.pool.Put(db)
+
+	if revs, err = db.RevisionGetByUUID(res.Message); err != nil {
+		t.Fatalf("Cannot load Revisions: %s", err.Error())
+	} else if len(revs) != 1 || revs[0].Field != objects.FieldReminder || revs[0].Old != "" {
+		t.Fatalf("Expected the creation of the Reminder to be recorded: %v", revs)
+	}
+
+	request(t, "GET", "/revision/undo", nil)
+
+	if r, err = db.ReminderGetByUUID(res.Message); err != nil {
+		t.Fatalf("Cannot look up Reminder: %s", err.Error())
+	} else if r != nil {
+		t.Errorf("Undoing the creation should have deleted the Reminder")
+	}
+
+	if err = back.reminderMerge("peer", []objects.Reminder{remote}, nil); err != nil {
+		t.Fatalf("Cannot merge Reminder: %s", err.Error())
+	} else if revs, err = db.RevisionGetByUUID(remote.UUID); err != nil {
+		t.Fatalf("Cannot load Revisions: %s", err.Error())
+	} else if len(revs) != 1 || revs[0].Field != objects.FieldReminder || revs[0].Who() != "peer" {
+		t.Errorf("Expected the creation of the Reminder by the Peer to be recorded: %v", revs)
+	}
+} // func TestRevisionCreate(t *testing.T)
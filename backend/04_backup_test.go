@@ -0,0 +1,77 @@
+// /home/krylon/go/src/github.com/blicero/theseus/backend/04_backup_test.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 17. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <>
+
+package backend
+
+import (
+	"fmt"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/blicero/theseus/common"
+	"github.com/blicero/theseus/database"
+	"github.com/blicero/theseus/objects"
+)
+
+func TestBackupRestore(t *testing.T) {
+	if back == nil {
+		t.SkipNow()
+	}
+
+	var (
+		err     error
+		db      *database.Database
+		r       *objects.Reminder
+		res     objects.Response
+		backups []objects.Backup
+		rem     = &objects.Reminder{
+			Title:     "Added after the backup",
+			Timestamp: time.Now().Add(time.Hour).Truncate(time.Minute),
+			UUID:      common.GetUUID(),
+		}
+	)
+
+	res = request(t, "POST", "/admin/backup/policy", url.Values{"interval": []string{"daily"}, "keep": []string{"1"}})
+	if res.Message != "daily, keep 1" {
+		t.Errorf("Unexpected backup policy: %q", res.Message)
+	}
+
+	// Backups are named after the second they were made in, and backupLoop
+	// made one when the Daemon started.
+	time.Sleep(time.Second)
+	request(t, "GET", "/admin/backup", nil)
+	time.Sleep(time.Second)
+	res = request(t, "GET", "/admin/backup", nil)
+
+	if backups, err = listBackups(); err != nil {
+		t.Fatalf("Cannot list backups: %s", err.Error())
+	} else if len(backups) != 1 {
+		t.Fatalf("Expected 1 backup to be kept, found %d", len(backups))
+	} else if backups[0].Name != res.Message {
+		t.Fatalf("The wrong backup was kept: %s, expected %s", backups[0].Name, res.Message)
+	}
+
+	db = back.pool.Get()
+	err = db.ReminderAdd(rem)
+	back.pool.Put(db)
+
+	if err != nil {
+		t.Fatalf("Cannot add Reminder: %s", err.Error())
+	}
+
+	request(t, "GET", "/admin/maintenance", nil)
+	request(t, "GET", fmt.Sprintf("/admin/backup/%s/restore", backups[0].Name), nil)
+
+	db = back.pool.Get()
+	defer back.pool.Put(db)
+
+	if r, err = db.ReminderGetByUUID(rem.UUID); err != nil {
+		t.Fatalf("Cannot look up Reminder: %s", err.Error())
+	} else if r != nil {
+		t.Errorf("Reminder %q was added after the backup, it should be gone", r.Title)
+	}
+} // func TestBackupRestore(t *testing.T)
@@ -18,6 +18,8 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"os/exec"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -34,13 +36,29 @@ import (
 )
 
 const (
-	notifyObj            = "org.freedesktop.Notifications"
-	notifyIntf           = "org.freedesktop.Notifications" // nolint: deadcode,unused,varcheck
-	notifyPath           = "/org/freedesktop/Notifications"
-	notifyMethod         = "org.freedesktop.Notifications.Notify"
-	queueDepth           = 5
-	queueTimeout         = time.Second * 30
-	defaultReminderDelay = time.Second * 300
+	notifyObj        = "org.freedesktop.Notifications"
+	notifyIntf       = "org.freedesktop.Notifications" // nolint: deadcode,unused,varcheck
+	notifyPath       = "/org/freedesktop/Notifications"
+	notifyMethod     = "org.freedesktop.Notifications.Notify"
+	queueDepth       = 5
+	queueTimeout     = time.Second * 30
+	alarmSound       = "alarm-clock-elapsed"
+	categoryReminder = "x-theseus.reminder"
+	categoryPreAlert = "x-theseus.prealert"
+	settingSnooze    = "snooze"
+	linkOpener       = "xdg-open"
+)
+
+// The keys of the actions we attach to Notifications. A snooze action's key
+// is actionSnooze followed by the Snooze, e.g. "snooze:15m", a check
+// action's key is actionCheck followed by the position of the checklist
+// item, e.g. "check:2".
+const (
+	actionOK     = "ok"
+	actionSnooze = "snooze:"
+	actionFinish = "finish"
+	actionOpen   = "open"
+	actionCheck  = "check:"
 )
 
 type service struct {
@@ -86,9 +104,12 @@ type Daemon struct {
 	signalQ    chan *dbus.Signal
 	nLock      sync.RWMutex
 	pending    map[uint32]int64
+	summaryID  uint32
+	summarized []int64
 	dnssd      *zeroconf.Server
 	pLock      sync.RWMutex
 	peers      map[string]service
+	bLock      sync.Mutex
 }
 
 // Summon summons a Daemon and returns it. No sacrifice or idolatry is required.
@@ -154,6 +175,11 @@ func Summon(addr string) (*Daemon, error) {
 		return nil, err
 	}
 
+	if err = objects.LoadCalendars(common.HolidayDir); err != nil {
+		d.log.Printf("[ERROR] Cannot load holiday calendars: %s\n",
+			err.Error())
+	}
+
 	d.signalQ = make(chan *dbus.Signal, 25)
 	d.bus.Signal(d.signalQ)
 
@@ -161,6 +187,7 @@ func Summon(addr string) (*Daemon, error) {
 
 	go d.notifyLoop()
 	go d.dbLoop()
+	go d.backupLoop()
 	go d.serveHTTP()
 
 	if err = d.initDnsSd(); err != nil {
@@ -261,26 +288,54 @@ func (d *Daemon) notifyLoop() {
 					ok  bool
 				)
 
-				if nid, ok = n.Body[0].(uint32); ok {
+				if nid, ok = n.Body[0].(uint32); ok && !d.handleSummary(nid, "") {
 					d.removePending(nid)
 				}
 			case "org.freedesktop.Notifications.ActionInvoked":
-				var action = n.Body[1].(string)
+				var (
+					nid    = n.Body[0].(uint32)
+					action = strings.ToLower(n.Body[1].(string))
+				)
 
 				d.log.Printf("[DEBUG] User clicked %s\n",
 					action)
 
-				switch strings.ToLower(action) {
-				case "delay":
-					if err = d.delayNotification(n.Body[0].(uint32)); err != nil {
+				switch {
+				case d.handleSummary(nid, action):
+					// The summary of the Notifications deferred
+					// during quiet hours.
+				case strings.HasPrefix(action, actionSnooze):
+					var z objects.Snooze
+
+					if z, err = objects.ParseSnooze(action[len(actionSnooze):]); err != nil {
+						d.log.Printf("[ERROR] Invalid snooze action %q: %s\n",
+							action,
+							err.Error())
+					} else if err = d.delayNotification(nid, z); err != nil {
 						d.log.Printf("[ERROR] Cannot delay Notification: %s\n",
 							err.Error())
 					}
-				case "ok":
-					if err = d.finishNotification(n.Body[0].(uint32)); err != nil {
+				case strings.HasPrefix(action, actionCheck):
+					var idx int
+
+					if idx, err = strconv.Atoi(action[len(actionCheck):]); err != nil {
+						d.log.Printf("[ERROR] Invalid check action %q: %s\n",
+							action,
+							err.Error())
+					} else if err = d.checkNotification(nid, idx); err != nil {
+						d.log.Printf("[ERROR] Cannot check off item from Notification: %s\n",
+							err.Error())
+					}
+				case action == actionOK, action == actionFinish:
+					if err = d.finishNotification(nid, action == actionFinish); err != nil {
 						d.log.Printf("[ERROR] Cannot finish Notification: %s\n",
 							err.Error())
 					}
+				case action == actionOpen:
+					if err = d.openLink(nid); err != nil {
+						d.log.Printf("[ERROR] Cannot open link from Notification: %s\n",
+							err.Error())
+					}
 				default:
 					d.log.Printf("[ERROR] Unknown action %q from Notification\n",
 						action)
@@ -301,14 +356,19 @@ func (d *Daemon) notifyLoop() {
 	}
 } // func (d *Daemon) notifyLoop()
 
+// notify posts the pending Notifications of a Reminder that are due.
+// The expire timeout depends on the Reminder's Priority, unless the caller
+// overrides it with a non-zero timeout (in milliseconds).
 func (d *Daemon) notify(r *objects.Reminder, timeout int32) error {
 	var (
-		err        error
-		head, body string
-		db         *database.Database
-		due, now   time.Time
-		pending    []objects.Notification
-		obj        = d.bus.Object(notifyObj, notifyPath)
+		err     error
+		done    bool
+		db      *database.Database
+		now     time.Time
+		dep     *objects.Dependency
+		pending []objects.Notification
+		style   *notifyStyle
+		obj     = d.bus.Object(notifyObj, notifyPath)
 	)
 
 	if obj == nil {
@@ -319,9 +379,6 @@ func (d *Daemon) notify(r *objects.Reminder, timeout int32) error {
 		return err
 	}
 
-	head, body = r.Payload()
-	due = r.DueNext(nil)
-
 	d.log.Printf("[DEBUG] Check pending Notifications for Reminder %d (%q)\n",
 		r.ID,
 		r.Title)
@@ -329,30 +386,172 @@ func (d *Daemon) notify(r *objects.Reminder, timeout int32) error {
 	db = d.pool.Get()
 	defer d.pool.Put(db)
 
+	// A Reminder that depends on another one is held back until its
+	// prerequisite is done.
+	if dep, err = d.blockedBy(db, r); err != nil {
+		return err
+	} else if dep != nil {
+		d.log.Printf("[DEBUG] Reminder %d (%q) is held back by its dependency %s\n",
+			r.ID,
+			r.Title,
+			dep)
+		return nil
+	}
+
+	// First, we bring the Notifications in the database up to date with
+	// the Reminder's schedule, then we post the ones that are due.
+	if pending, err = d.pendingNotifications(db, r); err != nil {
+		return err
+	} else if pending, err = d.addNext(db, r, pending); err != nil {
+		return err
+	} else if pending, err = d.addPrev(db, r, pending); err != nil {
+		return err
+	}
+
+	now = time.Now().Truncate(time.Minute)
+
+	if done, err = d.finishExhausted(db, r, pending, now); err != nil || done {
+		return err
+	}
+
+	style = d.styleFor(db, r)
+
+	for idx := range pending {
+		if err = d.post(db, obj, r, style, &pending[idx], now, timeout); err != nil {
+			return err
+		}
+	}
+
+	return nil
+} // func (d *Daemon) notify(n objects.Notification, timeout int32) error
+
+// pendingNotifications returns the Notifications of a Reminder that have
+// not been acknowledged, minus the ones that are stale or whose occurrence
+// has been skipped or moved.
+func (d *Daemon) pendingNotifications(db *database.Database, r *objects.Reminder) ([]objects.Notification, error) {
+	var (
+		err     error
+		pending []objects.Notification
+	)
+
 	if pending, err = db.NotificationGetByReminderPending(r); err != nil {
 		d.log.Printf("[ERROR] Cannot fetch pending Notifications for Reminder %q (%d): %s\n",
 			r.Title,
 			r.ID,
 			err.Error())
-		return err
+		return nil, err
 	}
 
 	d.log.Printf("[DEBUG] Found %d pending Notifications for Reminder %d\n",
 		len(pending),
 		r.ID)
 
-	var found bool
+	if pending, err = d.skipStale(db, r, pending); err != nil {
+		return nil, err
+	}
+
+	return d.dropExceptions(db, r, pending)
+} // func (d *Daemon) pendingNotifications(db *database.Database, r *objects.Reminder) ([]objects.Notification, error)
+
+// skipStale drops all but the most recent Notification of an Interval
+// Reminder.
+// Reminders that go off at short intervals would pile up lots of
+// Notifications if the daemon was not running for a while, or if
+// the user was away from the computer. The user only needs to know
+// about the most recent one, so we quietly drop the rest.
+func (d *Daemon) skipStale(db *database.Database, r *objects.Reminder, pending []objects.Notification) ([]objects.Notification, error) {
+	if r.Recur.Repeat != repeat.Interval {
+		return pending, nil
+	}
+
+	var (
+		err   error
+		cnt   int64
+		prev  = r.DuePrev(nil)
+		fresh = make([]objects.Notification, 0, len(pending))
+	)
+
+	if cnt, err = db.NotificationSkipStale(r, prev); err != nil {
+		d.log.Printf("[ERROR] Cannot skip stale Notifications for Reminder %q (%d): %s\n",
+			r.Title,
+			r.ID,
+			err.Error())
+		return nil, err
+	} else if cnt > 0 {
+		d.log.Printf("[INFO] Skipped %d stale Notifications for Reminder %q (%d)\n",
+			cnt,
+			r.Title,
+			r.ID)
+	}
 
 	for _, n := range pending {
-		if n.Timestamp.Truncate(time.Minute).Equal(due) {
+		if !n.Due().Before(prev) {
+			fresh = append(fresh, n)
+		}
+	}
+
+	return fresh, nil
+} // func (d *Daemon) skipStale(db *database.Database, r *objects.Reminder, pending []objects.Notification) ([]objects.Notification, error)
+
+// dropExceptions deletes the Notifications for occurrences that have been
+// skipped or moved to a different time, so they do not go off at their
+// original time.
+func (d *Daemon) dropExceptions(db *database.Database, r *objects.Reminder, pending []objects.Notification) ([]objects.Notification, error) {
+	if len(r.Exceptions) == 0 {
+		return pending, nil
+	}
+
+	var keep = make([]objects.Notification, 0, len(pending))
+
+	for idx, n := range pending {
+		var e = r.Exception(n.Due())
+
+		if e == nil || (!e.Skip() && e.Due.Truncate(time.Minute).Equal(n.Due().Truncate(time.Minute))) {
+			keep = append(keep, n)
+			continue
+		}
+
+		d.log.Printf("[DEBUG] Drop Notification %d for Reminder %d: %s\n",
+			n.ID,
+			r.ID,
+			e)
+
+		if err := db.NotificationDelete(&pending[idx]); err != nil {
+			d.log.Printf("[ERROR] Cannot delete Notification %d for Reminder %q (%d): %s\n",
+				n.ID,
+				r.Title,
+				r.ID,
+				err.Error())
+			return nil, err
+		}
+	}
+
+	return keep, nil
+} // func (d *Daemon) dropExceptions(db *database.Database, r *objects.Reminder, pending []objects.Notification) ([]objects.Notification, error)
+
+// addNext makes sure there is a Notification for the next occurrence of
+// the Reminder, and a pre-alert, if one is due.
+func (d *Daemon) addNext(db *database.Database, r *objects.Reminder, pending []objects.Notification) ([]objects.Notification, error) {
+	var (
+		err   error
+		found bool
+		n     *objects.Notification
+		due   = r.DueNext(nil)
+	)
+
+	// A Reminder whose recurrence rule is exhausted has no next due time.
+	if due.IsZero() {
+		return pending, nil
+	}
+
+	for _, p := range pending {
+		if !p.IsPreAlert() && p.Timestamp.Truncate(time.Minute).Equal(due) {
 			found = true
 			break
 		}
 	}
 
 	if !found {
-		var n *objects.Notification
-
 		d.log.Printf("[DEBUG] Adding Notification for Reminder %d at %s\n",
 			r.ID,
 			due.Format(common.TimestampFormat))
@@ -362,134 +561,387 @@ func (d *Daemon) notify(r *objects.Reminder, timeout int32) error {
 				r.ID,
 				due.Format(common.TimestampFormat),
 				err.Error())
-			return err
+			return nil, err
 		}
 
 		pending = append(pending, *n)
 	}
 
-	due = r.DuePrev(nil)
+	if len(r.Alerts) == 0 {
+		return pending, nil
+	}
+
+	return d.preAlert(db, r, due, pending)
+} // func (d *Daemon) addNext(db *database.Database, r *objects.Reminder, pending []objects.Notification) ([]objects.Notification, error)
+
+// addPrev makes sure there is a Notification for the most recent occurrence
+// of the Reminder, unless the user has acknowledged it already.
+func (d *Daemon) addPrev(db *database.Database, r *objects.Reminder, pending []objects.Notification) ([]objects.Notification, error) {
+	var (
+		err error
+		n   *objects.Notification
+		due = r.DuePrev(nil)
+	)
+
+	if due.IsZero() {
+		return pending, nil
+	}
 
 	d.log.Printf("[DEBUG] Looking for most recent Notification for Reminder %d at %s\n",
 		r.ID,
 		due.Format(common.TimestampFormat))
 
-	for _, n := range pending {
-		if n.Timestamp.Truncate(time.Minute).Equal(due) {
+	for _, p := range pending {
+		if !p.IsPreAlert() && p.Timestamp.Truncate(time.Minute).Equal(due) {
 			d.log.Printf("[DEBUG] Found Notification for Reminder %d at %s: %d\n",
 				r.ID,
 				due.Format(common.TimestampFormat),
-				n.ID)
-			found = true
-			break
+				p.ID)
+			return pending, nil
 		}
 	}
 
-	// If we don't find a pending Notification for the most recent
-	// Recurrence, *maybe* we should check if there is an *acknowledged*
-	// Notification before trying to display one?
-	if !found {
-		var n *objects.Notification
+	if n, err = db.NotificationGetByReminderStamp(r, due); err != nil {
+		d.log.Printf("[ERROR] Cannot look up Notification for Reminder %d at %s: %s\n",
+			r.ID,
+			due.Format(common.TimestampFormat),
+			err.Error())
+		return nil, err
+	} else if n != nil && n.Acknowledged.After(common.Epoch) {
+		d.log.Printf("[DEBUG] Found acknowledged Notification for Reminder %d at %s: Acknowledged at %s\n",
+			r.ID,
+			due.Format(common.TimestampFormat),
+			n.Acknowledged.Format(common.TimestampFormat))
+		return pending, nil
+	} else if n, err = db.NotificationAdd(r, due); err != nil {
+		d.log.Printf("[ERROR] Cannot add Notification for Reminder %d at %s: %s\n",
+			r.ID,
+			due.Format(common.TimestampFormat),
+			err.Error())
+		return nil, err
+	}
 
-		if n, err = db.NotificationGetByReminderStamp(r, due); err != nil {
-			d.log.Printf("[ERROR] Cannot look up Notification for Reminder %d at %s: %s\n",
-				r.ID,
-				due.Format(common.TimestampFormat),
-				err.Error())
-			return err
-		} else if n != nil && n.Acknowledged.After(common.Epoch) {
-			d.log.Printf("[DEBUG] Found acknowledged Notification for Reminder %d at %s: Acknowledged at %s\n",
-				r.ID,
-				due.Format(common.TimestampFormat),
-				n.Acknowledged.Format(common.TimestampFormat))
-			goto LETS_GO
-		} else if n, err = db.NotificationAdd(r, due); err != nil {
-			d.log.Printf("[ERROR] Cannot add Notification for Reminder %d at %s: %s\n",
-				r.ID,
-				due.Format(common.TimestampFormat),
-				err.Error())
-			return err
-		}
+	d.log.Printf("[DEBUG] Added Notification for Reminder %d at %s: %d\n",
+		r.ID,
+		due.Format(common.TimestampFormat),
+		n.ID)
+
+	return append(pending, *n), nil
+} // func (d *Daemon) addPrev(db *database.Database, r *objects.Reminder, pending []objects.Notification) ([]objects.Notification, error)
+
+// finishExhausted marks a recurring Reminder as finished once it has run its
+// course and the user has seen all of its Notifications. It returns true if
+// it did so.
+func (d *Daemon) finishExhausted(db *database.Database, r *objects.Reminder, pending []objects.Notification, now time.Time) (bool, error) {
+	if len(pending) > 0 || r.Finished || !r.Exhausted(&now) {
+		return false, nil
+	}
 
-		d.log.Printf("[DEBUG] Added Notification for Reminder %d at %s: %d\n",
+	d.log.Printf("[INFO] Reminder %d (%q) has no more occurrences, marking it as finished\n",
+		r.ID,
+		r.Title)
+
+	var (
+		err  error
+		prev = r.Clone()
+	)
+
+	if err = db.ReminderSetFinished(r, true); err != nil {
+		d.log.Printf("[ERROR] Cannot set finished-flag on Reminder %d (%q): %s\n",
 			r.ID,
-			due.Format(common.TimestampFormat),
-			n.ID)
-		pending = append(pending, *n)
+			r.Title,
+			err.Error())
+		return false, err
 	}
 
-LETS_GO:
-	now = time.Now().Truncate(time.Minute)
+	return true, d.recordRevisions(db, "", prev, r)
+} // func (d *Daemon) finishExhausted(db *database.Database, r *objects.Reminder, pending []objects.Notification, now time.Time) (bool, error)
 
-	for _, n := range pending {
-		if n.Timestamp.After(now.Add(queueTimeout)) {
-			continue
-		}
+// notifyStyle is what the Reminder's Tags and the quiet hours have to say
+// about posting its Notifications.
+type notifyStyle struct {
+	eff     objects.Reminder // The Reminder, with the Priority its Tags ask for
+	defs    objects.TagDefaults
+	snoozes []objects.Snooze
+	quiet   bool
+}
 
-		var msg = fmt.Sprintf("%s -- %s",
-			n.Timestamp.Format(common.TimestampFormatMinute),
-			body)
+// styleFor returns the notifyStyle for the given Reminder.
+func (d *Daemon) styleFor(db *database.Database, r *objects.Reminder) *notifyStyle {
+	var st = &notifyStyle{
+		eff:     *r,
+		defs:    d.tagDefaults(db, r),
+		snoozes: d.snoozeChoices(db, r),
+	}
 
-		var res = obj.Call(
-			notifyMethod,
-			0,
-			common.AppName,
-			uint32(0),
-			"",
+	// The Reminder's Tags may raise its Priority, but not lower it.
+	if st.defs.Priority > st.eff.Priority {
+		st.eff.Priority = st.defs.Priority
+	}
+
+	st.quiet = st.eff.Priority < objects.PriorityCritical &&
+		!st.defs.QuietExempt &&
+		d.isQuiet(db)
+
+	return st
+} // func (d *Daemon) styleFor(db *database.Database, r *objects.Reminder) *notifyStyle
+
+// post posts a single Notification, if it is due. A pre-alert whose
+// occurrence is due is dropped instead. The checks happen in this order:
+// Notifications held back during quiet hours are neither escalated nor
+// shown, and only the ones we do show count as an escalation step.
+func (d *Daemon) post(db *database.Database, obj dbus.BusObject, r *objects.Reminder, st *notifyStyle, n *objects.Notification, now time.Time, timeout int32) error {
+	var (
+		err        error
+		ok, held   bool
+		step       int
+		dbusID     uint32
+		onDisplay  bool
+		head, body string
+		icon       string
+		expire     int32
+		hints      map[string]dbus.Variant
+	)
+
+	if n.Timestamp.After(now.Add(queueTimeout)) {
+		return nil
+	} else if n.IsPreAlert() && !n.Due().After(now) {
+		// Once the occurrence is due, the Notification for the
+		// occurrence itself takes over.
+		return d.dropPreAlert(db, n)
+	}
+
+	dbusID, onDisplay = d.getNotificationID(n.ID)
+
+	if held, err = d.deferQuiet(db, r, n, st.quiet, onDisplay, now); err != nil || held {
+		return err
+	} else if step, ok = escalationStep(r, n, onDisplay, now); !ok {
+		return nil
+	}
+
+	head, body = r.Payload()
+
+	if icon, hints, expire = mkHints(&st.eff, n, step, st.defs.Channel); timeout != 0 {
+		expire = timeout
+	}
+
+	var res = obj.Call(
+		notifyMethod,
+		0,
+		common.AppName,
+		dbusID,
+		icon,
+		head,
+		mkMessage(n, body, now),
+		mkActions(r, n, st.snoozes),
+		hints,
+		expire,
+	)
+
+	if res.Err != nil {
+		d.log.Printf("[ERROR] Cannot send Notification %q: %s\n",
 			head,
-			msg,
-			[]string{
-				"OK",
-				"OK",
-				"Delay",
-				"Delay",
-			},
-			map[string]*dbus.Variant{},
-			timeout,
-		)
+			res.Err.Error())
+		return res.Err
+	}
+
+	var ret uint32
+
+	if err = res.Store(&ret); err != nil {
+		d.log.Printf("[ERROR] Cannot store return value of %s: %s\n",
+			notifyMethod,
+			err.Error())
+		return err
+	} else if common.Debug {
+		d.log.Printf("[DEBUG] RESPONSE: %d\n",
+			ret)
+	}
+
+	if step > 0 {
+		d.escalate(db, r, n, step, now)
+	}
 
-		if res.Err != nil {
-			d.log.Printf("[ERROR] Cannot send Notification %q: %s\n",
-				head,
-				res.Err.Error())
-			return res.Err
+	if err = db.NotificationDisplay(n, now); err != nil {
+		d.log.Printf("[ERROR] Cannot set Display stamp for Notification %d at %s: %s\n",
+			n.ID,
+			now.Format(common.TimestampFormat),
+			err.Error())
+	} else {
+		d.nLock.Lock()
+		if onDisplay && dbusID != ret {
+			delete(d.pending, dbusID)
 		}
+		d.pending[ret] = n.ID
+		d.nLock.Unlock()
+	}
+
+	return nil
+} // func (d *Daemon) post(db *database.Database, obj dbus.BusObject, r *objects.Reminder, st *notifyStyle, n *objects.Notification, now time.Time, timeout int32) error
+
+// deferQuiet returns true if a Notification has to wait for the quiet hours
+// to end. summarize will tell the user about it then. If it went up before
+// the quiet hours began, we leave it alone.
+func (d *Daemon) deferQuiet(db *database.Database, r *objects.Reminder, n *objects.Notification, quiet, onDisplay bool, now time.Time) (bool, error) {
+	if !n.Deferred.IsZero() {
+		return true, nil
+	} else if !quiet {
+		return false, nil
+	} else if onDisplay {
+		return true, nil
+	}
+
+	d.log.Printf("[DEBUG] Defer Notification %d for Reminder %d (%q) during quiet hours\n",
+		n.ID,
+		r.ID,
+		r.Title)
 
-		var ret uint32
+	return true, db.NotificationSetDeferred(n, now)
+} // func (d *Daemon) deferQuiet(db *database.Database, r *objects.Reminder, n *objects.Notification, quiet, onDisplay bool, now time.Time) (bool, error)
+
+// escalationStep decides if a Notification is to be posted (again), and at
+// which escalation step. If the Reminder asks for it, we keep nagging the
+// user about a Notification they have not acknowledged, getting louder
+// each time. Otherwise, we only post it again if it is no longer on
+// display.
+func escalationStep(r *objects.Reminder, n *objects.Notification, onDisplay bool, now time.Time) (int, bool) {
+	var esc = &r.Escalation
+
+	if n.IsPreAlert() || !esc.Enabled() {
+		return 0, !onDisplay
+	} else if n.Displayed.IsZero() {
+		return 0, true
+	} else if now.Before(esc.Next(n.Displayed)) {
+		return 0, false
+	}
 
-		if err = res.Store(&ret); err != nil {
-			d.log.Printf("[ERROR] Cannot store return value of %s: %s\n",
-				notifyMethod,
-				err.Error())
-			return err
-		} else if common.Debug {
-			d.log.Printf("[DEBUG] RESPONSE: %d\n",
-				ret)
+	return n.Step + 1, true
+} // func escalationStep(r *objects.Reminder, n *objects.Notification, onDisplay bool, now time.Time) (int, bool)
+
+// escalate records that a Notification has been posted at the given
+// escalation step.
+func (d *Daemon) escalate(db *database.Database, r *objects.Reminder, n *objects.Notification, step int, now time.Time) {
+	var s = r.Escalation.Step(n, step, now)
+
+	d.log.Printf("[INFO] Escalate Notification %d for Reminder %d (%q) to step %d (%s, %s)\n",
+		n.ID,
+		r.ID,
+		r.Title,
+		step,
+		s.Urgency,
+		s.Channel)
+
+	if err := db.NotificationEscalate(n, &s); err != nil {
+		d.log.Printf("[ERROR] Cannot record escalation of Notification %d: %s\n",
+			n.ID,
+			err.Error())
+	}
+} // func (d *Daemon) escalate(db *database.Database, r *objects.Reminder, n *objects.Notification, step int, now time.Time)
+
+// preAlert makes sure there is a pre-alert for the occurrence of the
+// Reminder at due, if one of its Alerts has come up. Only the most recent
+// pre-alert is shown, the ones it supersedes are dropped.
+func (d *Daemon) preAlert(db *database.Database, r *objects.Reminder, due time.Time, pending []objects.Notification) ([]objects.Notification, error) {
+	var (
+		err   error
+		lead  int
+		found bool
+		n     *objects.Notification
+		now   = time.Now()
+		keep  = make([]objects.Notification, 0, len(pending)+1)
+	)
+
+	if !due.After(now) {
+		return pending, nil
+	}
+
+	// The Alerts are ordered earliest first, so the last one that has
+	// come up is the most recent one.
+	for _, a := range r.AlertTimes(due) {
+		if !a.After(now.Add(queueTimeout)) {
+			lead = int(due.Sub(a).Seconds())
 		}
+	}
 
-		if err = db.NotificationDisplay(&n, now); err != nil {
-			d.log.Printf("[ERROR] Cannot set Display stamp for Notification %d at %s: %s\n",
-				n.ID,
-				now.Format(common.TimestampFormat),
-				err.Error())
-		} else {
-			d.nLock.Lock()
-			d.pending[ret] = n.ID
-			d.nLock.Unlock()
+	if lead == 0 {
+		return pending, nil
+	}
+
+	for idx, p := range pending {
+		if !p.IsPreAlert() || !p.Due().Equal(due) {
+			keep = append(keep, p)
+		} else if p.Lead == lead {
+			keep = append(keep, p)
+			found = true
+		} else if err = d.dropPreAlert(db, &pending[idx]); err != nil {
+			return nil, err
 		}
+	}
 
+	if found {
+		return keep, nil
+	} else if n, err = db.NotificationGetPreAlert(r, due, lead); err != nil {
+		d.log.Printf("[ERROR] Cannot look up pre-alert for Reminder %d at %s: %s\n",
+			r.ID,
+			due.Format(common.TimestampFormat),
+			err.Error())
+		return nil, err
+	} else if n != nil {
+		// We only get here if the user has acknowledged the
+		// pre-alert already.
+		return keep, nil
 	}
-	return nil
-} // func (d *Daemon) notify(n objects.Notification, timeout int32) error
 
-func (d *Daemon) finishNotification(notID uint32) error {
+	d.log.Printf("[DEBUG] Adding pre-alert for Reminder %d at %s, %s in advance\n",
+		r.ID,
+		due.Format(common.TimestampFormat),
+		objects.FormatLead(lead))
+
+	if n, err = db.NotificationAddPreAlert(r, due, lead); err != nil {
+		d.log.Printf("[ERROR] Cannot add pre-alert for Reminder %d at %s: %s\n",
+			r.ID,
+			due.Format(common.TimestampFormat),
+			err.Error())
+		return nil, err
+	}
+
+	return append(keep, *n), nil
+} // func (d *Daemon) preAlert(db *database.Database, r *objects.Reminder, due time.Time, pending []objects.Notification) ([]objects.Notification, error)
+
+// dropPreAlert gets rid of a pre-alert that has become pointless. If the
+// user has not seen it, we just delete it, otherwise we acknowledge it on
+// their behalf.
+func (d *Daemon) dropPreAlert(db *database.Database, n *objects.Notification) error {
+	var err error
+
+	d.log.Printf("[DEBUG] Drop pre-alert %d for Reminder %d at %s\n",
+		n.ID,
+		n.ReminderID,
+		n.Due().Format(common.TimestampFormat))
+
+	if n.Displayed.IsZero() {
+		err = db.NotificationDelete(n)
+	} else {
+		err = db.NotificationAcknowledge(n, time.Now())
+	}
+
+	if err != nil {
+		d.log.Printf("[ERROR] Cannot drop pre-alert %d for Reminder %d: %s\n",
+			n.ID,
+			n.ReminderID,
+			err.Error())
+	}
+
+	return err
+} // func (d *Daemon) dropPreAlert(db *database.Database, n *objects.Notification) error
+
+// finishNotification acknowledges a Notification the user has clicked OK
+// on. If forGood is true, a recurring Reminder is marked as finished, too,
+// instead of just counting the occurrence.
+func (d *Daemon) finishNotification(notID uint32, forGood bool) error {
 	var (
-		err      error
-		db       *database.Database
-		rem      *objects.Reminder
-		not      *objects.Notification
-		nid, rid int64
-		ok       bool
+		db  *database.Database
+		nid int64
+		ok  bool
 	)
 
 	db = d.pool.Get()
@@ -506,6 +958,19 @@ func (d *Daemon) finishNotification(notID uint32) error {
 
 	defer delete(d.pending, notID)
 
+	return d.finish(db, nid, forGood)
+} // func (d *Daemon) finishNotification(notID uint32, forGood bool) error
+
+// finish acknowledges the Notification with the given database ID, see
+// finishNotification.
+func (d *Daemon) finish(db *database.Database, nid int64, forGood bool) error {
+	var (
+		err       error
+		rid       int64
+		rem, prev *objects.Reminder
+		not       *objects.Notification
+	)
+
 	if not, err = db.NotificationGetByID(nid); err != nil {
 		d.log.Printf("[ERROR] Cannot get Notification %d: %s\n",
 			nid,
@@ -516,6 +981,17 @@ func (d *Daemon) finishNotification(notID uint32) error {
 		d.log.Printf("[CANTHAPPEN] Could not find Notification %d in database\n",
 			nid)
 		return nil
+	} else if not.IsPreAlert() {
+		// A pre-alert only tells the user something is coming up,
+		// acknowledging it does not count as an occurrence.
+		if err = db.NotificationAcknowledge(not, time.Now()); err != nil {
+			d.log.Printf("[ERROR] Failed to acknowledge pre-alert %d for Reminder %d: %s\n",
+				not.ID,
+				not.ReminderID,
+				err.Error())
+			return err
+		}
+		return nil
 	} else if rem, err = db.ReminderGetByID(not.ReminderID); err != nil {
 		d.log.Printf("[ERROR] Cannot look up Reminder #%d: %s\n",
 			rid,
@@ -525,12 +1001,32 @@ func (d *Daemon) finishNotification(notID uint32) error {
 		d.log.Printf("[DEBUG] Reminder #%d was not found in database.\n",
 			rid)
 		return nil
-	} else if rem.Recur.Repeat != repeat.Once {
+	}
+
+	prev = rem.Clone()
+
+	if rem.Recur.Repeat == repeat.Once && !forGood && !rem.ChecklistComplete() {
+		// A one-time Reminder with a checklist is not done before
+		// all items are checked, unless the user says so. We leave
+		// the Notification pending, so it comes up again.
+		var done, total = rem.ChecklistProgress()
+
+		d.log.Printf("[INFO] Reminder %d (%q) is not finished, %d of %d checklist items are done\n",
+			rem.ID,
+			rem.Title,
+			done,
+			total)
+		return nil
+	} else if rem.Recur.Repeat != repeat.Once && !forGood {
 		d.log.Printf("[DEBUG] Reminder %d (%q) is recurring (%s)\n",
 			rem.ID,
 			rem.Title,
 			rem.Recur.Repeat)
-	} else if err = db.ReminderSetFinished(rem, true); err != nil {
+
+		if err = d.advanceCounter(db, rem); err != nil {
+			return err
+		}
+	} else if err = db.ReminderSetFinished(rem, true); err != nil {
 		d.log.Printf("[ERROR] Cannot set finished-flag on Reminder %d (%q): %s\n",
 			rid,
 			rem.Title,
@@ -538,23 +1034,190 @@ func (d *Daemon) finishNotification(notID uint32) error {
 		return err
 	}
 
-	if err = db.NotificationAcknowledge(not, time.Now()); err != nil {
+	if err = d.recordRevisions(db, "", prev, rem); err != nil {
+		return err
+	} else if err = db.NotificationAcknowledge(not, time.Now()); err != nil {
 		d.log.Printf("[ERROR] Failed to acknowledge Notification %d for Reminder %d: %s\n",
 			not.ID,
 			rem.ID,
 			err.Error())
+	} else if err = d.triggerFollowUps(db, rem); err != nil {
+		return err
+	} else if forGood {
+		// The Reminder is done, so there is no point in showing any
+		// other Notifications for it that are still around.
+		return d.acknowledgePending(db, rem)
+	}
+
+	return nil
+} // func (d *Daemon) finish(db *database.Database, nid int64, forGood bool) error
+
+// acknowledgePending acknowledges all pending Notifications for the given
+// Reminder.
+func (d *Daemon) acknowledgePending(db *database.Database, rem *objects.Reminder) error {
+	var (
+		err     error
+		pending []objects.Notification
+	)
+
+	if pending, err = db.NotificationGetByReminderPending(rem); err != nil {
+		d.log.Printf("[ERROR] Cannot fetch pending Notifications for Reminder %d (%q): %s\n",
+			rem.ID,
+			rem.Title,
+			err.Error())
+		return err
+	}
+
+	for i := range pending {
+		if err = db.NotificationAcknowledge(&pending[i], time.Now()); err != nil {
+			d.log.Printf("[ERROR] Failed to acknowledge Notification %d for Reminder %d: %s\n",
+				pending[i].ID,
+				rem.ID,
+				err.Error())
+			return err
+		}
+	}
+
+	return nil
+} // func (d *Daemon) acknowledgePending(db *database.Database, rem *objects.Reminder) error
+
+// checkNotification checks off an item on the checklist of the Reminder a
+// Notification is about. The Notification is posted again with the
+// updated checklist, unless that finished the Reminder.
+func (d *Daemon) checkNotification(notID uint32, idx int) error {
+	var (
+		err error
+		db  *database.Database
+		rem *objects.Reminder
+		not *objects.Notification
+		nid int64
+		ok  bool
+	)
+
+	d.nLock.RLock()
+	nid, ok = d.pending[notID]
+	d.nLock.RUnlock()
+
+	if !ok {
+		d.log.Printf("[INFO] Notification ID %d was not found in cache\n",
+			notID)
+		return nil
+	}
+
+	defer d.removePending(notID)
+
+	db = d.pool.Get()
+	defer d.pool.Put(db)
+
+	if not, err = db.NotificationGetByID(nid); err != nil {
+		d.log.Printf("[ERROR] Cannot get Notification %d: %s\n",
+			nid,
+			err.Error())
+		return err
+	} else if not == nil {
+		d.log.Printf("[CANTHAPPEN] Could not find Notification %d in database\n",
+			nid)
+		return nil
+	} else if rem, err = db.ReminderGetByID(not.ReminderID); err != nil {
+		d.log.Printf("[ERROR] Cannot look up Reminder #%d: %s\n",
+			not.ReminderID,
+			err.Error())
+		return err
+	} else if rem == nil {
+		d.log.Printf("[DEBUG] Reminder #%d was not found in database.\n",
+			not.ReminderID)
+		return nil
+	}
+
+	return d.checkItem(db, rem, idx, true)
+} // func (d *Daemon) checkNotification(notID uint32, idx int) error
+
+// checkItem sets the done state of a checklist item. Once all items of a
+// one-time Reminder that is due are done, the Reminder is finished.
+func (d *Daemon) checkItem(db *database.Database, rem *objects.Reminder, idx int, done bool) error {
+	var (
+		err error
+		now = time.Now()
+	)
+
+	if err = db.ChecklistSetDone(rem, idx, done); err != nil {
+		d.log.Printf("[ERROR] Cannot update checklist of Reminder %d (%q): %s\n",
+			rem.ID,
+			rem.Title,
+			err.Error())
+		return err
+	} else if rem.Recur.Repeat != repeat.Once ||
+		rem.Finished ||
+		!rem.ChecklistComplete() ||
+		rem.DueNext(nil).After(now) {
+		return nil
+	}
+
+	d.log.Printf("[INFO] All checklist items of Reminder %d (%q) are done\n",
+		rem.ID,
+		rem.Title)
+
+	var prev = rem.Clone()
+
+	if err = db.ReminderSetFinished(rem, true); err != nil {
+		d.log.Printf("[ERROR] Cannot set finished-flag on Reminder %d (%q): %s\n",
+			rem.ID,
+			rem.Title,
+			err.Error())
+		return err
+	} else if err = d.recordRevisions(db, "", prev, rem); err != nil {
+		return err
+	} else if err = d.acknowledgePending(db, rem); err != nil {
+		return err
+	}
+
+	return d.triggerFollowUps(db, rem)
+} // func (d *Daemon) checkItem(db *database.Database, rem *objects.Reminder, idx int, done bool) error
+
+// advanceCounter counts an acknowledged occurrence of a recurring Reminder
+// and marks the Reminder as finished if that was the last one.
+func (d *Daemon) advanceCounter(db *database.Database, rem *objects.Reminder) error {
+	var (
+		err error
+		now = time.Now()
+	)
+
+	if rem.Recur.Limit == 0 || rem.Recur.Counter < rem.Recur.Limit {
+		if err = db.ReminderIncCounter(rem); err != nil {
+			d.log.Printf("[ERROR] Cannot increment counter of Reminder %d (%q): %s\n",
+				rem.ID,
+				rem.Title,
+				err.Error())
+			return err
+		}
+	}
+
+	if rem.Exhausted(&now) {
+		d.log.Printf("[INFO] Reminder %d (%q) is done after %d occurrences\n",
+			rem.ID,
+			rem.Title,
+			rem.Recur.Counter)
+
+		if err = db.ReminderSetFinished(rem, true); err != nil {
+			d.log.Printf("[ERROR] Cannot set finished-flag on Reminder %d (%q): %s\n",
+				rem.ID,
+				rem.Title,
+				err.Error())
+			return err
+		}
 	}
 
 	return nil
-} // func (d *Daemon) finishNotification(notID uint32) error
+} // func (d *Daemon) advanceCounter(db *database.Database, rem *objects.Reminder) error
 
 // What would it mean to delay a Reminder that goes off regularly?
 // In that case, we can't just update the timestamp in the database, now,
 // can we?
 // So what do we do in those cases?
-// Basically, we'd have to create a copy of the Reminder that is set to go off
-// in five minutes (or whatever). ...
-func (d *Daemon) delayNotification(nID uint32) error {
+// We move the occurrence the Notification was about, by adding an
+// Exception to the Reminder.
+// How long we delay it is up to the Snooze the user picked.
+func (d *Daemon) delayNotification(nID uint32, z objects.Snooze) error {
 	var (
 		err       error
 		db        *database.Database
@@ -562,12 +1225,12 @@ func (d *Daemon) delayNotification(nID uint32) error {
 		not       *objects.Notification
 		nid       int64
 		ok        bool
-		timestamp = time.Now().Add(defaultReminderDelay)
+		timestamp time.Time
 	)
 
-	d.log.Printf("[DEBUG] Delay Notification %d until %s\n",
+	d.log.Printf("[DEBUG] Delay Notification %d: %s\n",
 		nID,
-		timestamp.Format(common.TimestampFormat))
+		z)
 
 	db = d.pool.Get()
 	defer d.pool.Put(db)
@@ -596,6 +1259,10 @@ func (d *Daemon) delayNotification(nID uint32) error {
 		d.log.Printf("[ERROR] %s\n",
 			err.Error())
 		return err
+	} else if not.IsPreAlert() {
+		// There is nothing to delay about a pre-alert, the occurrence
+		// it warns about is going to come up anyway.
+		return d.dropPreAlert(db, not)
 	} else if rem, err = db.ReminderGetByID(not.ReminderID); err != nil {
 		d.log.Printf("[ERROR] Cannot look up Reminder #%d: %s\n",
 			not.ReminderID,
@@ -605,37 +1272,194 @@ func (d *Daemon) delayNotification(nID uint32) error {
 		d.log.Printf("[DEBUG] Reminder #%d was not found in database.\n",
 			not.ReminderID)
 		return nil
-	} else if rem.Recur.Repeat != repeat.Once {
-		// What does it mean to delay a Reminder that is set to go off
-		// regularly? We need to to post the notification again in a
-		// few minutes, but without touching the database record.
-		//
-		// (Unless the Reminder is also set to go off only a limited
-		// number of times, but currently we are completely ignoring
-		// that part part anyway.)
-		//
-		// How can we do that?
-		// The easiest way I can think of is to start a goroutine
-		// that just waits for the delay interval and sends the Reminder
-		// to the Notification queue again.
-		// I *think* this should be repeatable ad nauseam, so ...
-		// let's try.
-		go func() {
-			time.Sleep(defaultReminderDelay)
-			if d.IsAlive() {
-				d.Queue <- rem
+	}
+
+	// "Tomorrow morning" means the morning where the Reminder lives.
+	timestamp = z.Until(time.Now().In(rem.Location()))
+
+	d.log.Printf("[DEBUG] Delay Notification %d for Reminder %d (%q) until %s\n",
+		not.ID,
+		rem.ID,
+		rem.Title,
+		timestamp.Format(common.TimestampFormat))
+
+	// We keep track of snoozes for the history, a Reminder the user
+	// keeps putting off might need a different time.
+	if _, err = db.SnoozeAdd(not, time.Now(), timestamp); err != nil {
+		d.log.Printf("[ERROR] Cannot record snooze of Notification %d: %s\n",
+			not.ID,
+			err.Error())
+	}
+
+	if rem.Recur.Repeat != repeat.Once {
+		// Delaying a Reminder that goes off regularly must not touch
+		// its schedule, only the one occurrence the user has been
+		// notified about. So we move that occurrence a few minutes
+		// into the future, just as if the user had done so manually.
+		// (Delaying does not count towards the Reminder's Limit,
+		// only acknowledging an occurrence does.)
+		// If the occurrence has been moved before, we move it again.
+		var exc = objects.Exception{
+			Occurrence: not.Timestamp,
+			Due:        timestamp,
+		}
+
+		for _, e := range rem.Exceptions {
+			if !e.Skip() && e.Due.Truncate(time.Minute).Equal(not.Timestamp.Truncate(time.Minute)) {
+				exc.Occurrence = e.Occurrence
+				break
 			}
-		}()
-	} else if err = db.ReminderSetTimestamp(rem, timestamp); err != nil {
-		d.log.Printf("[ERROR] Cannot delay Reminder %d (%q): %s\n",
-			rem.ID,
-			rem.Title,
+		}
+
+		if err = db.ExceptionAdd(rem, &exc); err != nil {
+			d.log.Printf("[ERROR] Cannot move occurrence %s of Reminder %d (%q): %s\n",
+				exc.Occurrence.Format(common.TimestampFormat),
+				rem.ID,
+				rem.Title,
+				err.Error())
+			return err
+		} else if err = db.NotificationDelete(not); err != nil {
+			d.log.Printf("[ERROR] Cannot delete Notification %d: %s\n",
+				not.ID,
+				err.Error())
+			return err
+		} else if err = db.ReminderSetChanged(rem, time.Now()); err != nil {
+			d.log.Printf("[ERROR] Cannot set CTime on Reminder %d (%q): %s\n",
+				rem.ID,
+				rem.Title,
+				err.Error())
+			return err
+		}
+	} else {
+		var prev = rem.Clone()
+
+		if err = db.ReminderSetTimestamp(rem, timestamp); err != nil {
+			d.log.Printf("[ERROR] Cannot delay Reminder %d (%q): %s\n",
+				rem.ID,
+				rem.Title,
+				err.Error())
+			return err
+		}
+
+		return d.recordRevisions(db, "", prev, rem)
+	}
+
+	return nil
+} // func (d *Daemon) delayNotification(nID uint32, z objects.Snooze) error
+
+// snoozeChoices returns the choices for putting off Notifications for the
+// given Reminder. Unless the Reminder has its own, we use the ones the
+// user configured globally, or the built-in defaults.
+func (d *Daemon) snoozeChoices(db *database.Database, r *objects.Reminder) []objects.Snooze {
+	var (
+		err   error
+		ok    bool
+		value string
+		items []objects.Snooze
+	)
+
+	if len(r.Snooze) > 0 {
+		return r.Snooze
+	} else if value, ok, err = db.SettingGet(settingSnooze); err != nil || !ok || value == "" {
+		return objects.DefaultSnoozes
+	} else if items, err = objects.ParseSnoozes(value); err != nil {
+		d.log.Printf("[ERROR] Invalid snooze choices in settings: %s\n",
+			err.Error())
+		return objects.DefaultSnoozes
+	}
+
+	return items
+} // func (d *Daemon) snoozeChoices(db *database.Database, r *objects.Reminder) []objects.Snooze
+
+// tagDefaults returns the defaults the Reminder inherits from its Tags.
+// If the Tags cannot be loaded, the Reminder is notified about on its own
+// terms.
+func (d *Daemon) tagDefaults(db *database.Database, r *objects.Reminder) objects.TagDefaults {
+	var (
+		err  error
+		list []objects.Tag
+		tags map[string]objects.Tag
+	)
+
+	if len(r.Tags) == 0 {
+		return objects.TagDefaults{}
+	} else if list, err = db.TagGetAll(); err != nil {
+		d.log.Printf("[ERROR] Cannot load Tags: %s\n",
+			err.Error())
+		return objects.TagDefaults{}
+	}
+
+	tags = make(map[string]objects.Tag, len(list))
+
+	for _, t := range list {
+		tags[t.Name] = t
+	}
+
+	return r.TagDefaults(tags)
+} // func (d *Daemon) tagDefaults(db *database.Database, r *objects.Reminder) objects.TagDefaults
+
+// openLink opens the first URL in the description of the Reminder the
+// given Notification is about.
+func (d *Daemon) openLink(nID uint32) error {
+	var (
+		err  error
+		db   *database.Database
+		rem  *objects.Reminder
+		not  *objects.Notification
+		nid  int64
+		ok   bool
+		link string
+	)
+
+	d.nLock.RLock()
+	nid, ok = d.pending[nID]
+	d.nLock.RUnlock()
+
+	if !ok {
+		d.log.Printf("[INFO] Notification ID %d was not found in cache\n",
+			nID)
+		return nil
+	}
+
+	db = d.pool.Get()
+	defer d.pool.Put(db)
+
+	if not, err = db.NotificationGetByID(nid); err != nil {
+		d.log.Printf("[ERROR] Cannot get Notification %d: %s\n",
+			nid,
+			err.Error())
+		return err
+	} else if not == nil {
+		return fmt.Errorf("Did not find Notification %d in database", nid)
+	} else if rem, err = db.ReminderGetByID(not.ReminderID); err != nil {
+		d.log.Printf("[ERROR] Cannot look up Reminder #%d: %s\n",
+			not.ReminderID,
+			err.Error())
+		return err
+	} else if rem == nil {
+		return fmt.Errorf("Did not find Reminder %d in database", not.ReminderID)
+	} else if link = findLink(rem.Description); link == "" {
+		return fmt.Errorf("Reminder %d (%q) contains no link", rem.ID, rem.Title)
+	}
+
+	d.log.Printf("[DEBUG] Open %s for Reminder %d (%q)\n",
+		link,
+		rem.ID,
+		rem.Title)
+
+	var cmd = exec.Command(linkOpener, link)
+
+	if err = cmd.Start(); err != nil {
+		d.log.Printf("[ERROR] Cannot run %s: %s\n",
+			linkOpener,
 			err.Error())
 		return err
 	}
 
+	go cmd.Wait() // nolint: errcheck
+
 	return nil
-} // func (d *Daemon) delayNotification(nID uint32) error
+} // func (d *Daemon) openLink(nID uint32) error
 
 // dbLoop periodically checks for pending Reminders in the database.
 func (d *Daemon) dbLoop() {
@@ -666,29 +1490,43 @@ func (d *Daemon) dbCheck() error {
 		deadline  = time.Now().Add(queueTimeout)
 	)
 
+	// Pick up changes to the holiday calendars.
+	if err = objects.LoadCalendars(common.HolidayDir); err != nil {
+		d.log.Printf("[ERROR] Cannot load holiday calendars: %s\n",
+			err.Error())
+	}
+
+	// notify needs a connection of its own to process the Queue, so we
+	// must not hold on to ours while we wait for room in the Queue.
+	// Otherwise, a Pool that is waiting to be restored would never fill
+	// up again.
 	db = d.pool.Get()
-	defer d.pool.Put(db)
+	reminders, err = db.ReminderGetPendingWithNotifications(deadline)
+	d.pool.Put(db)
 
-	if reminders, err = db.ReminderGetPendingWithNotifications(deadline); err != nil {
+	if err != nil {
 		d.log.Printf("[ERROR] Cannot get pending Reminders from Database: %s\n",
 			err.Error())
 		return err
 	}
 
-	for idx, r := range reminders {
-		if _, ok := d.getNotificationID(r.ID); !ok {
-			d.Queue <- &reminders[idx]
-		} else if common.Debug {
-			d.log.Printf("[TRACE] Notification for Reminder %q (%d) is already on display\n",
-				r.Title,
-				r.ID)
-		}
+	// notify checks for each Notification if it is on display already,
+	// or if it is time to escalate it.
+	for idx := range reminders {
+		d.Queue <- &reminders[idx]
 	}
 
-	return nil
+	db = d.pool.Get()
+	defer d.pool.Put(db)
+
+	return d.summarize(db)
 } // func (d *Daemon) dbCheck() error
 
-func (d *Daemon) reminderMerge(remote []objects.Reminder) error {
+// reminderMerge merges the Reminders and Tombstones we received from a
+// Peer into the local database. Whichever happened last, a change or a
+// deletion, wins. The changes are recorded in the history of the
+// Reminders under the name of the Peer.
+func (d *Daemon) reminderMerge(peer string, remote []objects.Reminder, tombs []objects.Tombstone) error {
 	var (
 		err      error
 		local    []objects.Reminder
@@ -698,7 +1536,7 @@ func (d *Daemon) reminderMerge(remote []objects.Reminder) error {
 		txStatus bool
 	)
 
-	if len(remote) == 0 {
+	if len(remote) == 0 && len(tombs) == 0 {
 		d.log.Println("[TRACE] Remote object list is empty")
 		return nil
 	}
@@ -740,8 +1578,38 @@ func (d *Daemon) reminderMerge(remote []objects.Reminder) error {
 		idmap[rem.UUID] = idx
 	}
 
-	// Donnerstag, 22. 09. 2022, 19:52 -- I have not looked at this code since I introduced recurring Reminders.
-	// The added fields are not taken into consideration at all...
+	for _, t := range tombs {
+		if lidx, ok := idmap[t.UUID]; ok {
+			var remL = local[lidx]
+
+			if !t.Supersedes(&remL) {
+				// The Reminder was changed locally after it was
+				// deleted on the Peer, so it lives on, and we
+				// will send it back.
+				continue
+			} else if err = db.ReminderDelete(&remL); err != nil {
+				errmsg = fmt.Sprintf("Failed to delete Reminder %d (%q): %s",
+					remL.ID,
+					remL.UUID,
+					err.Error())
+				d.log.Printf("[ERROR] %s\n", errmsg)
+				return errors.New(errmsg)
+			} else if err = d.recordRevisions(db, peer, &remL, nil); err != nil {
+				return err
+			}
+
+			delete(idmap, t.UUID)
+		}
+
+		if err = db.TombstoneAdd(t.UUID, t.Deleted); err != nil {
+			errmsg = fmt.Sprintf("Failed to record deletion of Reminder %s: %s",
+				t.UUID,
+				err.Error())
+			d.log.Printf("[ERROR] %s\n", errmsg)
+			return errors.New(errmsg)
+		}
+	}
+
 	for _, remR := range remote {
 		var (
 			lidx  int
@@ -751,6 +1619,56 @@ func (d *Daemon) reminderMerge(remote []objects.Reminder) error {
 		)
 
 		if lidx, ok = idmap[remR.UUID]; !ok {
+			var (
+				tomb *objects.Tombstone
+				excs = remR.Exceptions
+			)
+
+			remR.Exceptions = nil
+
+			// The web interface does not accept a Recurrence that can
+			// never go off, so we should not accept it from a Peer,
+			// either.
+			if err = remR.Recur.Validate(); err != nil {
+				d.log.Printf("[ERROR] Reject new Reminder %q (%s) from Peer %s: %s\n",
+					remR.Title,
+					remR.UUID,
+					peer,
+					err.Error())
+				continue
+			} else if tomb, err = db.TombstoneGetByUUID(remR.UUID); err != nil {
+				errmsg = fmt.Sprintf("Failed to look for Tombstone of Reminder %q (%s): %s",
+					remR.Title,
+					remR.UUID,
+					err.Error())
+				d.log.Printf("[ERROR] %s\n", errmsg)
+				return errors.New(errmsg)
+			} else if tomb != nil && tomb.Supersedes(&remR) {
+				// We deleted the Reminder after the last change
+				// the Peer knows about.
+				continue
+			} else if tomb != nil {
+				if err = db.TombstoneDelete(remR.UUID); err != nil {
+					errmsg = fmt.Sprintf("Failed to remove Tombstone of Reminder %q (%s): %s",
+						remR.Title,
+						remR.UUID,
+						err.Error())
+					d.log.Printf("[ERROR] %s\n", errmsg)
+					return errors.New(errmsg)
+				}
+			}
+
+			// Like the Peer's changes to the dependencies of a
+			// Reminder we already have, the dependencies of a new
+			// one must not close a cycle.
+			if err = d.checkDepends(db, &remR, remR.Depends); err != nil {
+				d.log.Printf("[ERROR] Drop dependencies of new Reminder %q (%s): %s\n",
+					remR.Title,
+					remR.UUID,
+					err.Error())
+				remR.Depends = nil
+			}
+
 			// Add Reminder to database
 			if err = db.ReminderAdd(&remR); err != nil {
 				errmsg = fmt.Sprintf("Failed to add Reminder %q (%s) to database: %s",
@@ -766,6 +1684,8 @@ func (d *Daemon) reminderMerge(remote []objects.Reminder) error {
 					err.Error())
 				d.log.Printf("[ERROR] %s\n", errmsg)
 				return errors.New(errmsg)
+			} else if err = d.exceptionMerge(db, &remR, excs); err != nil {
+				return err
 			} else if err = db.ReminderSetChanged(&remR, ctime); err != nil {
 				errmsg = fmt.Sprintf("Failed to set CTime on Reminder %q (%s): %s",
 					remR.Title,
@@ -773,11 +1693,20 @@ func (d *Daemon) reminderMerge(remote []objects.Reminder) error {
 					err.Error())
 				d.log.Printf("[ERROR] %s\n", errmsg)
 				return errors.New(errmsg)
+			} else if err = d.recordRevisions(db, peer, nil, &remR); err != nil {
+				return err
 			}
 		} else if remL = local[lidx]; remR.Changed.After(remL.Changed) {
+			var (
+				prev, cur *objects.Reminder
+				keepRecur bool
+			)
+
 			// Update Reminder in local database
 			// This is slightly more tedious, because we need to
 			// check *which fields* we need to update.
+			prev = remL.Clone()
+
 			if remL.Title != remR.Title {
 				if err = db.ReminderSetTitle(&remL, remR.Title); err != nil {
 					errmsg = fmt.Sprintf("Failed to update title on Reminder %d (%q): %s",
@@ -800,7 +1729,135 @@ func (d *Daemon) reminderMerge(remote []objects.Reminder) error {
 				}
 			}
 
-			if !remL.Timestamp.Equal(remR.Timestamp) {
+			if remL.TimeZone != remR.TimeZone {
+				if err = db.ReminderSetTimeZone(&remL, remR.TimeZone); err != nil {
+					errmsg = fmt.Sprintf("Failed to update time zone on Reminder %d (%q): %s",
+						remL.ID,
+						remL.UUID,
+						err.Error())
+					d.log.Printf("[ERROR] %s\n", errmsg)
+					return errors.New(errmsg)
+				}
+			}
+
+			// The due time has to be updated along with the
+			// Recurrence, because the database checks that they
+			// agree with each other.
+			if !remL.Recur.Equal(&remR.Recur) {
+				if err = remR.Recur.Validate(); err != nil {
+					d.log.Printf("[ERROR] Keep local Recurrence of Reminder %d (%q): %s\n",
+						remL.ID,
+						remL.UUID,
+						err.Error())
+					keepRecur = true
+				} else if err = db.ReminderSetRecurrence(&remL, remR.Timestamp, remR.Recur); err != nil {
+					errmsg = fmt.Sprintf("Cannot set Recurrence for Reminder %d from %s to %s: %s",
+						remL.ID,
+						&remL.Recur,
+						&remR.Recur,
+						err.Error())
+					d.log.Printf("[ERROR] %s\n",
+						errmsg)
+					return errors.New(errmsg)
+				}
+			}
+
+			if remL.AlertsString() != remR.AlertsString() {
+				if err = db.ReminderSetAlerts(&remL, remR.Alerts); err != nil {
+					errmsg = fmt.Sprintf("Failed to update pre-alerts on Reminder %d (%q): %s",
+						remL.ID,
+						remL.UUID,
+						err.Error())
+					d.log.Printf("[ERROR] %s\n", errmsg)
+					return errors.New(errmsg)
+				}
+			}
+
+			if remL.Priority != remR.Priority {
+				if err = db.ReminderSetPriority(&remL, remR.Priority); err != nil {
+					errmsg = fmt.Sprintf("Failed to update priority on Reminder %d (%q): %s",
+						remL.ID,
+						remL.UUID,
+						err.Error())
+					d.log.Printf("[ERROR] %s\n", errmsg)
+					return errors.New(errmsg)
+				}
+			}
+
+			if remL.SnoozeString() != remR.SnoozeString() {
+				if err = db.ReminderSetSnooze(&remL, remR.Snooze); err != nil {
+					errmsg = fmt.Sprintf("Failed to update snooze choices on Reminder %d (%q): %s",
+						remL.ID,
+						remL.UUID,
+						err.Error())
+					d.log.Printf("[ERROR] %s\n", errmsg)
+					return errors.New(errmsg)
+				}
+			}
+
+			if remL.TagsString() != remR.TagsString() {
+				if err = db.ReminderSetTags(&remL, remR.Tags); err != nil {
+					errmsg = fmt.Sprintf("Failed to update tags on Reminder %d (%q): %s",
+						remL.ID,
+						remL.UUID,
+						err.Error())
+					d.log.Printf("[ERROR] %s\n", errmsg)
+					return errors.New(errmsg)
+				}
+			}
+
+			if remL.ChecklistString() != remR.ChecklistString() {
+				if err = db.ReminderSetChecklist(&remL, remR.Checklist); err != nil {
+					errmsg = fmt.Sprintf("Failed to update checklist on Reminder %d (%q): %s",
+						remL.ID,
+						remL.UUID,
+						err.Error())
+					d.log.Printf("[ERROR] %s\n", errmsg)
+					return errors.New(errmsg)
+				}
+			}
+
+			if !objects.SameDepends(remL.Depends, remR.Depends) {
+				if err = d.checkDepends(db, &remL, remR.Depends); err != nil {
+					d.log.Printf("[ERROR] Keep local dependencies of Reminder %d (%q): %s\n",
+						remL.ID,
+						remL.UUID,
+						err.Error())
+				} else if err = db.ReminderSetDepends(&remL, remR.Depends); err != nil {
+					errmsg = fmt.Sprintf("Failed to update dependencies on Reminder %d (%q): %s",
+						remL.ID,
+						remL.UUID,
+						err.Error())
+					d.log.Printf("[ERROR] %s\n", errmsg)
+					return errors.New(errmsg)
+				}
+			}
+
+			if remL.Escalation != remR.Escalation {
+				if err = db.ReminderSetEscalation(&remL, remR.Escalation); err != nil {
+					errmsg = fmt.Sprintf("Failed to update escalation on Reminder %d (%q): %s",
+						remL.ID,
+						remL.UUID,
+						err.Error())
+					d.log.Printf("[ERROR] %s\n", errmsg)
+					return errors.New(errmsg)
+				}
+			}
+
+			if remL.Recur.Counter != remR.Recur.Counter {
+				if err = db.ReminderSetCounter(&remL, remR.Recur.Counter); err != nil {
+					errmsg = fmt.Sprintf("Failed to update counter on Reminder %d (%q): %s",
+						remL.ID,
+						remL.UUID,
+						err.Error())
+					d.log.Printf("[ERROR] %s\n", errmsg)
+					return errors.New(errmsg)
+				}
+			}
+
+			// If we kept the local Recurrence, the Peer's due time
+			// may not fit it.
+			if !keepRecur && !remL.Timestamp.Equal(remR.Timestamp) {
 				if err = db.ReminderSetTimestamp(&remL, remR.Timestamp); err != nil {
 					errmsg = fmt.Sprintf("Failed to update timestamp on Reminder %d (%q): %s",
 						remL.ID,
@@ -822,35 +1879,8 @@ func (d *Daemon) reminderMerge(remote []objects.Reminder) error {
 				}
 			}
 
-			// I just learned that in Go, comparing structs for
-			// equality is a-okay and works just about as one
-			// would expect.
-			if remL.Recur != remR.Recur {
-				if remL.Recur.Repeat != remR.Recur.Repeat {
-					if err = db.ReminderSetRepeat(&remL, remR.Recur.Repeat); err != nil {
-						errmsg = fmt.Sprintf("Cannot set repeat mode for Reminder %d from %s to %s: %s",
-							remL.ID,
-							remL.Recur.Repeat,
-							remR.Recur.Repeat,
-							err.Error())
-						d.log.Printf("[ERROR] %s\n",
-							errmsg)
-						return errors.New(errmsg)
-					}
-				}
-
-				if remL.Recur.Days != remR.Recur.Days {
-					if err = db.ReminderSetWeekdays(&remL, remR.Recur.Days); err != nil {
-						errmsg = fmt.Sprintf("Cannot set Weekdays for Reminder %d from %s to %s: %s",
-							remL.ID,
-							remL.Recur.Days,
-							remR.Recur.Days,
-							err.Error())
-						d.log.Printf("[ERROR] %s\n",
-							errmsg)
-						return errors.New(errmsg)
-					}
-				}
+			if err = d.exceptionMerge(db, &remL, remR.Exceptions); err != nil {
+				return err
 			}
 
 			if err = db.ReminderSetChanged(&remL, ctime); err != nil {
@@ -861,24 +1891,91 @@ func (d *Daemon) reminderMerge(remote []objects.Reminder) error {
 				d.log.Printf("[ERROR] %s\n", errmsg)
 				return errors.New(errmsg)
 			}
+
+			if cur, err = db.ReminderGetByID(remL.ID); err != nil {
+				errmsg = fmt.Sprintf("Cannot reload Reminder %d (%q): %s",
+					remL.ID,
+					remL.UUID,
+					err.Error())
+				d.log.Printf("[ERROR] %s\n", errmsg)
+				return errors.New(errmsg)
+			} else if err = d.recordRevisions(db, peer, prev, cur); err != nil {
+				return err
+			}
 		}
 	}
 
 	txStatus = true
 	return nil
-} // func (d *Daemon) reminderMerge(remote []objects.Reminder) error
+} // func (d *Daemon) reminderMerge(peer string, remote []objects.Reminder, tombs []objects.Tombstone) error
+
+// exceptionMerge brings the Exceptions of a local Reminder in line with
+// those of its remote counterpart.
+func (d *Daemon) exceptionMerge(db *database.Database, rem *objects.Reminder, remote []objects.Exception) error {
+	var (
+		err   error
+		local = make([]objects.Exception, len(rem.Exceptions))
+	)
+
+	copy(local, rem.Exceptions)
+
+	for _, e := range local {
+		var found bool
+
+		for _, x := range remote {
+			if x.Occurrence.Truncate(time.Minute).Equal(e.Occurrence.Truncate(time.Minute)) {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			if err = db.ExceptionDelete(rem, &e); err != nil {
+				d.log.Printf("[ERROR] Cannot delete Exception %s of Reminder %d (%q): %s\n",
+					e.String(),
+					rem.ID,
+					rem.UUID,
+					err.Error())
+				return err
+			}
+		}
+	}
+
+	for _, x := range remote {
+		var e = rem.Exception(x.Occurrence)
+
+		if e != nil && e.Due.Equal(x.Due) {
+			continue
+		}
+
+		x.ID = 0
+
+		if err = db.ExceptionAdd(rem, &x); err != nil {
+			d.log.Printf("[ERROR] Cannot add Exception %s to Reminder %d (%q): %s\n",
+				x.String(),
+				rem.ID,
+				rem.UUID,
+				err.Error())
+			return err
+		}
+	}
+
+	return nil
+} // func (d *Daemon) exceptionMerge(db *database.Database, rem *objects.Reminder, remote []objects.Exception) error
 
 func (d *Daemon) synchronize(peer *objects.Peer) error {
 	var (
-		err                  error
-		addr                 url.URL
-		uri                  string
-		buf                  bytes.Buffer
-		client               http.Client
-		res                  *http.Response
-		answer               objects.Response
-		remote, local, delta []objects.Reminder
-		db                   *database.Database
+		err          error
+		addr         url.URL
+		uri          string
+		buf          bytes.Buffer
+		client       http.Client
+		res          *http.Response
+		answer       objects.Response
+		remote, data *objects.SyncData
+		delta        []objects.Reminder
+		db           *database.Database
+		legacy       bool
 	)
 
 	addr = url.URL{
@@ -886,7 +1983,8 @@ func (d *Daemon) synchronize(peer *objects.Peer) error {
 		Host: fmt.Sprintf("%s:%d",
 			peer.Hostname,
 			peer.Port),
-		Path: "/sync/pull",
+		Path:     "/sync/pull",
+		RawQuery: url.Values{"peer": []string{d.hostname}}.Encode(),
 	}
 
 	uri = addr.String()
@@ -923,37 +2021,39 @@ func (d *Daemon) synchronize(peer *objects.Peer) error {
 			peer.Hostname,
 			answer)
 		return errors.New(answer.Message)
-	} else if err = ffjson.Unmarshal(buf.Bytes(), &remote); err != nil {
+	} else if remote, err = decodeSyncData(buf.Bytes()); err != nil {
 		d.log.Printf("[ERROR] Cannot decode response from %s: %s\n%s\n",
 			peer.Hostname,
 			err.Error(),
 			buf.Bytes())
 		return err
-	} else if err = d.reminderMerge(remote); err != nil {
+	} else if err = d.reminderMerge(peerName(remote, peer.Hostname), remote.Reminders, remote.Tombstones); err != nil {
 		d.log.Printf("[ERROR] Failed to merge Reminder items from %s into local database: %s\n",
 			peer.Hostname,
 			err.Error())
 		return err
 	}
 
-	var idmap = make(map[string]int, len(remote))
+	// A Peer that does not tell us its name runs an older version that
+	// knows nothing about Tombstones.
+	legacy = remote.Peer == ""
+
+	var idmap = make(map[string]int, len(remote.Reminders))
 
-	for idx, val := range remote {
+	for idx, val := range remote.Reminders {
 		idmap[val.UUID] = idx
 	}
 
 	db = d.pool.Get()
 	defer d.pool.Put(db)
 
-	if local, err = db.ReminderGetAll(); err != nil {
-		d.log.Printf("[ERROR] Cannot get Reminders from database: %s\n",
-			err.Error())
+	if data, err = d.collectSyncData(db); err != nil {
 		return err
 	}
 
 	delta = make([]objects.Reminder, 0)
 
-	for _, l := range local {
+	for _, l := range data.Reminders {
 		var (
 			idx int
 			ok  bool
@@ -962,18 +2062,29 @@ func (d *Daemon) synchronize(peer *objects.Peer) error {
 
 		if idx, ok = idmap[l.UUID]; !ok {
 			delta = append(delta, l)
-		} else if r = remote[idx]; l.IsNewer(&r) {
+		} else if r = remote.Reminders[idx]; l.IsNewer(&r) {
 			delta = append(delta, l)
 		}
 	}
 
-	if len(delta) == 0 {
+	// We always push to Peers that understand Tombstones, because the
+	// push acknowledges the data we pulled from them.
+	if legacy && len(delta) == 0 {
 		return nil
 	}
 
 	var j []byte
 
-	if j, err = ffjson.Marshal(delta); err != nil {
+	data.Reminders = delta
+	data.Ack = remote.Stamp
+
+	if legacy {
+		j, err = ffjson.Marshal(data.Reminders)
+	} else {
+		j, err = ffjson.Marshal(data)
+	}
+
+	if err != nil {
 		d.log.Printf("[ERROR] Cannot serialize Reminders to send to remote peer: %s\n",
 			err.Error())
 		return err
@@ -984,6 +2095,7 @@ func (d *Daemon) synchronize(peer *objects.Peer) error {
 	var body = bytes.NewReader(j)
 
 	addr.Path = "/sync/push"
+	addr.RawQuery = ""
 	uri = addr.String()
 
 	if res, err = client.Post(uri, "application/json", body); err != nil {
@@ -1014,6 +2126,15 @@ func (d *Daemon) synchronize(peer *objects.Peer) error {
 			peer.Hostname,
 			answer.Message)
 		return errors.New(answer.Message)
+	} else if !legacy {
+		// The Reminders are in sync at this point, so we do not fail
+		// the synchronization, but if this keeps happening, we never
+		// get to discard any Tombstones.
+		if err = d.peerSeen(db, remote.Peer, data.Stamp); err != nil {
+			d.log.Printf("[ERROR] Cannot record acknowledgement from Peer %s: %s\n",
+				peer.Hostname,
+				err.Error())
+		}
 	}
 
 	return nil
@@ -0,0 +1,378 @@
+// /home/krylon/go/src/github.com/blicero/theseus/backend/backup.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 17. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <>
+
+package backend
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/blicero/theseus/common"
+	"github.com/blicero/theseus/database"
+	"github.com/blicero/theseus/objects"
+	"github.com/gorilla/mux"
+	"github.com/pquerna/ffjson/ffjson"
+)
+
+// Backups of the database are kept in common.BackupDir, one file per
+// backup, named after the time it was made. backupLoop makes a backup
+// whenever the policy says one is due, judging by the newest backup in the
+// folder, and then removes the ones the policy no longer keeps. Backups
+// made through the web interface count towards that, too.
+
+const (
+	settingBackupInterval = "backup.interval"
+	settingBackupKeep     = "backup.keep"
+	backupStampFormat     = "20060102-150405"
+	backupCheckInterval   = time.Hour
+)
+
+var backupPat = regexp.MustCompile(fmt.Sprintf(`^%s-(\d{8}-\d{6})\.db$`,
+	strings.ToLower(common.AppName)))
+
+// backupPolicy returns the policy for scheduled backups.
+func (d *Daemon) backupPolicy(db *database.Database) (objects.BackupPolicy, error) {
+	var (
+		err    error
+		ok     bool
+		str    string
+		policy = objects.DefaultBackupPolicy
+	)
+
+	if str, ok, err = db.SettingGet(settingBackupInterval); err != nil {
+		return policy, err
+	} else if ok {
+		if policy.Interval, err = objects.ParseBackupInterval(str); err != nil {
+			d.log.Printf("[ERROR] Invalid value for %s: %s\n",
+				settingBackupInterval,
+				err.Error())
+			policy.Interval = objects.DefaultBackupPolicy.Interval
+		}
+	}
+
+	if str, ok, err = db.SettingGet(settingBackupKeep); err != nil {
+		return policy, err
+	} else if ok {
+		if policy.Keep, err = strconv.Atoi(str); err != nil || policy.Keep < 0 {
+			d.log.Printf("[ERROR] Invalid value for %s: %q\n",
+				settingBackupKeep,
+				str)
+			policy.Keep = objects.DefaultBackupPolicy.Keep
+		}
+	}
+
+	return policy, nil
+} // func (d *Daemon) backupPolicy(db *database.Database) (objects.BackupPolicy, error)
+
+// listBackups returns the backups in common.BackupDir, newest first.
+func listBackups() ([]objects.Backup, error) {
+	var (
+		err     error
+		entries []os.DirEntry
+		backups []objects.Backup
+	)
+
+	if entries, err = os.ReadDir(common.BackupDir); err != nil {
+		return nil, err
+	}
+
+	for _, e := range entries {
+		var (
+			info  os.FileInfo
+			stamp time.Time
+			m     = backupPat.FindStringSubmatch(e.Name())
+		)
+
+		if m == nil || !e.Type().IsRegular() {
+			continue
+		} else if stamp, err = time.ParseInLocation(backupStampFormat, m[1], time.Local); err != nil {
+			continue
+		} else if info, err = e.Info(); err != nil {
+			return nil, err
+		}
+
+		backups = append(backups, objects.Backup{
+			Name:  e.Name(),
+			Size:  info.Size(),
+			Stamp: stamp,
+		})
+	}
+
+	sort.Slice(backups, func(i, j int) bool { return backups[i].Stamp.After(backups[j].Stamp) })
+
+	return backups, nil
+} // func listBackups() ([]objects.Backup, error)
+
+// backup makes a backup of the database and removes the backups the policy
+// no longer keeps. It takes a connection from the Pool only after getting
+// hold of bLock, so it cannot block a restore that is waiting for the Pool.
+func (d *Daemon) backup() (*objects.Backup, error) {
+	var (
+		err     error
+		db      *database.Database
+		policy  objects.BackupPolicy
+		backups []objects.Backup
+		info    os.FileInfo
+		now     = time.Now()
+		bak     = &objects.Backup{
+			Name: fmt.Sprintf("%s-%s.db",
+				strings.ToLower(common.AppName),
+				now.Format(backupStampFormat)),
+			Stamp: now.Truncate(time.Second),
+		}
+		path = filepath.Join(common.BackupDir, bak.Name)
+	)
+
+	d.bLock.Lock()
+	defer d.bLock.Unlock()
+
+	db = d.pool.Get()
+	defer d.pool.Put(db)
+
+	if err = db.Backup(path); err != nil {
+		d.log.Printf("[ERROR] Cannot back up database to %s: %s\n",
+			path,
+			err.Error())
+		return nil, err
+	} else if info, err = os.Stat(path); err != nil {
+		d.log.Printf("[ERROR] Cannot stat backup %s: %s\n",
+			path,
+			err.Error())
+		return nil, err
+	}
+
+	bak.Size = info.Size()
+	d.log.Printf("[INFO] Backed up database to %s\n", path)
+
+	if policy, err = d.backupPolicy(db); err != nil {
+		d.log.Printf("[ERROR] Cannot load backup policy: %s\n",
+			err.Error())
+	} else if backups, err = listBackups(); err != nil {
+		d.log.Printf("[ERROR] Cannot list backups: %s\n",
+			err.Error())
+	} else {
+		for _, old := range policy.Expired(backups) {
+			d.log.Printf("[INFO] Remove expired backup %s\n", old.Name)
+			if err = os.Remove(filepath.Join(common.BackupDir, old.Name)); err != nil {
+				d.log.Printf("[ERROR] Cannot remove backup %s: %s\n",
+					old.Name,
+					err.Error())
+			}
+		}
+	}
+
+	return bak, nil
+} // func (d *Daemon) backup() (*objects.Backup, error)
+
+// backupLoop periodically checks if a scheduled backup is due.
+func (d *Daemon) backupLoop() {
+	defer d.log.Println("[TRACE] backupLoop is shutting down")
+
+	var ticker = time.NewTicker(backupCheckInterval)
+	defer ticker.Stop()
+
+	for d.IsAlive() {
+		var err error
+
+		if err = d.backupCheck(); err != nil {
+			d.log.Printf("[ERROR] Scheduled backup failed: %s\n",
+				err.Error())
+		}
+
+		<-ticker.C
+	}
+} // func (d *Daemon) backupLoop()
+
+// backupCheck makes a backup if the policy says one is due.
+func (d *Daemon) backupCheck() error {
+	var (
+		err     error
+		db      *database.Database
+		policy  objects.BackupPolicy
+		backups []objects.Backup
+		last    time.Time
+	)
+
+	db = d.pool.Get()
+	policy, err = d.backupPolicy(db)
+	d.pool.Put(db)
+
+	if err != nil {
+		return err
+	} else if backups, err = listBackups(); err != nil {
+		return err
+	} else if len(backups) > 0 {
+		last = backups[0].Stamp
+	}
+
+	if policy.Due(last, time.Now()) {
+		_, err = d.backup()
+	}
+
+	return err
+} // func (d *Daemon) backupCheck() error
+
+// restore replaces the database with the backup of the given name.
+func (d *Daemon) restore(name string) error {
+	if !backupPat.MatchString(name) {
+		return fmt.Errorf("Invalid backup name %q", name)
+	}
+
+	d.bLock.Lock()
+	defer d.bLock.Unlock()
+
+	return d.pool.Restore(filepath.Join(common.BackupDir, name))
+} // func (d *Daemon) restore(name string) error
+
+func (d *Daemon) handleBackupCreate(w http.ResponseWriter, r *http.Request) {
+	d.log.Printf("[TRACE] Handle %s from %s\n",
+		r.URL,
+		r.RemoteAddr)
+
+	var (
+		err error
+		bak *objects.Backup
+		res = objects.Response{ID: d.getID()}
+	)
+
+	if bak, err = d.backup(); err != nil {
+		res.Message = fmt.Sprintf("Cannot back up database: %s", err.Error())
+	} else {
+		res.Status = true
+		res.Message = bak.Name
+	}
+
+	d.sendResponseJSON(w, &res)
+} // func (d *Daemon) handleBackupCreate(w http.ResponseWriter, r *http.Request)
+
+func (d *Daemon) handleBackupList(w http.ResponseWriter, r *http.Request) {
+	d.log.Printf("[TRACE] Handle %s from %s\n",
+		r.URL,
+		r.RemoteAddr)
+
+	var (
+		err     error
+		backups []objects.Backup
+		buf     []byte
+	)
+
+	if backups, err = listBackups(); err != nil {
+		d.log.Printf("[ERROR] Cannot list backups: %s\n",
+			err.Error())
+	} else if buf, err = ffjson.Marshal(backups); err != nil {
+		d.log.Printf("[ERROR] Cannot serialize list of backups: %s\n",
+			err.Error())
+	}
+
+	defer ffjson.Pool(buf)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+	w.Write(buf) // nolint: errcheck
+} // func (d *Daemon) handleBackupList(w http.ResponseWriter, r *http.Request)
+
+// handleBackupPolicy reports the backup policy. If the form fields interval
+// or keep are given, it changes the policy first.
+func (d *Daemon) handleBackupPolicy(w http.ResponseWriter, r *http.Request) {
+	d.log.Printf("[TRACE] Handle %s from %s\n",
+		r.URL,
+		r.RemoteAddr)
+
+	var (
+		err    error
+		db     *database.Database
+		msg    string
+		keep   int
+		policy objects.BackupPolicy
+		res    = objects.Response{ID: d.getID()}
+	)
+
+	if err = r.ParseForm(); err != nil {
+		msg = fmt.Sprintf("Cannot parse form data: %s", err.Error())
+		d.log.Printf("[ERROR] %s\n", msg)
+		res.Message = msg
+		goto SEND_RESPONSE
+	}
+
+	db = d.pool.Get()
+	defer d.pool.Put(db)
+
+	if policy, err = d.backupPolicy(db); err != nil {
+		msg = fmt.Sprintf("Cannot load backup policy: %s", err.Error())
+		d.log.Printf("[ERROR] %s\n", msg)
+		res.Message = msg
+		goto SEND_RESPONSE
+	}
+
+	if r.Form.Has("interval") {
+		if policy.Interval, err = objects.ParseBackupInterval(r.FormValue("interval")); err != nil {
+			msg = err.Error()
+			d.log.Printf("[ERROR] %s\n", msg)
+			res.Message = msg
+			goto SEND_RESPONSE
+		} else if err = db.SettingSet(settingBackupInterval, policy.Interval.String()); err != nil {
+			msg = fmt.Sprintf("Cannot save backup interval: %s", err.Error())
+			d.log.Printf("[ERROR] %s\n", msg)
+			res.Message = msg
+			goto SEND_RESPONSE
+		}
+	}
+
+	if r.Form.Has("keep") {
+		if keep, err = strconv.Atoi(r.FormValue("keep")); err != nil || keep < 0 {
+			msg = fmt.Sprintf("Invalid number of backups to keep: %q", r.FormValue("keep"))
+			d.log.Printf("[ERROR] %s\n", msg)
+			res.Message = msg
+			goto SEND_RESPONSE
+		} else if err = db.SettingSet(settingBackupKeep, strconv.Itoa(keep)); err != nil {
+			msg = fmt.Sprintf("Cannot save number of backups to keep: %s", err.Error())
+			d.log.Printf("[ERROR] %s\n", msg)
+			res.Message = msg
+			goto SEND_RESPONSE
+		}
+
+		policy.Keep = keep
+	}
+
+	res.Status = true
+	res.Message = policy.String()
+
+SEND_RESPONSE:
+	d.sendResponseJSON(w, &res)
+} // func (d *Daemon) handleBackupPolicy(w http.ResponseWriter, r *http.Request)
+
+func (d *Daemon) handleBackupRestore(w http.ResponseWriter, r *http.Request) {
+	d.log.Printf("[TRACE] Handle %s from %s\n",
+		r.URL,
+		r.RemoteAddr)
+
+	var (
+		err  error
+		vars = mux.Vars(r)
+		name = vars["name"]
+		res  = objects.Response{ID: d.getID()}
+	)
+
+	if err = d.restore(name); err != nil {
+		res.Message = fmt.Sprintf("Cannot restore backup %s: %s",
+			name,
+			err.Error())
+		d.log.Printf("[ERROR] %s\n", res.Message)
+	} else {
+		d.log.Printf("[INFO] Restored database from backup %s\n", name)
+		res.Status = true
+		res.Message = name
+	}
+
+	d.sendResponseJSON(w, &res)
+} // func (d *Daemon) handleBackupRestore(w http.ResponseWriter, r *http.Request)
@@ -0,0 +1,156 @@
+// /home/krylon/go/src/github.com/blicero/theseus/backend/depend.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 17. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <>
+
+package backend
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/blicero/theseus/common"
+	"github.com/blicero/theseus/database"
+	"github.com/blicero/theseus/objects"
+)
+
+// blockedBy returns the Dependency that keeps the given Reminder from going
+// off, or nil if there is none.
+func (d *Daemon) blockedBy(db *database.Database, r *objects.Reminder) (*objects.Dependency, error) {
+	for i := range r.Depends {
+		var (
+			err    error
+			prereq *objects.Reminder
+			dep    = &r.Depends[i]
+		)
+
+		if dep.Kind == objects.DepAfter {
+			if prereq, err = db.ReminderGetByUUID(dep.Prereq); err != nil {
+				d.log.Printf("[ERROR] Cannot look up prerequisite %s of Reminder %d (%q): %s\n",
+					dep.Prereq,
+					r.ID,
+					r.Title,
+					err.Error())
+				return nil, err
+			}
+		}
+
+		if dep.Holds(prereq) {
+			return dep, nil
+		}
+	}
+
+	return nil, nil
+} // func (d *Daemon) blockedBy(db *database.Database, r *objects.Reminder) (*objects.Dependency, error)
+
+// triggerFollowUps schedules the follow-ups of a Reminder the user has
+// just acknowledged a Notification for.
+func (d *Daemon) triggerFollowUps(db *database.Database, rem *objects.Reminder) error {
+	var (
+		err error
+		ids []int64
+		now = time.Now()
+	)
+
+	if ids, err = db.DependencyGetFollowUps(rem); err != nil {
+		d.log.Printf("[ERROR] Cannot look up follow-ups of Reminder %d (%q): %s\n",
+			rem.ID,
+			rem.Title,
+			err.Error())
+		return err
+	}
+
+	for _, id := range ids {
+		var f *objects.Reminder
+
+		if f, err = db.ReminderGetByID(id); err != nil {
+			d.log.Printf("[ERROR] Cannot look up Reminder #%d: %s\n",
+				id,
+				err.Error())
+			return err
+		} else if f == nil {
+			continue
+		}
+
+		for _, dep := range f.Depends {
+			if dep.Kind != objects.DepFollowUp || dep.Prereq != rem.UUID {
+				continue
+			}
+
+			var due = now.Add(time.Duration(dep.Delay) * time.Second).Truncate(time.Minute)
+
+			d.log.Printf("[INFO] Schedule follow-up %d (%q) of Reminder %d (%q) for %s\n",
+				f.ID,
+				f.Title,
+				rem.ID,
+				rem.Title,
+				due.Format(common.TimestampFormat))
+
+			var prev = f.Clone()
+
+			if err = db.ReminderReactivate(f, due); err != nil {
+				d.log.Printf("[ERROR] Cannot schedule follow-up %d (%q): %s\n",
+					f.ID,
+					f.Title,
+					err.Error())
+				return err
+			} else if err = d.recordRevisions(db, "", prev, f); err != nil {
+				return err
+			} else if err = db.DependencyTrigger(f, rem.UUID, now); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+} // func (d *Daemon) triggerFollowUps(db *database.Database, rem *objects.Reminder) error
+
+// checkDepends checks if the given Dependencies make sense for the
+// Reminder, in particular that they do not create a cycle.
+func (d *Daemon) checkDepends(db *database.Database, r *objects.Reminder, deps []objects.Dependency) error {
+	var (
+		err       error
+		reminders []objects.Reminder
+		cycle     []string
+		titles    = make(map[string]string)
+		seen      = make(map[string]bool)
+	)
+
+	for i := range deps {
+		if err = deps[i].Validate(r); err != nil {
+			return err
+		} else if seen[deps[i].Prereq] {
+			return fmt.Errorf("Reminder %q depends on %s more than once",
+				r.Title,
+				deps[i].Prereq)
+		}
+		seen[deps[i].Prereq] = true
+	}
+
+	if len(deps) == 0 {
+		return nil
+	} else if reminders, err = db.ReminderGetAll(); err != nil {
+		d.log.Printf("[ERROR] Cannot load Reminders: %s\n",
+			err.Error())
+		return err
+	} else if cycle = objects.FindCycle(reminders, r.UUID, deps); cycle == nil {
+		return nil
+	}
+
+	for i := range reminders {
+		titles[reminders[i].UUID] = reminders[i].Title
+	}
+	titles[r.UUID] = r.Title
+
+	for i, uuid := range cycle {
+		if title, ok := titles[uuid]; ok {
+			cycle[i] = fmt.Sprintf("%q", title)
+		}
+	}
+
+	return fmt.Errorf("Dependencies of %q would create a cycle: %s",
+		r.Title,
+		strings.Join(cycle, " → "))
+} // func (d *Daemon) checkDepends(db *database.Database, r *objects.Reminder, deps []objects.Dependency) error
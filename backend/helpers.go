@@ -8,8 +8,15 @@ package backend
 
 import (
 	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/blicero/theseus/common"
+	"github.com/blicero/theseus/objects"
+	"github.com/blicero/theseus/objects/repeat"
+	"github.com/godbus/dbus/v5"
 	"github.com/grandcat/zeroconf"
 )
 
@@ -32,3 +39,122 @@ func rrStr(rr *zeroconf.ServiceEntry) string {
 		rr.HostName,
 		rr.Port)
 } // func rrStr(rr *zeroconf.ServiceEntry) string
+
+// mkHints returns the icon, the hints and the expire timeout for posting a
+// Notification about the given Reminder at the given escalation step.
+// The Priority of the Reminder determines the defaults, escalation can
+// only raise the urgency above that. ch is the Channel the Reminder's Tags
+// ask for, escalation can only make it louder.
+func mkHints(r *objects.Reminder, n *objects.Notification, step int, ch objects.Channel) (string, map[string]dbus.Variant, int32) {
+	var (
+		urgency = r.Priority.Urgency()
+		hints   = make(map[string]dbus.Variant)
+	)
+
+	if n.IsPreAlert() {
+		hints["category"] = dbus.MakeVariant(categoryPreAlert)
+	} else {
+		hints["category"] = dbus.MakeVariant(categoryReminder)
+
+		if r.Escalation.Enabled() {
+			if u := r.Escalation.Urgency(step); u > urgency {
+				urgency = u
+			}
+
+			if c := r.Escalation.ChannelFor(step); c > ch {
+				ch = c
+			}
+		}
+
+		if ch == objects.ChannelSound {
+			hints["sound-name"] = dbus.MakeVariant(alarmSound)
+		}
+	}
+
+	hints["urgency"] = dbus.MakeVariant(byte(urgency))
+
+	if r.Priority.Resident() {
+		hints["resident"] = dbus.MakeVariant(true)
+	}
+
+	return r.Priority.Icon(), hints, r.Priority.Timeout()
+} // func mkHints(r *objects.Reminder, n *objects.Notification, step int, ch objects.Channel) (string, map[string]dbus.Variant, int32)
+
+// mkActions returns the actions for a Notification about the given
+// Reminder, as key/label pairs. Besides acknowledging it, the user can
+// snooze it, check off the next item on its checklist, finish a recurring
+// Reminder for good (or a one-time Reminder whose checklist is not done),
+// or open a link from the description. Pre-alerts cannot be snoozed, the
+// occurrence comes up anyway.
+func mkActions(r *objects.Reminder, n *objects.Notification, snoozes []objects.Snooze) []string {
+	var actions = []string{actionOK, "OK"}
+
+	if !n.IsPreAlert() {
+		for _, z := range snoozes {
+			actions = append(actions, actionSnooze+z.String(), z.Label())
+		}
+
+		if idx := r.NextOpenItem(); idx >= 0 {
+			actions = append(actions,
+				actionCheck+strconv.Itoa(idx),
+				fmt.Sprintf("Check “%s”", r.Checklist[idx].Text))
+		}
+
+		if r.Recur.Repeat != repeat.Once {
+			actions = append(actions, actionFinish, "Done for good")
+		} else if !r.ChecklistComplete() {
+			actions = append(actions, actionFinish, "Done anyway")
+		}
+	}
+
+	if findLink(r.Description) != "" {
+		actions = append(actions, actionOpen, "Open link")
+	}
+
+	return actions
+} // func mkActions(r *objects.Reminder, n *objects.Notification, snoozes []objects.Snooze) []string
+
+// mkMessage returns the body of a Notification. Pre-alerts say how long it
+// is until the occurrence.
+func mkMessage(n *objects.Notification, body string, now time.Time) string {
+	if n.IsPreAlert() {
+		return fmt.Sprintf("Due in %s (%s) -- %s",
+			objects.FormatLead(int(n.Due().Sub(now).Seconds())),
+			n.Due().Format(common.TimestampFormatMinute),
+			body)
+	}
+
+	return fmt.Sprintf("%s -- %s",
+		n.Timestamp.Format(common.TimestampFormatMinute),
+		body)
+} // func mkMessage(n *objects.Notification, body string, now time.Time) string
+
+var linkRe = regexp.MustCompile(`(?i)\b(?:https?|ftp)://\S+`)
+
+// findLink returns the first URL in the given text, or an empty string if
+// there is none.
+func findLink(s string) string {
+	return strings.TrimRight(linkRe.FindString(s), ".,;:!?)]}>\"'")
+} // func findLink(s string) string
+
+// filterTags returns the Reminders that have all of the given Tags.
+func filterTags(reminders []objects.Reminder, tags []string) []objects.Reminder {
+	if len(tags) == 0 {
+		return reminders
+	}
+
+	var matches = make([]objects.Reminder, 0, len(reminders))
+
+REMINDER:
+	for _, r := range reminders {
+		for _, t := range tags {
+			if !r.HasTag(t) {
+				continue REMINDER
+			}
+		}
+
+		matches = append(matches, r)
+	}
+
+	return matches
+} // func filterTags(reminders []objects.Reminder, tags []string) []objects.Reminder
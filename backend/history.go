@@ -0,0 +1,228 @@
+// /home/krylon/go/src/github.com/blicero/theseus/backend/history.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 17. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <>
+
+package backend
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/blicero/theseus/database"
+	"github.com/blicero/theseus/objects"
+	"github.com/gorilla/mux"
+	"github.com/pquerna/ffjson/ffjson"
+)
+
+// defaultHistoryDays is how far back we look for the history of Reminders
+// unless the client asks for something else.
+const defaultHistoryDays = 90
+
+// defaultMostSnoozed is how many of the most-snoozed Reminders we list by
+// default.
+const defaultMostSnoozed = 5
+
+// historySince returns the start of the history the client asked for
+// in the query parameter "from".
+func historySince(r *http.Request) (time.Time, error) {
+	var str = r.URL.Query().Get("from")
+
+	if str == "" {
+		var now = time.Now()
+		return time.Date(now.Year(), now.Month(), now.Day()-defaultHistoryDays, 0, 0, 0, 0, time.Local), nil
+	}
+
+	return parseRangeTime(str)
+} // func historySince(r *http.Request) (time.Time, error)
+
+// reminderHistory loads the history of the given Reminder since the given
+// time.
+func (d *Daemon) reminderHistory(db *database.Database, r *objects.Reminder, since time.Time) (*objects.History, error) {
+	var (
+		err     error
+		notes   []objects.Notification
+		snoozes []objects.SnoozeEvent
+		hist    = new(objects.History)
+	)
+
+	if notes, err = db.NotificationGetHistory(r, since); err != nil {
+		d.log.Printf("[ERROR] Cannot load Notifications of Reminder %d (%q): %s\n",
+			r.ID,
+			r.Title,
+			err.Error())
+		return nil, err
+	} else if snoozes, err = db.SnoozeGetByReminder(r, since); err != nil {
+		d.log.Printf("[ERROR] Cannot load snoozes of Reminder %d (%q): %s\n",
+			r.ID,
+			r.Title,
+			err.Error())
+		return nil, err
+	}
+
+	hist.Items = objects.BuildHistory(notes, snoozes)
+	hist.Stats = objects.ComputeStats(r, hist.Items, objects.OnTimeWindow)
+
+	return hist, nil
+} // func (d *Daemon) reminderHistory(db *database.Database, r *objects.Reminder, since time.Time) (*objects.History, error)
+
+// handleReminderHistory sends the occurrences of a Reminder since the
+// time given in the query parameter "from" (90 days ago by default), with
+// the time it took to display and acknowledge them, along with the
+// statistics of the Reminder.
+func (d *Daemon) handleReminderHistory(w http.ResponseWriter, r *http.Request) {
+	d.log.Printf("[TRACE] Handle %s from %s\n",
+		r.URL,
+		r.RemoteAddr)
+
+	var (
+		err        error
+		idstr, msg string
+		id         int64
+		since      time.Time
+		db         *database.Database
+		rem        *objects.Reminder
+		hist       *objects.History
+		buf        []byte
+		res        = objects.Response{ID: d.getID()}
+	)
+
+	idstr = mux.Vars(r)["id"]
+
+	if id, err = strconv.ParseInt(idstr, 10, 64); err != nil {
+		msg = fmt.Sprintf("Cannot parse ID %q: %s",
+			idstr,
+			err.Error())
+		d.log.Printf("[ERROR] %s\n", msg)
+		res.Message = msg
+		d.sendResponseJSON(w, &res)
+		return
+	} else if since, err = historySince(r); err != nil {
+		msg = fmt.Sprintf("Cannot parse start of history: %s",
+			err.Error())
+		d.log.Printf("[ERROR] %s\n", msg)
+		res.Message = msg
+		d.sendResponseJSON(w, &res)
+		return
+	}
+
+	db = d.pool.Get()
+	defer d.pool.Put(db)
+
+	if rem, err = db.ReminderGetByID(id); err != nil {
+		msg = fmt.Sprintf("Cannot lookup Reminder by ID %d: %s",
+			id,
+			err.Error())
+		d.log.Printf("[ERROR] %s\n", msg)
+		res.Message = msg
+		d.sendResponseJSON(w, &res)
+		return
+	} else if rem == nil {
+		msg = fmt.Sprintf("Did not find Reminder %d in database", id)
+		d.log.Printf("[INFO] %s\n", msg)
+		res.Message = msg
+		d.sendResponseJSON(w, &res)
+		return
+	} else if hist, err = d.reminderHistory(db, rem, since); err != nil {
+		msg = fmt.Sprintf("Cannot load history of Reminder %d (%q): %s",
+			id,
+			rem.Title,
+			err.Error())
+		res.Message = msg
+		d.sendResponseJSON(w, &res)
+		return
+	} else if buf, err = ffjson.Marshal(hist); err != nil {
+		d.log.Printf("[ERROR] Cannot serialize history of Reminder %d: %s\n",
+			id,
+			err.Error())
+	}
+
+	defer ffjson.Pool(buf)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+	w.Write(buf) // nolint: errcheck
+} // func (d *Daemon) handleReminderHistory(w http.ResponseWriter, r *http.Request)
+
+// handleHistoryStats sends the statistics of all Reminders since the time
+// given in the query parameter "from", along with the ones that were
+// snoozed most often. The query parameter "max" limits the length of the
+// latter list.
+func (d *Daemon) handleHistoryStats(w http.ResponseWriter, r *http.Request) {
+	d.log.Printf("[TRACE] Handle %s from %s\n",
+		r.URL,
+		r.RemoteAddr)
+
+	var (
+		err       error
+		msg       string
+		db        *database.Database
+		reminders []objects.Reminder
+		buf       []byte
+		max       = defaultMostSnoozed
+		summary   = new(objects.HistorySummary)
+		res       = objects.Response{ID: d.getID()}
+	)
+
+	if summary.From, err = historySince(r); err != nil {
+		msg = fmt.Sprintf("Cannot parse start of history: %s",
+			err.Error())
+		d.log.Printf("[ERROR] %s\n", msg)
+		res.Message = msg
+		d.sendResponseJSON(w, &res)
+		return
+	} else if str := r.URL.Query().Get("max"); str != "" {
+		if max, err = strconv.Atoi(str); err != nil || max < 0 {
+			msg = fmt.Sprintf("Invalid number of most-snoozed Reminders: %q",
+				str)
+			d.log.Printf("[ERROR] %s\n", msg)
+			res.Message = msg
+			d.sendResponseJSON(w, &res)
+			return
+		}
+	}
+
+	db = d.pool.Get()
+	defer d.pool.Put(db)
+
+	if reminders, err = db.ReminderGetAll(); err != nil {
+		msg = fmt.Sprintf("Cannot load Reminders: %s", err.Error())
+		d.log.Printf("[ERROR] %s\n", msg)
+		res.Message = msg
+		d.sendResponseJSON(w, &res)
+		return
+	}
+
+	summary.Reminders = make([]objects.ReminderStats, 0, len(reminders))
+
+	for idx := range reminders {
+		var hist *objects.History
+
+		if hist, err = d.reminderHistory(db, &reminders[idx], summary.From); err != nil {
+			res.Message = fmt.Sprintf("Cannot load history of Reminder %d (%q): %s",
+				reminders[idx].ID,
+				reminders[idx].Title,
+				err.Error())
+			d.sendResponseJSON(w, &res)
+			return
+		} else if hist.Stats.Occurrences > 0 {
+			summary.Reminders = append(summary.Reminders, hist.Stats)
+		}
+	}
+
+	summary.MostSnoozed = objects.MostSnoozed(summary.Reminders, max)
+
+	if buf, err = ffjson.Marshal(summary); err != nil {
+		d.log.Printf("[ERROR] Cannot serialize history statistics: %s\n",
+			err.Error())
+	}
+
+	defer ffjson.Pool(buf)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+	w.Write(buf) // nolint: errcheck
+} // func (d *Daemon) handleHistoryStats(w http.ResponseWriter, r *http.Request)
@@ -0,0 +1,239 @@
+// /home/krylon/go/src/github.com/blicero/theseus/backend/quiet.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 17. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <>
+
+package backend
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/blicero/theseus/common"
+	"github.com/blicero/theseus/database"
+	"github.com/blicero/theseus/objects"
+	"github.com/godbus/dbus/v5"
+)
+
+// During quiet hours, notify holds back Notifications for all but critical
+// Reminders and marks them as deferred. Once the quiet hours are over,
+// dbCheck posts a single summary of the deferred Notifications. The user
+// can dismiss them all at once, or have them shown one by one. If the
+// summary is closed without picking either, they are shown one by one, so
+// nothing gets lost.
+
+const (
+	settingQuietHours  = "quiet.schedule"
+	settingQuietManual = "quiet.manual"
+	categorySummary    = "x-theseus.summary"
+	actionShow         = "show"
+)
+
+// quietStatus returns the current state of the quiet hours.
+func (d *Daemon) quietStatus(db *database.Database) (objects.QuietStatus, error) {
+	var (
+		err    error
+		ok     bool
+		str    string
+		q      objects.QuietHours
+		status objects.QuietStatus
+	)
+
+	if str, ok, err = db.SettingGet(settingQuietManual); err != nil {
+		return status, err
+	} else if ok {
+		if status.Manual, err = strconv.ParseBool(str); err != nil {
+			d.log.Printf("[ERROR] Invalid value for %s: %q\n",
+				settingQuietManual,
+				str)
+		}
+	}
+
+	if str, _, err = db.SettingGet(settingQuietHours); err != nil {
+		return status, err
+	} else if q, err = objects.ParseQuietHours(str); err != nil {
+		d.log.Printf("[ERROR] Invalid quiet hours in settings: %s\n",
+			err.Error())
+	}
+
+	status.Schedule = q.String()
+	status.Active = status.Manual || q.Active(time.Now())
+
+	return status, nil
+} // func (d *Daemon) quietStatus(db *database.Database) (objects.QuietStatus, error)
+
+// isQuiet returns true if we are within quiet hours. If we cannot tell,
+// we err on the side of showing Notifications.
+func (d *Daemon) isQuiet(db *database.Database) bool {
+	var (
+		err    error
+		status objects.QuietStatus
+	)
+
+	if status, err = d.quietStatus(db); err != nil {
+		d.log.Printf("[ERROR] Cannot determine if we are in quiet hours: %s\n",
+			err.Error())
+		return false
+	}
+
+	return status.Active
+} // func (d *Daemon) isQuiet(db *database.Database) bool
+
+// summarize posts a summary of the Notifications that have been deferred
+// during quiet hours, once they are over. If a summary is on display
+// already, it is replaced if more Notifications have been deferred since.
+func (d *Daemon) summarize(db *database.Database) error {
+	var (
+		err      error
+		deferred []objects.Notification
+		ids      []int64
+		lines    []string
+		same     bool
+		obj      = d.bus.Object(notifyObj, notifyPath)
+	)
+
+	if d.isQuiet(db) {
+		return nil
+	} else if deferred, err = db.NotificationGetDeferred(); err != nil {
+		d.log.Printf("[ERROR] Cannot load deferred Notifications: %s\n",
+			err.Error())
+		return err
+	} else if len(deferred) == 0 {
+		return nil
+	}
+
+	for _, n := range deferred {
+		var rem *objects.Reminder
+
+		if rem, err = db.ReminderGetByID(n.ReminderID); err != nil {
+			d.log.Printf("[ERROR] Cannot look up Reminder #%d: %s\n",
+				n.ReminderID,
+				err.Error())
+			return err
+		} else if rem == nil {
+			continue
+		} else if n.IsPreAlert() {
+			lines = append(lines, fmt.Sprintf("%s -- %s (upcoming)",
+				n.Due().Format(common.TimestampFormatMinute),
+				rem.Title))
+		} else {
+			lines = append(lines, fmt.Sprintf("%s -- %s",
+				n.Timestamp.Format(common.TimestampFormatMinute),
+				rem.Title))
+		}
+
+		ids = append(ids, n.ID)
+	}
+
+	d.nLock.RLock()
+	var summaryID = d.summaryID
+	if same = len(ids) == len(d.summarized); same {
+		for i, id := range ids {
+			if d.summarized[i] != id {
+				same = false
+				break
+			}
+		}
+	}
+	d.nLock.RUnlock()
+
+	if same && summaryID != 0 {
+		return nil
+	}
+
+	var (
+		head  = fmt.Sprintf("%d Reminders came up during quiet hours", len(ids))
+		hints = map[string]dbus.Variant{
+			"category": dbus.MakeVariant(categorySummary),
+			"urgency":  dbus.MakeVariant(byte(objects.UrgencyNormal)),
+			"resident": dbus.MakeVariant(true),
+		}
+		actions = []string{
+			actionOK,
+			"Dismiss all",
+			actionShow,
+			"Show each",
+		}
+	)
+
+	var res = obj.Call(
+		notifyMethod,
+		0,
+		common.AppName,
+		summaryID,
+		"appointment-missed",
+		head,
+		strings.Join(lines, "\n"),
+		actions,
+		hints,
+		int32(0),
+	)
+
+	if res.Err != nil {
+		d.log.Printf("[ERROR] Cannot send summary of deferred Notifications: %s\n",
+			res.Err.Error())
+		return res.Err
+	}
+
+	var ret uint32
+
+	if err = res.Store(&ret); err != nil {
+		d.log.Printf("[ERROR] Cannot store return value of %s: %s\n",
+			notifyMethod,
+			err.Error())
+		return err
+	}
+
+	d.nLock.Lock()
+	d.summaryID = ret
+	d.summarized = ids
+	d.nLock.Unlock()
+
+	return nil
+} // func (d *Daemon) summarize(db *database.Database) error
+
+// handleSummary deals with the user's reaction to the summary of deferred
+// Notifications. An empty action means the summary was closed. It returns
+// false if the given ID is not the summary.
+func (d *Daemon) handleSummary(id uint32, action string) bool {
+	var (
+		err error
+		db  *database.Database
+		ids []int64
+	)
+
+	d.nLock.Lock()
+	if id == 0 || id != d.summaryID {
+		d.nLock.Unlock()
+		return false
+	}
+
+	ids = d.summarized
+	d.summaryID = 0
+	d.summarized = nil
+	d.nLock.Unlock()
+
+	db = d.pool.Get()
+	defer d.pool.Put(db)
+
+	for _, nid := range ids {
+		var n *objects.Notification
+
+		if action == actionOK {
+			err = d.finish(db, nid, false)
+		} else if n, err = db.NotificationGetByID(nid); err == nil && n != nil {
+			err = db.NotificationSetDeferred(n, time.Time{})
+		}
+
+		if err != nil {
+			d.log.Printf("[ERROR] Cannot process deferred Notification %d: %s\n",
+				nid,
+				err.Error())
+		}
+	}
+
+	return true
+} // func (d *Daemon) handleSummary(id uint32, action string) bool
@@ -0,0 +1,473 @@
+// /home/krylon/go/src/github.com/blicero/theseus/backend/revision.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 17. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <>
+
+package backend
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/blicero/theseus/database"
+	"github.com/blicero/theseus/objects"
+	"github.com/gorilla/mux"
+	"github.com/pquerna/ffjson/ffjson"
+)
+
+// Every change to a Reminder, be it an edit, finishing it or deleting it,
+// is recorded as a list of Revisions, one for each field that changed.
+// The history is append-only: Undoing an operation or reverting a
+// Reminder to an earlier Revision is an operation in its own right, that
+// can in turn be undone.
+
+// maxUndo is the largest number of operations a client can undo at once.
+const maxUndo = 100
+
+// recordRevisions records the changes between two versions of a Reminder
+// as one operation. peer is the Peer the changes came from, empty if they
+// were made locally.
+func (d *Daemon) recordRevisions(db *database.Database, peer string, before, after *objects.Reminder) error {
+	var (
+		err  error
+		revs []objects.Revision
+	)
+
+	if revs, err = objects.RevisionsBetween(before, after); err != nil {
+		d.log.Printf("[ERROR] Cannot compute changes to Reminder: %s\n",
+			err.Error())
+		return err
+	} else if _, err = db.RevisionAdd(peer, 0, revs); err != nil {
+		d.log.Printf("[ERROR] Cannot record changes to Reminder: %s\n",
+			err.Error())
+		return err
+	}
+
+	return nil
+} // func (d *Daemon) recordRevisions(db *database.Database, peer string, before, after *objects.Reminder) error
+
+// reminderApply updates the fields of the Reminder cur we keep Revisions
+// for to the values they have in target.
+func (d *Daemon) reminderApply(db *database.Database, cur, target *objects.Reminder) error {
+	for _, name := range objects.RevisionFields {
+		var (
+			err            error
+			oldVal, newVal string
+		)
+
+		if oldVal, err = cur.FieldValue(name); err != nil {
+			return err
+		} else if newVal, err = target.FieldValue(name); err != nil {
+			return err
+		} else if oldVal == newVal {
+			continue
+		}
+
+		switch name {
+		case "Title":
+			err = db.ReminderSetTitle(cur, target.Title)
+		case "Description":
+			err = db.ReminderSetDescription(cur, target.Description)
+		case "TimeZone":
+			err = db.ReminderSetTimeZone(cur, target.TimeZone)
+		case "Recur":
+			err = db.ReminderSetRecurrence(cur, target.Timestamp, target.Recur)
+		case "Timestamp":
+			err = db.ReminderSetTimestamp(cur, target.Timestamp)
+		case "Alerts":
+			err = db.ReminderSetAlerts(cur, target.Alerts)
+		case "Escalation":
+			err = db.ReminderSetEscalation(cur, target.Escalation)
+		case "Priority":
+			err = db.ReminderSetPriority(cur, target.Priority)
+		case "Snooze":
+			err = db.ReminderSetSnooze(cur, target.Snooze)
+		case "Tags":
+			err = db.ReminderSetTags(cur, target.Tags)
+		case "Checklist":
+			err = db.ReminderSetChecklist(cur, target.Checklist)
+		case "Depends":
+			if err = d.checkDepends(db, cur, target.Depends); err == nil {
+				err = db.ReminderSetDepends(cur, target.Depends)
+			}
+		case "Finished":
+			err = db.ReminderSetFinished(cur, target.Finished)
+		}
+
+		if err != nil {
+			d.log.Printf("[ERROR] Cannot restore %s of Reminder %d (%q): %s\n",
+				name,
+				cur.ID,
+				cur.Title,
+				err.Error())
+			return err
+		}
+	}
+
+	return nil
+} // func (d *Daemon) reminderApply(db *database.Database, cur, target *objects.Reminder) error
+
+// reminderRestore brings the Reminder with the given UUID into the state
+// given by target, deleting it if target is nil, or bringing it back if it
+// has been deleted. It returns the Revisions that describe the change.
+func (d *Daemon) reminderRestore(db *database.Database, uuid string, target *objects.Reminder) ([]objects.Revision, error) {
+	var (
+		err         error
+		cur, before *objects.Reminder
+	)
+
+	if cur, err = db.ReminderGetByUUID(uuid); err != nil {
+		d.log.Printf("[ERROR] Cannot look up Reminder %s: %s\n",
+			uuid,
+			err.Error())
+		return nil, err
+	}
+
+	switch {
+	case cur == nil && target == nil:
+		return nil, nil
+	case target == nil:
+		if err = db.ReminderDelete(cur); err != nil {
+			return nil, err
+		} else if err = db.TombstoneAdd(uuid, time.Now()); err != nil {
+			return nil, err
+		}
+
+		return objects.RevisionsBetween(cur, nil)
+	case cur == nil:
+		var (
+			rem  = target.Clone()
+			excs = rem.Exceptions
+		)
+
+		rem.ID = 0
+		rem.Exceptions = nil
+
+		if err = db.ReminderAdd(rem); err != nil {
+			return nil, err
+		} else if err = db.ReminderSetFinished(rem, rem.Finished); err != nil {
+			return nil, err
+		} else if err = d.exceptionMerge(db, rem, excs); err != nil {
+			return nil, err
+		} else if err = db.TombstoneDelete(uuid); err != nil {
+			return nil, err
+		}
+
+		return objects.RevisionsBetween(nil, rem)
+	}
+
+	before = cur.Clone()
+
+	if err = d.reminderApply(db, cur, target); err != nil {
+		return nil, err
+	} else if cur, err = db.ReminderGetByID(cur.ID); err != nil {
+		return nil, err
+	}
+
+	return objects.RevisionsBetween(before, cur)
+} // func (d *Daemon) reminderRestore(db *database.Database, uuid string, target *objects.Reminder) ([]objects.Revision, error)
+
+// reminderRevert brings a Reminder back to the state it was in right after
+// the given Revision. It returns the ID of the operation that records the
+// change, 0 if nothing had to be changed.
+func (d *Daemon) reminderRevert(db *database.Database, rev *objects.Revision) (int64, error) {
+	var (
+		err         error
+		cur, target *objects.Reminder
+		all, later  []objects.Revision
+		revs        []objects.Revision
+	)
+
+	if all, err = db.RevisionGetByUUID(rev.UUID); err != nil {
+		return 0, err
+	}
+
+	for _, r := range all {
+		if r.Op > rev.Op {
+			later = append(later, r)
+		}
+	}
+
+	if cur, err = db.ReminderGetByUUID(rev.UUID); err != nil {
+		return 0, err
+	} else if target, err = objects.Rewind(cur, later); err != nil {
+		return 0, err
+	} else if revs, err = d.reminderRestore(db, rev.UUID, target); err != nil {
+		return 0, err
+	}
+
+	return db.RevisionAdd("", 0, revs)
+} // func (d *Daemon) reminderRevert(db *database.Database, rev *objects.Revision) (int64, error)
+
+// reminderUndo takes back the last cnt local operations that have not been
+// undone, yet, and returns how many it undid.
+func (d *Daemon) reminderUndo(db *database.Database, cnt int) (int, error) {
+	var (
+		err error
+		ops []int64
+	)
+
+	if ops, err = db.RevisionGetUndoable(cnt); err != nil {
+		return 0, err
+	}
+
+	for _, op := range ops {
+		var (
+			revs, undo []objects.Revision
+			uuids      []string
+			byUUID     = make(map[string][]objects.Revision)
+		)
+
+		if revs, err = db.RevisionGetByOp(op); err != nil {
+			return 0, err
+		}
+
+		for _, r := range revs {
+			if _, ok := byUUID[r.UUID]; !ok {
+				uuids = append(uuids, r.UUID)
+			}
+			byUUID[r.UUID] = append(byUUID[r.UUID], r)
+		}
+
+		for _, uuid := range uuids {
+			var (
+				cur, target *objects.Reminder
+				changes     []objects.Revision
+			)
+
+			if cur, err = db.ReminderGetByUUID(uuid); err != nil {
+				return 0, err
+			} else if target, err = objects.Rewind(cur, byUUID[uuid]); err != nil {
+				return 0, err
+			} else if changes, err = d.reminderRestore(db, uuid, target); err != nil {
+				return 0, err
+			}
+
+			undo = append(undo, changes...)
+		}
+
+		// Even if there is nothing left to change, because the fields
+		// have been changed back in the meantime, we record that the
+		// operation has been undone.
+		if _, err = db.RevisionAdd("", op, undo); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(ops), nil
+} // func (d *Daemon) reminderUndo(db *database.Database, cnt int) (int, error)
+
+// handleReminderRevisions sends the Revisions of a Reminder, oldest first.
+func (d *Daemon) handleReminderRevisions(w http.ResponseWriter, r *http.Request) {
+	d.log.Printf("[TRACE] Handle %s from %s\n",
+		r.URL,
+		r.RemoteAddr)
+
+	var (
+		err        error
+		idstr, msg string
+		id         int64
+		db         *database.Database
+		rem        *objects.Reminder
+		revs       []objects.Revision
+		buf        []byte
+		res        = objects.Response{ID: d.getID()}
+	)
+
+	idstr = mux.Vars(r)["id"]
+
+	if id, err = strconv.ParseInt(idstr, 10, 64); err != nil {
+		msg = fmt.Sprintf("Cannot parse ID %q: %s",
+			idstr,
+			err.Error())
+		d.log.Printf("[ERROR] %s\n", msg)
+		res.Message = msg
+		d.sendResponseJSON(w, &res)
+		return
+	}
+
+	db = d.pool.Get()
+	defer d.pool.Put(db)
+
+	if rem, err = db.ReminderGetByID(id); err != nil {
+		msg = fmt.Sprintf("Cannot lookup Reminder by ID %d: %s",
+			id,
+			err.Error())
+		d.log.Printf("[ERROR] %s\n", msg)
+		res.Message = msg
+		d.sendResponseJSON(w, &res)
+		return
+	} else if rem == nil {
+		msg = fmt.Sprintf("Did not find Reminder %d in database", id)
+		d.log.Printf("[INFO] %s\n", msg)
+		res.Message = msg
+		d.sendResponseJSON(w, &res)
+		return
+	} else if revs, err = db.RevisionGetByUUID(rem.UUID); err != nil {
+		msg = fmt.Sprintf("Cannot load Revisions of Reminder %d (%q): %s",
+			id,
+			rem.Title,
+			err.Error())
+		d.log.Printf("[ERROR] %s\n", msg)
+		res.Message = msg
+		d.sendResponseJSON(w, &res)
+		return
+	} else if buf, err = ffjson.Marshal(revs); err != nil {
+		d.log.Printf("[ERROR] Cannot serialize Revisions of Reminder %d: %s\n",
+			id,
+			err.Error())
+	}
+
+	defer ffjson.Pool(buf)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+	w.Write(buf) // nolint: errcheck
+} // func (d *Daemon) handleReminderRevisions(w http.ResponseWriter, r *http.Request)
+
+// handleRevisionRevert brings a Reminder back to the state it was in right
+// after the given Revision.
+func (d *Daemon) handleRevisionRevert(w http.ResponseWriter, r *http.Request) {
+	d.log.Printf("[TRACE] Handle %s from %s\n",
+		r.URL,
+		r.RemoteAddr)
+
+	var (
+		err        error
+		idstr, msg string
+		id, op     int64
+		db         *database.Database
+		rev        *objects.Revision
+		res        = objects.Response{ID: d.getID()}
+	)
+
+	idstr = mux.Vars(r)["id"]
+
+	if id, err = strconv.ParseInt(idstr, 10, 64); err != nil {
+		msg = fmt.Sprintf("Cannot parse ID %q: %s",
+			idstr,
+			err.Error())
+		d.log.Printf("[ERROR] %s\n", msg)
+		res.Message = msg
+		goto SEND_RESPONSE
+	}
+
+	db = d.pool.Get()
+	defer d.pool.Put(db)
+
+	if err = db.Begin(); err != nil {
+		msg = fmt.Sprintf("Cannot begin transaction: %s",
+			err.Error())
+		d.log.Printf("[ERROR] %s\n", msg)
+		res.Message = msg
+		goto SEND_RESPONSE
+	} else if rev, err = db.RevisionGetByID(id); err != nil {
+		msg = fmt.Sprintf("Cannot lookup Revision %d: %s",
+			id,
+			err.Error())
+		d.log.Printf("[ERROR] %s\n", msg)
+		res.Message = msg
+		db.Rollback() // nolint: errcheck
+		goto SEND_RESPONSE
+	} else if rev == nil {
+		msg = fmt.Sprintf("Did not find Revision %d in database", id)
+		d.log.Printf("[INFO] %s\n", msg)
+		res.Message = msg
+		db.Rollback() // nolint: errcheck
+		goto SEND_RESPONSE
+	} else if op, err = d.reminderRevert(db, rev); err != nil {
+		msg = fmt.Sprintf("Failed to revert Reminder %s to Revision %d: %s",
+			rev.UUID,
+			id,
+			err.Error())
+		d.log.Printf("[ERROR] %s\n", msg)
+		res.Message = msg
+		db.Rollback() // nolint: errcheck
+		goto SEND_RESPONSE
+	} else if err = db.Commit(); err != nil {
+		msg = fmt.Sprintf("Cannot commit transaction: %s",
+			err.Error())
+		d.log.Printf("[ERROR] %s\n", msg)
+		res.Message = msg
+		goto SEND_RESPONSE
+	}
+
+	if op == 0 {
+		res.Message = fmt.Sprintf("Reminder %s is unchanged since Revision %d",
+			rev.UUID,
+			id)
+	} else {
+		res.Message = fmt.Sprintf("Reverted Reminder %s to Revision %d",
+			rev.UUID,
+			id)
+	}
+	res.Status = true
+
+SEND_RESPONSE:
+	d.sendResponseJSON(w, &res)
+} // func (d *Daemon) handleRevisionRevert(w http.ResponseWriter, r *http.Request)
+
+// handleRevisionUndo takes back the last local operations. The query
+// parameter "n" says how many, one by default.
+func (d *Daemon) handleRevisionUndo(w http.ResponseWriter, r *http.Request) {
+	d.log.Printf("[TRACE] Handle %s from %s\n",
+		r.URL,
+		r.RemoteAddr)
+
+	var (
+		err      error
+		msg      string
+		cnt, num int
+		db       *database.Database
+		res      = objects.Response{ID: d.getID()}
+	)
+
+	cnt = 1
+
+	if str := r.URL.Query().Get("n"); str != "" {
+		if cnt, err = strconv.Atoi(str); err != nil || cnt <= 0 || cnt > maxUndo {
+			msg = fmt.Sprintf("Invalid number of operations to undo: %q", str)
+			d.log.Printf("[ERROR] %s\n", msg)
+			res.Message = msg
+			goto SEND_RESPONSE
+		}
+	}
+
+	db = d.pool.Get()
+	defer d.pool.Put(db)
+
+	if err = db.Begin(); err != nil {
+		msg = fmt.Sprintf("Cannot begin transaction: %s",
+			err.Error())
+		d.log.Printf("[ERROR] %s\n", msg)
+		res.Message = msg
+		goto SEND_RESPONSE
+	} else if num, err = d.reminderUndo(db, cnt); err != nil {
+		msg = fmt.Sprintf("Failed to undo the last %d operations: %s",
+			cnt,
+			err.Error())
+		d.log.Printf("[ERROR] %s\n", msg)
+		res.Message = msg
+		db.Rollback() // nolint: errcheck
+		goto SEND_RESPONSE
+	} else if err = db.Commit(); err != nil {
+		msg = fmt.Sprintf("Cannot commit transaction: %s",
+			err.Error())
+		d.log.Printf("[ERROR] %s\n", msg)
+		res.Message = msg
+		goto SEND_RESPONSE
+	}
+
+	if num == 0 {
+		res.Message = "Nothing to undo"
+	} else {
+		res.Message = fmt.Sprintf("Undid %d operations", num)
+	}
+	res.Status = true
+
+SEND_RESPONSE:
+	d.sendResponseJSON(w, &res)
+} // func (d *Daemon) handleRevisionUndo(w http.ResponseWriter, r *http.Request)
@@ -0,0 +1,127 @@
+// /home/krylon/go/src/github.com/blicero/theseus/backend/tombstone.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 17. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <>
+
+package backend
+
+import (
+	"bytes"
+	"time"
+
+	"github.com/blicero/theseus/database"
+	"github.com/blicero/theseus/objects"
+	"github.com/pquerna/ffjson/ffjson"
+)
+
+// When a Reminder is deleted, we keep a Tombstone around, so the deletion
+// is passed on to our Peers when we synchronize. Once all Peers we know of
+// have seen a Tombstone, we can let go of it. Peers we have not heard
+// from in a long time are forgotten, so they do not keep Tombstones around
+// forever.
+//
+// Synchronizing happens in two steps, the initiating Peer pulls our data,
+// merges it, then pushes its own data to us. The push acknowledges the
+// pull, so we know the other Peer has seen our Tombstones, and a
+// successful push tells the initiating Peer the same.
+//
+// Older versions of the program exchanged plain lists of Reminders
+// without any Tombstones, we still understand those.
+
+// peerExpiry is how long we remember a Peer we have not synchronized with.
+const peerExpiry = 90 * 24 * time.Hour
+
+// decodeSyncData parses the data sent by a Peer during synchronization.
+// Legacy Peers send a list of Reminders rather than a SyncData object.
+func decodeSyncData(buf []byte) (*objects.SyncData, error) {
+	var (
+		err  error
+		data = new(objects.SyncData)
+	)
+
+	if bytes.HasPrefix(bytes.TrimSpace(buf), []byte("[")) {
+		err = ffjson.Unmarshal(buf, &data.Reminders)
+	} else {
+		err = ffjson.Unmarshal(buf, data)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return data, nil
+} // func decodeSyncData(buf []byte) (*objects.SyncData, error)
+
+// peerName returns the name a Peer sent along with its data. Legacy Peers
+// do not send one, so we go by the address we know them by.
+func peerName(data *objects.SyncData, addr string) string {
+	if data.Peer != "" {
+		return data.Peer
+	}
+
+	return addr
+} // func peerName(data *objects.SyncData, addr string) string
+
+// collectSyncData assembles the Reminders and Tombstones we send to a Peer.
+func (d *Daemon) collectSyncData(db *database.Database) (*objects.SyncData, error) {
+	var (
+		err  error
+		data = &objects.SyncData{
+			Peer:  d.hostname,
+			Stamp: time.Now(),
+		}
+	)
+
+	if data.Reminders, err = db.ReminderGetAll(); err != nil {
+		d.log.Printf("[ERROR] Cannot get list of Reminders from database: %s\n",
+			err.Error())
+		return nil, err
+	} else if data.Tombstones, err = db.TombstoneGetAll(); err != nil {
+		d.log.Printf("[ERROR] Cannot get list of Tombstones from database: %s\n",
+			err.Error())
+		return nil, err
+	}
+
+	return data, nil
+} // func (d *Daemon) collectSyncData(db *database.Database) (*objects.SyncData, error)
+
+// peerSeen records that the given Peer has seen our Tombstones up to the
+// given time and discards the Tombstones all Peers have seen.
+func (d *Daemon) peerSeen(db *database.Database, peer string, seen time.Time) error {
+	var (
+		err    error
+		cnt    int64
+		ok     bool
+		cutoff time.Time
+		peers  []objects.PeerState
+	)
+
+	if peer == "" || seen.IsZero() {
+		return nil
+	} else if err = db.PeerSetSeen(peer, seen); err != nil {
+		d.log.Printf("[ERROR] Cannot record that Peer %s has seen our Tombstones: %s\n",
+			peer,
+			err.Error())
+		return err
+	} else if err = db.PeerPurge(time.Now().Add(-peerExpiry)); err != nil {
+		d.log.Printf("[ERROR] Cannot forget Peers we have not seen in a while: %s\n",
+			err.Error())
+		return err
+	} else if peers, err = db.PeerGetAll(); err != nil {
+		d.log.Printf("[ERROR] Cannot load Peers: %s\n",
+			err.Error())
+		return err
+	} else if cutoff, ok = objects.TombstoneCutoff(peers); !ok {
+		return nil
+	} else if cnt, err = db.TombstonePurge(cutoff); err != nil {
+		d.log.Printf("[ERROR] Cannot purge Tombstones: %s\n",
+			err.Error())
+		return err
+	} else if cnt > 0 {
+		d.log.Printf("[DEBUG] Purged %d Tombstones all Peers have seen\n",
+			cnt)
+	}
+
+	return nil
+} // func (d *Daemon) peerSeen(db *database.Database, peer string, seen time.Time) error
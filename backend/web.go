@@ -11,32 +11,63 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/blicero/theseus/common"
 	"github.com/blicero/theseus/database"
 	"github.com/blicero/theseus/objects"
+	"github.com/blicero/theseus/objects/repeat"
 	"github.com/gorilla/mux"
 	"github.com/pquerna/ffjson/ffjson"
 )
 
 func (d *Daemon) initWebHandlers() error {
 	d.router.HandleFunc("/reminder/add", d.handleReminderAdd)
+	d.router.HandleFunc("/reminder/quickadd", d.handleReminderQuickAdd)
 	d.router.HandleFunc("/reminder/pending", d.handleReminderGetPending)
 	d.router.HandleFunc("/reminder/all", d.handleReminderGetAll)
+	d.router.HandleFunc("/reminder/search", d.handleReminderSearch)
+	d.router.HandleFunc("/reminder/occurrences", d.handleReminderGetOccurrences)
 	d.router.HandleFunc("/reminder/edit/title", d.handleReminderSetTitle)
 	d.router.HandleFunc("/reminder/edit/timestamp", d.handleReminderSetTimestamp)
 	d.router.HandleFunc("/reminder/{id:(?:\\d+)}/update", d.handleReminderUpdate)
 	d.router.HandleFunc("/reminder/{id:(?:\\d+)}/reactivate", d.handleReminderReactivate)
 	d.router.HandleFunc("/reminder/{id:(?:\\d+)}/delete", d.handleReminderDelete)
+	d.router.HandleFunc("/reminder/{id:(?:\\d+)}/exception", d.handleReminderExceptionAdd)
+	d.router.HandleFunc("/reminder/{id:(?:\\d+)}/exception/{eid:(?:\\d+)}/delete", d.handleReminderExceptionDelete)
 	d.router.HandleFunc("/reminder/{id:(?:\\d+)}/set_finished/{flag:(?i:\\w+)}", d.handleReminderSetFinished)
+	d.router.HandleFunc("/reminder/{id:(?:\\d+)}/checklist/{idx:(?:\\d+)}/{flag:(?i:\\w+)}", d.handleReminderCheckItem)
+	d.router.HandleFunc("/reminder/{id:(?:\\d+)}/history", d.handleReminderHistory)
+	d.router.HandleFunc("/reminder/{id:(?:\\d+)}/revisions", d.handleReminderRevisions)
+
+	d.router.HandleFunc("/revision/{id:(?:\\d+)}/revert", d.handleRevisionRevert)
+	d.router.HandleFunc("/revision/undo", d.handleRevisionUndo)
+
+	d.router.HandleFunc("/history/stats", d.handleHistoryStats)
+
+	d.router.HandleFunc("/tag/all", d.handleTagGetAll)
+	d.router.HandleFunc("/tag/set", d.handleTagSet)
+	d.router.HandleFunc("/tag/{name}/delete", d.handleTagDelete)
+
+	d.router.HandleFunc("/settings/snooze", d.handleSettingsSnooze)
+	d.router.HandleFunc("/dnd/status", d.handleQuietStatus)
+	d.router.HandleFunc("/dnd/schedule", d.handleQuietSchedule)
+	d.router.HandleFunc("/dnd/{flag:(?i:on|off|true|false)}", d.handleQuietSet)
 
 	d.router.HandleFunc("/peer/all", d.handlePeerListGet)
 	d.router.HandleFunc("/sync/pull", d.handleReminderSyncPull)
 	d.router.HandleFunc("/sync/push", d.handleReminderSyncPush)
 	d.router.HandleFunc("/sync/start", d.handleReminderSyncStart)
 
+	d.router.HandleFunc("/admin/maintenance", d.handleDBMaintenance)
+	d.router.HandleFunc("/admin/backup", d.handleBackupCreate)
+	d.router.HandleFunc("/admin/backup/list", d.handleBackupList)
+	d.router.HandleFunc("/admin/backup/policy", d.handleBackupPolicy)
+	d.router.HandleFunc("/admin/backup/{name}/restore", d.handleBackupRestore)
+
 	return nil
 } // func (d *Daemon) initWebHandlers() error
 
@@ -58,10 +89,31 @@ func (d *Daemon) serveHTTP() {
 	}
 } // func (d *Daemon) serveHTTP()
 
+// handleDBMaintenance checkpoints the write-ahead log and vacuums, reindexes
+// and analyzes the database.
 func (d *Daemon) handleDBMaintenance(w http.ResponseWriter, r *http.Request) {
 	d.log.Printf("[TRACE] Handle %s from %s\n",
 		r.URL,
 		r.RemoteAddr)
+
+	var (
+		err error
+		db  *database.Database
+		res = objects.Response{ID: d.getID()}
+	)
+
+	db = d.pool.Get()
+	defer d.pool.Put(db)
+
+	if err = db.PerformMaintenance(); err != nil {
+		res.Message = fmt.Sprintf("Database maintenance failed: %s", err.Error())
+		d.log.Printf("[ERROR] %s\n", res.Message)
+	} else {
+		res.Status = true
+		res.Message = "Database maintenance complete"
+	}
+
+	d.sendResponseJSON(w, &res)
 } // func (d *Daemon) handleDBMaintenance(w http.ResponseWriter, r *http.Request)
 
 func (d *Daemon) handleReminderAdd(w http.ResponseWriter, r *http.Request) {
@@ -87,6 +139,71 @@ func (d *Daemon) handleReminderAdd(w http.ResponseWriter, r *http.Request) {
 			err.Error())
 		response.Message = err.Error()
 		goto SEND_RESPONSE
+	} else if rem.TimeZone == "" {
+		rem.TimeZone = common.LocalZoneName()
+	} else if _, err = time.LoadLocation(rem.TimeZone); err != nil {
+		msg = fmt.Sprintf("Invalid time zone %q: %s",
+			rem.TimeZone,
+			err.Error())
+		d.log.Printf("[ERROR] %s\n", msg)
+		response.Message = msg
+		goto SEND_RESPONSE
+	}
+
+	if rem.Recur.Repeat == repeat.RRule {
+		if err = rem.SetRRule(rem.Recur.Rule); err != nil {
+			msg = fmt.Sprintf("Invalid recurrence rule %q: %s",
+				rem.Recur.Rule,
+				err.Error())
+			d.log.Printf("[ERROR] %s\n", msg)
+			response.Message = msg
+			goto SEND_RESPONSE
+		}
+	}
+
+	// This sorts the times of day, and rejects those that are out of range.
+	if err = rem.Recur.SetTimes(rem.Recur.TimesString()); err != nil {
+		msg = fmt.Sprintf("Invalid times of day: %s", err.Error())
+		d.log.Printf("[ERROR] %s\n", msg)
+		response.Message = msg
+		goto SEND_RESPONSE
+	}
+
+	if err = rem.SetAlerts(rem.AlertsString()); err != nil {
+		msg = fmt.Sprintf("Invalid pre-alerts: %s", err.Error())
+		d.log.Printf("[ERROR] %s\n", msg)
+		response.Message = msg
+		goto SEND_RESPONSE
+	} else if err = rem.Escalation.Validate(); err != nil {
+		msg = err.Error()
+		d.log.Printf("[ERROR] %s\n", msg)
+		response.Message = msg
+		goto SEND_RESPONSE
+	} else if err = rem.Recur.Validate(); err != nil {
+		msg = fmt.Sprintf("Invalid recurrence: %s", err.Error())
+		d.log.Printf("[ERROR] %s\n", msg)
+		response.Message = msg
+		goto SEND_RESPONSE
+	} else if !rem.Priority.Valid() {
+		msg = fmt.Sprintf("Invalid priority: %s", rem.Priority)
+		d.log.Printf("[ERROR] %s\n", msg)
+		response.Message = msg
+		goto SEND_RESPONSE
+	} else if err = rem.SetSnooze(rem.SnoozeString()); err != nil {
+		msg = fmt.Sprintf("Invalid snooze choices: %s", err.Error())
+		d.log.Printf("[ERROR] %s\n", msg)
+		response.Message = msg
+		goto SEND_RESPONSE
+	} else if err = rem.SetTags(rem.TagsString()); err != nil {
+		msg = fmt.Sprintf("Invalid tags: %s", err.Error())
+		d.log.Printf("[ERROR] %s\n", msg)
+		response.Message = msg
+		goto SEND_RESPONSE
+	} else if err = rem.SetChecklist(rem.ChecklistString()); err != nil {
+		msg = fmt.Sprintf("Invalid checklist: %s", err.Error())
+		d.log.Printf("[ERROR] %s\n", msg)
+		response.Message = msg
+		goto SEND_RESPONSE
 	}
 
 	rem.UUID = common.GetUUID()
@@ -94,13 +211,24 @@ func (d *Daemon) handleReminderAdd(w http.ResponseWriter, r *http.Request) {
 	db = d.pool.Get()
 	defer d.pool.Put(db)
 
-	if err = db.ReminderAdd(&rem); err != nil {
+	if err = d.checkDepends(db, &rem, rem.Depends); err != nil {
+		msg = fmt.Sprintf("Invalid dependencies: %s", err.Error())
+		d.log.Printf("[ERROR] %s\n", msg)
+		response.Message = msg
+		goto SEND_RESPONSE
+	} else if err = db.ReminderAdd(&rem); err != nil {
 		msg = fmt.Sprintf("Cannot add Reminder %q to database: %s",
 			rem.Title,
 			err.Error())
 		d.log.Printf("[ERROR] %s\n", msg)
 		response.Message = msg
 		goto SEND_RESPONSE
+	} else if err = d.recordRevisions(db, "", nil, &rem); err != nil {
+		msg = fmt.Sprintf("Cannot record creation of Reminder %q: %s",
+			rem.Title,
+			err.Error())
+		response.Message = msg
+		goto SEND_RESPONSE
 	}
 
 	response.Message = rem.UUID
@@ -110,6 +238,92 @@ SEND_RESPONSE:
 	d.sendResponseJSON(w, &response)
 } // func (d *Daemon) handleReminderAdd(w http.ResponseWriter, r *http.Request)
 
+// handleReminderQuickAdd creates a Reminder from a phrase like "call mom
+// tomorrow at 9" (form field "text"). The phrase is interpreted in the time
+// zone given in the form field "tz", or the local time zone if there is
+// none.
+func (d *Daemon) handleReminderQuickAdd(w http.ResponseWriter, r *http.Request) {
+	d.log.Printf("[TRACE] Handle %s from %s\n",
+		r.URL,
+		r.RemoteAddr)
+
+	var (
+		err       error
+		rem       *objects.Reminder
+		db        *database.Database
+		msg, text string
+		loc       = time.Local
+		response  = objects.Response{ID: d.getID()}
+	)
+
+	if err = r.ParseForm(); err != nil {
+		d.log.Printf("[ERROR] Cannot parse form data: %s\n",
+			err.Error())
+		response.Message = err.Error()
+		goto SEND_RESPONSE
+	} else if text = strings.TrimSpace(r.PostFormValue("text")); text == "" {
+		msg = "No text was given"
+		d.log.Printf("[ERROR] %s\n", msg)
+		response.Message = msg
+		goto SEND_RESPONSE
+	} else if tz := r.PostFormValue("tz"); tz != "" {
+		if loc, err = time.LoadLocation(tz); err != nil {
+			msg = fmt.Sprintf("Invalid time zone %q: %s",
+				tz,
+				err.Error())
+			d.log.Printf("[ERROR] %s\n", msg)
+			response.Message = msg
+			goto SEND_RESPONSE
+		}
+	}
+
+	if rem, err = objects.ParseReminder(text, time.Now().In(loc)); err != nil {
+		msg = fmt.Sprintf("Cannot parse %q: %s",
+			text,
+			err.Error())
+		d.log.Printf("[ERROR] %s\n", msg)
+		response.Message = msg
+		goto SEND_RESPONSE
+	} else if err = rem.Recur.Validate(); err != nil {
+		msg = fmt.Sprintf("Invalid recurrence in %q: %s",
+			text,
+			err.Error())
+		d.log.Printf("[ERROR] %s\n", msg)
+		response.Message = msg
+		goto SEND_RESPONSE
+	} else if rem.TimeZone == "" {
+		rem.TimeZone = common.LocalZoneName()
+	}
+
+	rem.UUID = common.GetUUID()
+
+	db = d.pool.Get()
+	defer d.pool.Put(db)
+
+	if err = db.ReminderAdd(rem); err != nil {
+		msg = fmt.Sprintf("Cannot add Reminder %q to database: %s",
+			rem.Title,
+			err.Error())
+		d.log.Printf("[ERROR] %s\n", msg)
+		response.Message = msg
+		goto SEND_RESPONSE
+	} else if err = d.recordRevisions(db, "", nil, rem); err != nil {
+		msg = fmt.Sprintf("Cannot record creation of Reminder %q: %s",
+			rem.Title,
+			err.Error())
+		response.Message = msg
+		goto SEND_RESPONSE
+	}
+
+	response.Message = fmt.Sprintf("Added %q, due %s",
+		rem.Title,
+		rem.DueNext(nil).In(loc).Format(common.TimestampFormatMinute))
+	response.Status = true
+
+SEND_RESPONSE:
+	d.sendResponseJSON(w, &response)
+} // func (d *Daemon) handleReminderQuickAdd(w http.ResponseWriter, r *http.Request)
+
 func (d *Daemon) handleReminderGetPending(w http.ResponseWriter, r *http.Request) {
 	// d.log.Printf("[TRACE] Handle %s from %s\n",
 	// 	r.URL,
@@ -144,6 +358,9 @@ func (d *Daemon) handleReminderGetPending(w http.ResponseWriter, r *http.Request
 	w.Write(buf) // nolint: errcheck
 } // func (d *Daemon) handleReminderGetPending(w http.ResponseWriter, r *http.Request)
 
+// handleReminderGetAll returns all Reminders. If the query string contains
+// one or more tag parameters, only the Reminders that have all of the given
+// Tags are returned.
 func (d *Daemon) handleReminderGetAll(w http.ResponseWriter, r *http.Request) {
 	// d.log.Printf("[TRACE] Handle %s from %s\n",
 	// 	r.URL,
@@ -154,6 +371,7 @@ func (d *Daemon) handleReminderGetAll(w http.ResponseWriter, r *http.Request) {
 		db        *database.Database
 		reminders []objects.Reminder
 		buf       []byte
+		tags      = r.URL.Query()["tag"]
 	)
 
 	db = d.pool.Get()
@@ -163,7 +381,7 @@ func (d *Daemon) handleReminderGetAll(w http.ResponseWriter, r *http.Request) {
 		d.log.Printf("[ERROR] Cannot load Reminders: %s\n",
 			err.Error())
 
-	} else if buf, err = ffjson.Marshal(reminders); err != nil {
+	} else if buf, err = ffjson.Marshal(filterTags(reminders, tags)); err != nil {
 		d.log.Printf("[ERROR] Cannot serialize Reminder list: %s\n",
 			err.Error())
 
@@ -176,6 +394,237 @@ func (d *Daemon) handleReminderGetAll(w http.ResponseWriter, r *http.Request) {
 	w.Write(buf) // nolint: errcheck
 } // func (d *Daemon) handleReminderGetAll(w http.ResponseWriter, r *http.Request)
 
+// defaultSearchResults is how many Reminders a search returns unless the
+// client asks for something else.
+const defaultSearchResults = 50
+
+// handleReminderSearch sends the Reminders whose title or description
+// match the query parameter "q", best matches first. The query parameter
+// "max" limits the number of results.
+func (d *Daemon) handleReminderSearch(w http.ResponseWriter, r *http.Request) {
+	d.log.Printf("[TRACE] Handle %s from %s\n",
+		r.URL,
+		r.RemoteAddr)
+
+	var (
+		err     error
+		msg     string
+		db      *database.Database
+		results []objects.SearchResult
+		buf     []byte
+		max     = defaultSearchResults
+		q       = r.URL.Query().Get("q")
+		res     = objects.Response{ID: d.getID()}
+	)
+
+	if str := r.URL.Query().Get("max"); str != "" {
+		if max, err = strconv.Atoi(str); err != nil || max <= 0 {
+			msg = fmt.Sprintf("Invalid number of search results: %q", str)
+			d.log.Printf("[ERROR] %s\n", msg)
+			res.Message = msg
+			d.sendResponseJSON(w, &res)
+			return
+		}
+	}
+
+	db = d.pool.Get()
+	defer d.pool.Put(db)
+
+	if results, err = db.ReminderSearch(q, max); err != nil {
+		msg = fmt.Sprintf("Cannot search for %q: %s",
+			q,
+			err.Error())
+		d.log.Printf("[ERROR] %s\n", msg)
+		res.Message = msg
+		d.sendResponseJSON(w, &res)
+		return
+	} else if buf, err = ffjson.Marshal(results); err != nil {
+		d.log.Printf("[ERROR] Cannot serialize search results: %s\n",
+			err.Error())
+	}
+
+	defer ffjson.Pool(buf)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+	w.Write(buf) // nolint: errcheck
+} // func (d *Daemon) handleReminderSearch(w http.ResponseWriter, r *http.Request)
+
+// maxOccurrenceWindow is the longest interval a client can ask for the
+// occurrences of all Reminders.
+const maxOccurrenceWindow = 366 * 24 * time.Hour
+
+// parseRangeTime parses the boundary of an interval given by a client, either
+// as an RFC3339 timestamp or as a date, which is taken to mean midnight,
+// local time.
+func parseRangeTime(s string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+
+	return time.ParseInLocation(common.TimestampFormatDate, s, time.Local)
+} // func parseRangeTime(s string) (time.Time, error)
+
+// handleReminderGetOccurrences sends the occurrences of all Reminders in the
+// interval [from, to), along with the state of their Notifications.
+// Both ends of the interval are optional, by default it covers the
+// week starting today.
+func (d *Daemon) handleReminderGetOccurrences(w http.ResponseWriter, r *http.Request) {
+	d.log.Printf("[TRACE] Handle %s from %s\n",
+		r.URL,
+		r.RemoteAddr)
+
+	var (
+		err           error
+		msg           string
+		db            *database.Database
+		reminders     []objects.Reminder
+		notifications []objects.Notification
+		occ           []objects.Occurrence
+		buf           []byte
+		from, to      time.Time
+		now           = time.Now()
+		res           = objects.Response{ID: d.getID()}
+	)
+
+	if str := r.URL.Query().Get("from"); str == "" {
+		from = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	} else if from, err = parseRangeTime(str); err != nil {
+		msg = fmt.Sprintf("Cannot parse start of interval %q: %s",
+			str,
+			err.Error())
+		d.log.Printf("[ERROR] %s\n", msg)
+		res.Message = msg
+		d.sendResponseJSON(w, &res)
+		return
+	}
+
+	if str := r.URL.Query().Get("to"); str == "" {
+		to = from.AddDate(0, 0, 7)
+	} else if to, err = parseRangeTime(str); err != nil {
+		msg = fmt.Sprintf("Cannot parse end of interval %q: %s",
+			str,
+			err.Error())
+		d.log.Printf("[ERROR] %s\n", msg)
+		res.Message = msg
+		d.sendResponseJSON(w, &res)
+		return
+	}
+
+	if !from.Before(to) {
+		msg = fmt.Sprintf("Invalid interval: %s is not before %s",
+			from.Format(common.TimestampFormat),
+			to.Format(common.TimestampFormat))
+		d.log.Printf("[ERROR] %s\n", msg)
+		res.Message = msg
+		d.sendResponseJSON(w, &res)
+		return
+	} else if to.Sub(from) > maxOccurrenceWindow {
+		msg = fmt.Sprintf("Interval is too long: %s - %s (max. %d days)",
+			from.Format(common.TimestampFormat),
+			to.Format(common.TimestampFormat),
+			maxOccurrenceWindow/(24*time.Hour))
+		d.log.Printf("[ERROR] %s\n", msg)
+		res.Message = msg
+		d.sendResponseJSON(w, &res)
+		return
+	}
+
+	db = d.pool.Get()
+	defer d.pool.Put(db)
+
+	if reminders, err = db.ReminderGetAll(); err != nil {
+		msg = fmt.Sprintf("Cannot load Reminders: %s", err.Error())
+		d.log.Printf("[ERROR] %s\n", msg)
+		res.Message = msg
+		d.sendResponseJSON(w, &res)
+		return
+	} else if notifications, err = db.NotificationGetByRange(from, to); err != nil {
+		msg = fmt.Sprintf("Cannot load Notifications: %s", err.Error())
+		d.log.Printf("[ERROR] %s\n", msg)
+		res.Message = msg
+		d.sendResponseJSON(w, &res)
+		return
+	}
+
+	occ = mergeOccurrences(reminders, notifications, from, to)
+
+	if buf, err = ffjson.Marshal(occ); err != nil {
+		d.log.Printf("[ERROR] Cannot serialize Occurrence list: %s\n",
+			err.Error())
+	}
+
+	defer ffjson.Pool(buf)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+	w.Write(buf) // nolint: errcheck
+} // func (d *Daemon) handleReminderGetOccurrences(w http.ResponseWriter, r *http.Request)
+
+// mergeOccurrences expands the Reminders into their occurrences in the
+// interval [from, to) and attaches the matching Notifications. Notifications
+// that do not match any occurrence, e.g. because the Reminder has been
+// rescheduled since, are added as occurrences of their own, as long as
+// the Reminder still exists.
+func mergeOccurrences(reminders []objects.Reminder, notifications []objects.Notification, from, to time.Time) []objects.Occurrence {
+	type occKey struct {
+		id    int64
+		stamp int64
+	}
+
+	var (
+		occ    = objects.Expand(reminders, from, to)
+		idx    = make(map[occKey]int, len(occ))
+		titles = make(map[int64]string, len(reminders))
+		extra  bool
+	)
+
+	for i := range reminders {
+		titles[reminders[i].ID] = reminders[i].Title
+	}
+
+	for i := range occ {
+		idx[occKey{occ[i].ReminderID, occ[i].Timestamp.Unix()}] = i
+	}
+
+	for i := range notifications {
+		var (
+			n     = &notifications[i]
+			key   = occKey{n.ReminderID, n.Timestamp.Truncate(time.Minute).Unix()}
+			title string
+			ok    bool
+		)
+
+		// Pre-alerts are not occurrences in their own right.
+		if n.IsPreAlert() {
+			continue
+		} else if j, found := idx[key]; found {
+			occ[j].SetNotification(n)
+		} else if title, ok = titles[n.ReminderID]; ok {
+			var o = objects.Occurrence{
+				ReminderID: n.ReminderID,
+				Title:      title,
+				Timestamp:  n.Timestamp,
+			}
+
+			o.SetNotification(n)
+			occ = append(occ, o)
+			extra = true
+		}
+	}
+
+	if extra {
+		sort.SliceStable(occ, func(i, j int) bool {
+			if occ[i].Timestamp.Equal(occ[j].Timestamp) {
+				return occ[i].ReminderID < occ[j].ReminderID
+			}
+			return occ[i].Timestamp.Before(occ[j].Timestamp)
+		})
+	}
+
+	return occ
+} // func mergeOccurrences(reminders []objects.Reminder, notifications []objects.Notification, from, to time.Time) []objects.Occurrence
+
 func (d *Daemon) handleReminderSetTitle(w http.ResponseWriter, r *http.Request) {
 	d.log.Printf("[TRACE] Handle %s from %s\n",
 		r.URL,
@@ -186,7 +635,7 @@ func (d *Daemon) handleReminderSetTitle(w http.ResponseWriter, r *http.Request)
 		db                *database.Database
 		idstr, title, msg string
 		id                int64
-		rem               *objects.Reminder
+		rem, prev         *objects.Reminder
 		response          = objects.Response{ID: d.getID()}
 	)
 
@@ -226,7 +675,11 @@ func (d *Daemon) handleReminderSetTitle(w http.ResponseWriter, r *http.Request)
 		d.log.Printf("[DEBUG] %s\n", msg)
 		response.Message = msg
 		goto SEND_RESPONSE
-	} else if err = db.ReminderSetTitle(rem, title); err != nil {
+	}
+
+	prev = rem.Clone()
+
+	if err = db.ReminderSetTitle(rem, title); err != nil {
 		msg = fmt.Sprintf("Cannot update Title of Reminder %d (%q): %s",
 			id,
 			rem.Title,
@@ -234,6 +687,12 @@ func (d *Daemon) handleReminderSetTitle(w http.ResponseWriter, r *http.Request)
 		d.log.Printf("[ERROR] %s\n", err.Error())
 		response.Message = msg
 		goto SEND_RESPONSE
+	} else if err = d.recordRevisions(db, "", prev, rem); err != nil {
+		msg = fmt.Sprintf("Cannot record change of Title for Reminder %d: %s",
+			id,
+			err.Error())
+		response.Message = msg
+		goto SEND_RESPONSE
 	}
 
 	response.Message = fmt.Sprintf("Title was updated to %q", title)
@@ -254,7 +713,7 @@ func (d *Daemon) handleReminderSetTimestamp(w http.ResponseWriter, r *http.Reque
 		tstr, idstr, msg string
 		id               int64
 		t                time.Time
-		rem              *objects.Reminder
+		rem, prev        *objects.Reminder
 		res              = objects.Response{ID: d.getID()}
 	)
 
@@ -294,7 +753,17 @@ func (d *Daemon) handleReminderSetTimestamp(w http.ResponseWriter, r *http.Reque
 		d.log.Printf("[ERROR] %s\n", msg)
 		res.Message = msg
 		goto SEND_RESPONSE
-	} else if err = db.ReminderSetTimestamp(rem, t); err != nil {
+	} else if rem == nil {
+		msg = fmt.Sprintf("Reminder #%d was not found in database",
+			id)
+		d.log.Printf("[DEBUG] %s\n", msg)
+		res.Message = msg
+		goto SEND_RESPONSE
+	}
+
+	prev = rem.Clone()
+
+	if err = db.ReminderSetTimestamp(rem, t); err != nil {
 		msg = fmt.Sprintf("Failed to update Timestamp of Reminder %d (%q) to %s\n",
 			id,
 			rem.Title,
@@ -302,6 +771,12 @@ func (d *Daemon) handleReminderSetTimestamp(w http.ResponseWriter, r *http.Reque
 		d.log.Printf("[ERROR] %s\n", msg)
 		res.Message = msg
 		goto SEND_RESPONSE
+	} else if err = d.recordRevisions(db, "", prev, rem); err != nil {
+		msg = fmt.Sprintf("Cannot record change of Timestamp for Reminder %d: %s",
+			id,
+			err.Error())
+		res.Message = msg
+		goto SEND_RESPONSE
 	}
 
 	res.Status = true
@@ -322,6 +797,7 @@ func (d *Daemon) handleReminderUpdate(w http.ResponseWriter, r *http.Request) {
 		jstr, msg string
 		remR      objects.Reminder
 		remL      *objects.Reminder
+		prev, cur *objects.Reminder
 		res       = objects.Response{ID: d.getID()}
 		txStatus  bool
 	)
@@ -342,23 +818,92 @@ func (d *Daemon) handleReminderUpdate(w http.ResponseWriter, r *http.Request) {
 		res.Message = msg
 		d.log.Printf("[ERROR] %s\n", msg)
 		goto SEND_RESPONSE
+	} else if remR.TimeZone != "" {
+		if _, err = time.LoadLocation(remR.TimeZone); err != nil {
+			msg = fmt.Sprintf("Invalid time zone %q: %s",
+				remR.TimeZone,
+				err.Error())
+			d.log.Printf("[ERROR] %s\n", msg)
+			res.Message = msg
+			goto SEND_RESPONSE
+		}
 	}
 
-	db = d.pool.Get()
-	defer d.pool.Put(db)
+	if remR.Recur.Repeat == repeat.RRule {
+		if err = remR.SetRRule(remR.Recur.Rule); err != nil {
+			msg = fmt.Sprintf("Invalid recurrence rule %q: %s",
+				remR.Recur.Rule,
+				err.Error())
+			d.log.Printf("[ERROR] %s\n", msg)
+			res.Message = msg
+			goto SEND_RESPONSE
+		}
+	}
 
-	if remL, err = db.ReminderGetByID(remR.ID); err != nil {
-		msg = fmt.Sprintf("Failed to look up Reminder #%d: %s",
-			remR.ID,
-			err.Error())
+	if err = remR.Recur.SetTimes(remR.Recur.TimesString()); err != nil {
+		msg = fmt.Sprintf("Invalid times of day: %s", err.Error())
 		d.log.Printf("[ERROR] %s\n", msg)
 		res.Message = msg
 		goto SEND_RESPONSE
-	} else if remL == nil {
-		msg = fmt.Sprintf("Could not find Reminder #%d in database", remR.ID)
+	}
+
+	if err = remR.SetAlerts(remR.AlertsString()); err != nil {
+		msg = fmt.Sprintf("Invalid pre-alerts: %s", err.Error())
+		d.log.Printf("[ERROR] %s\n", msg)
+		res.Message = msg
+		goto SEND_RESPONSE
+	} else if err = remR.Escalation.Validate(); err != nil {
+		msg = err.Error()
+		d.log.Printf("[ERROR] %s\n", msg)
+		res.Message = msg
+		goto SEND_RESPONSE
+	} else if err = remR.Recur.Validate(); err != nil {
+		msg = fmt.Sprintf("Invalid recurrence: %s", err.Error())
+		d.log.Printf("[ERROR] %s\n", msg)
+		res.Message = msg
+		goto SEND_RESPONSE
+	} else if !remR.Priority.Valid() {
+		msg = fmt.Sprintf("Invalid priority: %s", remR.Priority)
+		d.log.Printf("[ERROR] %s\n", msg)
+		res.Message = msg
+		goto SEND_RESPONSE
+	} else if err = remR.SetSnooze(remR.SnoozeString()); err != nil {
+		msg = fmt.Sprintf("Invalid snooze choices: %s", err.Error())
+		d.log.Printf("[ERROR] %s\n", msg)
+		res.Message = msg
+		goto SEND_RESPONSE
+	} else if err = remR.SetTags(remR.TagsString()); err != nil {
+		msg = fmt.Sprintf("Invalid tags: %s", err.Error())
+		d.log.Printf("[ERROR] %s\n", msg)
+		res.Message = msg
+		goto SEND_RESPONSE
+	} else if err = remR.SetChecklist(remR.ChecklistString()); err != nil {
+		msg = fmt.Sprintf("Invalid checklist: %s", err.Error())
+		d.log.Printf("[ERROR] %s\n", msg)
+		res.Message = msg
+		goto SEND_RESPONSE
+	}
+
+	db = d.pool.Get()
+	defer d.pool.Put(db)
+
+	if remL, err = db.ReminderGetByID(remR.ID); err != nil {
+		msg = fmt.Sprintf("Failed to look up Reminder #%d: %s",
+			remR.ID,
+			err.Error())
+		d.log.Printf("[ERROR] %s\n", msg)
+		res.Message = msg
+		goto SEND_RESPONSE
+	} else if remL == nil {
+		msg = fmt.Sprintf("Could not find Reminder #%d in database", remR.ID)
 		d.log.Printf("[DEBUG] %s\n", msg)
 		res.Message = msg
 		goto SEND_RESPONSE
+	} else if err = d.checkDepends(db, remL, remR.Depends); err != nil {
+		msg = fmt.Sprintf("Invalid dependencies: %s", err.Error())
+		d.log.Printf("[ERROR] %s\n", msg)
+		res.Message = msg
+		goto SEND_RESPONSE
 	} else if err = db.Begin(); err != nil {
 		msg = fmt.Sprintf("Error starting transaction: %s",
 			err.Error())
@@ -367,6 +912,121 @@ func (d *Daemon) handleReminderUpdate(w http.ResponseWriter, r *http.Request) {
 		goto SEND_RESPONSE
 	}
 
+	prev = remL.Clone()
+
+	if remL.TimeZone != remR.TimeZone {
+		if err = db.ReminderSetTimeZone(remL, remR.TimeZone); err != nil {
+			msg = fmt.Sprintf("Failed to update time zone of Reminder %d from %q to %q: %s",
+				remL.ID,
+				remL.TimeZone,
+				remR.TimeZone,
+				err.Error())
+			d.log.Printf("[ERROR] %s\n", msg)
+			res.Message = msg
+			goto SEND_RESPONSE
+		}
+	}
+
+	if remL.Recur.Repeat != remR.Recur.Repeat ||
+		remL.Recur.Days != remR.Recur.Days ||
+		remL.Recur.Day != remR.Recur.Day ||
+		remL.Recur.Week != remR.Recur.Week ||
+		remL.Recur.Fallback != remR.Recur.Fallback ||
+		remL.Recur.Month != remR.Recur.Month ||
+		remL.Recur.Period != remR.Recur.Period ||
+		remL.Recur.Rule != remR.Recur.Rule ||
+		remL.Recur.Limit != remR.Recur.Limit ||
+		remL.Recur.TimesString() != remR.Recur.TimesString() ||
+		remL.Recur.Calendar != remR.Recur.Calendar ||
+		remL.Recur.Holidays != remR.Recur.Holidays ||
+		!remL.Recur.Until.Equal(remR.Recur.Until) {
+		if err = db.ReminderSetRecurrence(remL, remR.Timestamp, remR.Recur); err != nil {
+			msg = fmt.Sprintf("Error updating Recurrence on Reminder %d: %s",
+				remL.ID,
+				err.Error())
+			d.log.Printf("[ERROR] %s\n", msg)
+			res.Message = msg
+			goto SEND_RESPONSE
+		}
+	}
+
+	if remL.AlertsString() != remR.AlertsString() {
+		if err = db.ReminderSetAlerts(remL, remR.Alerts); err != nil {
+			msg = fmt.Sprintf("Error updating pre-alerts on Reminder %d: %s",
+				remL.ID,
+				err.Error())
+			d.log.Printf("[ERROR] %s\n", msg)
+			res.Message = msg
+			goto SEND_RESPONSE
+		}
+	}
+
+	if remL.Priority != remR.Priority {
+		if err = db.ReminderSetPriority(remL, remR.Priority); err != nil {
+			msg = fmt.Sprintf("Error updating priority on Reminder %d: %s",
+				remL.ID,
+				err.Error())
+			d.log.Printf("[ERROR] %s\n", msg)
+			res.Message = msg
+			goto SEND_RESPONSE
+		}
+	}
+
+	if remL.SnoozeString() != remR.SnoozeString() {
+		if err = db.ReminderSetSnooze(remL, remR.Snooze); err != nil {
+			msg = fmt.Sprintf("Error updating snooze choices on Reminder %d: %s",
+				remL.ID,
+				err.Error())
+			d.log.Printf("[ERROR] %s\n", msg)
+			res.Message = msg
+			goto SEND_RESPONSE
+		}
+	}
+
+	if remL.TagsString() != remR.TagsString() {
+		if err = db.ReminderSetTags(remL, remR.Tags); err != nil {
+			msg = fmt.Sprintf("Error updating tags on Reminder %d: %s",
+				remL.ID,
+				err.Error())
+			d.log.Printf("[ERROR] %s\n", msg)
+			res.Message = msg
+			goto SEND_RESPONSE
+		}
+	}
+
+	if remL.ChecklistString() != remR.ChecklistString() {
+		if err = db.ReminderSetChecklist(remL, remR.Checklist); err != nil {
+			msg = fmt.Sprintf("Error updating checklist on Reminder %d: %s",
+				remL.ID,
+				err.Error())
+			d.log.Printf("[ERROR] %s\n", msg)
+			res.Message = msg
+			goto SEND_RESPONSE
+		}
+	}
+
+	if !objects.SameDepends(remL.Depends, remR.Depends) {
+		if err = db.ReminderSetDepends(remL, remR.Depends); err != nil {
+			msg = fmt.Sprintf("Error updating dependencies on Reminder %d: %s",
+				remL.ID,
+				err.Error())
+			d.log.Printf("[ERROR] %s\n", msg)
+			res.Message = msg
+			goto SEND_RESPONSE
+		}
+	}
+
+	if remL.Escalation != remR.Escalation {
+		if err = db.ReminderSetEscalation(remL, remR.Escalation); err != nil {
+			msg = fmt.Sprintf("Error updating escalation on Reminder %d: %s",
+				remL.ID,
+				err.Error())
+			d.log.Printf("[ERROR] %s\n", msg)
+			res.Message = msg
+			goto SEND_RESPONSE
+		}
+	}
+
 	if durAbs(remR.Timestamp.Sub(remL.Timestamp)) > time.Minute {
 		if err = db.ReminderSetTimestamp(remL, remR.Timestamp); err != nil {
 			msg = fmt.Sprintf("Error updating timestamp on Reminder %d: %s",
@@ -402,6 +1062,21 @@ func (d *Daemon) handleReminderUpdate(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	if cur, err = db.ReminderGetByID(remL.ID); err != nil {
+		msg = fmt.Sprintf("Failed to reload Reminder %d: %s",
+			remL.ID,
+			err.Error())
+		d.log.Printf("[ERROR] %s\n", msg)
+		res.Message = msg
+		goto SEND_RESPONSE
+	} else if err = d.recordRevisions(db, "", prev, cur); err != nil {
+		msg = fmt.Sprintf("Failed to record changes to Reminder %d: %s",
+			remL.ID,
+			err.Error())
+		res.Message = msg
+		goto SEND_RESPONSE
+	}
+
 	res.Status = true
 	res.Message = "OK"
 	txStatus = true
@@ -415,7 +1090,7 @@ SEND_RESPONSE:
 			res.Message = msg
 			res.Status = false
 		}
-	} else {
+	} else if db != nil {
 		if err = db.Rollback(); err != nil {
 			msg = fmt.Sprintf("Failed to rollback transaction: %s",
 				err.Error())
@@ -437,7 +1112,7 @@ func (d *Daemon) handleReminderReactivate(w http.ResponseWriter, r *http.Request
 		idstr, msg string
 		id         int64
 		db         *database.Database
-		rem        *objects.Reminder
+		rem, prev  *objects.Reminder
 		res        = objects.Response{ID: d.getID()}
 	)
 
@@ -475,6 +1150,32 @@ func (d *Daemon) handleReminderReactivate(w http.ResponseWriter, r *http.Request
 		d.log.Printf("[INFO] %s\n", msg)
 		res.Message = msg
 		goto SEND_RESPONSE
+	}
+
+	prev = rem.Clone()
+
+	if rem.Recur.Repeat != repeat.Once {
+		// Recurring Reminders keep their schedule, but if they finished
+		// because they reached their Limit, they start counting anew.
+		if err = db.ReminderSetFinished(rem, false); err != nil {
+			msg = fmt.Sprintf("Cannot clear Finished flag for Reminder %d (%q): %s",
+				id,
+				rem.Title,
+				err.Error())
+			d.log.Printf("[ERROR] %s\n", msg)
+			res.Message = msg
+			goto SEND_RESPONSE
+		} else if rem.Recur.Limit > 0 && rem.Recur.Counter >= rem.Recur.Limit {
+			if err = db.ReminderResetCounter(rem); err != nil {
+				msg = fmt.Sprintf("Cannot reset counter for Reminder %d (%q): %s",
+					id,
+					rem.Title,
+					err.Error())
+				d.log.Printf("[ERROR] %s\n", msg)
+				res.Message = msg
+				goto SEND_RESPONSE
+			}
+		}
 		// } else if err = db.ReminderSetFinished(rem, false); err != nil {
 	} else if err = db.ReminderReactivate(rem, time.Now().Add(time.Minute*60)); err != nil {
 		msg = fmt.Sprintf("Cannot clear Finished flag for Reminder %d (%q): %s",
@@ -494,6 +1195,14 @@ func (d *Daemon) handleReminderReactivate(w http.ResponseWriter, r *http.Request
 		goto SEND_RESPONSE
 	} */
 
+	if err = d.recordRevisions(db, "", prev, rem); err != nil {
+		res.Message = fmt.Sprintf("Cannot record reactivation of Reminder %d (%q): %s",
+			id,
+			rem.Title,
+			err.Error())
+		goto SEND_RESPONSE
+	}
+
 	res.Status = true
 
 SEND_RESPONSE:
@@ -518,7 +1227,7 @@ func (d *Daemon) handleReminderSetFinished(w http.ResponseWriter, r *http.Reques
 		id                  int64
 		flag                bool
 		db                  *database.Database
-		rem                 *objects.Reminder
+		rem, prev           *objects.Reminder
 		res                 = objects.Response{ID: d.getID()}
 	)
 
@@ -564,7 +1273,11 @@ func (d *Daemon) handleReminderSetFinished(w http.ResponseWriter, r *http.Reques
 		res.Status = true
 		res.Message = msg
 		goto SEND_RESPONSE
-	} else if err = db.ReminderSetFinished(rem, flag); err != nil {
+	}
+
+	prev = rem.Clone()
+
+	if err = db.ReminderSetFinished(rem, flag); err != nil {
 		msg = fmt.Sprintf("Cannot set Finished flag for Reminder %q (%d) to %t: %s\n",
 			rem.Title,
 			rem.ID,
@@ -572,6 +1285,12 @@ func (d *Daemon) handleReminderSetFinished(w http.ResponseWriter, r *http.Reques
 			err.Error())
 		d.log.Printf("[ERROR] %s\n", msg)
 		goto SEND_RESPONSE
+	} else if err = d.recordRevisions(db, "", prev, rem); err != nil {
+		msg = fmt.Sprintf("Cannot record change of Finished flag for Reminder %q (%d): %s",
+			rem.Title,
+			rem.ID,
+			err.Error())
+		goto SEND_RESPONSE
 	} else {
 		res.Status = true
 		res.Message = "Success"
@@ -587,6 +1306,94 @@ SEND_RESPONSE:
 	d.sendResponseJSON(w, &res)
 } // func (d *Daemon) handleReminderSetFinished(w http.ResponseWriter, r *http.request)
 
+// handleReminderCheckItem checks or unchecks an item on a Reminder's
+// checklist.
+func (d *Daemon) handleReminderCheckItem(w http.ResponseWriter, r *http.Request) {
+	d.log.Printf("[TRACE] Handle %s from %s\n",
+		r.URL,
+		r.RemoteAddr)
+
+	var (
+		err                 error
+		vars                map[string]string
+		idstr, msg, flagStr string
+		id                  int64
+		idx                 int
+		flag                bool
+		db                  *database.Database
+		rem                 *objects.Reminder
+		res                 = objects.Response{ID: d.getID()}
+	)
+
+	vars = mux.Vars(r)
+
+	idstr = vars["id"]
+	flagStr = vars["flag"]
+
+	if id, err = strconv.ParseInt(idstr, 10, 64); err != nil {
+		msg = fmt.Sprintf("Cannot parse ID %q: %s",
+			idstr,
+			err.Error())
+		d.log.Printf("[ERROR] %s\n", msg)
+		res.Message = msg
+		goto SEND_RESPONSE
+	} else if idx, err = strconv.Atoi(vars["idx"]); err != nil {
+		msg = fmt.Sprintf("Cannot parse checklist index %q: %s",
+			vars["idx"],
+			err.Error())
+		d.log.Printf("[ERROR] %s\n", msg)
+		res.Message = msg
+		goto SEND_RESPONSE
+	} else if flag, err = strconv.ParseBool(flagStr); err != nil {
+		msg = fmt.Sprintf("Cannot parse Flag %q: %s",
+			flagStr,
+			err.Error())
+		d.log.Printf("[ERROR] %s\n", msg)
+		res.Message = msg
+		goto SEND_RESPONSE
+	}
+
+	db = d.pool.Get()
+	defer d.pool.Put(db)
+
+	if err = db.Begin(); err != nil {
+		msg = fmt.Sprintf("Cannot start DB transaction: %s",
+			err.Error())
+		d.log.Printf("[ERROR] %s\n", msg)
+		res.Message = msg
+		goto SEND_RESPONSE
+	} else if rem, err = db.ReminderGetByID(id); err != nil {
+		msg = fmt.Sprintf("Cannot get Reminder #%d from DB: %s",
+			id,
+			err.Error())
+		d.log.Printf("[ERROR] %s\n", msg)
+		res.Message = msg
+		goto SEND_RESPONSE
+	} else if rem == nil {
+		msg = fmt.Sprintf("Reminder #%d was not found", id)
+		d.log.Printf("[ERROR] %s\n", msg)
+		res.Message = msg
+		goto SEND_RESPONSE
+	} else if err = d.checkItem(db, rem, idx, flag); err != nil {
+		res.Message = err.Error()
+		goto SEND_RESPONSE
+	}
+
+	res.Status = true
+	res.Message = rem.ChecklistString()
+
+SEND_RESPONSE:
+	if db != nil {
+		if res.Status {
+			db.Commit() // nolint: errcheck
+		} else {
+			db.Rollback() // nolint: errcheck
+		}
+	}
+
+	d.sendResponseJSON(w, &res)
+} // func (d *Daemon) handleReminderCheckItem(w http.ResponseWriter, r *http.Request)
+
 func (d *Daemon) handleReminderDelete(w http.ResponseWriter, r *http.Request) {
 	d.log.Printf("[TRACE] Handle %s from %s\n",
 		r.URL,
@@ -630,6 +1437,12 @@ func (d *Daemon) handleReminderDelete(w http.ResponseWriter, r *http.Request) {
 		d.log.Printf("[INFO] %s\n", msg)
 		res.Message = msg
 		goto SEND_RESPONSE
+	} else if err = db.Begin(); err != nil {
+		msg = fmt.Sprintf("Cannot begin transaction: %s",
+			err.Error())
+		d.log.Printf("[ERROR] %s\n", msg)
+		res.Message = msg
+		goto SEND_RESPONSE
 	} else if err = db.ReminderDelete(rem); err != nil {
 		msg = fmt.Sprintf("Failed to delete Reminder %d (%q): %s",
 			id,
@@ -637,6 +1450,30 @@ func (d *Daemon) handleReminderDelete(w http.ResponseWriter, r *http.Request) {
 			err.Error())
 		d.log.Printf("[ERROR] %s\n", msg)
 		res.Message = msg
+		db.Rollback() // nolint: errcheck
+		goto SEND_RESPONSE
+	} else if err = db.TombstoneAdd(rem.UUID, time.Now()); err != nil {
+		msg = fmt.Sprintf("Failed to record deletion of Reminder %d (%q): %s",
+			id,
+			rem.Title,
+			err.Error())
+		d.log.Printf("[ERROR] %s\n", msg)
+		res.Message = msg
+		db.Rollback() // nolint: errcheck
+		goto SEND_RESPONSE
+	} else if err = d.recordRevisions(db, "", rem, nil); err != nil {
+		msg = fmt.Sprintf("Failed to record deletion of Reminder %d (%q) in its history: %s",
+			id,
+			rem.Title,
+			err.Error())
+		res.Message = msg
+		db.Rollback() // nolint: errcheck
+		goto SEND_RESPONSE
+	} else if err = db.Commit(); err != nil {
+		msg = fmt.Sprintf("Cannot commit transaction: %s",
+			err.Error())
+		d.log.Printf("[ERROR] %s\n", msg)
+		res.Message = msg
 		goto SEND_RESPONSE
 	}
 
@@ -649,29 +1486,276 @@ SEND_RESPONSE:
 	d.sendResponseJSON(w, &res)
 } // func (d *Daemon) handleReminderDelete(w http.ResponseWriter, r *http.Request)
 
-func (d *Daemon) handleReminderSyncPull(w http.ResponseWriter, r *http.Request) {
+// handleReminderExceptionAdd skips or moves a single occurrence of a
+// recurring Reminder.
+func (d *Daemon) handleReminderExceptionAdd(w http.ResponseWriter, r *http.Request) {
 	d.log.Printf("[TRACE] Handle %s from %s\n",
 		r.URL,
 		r.RemoteAddr)
 
 	var (
-		err       error
-		msg       string
-		db        *database.Database
-		buf       []byte
-		reminders []objects.Reminder
+		err              error
+		vars             map[string]string
+		idstr, jstr, msg string
+		id               int64
+		db               *database.Database
+		rem              *objects.Reminder
+		exc              objects.Exception
+		res              = objects.Response{ID: d.getID()}
 	)
 
-	db = d.pool.Get()
-	defer d.pool.Put(db)
+	vars = mux.Vars(r)
 
-	if reminders, err = db.ReminderGetAll(); err != nil {
-		msg = fmt.Sprintf("Cannot get list of Reminders from database: %s",
+	idstr = vars["id"]
+
+	if id, err = strconv.ParseInt(idstr, 10, 64); err != nil {
+		msg = fmt.Sprintf("Cannot parse ID %q: %s",
+			idstr,
+			err.Error())
+		d.log.Printf("[ERROR] %s\n", msg)
+		res.Message = msg
+		goto SEND_RESPONSE
+	} else if err = r.ParseForm(); err != nil {
+		msg = fmt.Sprintf("Cannot parse form data: %s", err.Error())
+		d.log.Printf("[ERROR] %s\n", msg)
+		res.Message = msg
+		goto SEND_RESPONSE
+	}
+
+	jstr = r.FormValue("exception")
+
+	if err = ffjson.Unmarshal([]byte(jstr), &exc); err != nil {
+		msg = fmt.Sprintf("Cannot parse Exception: %s\n%s",
+			err.Error(),
+			jstr)
+		d.log.Printf("[ERROR] %s\n", msg)
+		res.Message = msg
+		goto SEND_RESPONSE
+	} else if exc.Occurrence.IsZero() {
+		msg = "Exception does not specify an occurrence"
+		d.log.Printf("[ERROR] %s\n", msg)
+		res.Message = msg
+		goto SEND_RESPONSE
+	}
+
+	db = d.pool.Get()
+	defer d.pool.Put(db)
+
+	if err = db.Begin(); err != nil {
+		msg = fmt.Sprintf("Error starting transaction: %s",
+			err.Error())
+		d.log.Printf("[ERROR] %s\n", msg)
+		res.Message = msg
+		goto SEND_RESPONSE
+	} else if rem, err = db.ReminderGetByID(id); err != nil {
+		msg = fmt.Sprintf("Cannot lookup Reminder by ID %d: %s",
+			id,
+			err.Error())
+		d.log.Printf("[ERROR] %s\n", msg)
+		res.Message = msg
+		goto SEND_RESPONSE
+	} else if rem == nil {
+		msg = fmt.Sprintf("Did not find Reminder %d in database", id)
+		d.log.Printf("[INFO] %s\n", msg)
+		res.Message = msg
+		goto SEND_RESPONSE
+	} else if rem.Recur.Repeat == repeat.Once {
+		msg = fmt.Sprintf("Reminder %d (%q) does not recur, edit its timestamp instead",
+			id,
+			rem.Title)
+		d.log.Printf("[ERROR] %s\n", msg)
+		res.Message = msg
+		goto SEND_RESPONSE
+	}
+
+	exc.ID = 0
+	exc.Changed = time.Now()
+
+	if err = db.ExceptionAdd(rem, &exc); err != nil {
+		msg = fmt.Sprintf("Cannot add %s to Reminder %d (%q): %s",
+			exc.String(),
+			id,
+			rem.Title,
+			err.Error())
+		d.log.Printf("[ERROR] %s\n", msg)
+		res.Message = msg
+		goto SEND_RESPONSE
+	} else if err = db.ReminderSetChanged(rem, exc.Changed); err != nil {
+		msg = fmt.Sprintf("Cannot set CTime on Reminder %d (%q): %s",
+			id,
+			rem.Title,
+			err.Error())
+		d.log.Printf("[ERROR] %s\n", msg)
+		res.Message = msg
+		goto SEND_RESPONSE
+	}
+
+	res.Message = fmt.Sprintf("Added %s to Reminder %d (%q)",
+		exc.String(),
+		id,
+		rem.Title)
+	res.Status = true
+
+SEND_RESPONSE:
+	// If we bail out before getting a database connection, there is no
+	// transaction to finish.
+	if db != nil {
+		if res.Status {
+			db.Commit() // nolint: errcheck
+		} else {
+			db.Rollback() // nolint: errcheck
+		}
+	}
+
+	d.sendResponseJSON(w, &res)
+} // func (d *Daemon) handleReminderExceptionAdd(w http.ResponseWriter, r *http.Request)
+
+// handleReminderExceptionDelete removes an Exception from a Reminder, so the
+// occurrence it applied to is due as scheduled again.
+func (d *Daemon) handleReminderExceptionDelete(w http.ResponseWriter, r *http.Request) {
+	d.log.Printf("[TRACE] Handle %s from %s\n",
+		r.URL,
+		r.RemoteAddr)
+
+	var (
+		err                error
+		vars               map[string]string
+		idstr, eidstr, msg string
+		id, eid            int64
+		db                 *database.Database
+		rem                *objects.Reminder
+		exc                *objects.Exception
+		res                = objects.Response{ID: d.getID()}
+	)
+
+	vars = mux.Vars(r)
+
+	idstr = vars["id"]
+	eidstr = vars["eid"]
+
+	if id, err = strconv.ParseInt(idstr, 10, 64); err != nil {
+		msg = fmt.Sprintf("Cannot parse ID %q: %s",
+			idstr,
+			err.Error())
+		d.log.Printf("[ERROR] %s\n", msg)
+		res.Message = msg
+		goto SEND_RESPONSE
+	} else if eid, err = strconv.ParseInt(eidstr, 10, 64); err != nil {
+		msg = fmt.Sprintf("Cannot parse Exception ID %q: %s",
+			eidstr,
+			err.Error())
+		d.log.Printf("[ERROR] %s\n", msg)
+		res.Message = msg
+		goto SEND_RESPONSE
+	}
+
+	db = d.pool.Get()
+	defer d.pool.Put(db)
+
+	if err = db.Begin(); err != nil {
+		msg = fmt.Sprintf("Error starting transaction: %s",
+			err.Error())
+		d.log.Printf("[ERROR] %s\n", msg)
+		res.Message = msg
+		goto SEND_RESPONSE
+	} else if rem, err = db.ReminderGetByID(id); err != nil {
+		msg = fmt.Sprintf("Cannot lookup Reminder by ID %d: %s",
+			id,
+			err.Error())
+		d.log.Printf("[ERROR] %s\n", msg)
+		res.Message = msg
+		goto SEND_RESPONSE
+	} else if rem == nil {
+		msg = fmt.Sprintf("Did not find Reminder %d in database", id)
+		d.log.Printf("[INFO] %s\n", msg)
+		res.Message = msg
+		goto SEND_RESPONSE
+	}
+
+	for i := range rem.Exceptions {
+		if rem.Exceptions[i].ID == eid {
+			var e = rem.Exceptions[i]
+			exc = &e
+			break
+		}
+	}
+
+	if exc == nil {
+		msg = fmt.Sprintf("Reminder %d (%q) has no Exception %d",
+			id,
+			rem.Title,
+			eid)
+		d.log.Printf("[INFO] %s\n", msg)
+		res.Message = msg
+		goto SEND_RESPONSE
+	} else if err = db.ExceptionDelete(rem, exc); err != nil {
+		msg = fmt.Sprintf("Cannot delete %s of Reminder %d (%q): %s",
+			exc.String(),
+			id,
+			rem.Title,
+			err.Error())
+		d.log.Printf("[ERROR] %s\n", msg)
+		res.Message = msg
+		goto SEND_RESPONSE
+	} else if err = db.ReminderSetChanged(rem, time.Now()); err != nil {
+		msg = fmt.Sprintf("Cannot set CTime on Reminder %d (%q): %s",
+			id,
+			rem.Title,
+			err.Error())
+		d.log.Printf("[ERROR] %s\n", msg)
+		res.Message = msg
+		goto SEND_RESPONSE
+	}
+
+	res.Message = fmt.Sprintf("Removed %s from Reminder %d (%q)",
+		exc.String(),
+		id,
+		rem.Title)
+	res.Status = true
+
+SEND_RESPONSE:
+	// If we bail out before getting a database connection, there is no
+	// transaction to finish.
+	if db != nil {
+		if res.Status {
+			db.Commit() // nolint: errcheck
+		} else {
+			db.Rollback() // nolint: errcheck
+		}
+	}
+
+	d.sendResponseJSON(w, &res)
+} // func (d *Daemon) handleReminderExceptionDelete(w http.ResponseWriter, r *http.Request)
+
+func (d *Daemon) handleReminderSyncPull(w http.ResponseWriter, r *http.Request) {
+	d.log.Printf("[TRACE] Handle %s from %s\n",
+		r.URL,
+		r.RemoteAddr)
+
+	var (
+		err  error
+		msg  string
+		db   *database.Database
+		buf  []byte
+		data *objects.SyncData
+	)
+
+	db = d.pool.Get()
+	defer d.pool.Put(db)
+
+	// Legacy Peers do not identify themselves and expect a plain list
+	// of Reminders.
+	if data, err = d.collectSyncData(db); err != nil {
+		msg = fmt.Sprintf("Cannot collect data to synchronize: %s",
 			err.Error())
-		d.log.Printf("[ERROR] %s\n",
-			msg)
 		goto SEND_ERROR
-	} else if buf, err = ffjson.Marshal(reminders); err != nil {
+	} else if r.URL.Query().Get("peer") == "" {
+		buf, err = ffjson.Marshal(data.Reminders)
+	} else {
+		buf, err = ffjson.Marshal(data)
+	}
+
+	if err != nil {
 		msg = fmt.Sprintf("Cannot serialize Response: %s",
 			err.Error())
 		d.log.Printf("[ERROR] %s\n",
@@ -711,7 +1795,8 @@ func (d *Daemon) handleReminderSyncPush(w http.ResponseWriter, r *http.Request)
 	var (
 		err    error
 		buf    bytes.Buffer
-		remote []objects.Reminder
+		remote *objects.SyncData
+		db     *database.Database
 		msg    string
 		status bool
 		res    objects.Response
@@ -725,17 +1810,29 @@ func (d *Daemon) handleReminderSyncPush(w http.ResponseWriter, r *http.Request)
 		msg = fmt.Sprintf("Cannot read Request body: %s",
 			err.Error())
 		d.log.Printf("[ERROR] %s\n", msg)
-	} else if err = ffjson.Unmarshal(buf.Bytes(), &remote); err != nil {
+	} else if remote, err = decodeSyncData(buf.Bytes()); err != nil {
 		msg = fmt.Sprintf("Cannot parse JSON data: %s",
 			err.Error())
 		d.log.Printf("[ERROR] %s\n", msg)
-	} else if err = d.reminderMerge(remote); err != nil {
+	} else if err = d.reminderMerge(peerName(remote, r.RemoteAddr), remote.Reminders, remote.Tombstones); err != nil {
 		msg = fmt.Sprintf("Failed to merge Reminders: %s",
 			err.Error())
 		d.log.Printf("[ERROR] %s\n", msg)
 	} else {
 		msg = "Success"
 		status = true
+
+		// The push acknowledges the data the Peer pulled from us.
+		db = d.pool.Get()
+		defer d.pool.Put(db)
+
+		if err = d.peerSeen(db, remote.Peer, remote.Ack); err != nil {
+			msg = fmt.Sprintf("Cannot record acknowledgement from Peer %s: %s",
+				remote.Peer,
+				err.Error())
+			d.log.Printf("[ERROR] %s\n", msg)
+			status = false
+		}
 	}
 
 	res.ID = d.getID()
@@ -848,6 +1945,332 @@ func (d *Daemon) handlePeerListGet(w http.ResponseWriter, r *http.Request) {
 	w.Write(buf) // nolint: errcheck
 } // func (d *Daemon) handlePeerListGet(w http.ResponseWriter, r *http.Request)
 
+// handleSettingsSnooze returns the global snooze choices. If the request
+// is a POST, it sets them first. An empty list restores the defaults.
+func (d *Daemon) handleSettingsSnooze(w http.ResponseWriter, r *http.Request) {
+	d.log.Printf("[TRACE] Handle %s from %s\n",
+		r.URL,
+		r.RemoteAddr)
+
+	var (
+		err      error
+		db       *database.Database
+		msg, str string
+		ok       bool
+		items    []objects.Snooze
+		res      = objects.Response{ID: d.getID()}
+	)
+
+	db = d.pool.Get()
+	defer d.pool.Put(db)
+
+	if r.Method == http.MethodPost {
+		if err = r.ParseForm(); err != nil {
+			msg = fmt.Sprintf("Cannot parse form data: %s", err.Error())
+			d.log.Printf("[ERROR] %s\n", msg)
+			res.Message = msg
+			goto SEND_RESPONSE
+		} else if items, err = objects.ParseSnoozes(r.FormValue("snooze")); err != nil {
+			msg = fmt.Sprintf("Invalid snooze choices: %s", err.Error())
+			d.log.Printf("[ERROR] %s\n", msg)
+			res.Message = msg
+			goto SEND_RESPONSE
+		} else if err = db.SettingSet(settingSnooze, objects.FormatSnoozes(items)); err != nil {
+			msg = fmt.Sprintf("Cannot save snooze choices: %s", err.Error())
+			d.log.Printf("[ERROR] %s\n", msg)
+			res.Message = msg
+			goto SEND_RESPONSE
+		}
+	}
+
+	if str, ok, err = db.SettingGet(settingSnooze); err != nil {
+		msg = fmt.Sprintf("Cannot load snooze choices: %s", err.Error())
+		d.log.Printf("[ERROR] %s\n", msg)
+		res.Message = msg
+		goto SEND_RESPONSE
+	} else if !ok || str == "" {
+		str = objects.FormatSnoozes(objects.DefaultSnoozes)
+	}
+
+	res.Status = true
+	res.Message = str
+
+SEND_RESPONSE:
+	d.sendResponseJSON(w, &res)
+} // func (d *Daemon) handleSettingsSnooze(w http.ResponseWriter, r *http.Request)
+
+func (d *Daemon) handleQuietStatus(w http.ResponseWriter, r *http.Request) {
+	d.log.Printf("[TRACE] Handle %s from %s\n",
+		r.URL,
+		r.RemoteAddr)
+
+	var (
+		err      error
+		db       *database.Database
+		buf      []byte
+		status   objects.QuietStatus
+		deferred []objects.Notification
+	)
+
+	db = d.pool.Get()
+	defer d.pool.Put(db)
+
+	if status, err = d.quietStatus(db); err != nil {
+		d.log.Printf("[ERROR] Cannot determine status of quiet hours: %s\n",
+			err.Error())
+	} else if deferred, err = db.NotificationGetDeferred(); err != nil {
+		d.log.Printf("[ERROR] Cannot load deferred Notifications: %s\n",
+			err.Error())
+	}
+
+	status.Deferred = len(deferred)
+
+	if buf, err = ffjson.Marshal(&status); err != nil {
+		d.log.Printf("[ERROR] Cannot serialize status of quiet hours: %s\n",
+			err.Error())
+	}
+
+	defer ffjson.Pool(buf)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+	w.Write(buf) // nolint: errcheck
+} // func (d *Daemon) handleQuietStatus(w http.ResponseWriter, r *http.Request)
+
+// handleQuietSet switches do-not-disturb on or off by hand, regardless of
+// the schedule.
+func (d *Daemon) handleQuietSet(w http.ResponseWriter, r *http.Request) {
+	d.log.Printf("[TRACE] Handle %s from %s\n",
+		r.URL,
+		r.RemoteAddr)
+
+	var (
+		err          error
+		db           *database.Database
+		msg, flagStr string
+		flag         bool
+		res          = objects.Response{ID: d.getID()}
+	)
+
+	flagStr = strings.ToLower(mux.Vars(r)["flag"])
+
+	switch flagStr {
+	case "on", "true":
+		flag = true
+	}
+
+	db = d.pool.Get()
+	defer d.pool.Put(db)
+
+	if err = db.SettingSet(settingQuietManual, strconv.FormatBool(flag)); err != nil {
+		msg = fmt.Sprintf("Cannot switch do-not-disturb %s: %s",
+			flagStr,
+			err.Error())
+		d.log.Printf("[ERROR] %s\n", msg)
+		res.Message = msg
+		goto SEND_RESPONSE
+	}
+
+	res.Status = true
+	if flag {
+		res.Message = "Do not disturb is on"
+	} else {
+		res.Message = "Do not disturb is off"
+	}
+
+SEND_RESPONSE:
+	d.sendResponseJSON(w, &res)
+} // func (d *Daemon) handleQuietSet(w http.ResponseWriter, r *http.Request)
+
+// handleQuietSchedule sets the weekly schedule of quiet hours.
+func (d *Daemon) handleQuietSchedule(w http.ResponseWriter, r *http.Request) {
+	d.log.Printf("[TRACE] Handle %s from %s\n",
+		r.URL,
+		r.RemoteAddr)
+
+	var (
+		err error
+		db  *database.Database
+		msg string
+		q   objects.QuietHours
+		res = objects.Response{ID: d.getID()}
+	)
+
+	if err = r.ParseForm(); err != nil {
+		msg = fmt.Sprintf("Cannot parse form data: %s", err.Error())
+		d.log.Printf("[ERROR] %s\n", msg)
+		res.Message = msg
+		goto SEND_RESPONSE
+	} else if q, err = objects.ParseQuietHours(r.FormValue("schedule")); err != nil {
+		msg = fmt.Sprintf("Invalid quiet hours: %s", err.Error())
+		d.log.Printf("[ERROR] %s\n", msg)
+		res.Message = msg
+		goto SEND_RESPONSE
+	}
+
+	db = d.pool.Get()
+	defer d.pool.Put(db)
+
+	if err = db.SettingSet(settingQuietHours, q.String()); err != nil {
+		msg = fmt.Sprintf("Cannot save quiet hours: %s", err.Error())
+		d.log.Printf("[ERROR] %s\n", msg)
+		res.Message = msg
+		goto SEND_RESPONSE
+	}
+
+	res.Status = true
+	res.Message = q.String()
+
+SEND_RESPONSE:
+	d.sendResponseJSON(w, &res)
+} // func (d *Daemon) handleQuietSchedule(w http.ResponseWriter, r *http.Request)
+
+// Tags
+
+func (d *Daemon) handleTagGetAll(w http.ResponseWriter, r *http.Request) {
+	d.log.Printf("[TRACE] Handle %s from %s\n",
+		r.URL,
+		r.RemoteAddr)
+
+	var (
+		err  error
+		db   *database.Database
+		tags []objects.Tag
+		buf  []byte
+	)
+
+	db = d.pool.Get()
+	defer d.pool.Put(db)
+
+	if tags, err = db.TagGetAll(); err != nil {
+		d.log.Printf("[ERROR] Cannot load Tags: %s\n",
+			err.Error())
+	} else if buf, err = ffjson.Marshal(tags); err != nil {
+		d.log.Printf("[ERROR] Cannot serialize Tag list: %s\n",
+			err.Error())
+	}
+
+	defer ffjson.Pool(buf)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+	w.Write(buf) // nolint: errcheck
+} // func (d *Daemon) handleTagGetAll(w http.ResponseWriter, r *http.Request)
+
+// handleTagSet creates a Tag or changes the defaults it passes on to its
+// Reminders. The form fields are name, priority, channel and quiet_exempt,
+// only name is required.
+func (d *Daemon) handleTagSet(w http.ResponseWriter, r *http.Request) {
+	d.log.Printf("[TRACE] Handle %s from %s\n",
+		r.URL,
+		r.RemoteAddr)
+
+	var (
+		err   error
+		db    *database.Database
+		msg   string
+		names []string
+		tag   objects.Tag
+		res   = objects.Response{ID: d.getID()}
+	)
+
+	if err = r.ParseForm(); err != nil {
+		msg = fmt.Sprintf("Cannot parse form data: %s", err.Error())
+		d.log.Printf("[ERROR] %s\n", msg)
+		res.Message = msg
+		goto SEND_RESPONSE
+	} else if names, err = objects.ParseTags(r.FormValue("name")); err != nil {
+		msg = err.Error()
+		d.log.Printf("[ERROR] %s\n", msg)
+		res.Message = msg
+		goto SEND_RESPONSE
+	} else if len(names) != 1 {
+		msg = fmt.Sprintf("Expected exactly one tag, not %q", r.FormValue("name"))
+		d.log.Printf("[ERROR] %s\n", msg)
+		res.Message = msg
+		goto SEND_RESPONSE
+	}
+
+	tag.Name = names[0]
+
+	if str := r.FormValue("priority"); str != "" {
+		if tag.Priority, err = objects.ParsePriority(str); err != nil {
+			msg = err.Error()
+			d.log.Printf("[ERROR] %s\n", msg)
+			res.Message = msg
+			goto SEND_RESPONSE
+		}
+	}
+
+	switch strings.ToLower(r.FormValue("channel")) {
+	case "", "desktop":
+		tag.Channel = objects.ChannelDesktop
+	case "sound":
+		tag.Channel = objects.ChannelSound
+	default:
+		msg = fmt.Sprintf("Invalid notification channel %q", r.FormValue("channel"))
+		d.log.Printf("[ERROR] %s\n", msg)
+		res.Message = msg
+		goto SEND_RESPONSE
+	}
+
+	if str := r.FormValue("quiet_exempt"); str != "" {
+		if tag.QuietExempt, err = strconv.ParseBool(str); err != nil {
+			msg = fmt.Sprintf("Invalid value for quiet_exempt: %q", str)
+			d.log.Printf("[ERROR] %s\n", msg)
+			res.Message = msg
+			goto SEND_RESPONSE
+		}
+	}
+
+	db = d.pool.Get()
+	defer d.pool.Put(db)
+
+	if err = db.TagSet(&tag); err != nil {
+		msg = fmt.Sprintf("Cannot save Tag %q: %s", tag.Name, err.Error())
+		d.log.Printf("[ERROR] %s\n", msg)
+		res.Message = msg
+		goto SEND_RESPONSE
+	}
+
+	res.Status = true
+	res.Message = tag.Name
+
+SEND_RESPONSE:
+	d.sendResponseJSON(w, &res)
+} // func (d *Daemon) handleTagSet(w http.ResponseWriter, r *http.Request)
+
+// handleTagDelete removes a Tag from all Reminders.
+func (d *Daemon) handleTagDelete(w http.ResponseWriter, r *http.Request) {
+	d.log.Printf("[TRACE] Handle %s from %s\n",
+		r.URL,
+		r.RemoteAddr)
+
+	var (
+		err  error
+		db   *database.Database
+		msg  string
+		name = strings.ToLower(mux.Vars(r)["name"])
+		res  = objects.Response{ID: d.getID()}
+	)
+
+	db = d.pool.Get()
+	defer d.pool.Put(db)
+
+	if err = db.TagDelete(name); err != nil {
+		msg = fmt.Sprintf("Cannot delete Tag %q: %s", name, err.Error())
+		d.log.Printf("[ERROR] %s\n", msg)
+		res.Message = msg
+		goto SEND_RESPONSE
+	}
+
+	res.Status = true
+	res.Message = fmt.Sprintf("Tag %q was deleted", name)
+
+SEND_RESPONSE:
+	d.sendResponseJSON(w, &res)
+} // func (d *Daemon) handleTagDelete(w http.ResponseWriter, r *http.Request)
+
 //////////////////////////////////////////////////////////////////////////////////////////////////
 /// Helpers //////////////////////////////////////////////////////////////////////////////////////
 //////////////////////////////////////////////////////////////////////////////////////////////////
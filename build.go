@@ -252,7 +252,8 @@ This flag is not case-sensitive.`, strings.Join(orderedSteps, ", ")))
 		var sWorkerCnt = strconv.FormatInt(int64(workerCnt), 10)
 		// var cmd = exec.Command("go", "build", "-v", "-p", sWorkerCnt)
 		// The -tags flag is required so the build will succeed on Debian.
-		var args = []string{"build", "-v", "-tags", "pango_1_42,gtk_3_22", "-p", sWorkerCnt}
+		// sqlite_fts5 enables the full-text index for searching Reminders.
+		var args = []string{"build", "-v", "-tags", "pango_1_42,gtk_3_22,sqlite_fts5", "-p", sWorkerCnt}
 
 		if raceDetect && ((runtime.GOOS == "linux" || runtime.GOOS == "freebsd") && runtime.GOARCH == "amd64") {
 			dbg.Println("[INFO] Building with race detection enabled.")
@@ -385,9 +386,9 @@ func worker(n int, op string, pkgq <-chan string, errq chan<- error, wg *sync.Wa
 			// 	pkg)
 		} else if op == "test" {
 			if runtime.GOOS == "openbsd" || runtime.GOARCH == "386" || runtime.GOARCH == "arm" {
-				cmd = exec.Command("go", op, "-v", "-timeout", "30m", pkg)
+				cmd = exec.Command("go", op, "-v", "-tags", "sqlite_fts5", "-timeout", "30m", pkg)
 			} else {
-				cmd = exec.Command("go", op, "-v", "-timeout", "30m", "-race", pkg)
+				cmd = exec.Command("go", op, "-v", "-tags", "sqlite_fts5", "-timeout", "30m", "-race", pkg)
 			}
 		} else {
 			cmd = exec.Command("go", op, "-v", pkg)
@@ -26,7 +26,11 @@ import (
 )
 
 const (
-	addPath = "/reminder/add"
+	addPath      = "/reminder/add"
+	quickAddPath = "/reminder/quickadd"
+	dndPath      = "/dnd/%t"
+	dndSchedPath = "/dnd/schedule"
+	dndStatPath  = "/dnd/status"
 )
 
 // Client is the basic implementation of a Theseus client,
@@ -75,10 +79,6 @@ func (c *Client) SubmitReminder(r *objects.Reminder) error {
 	var (
 		err     error
 		sendBuf []byte
-		msg     string
-		rcvBuf  bytes.Buffer
-		hres    *http.Response
-		ores    objects.Response
 		values  = make(url.Values)
 	)
 
@@ -92,39 +92,161 @@ func (c *Client) SubmitReminder(r *objects.Reminder) error {
 
 	values["reminder"] = []string{string(sendBuf)}
 
-	if hres, err = c.Client.PostForm(c.Server.String(), values); err != nil {
-		c.log.Printf("[ERROR] Failed to POST Reminder to %s: %s\n",
-			c.Server,
+	_, err = c.post(addPath, values)
+	return err
+} // func (c *Client) SubmitReminder(r *objects.Reminder) error
+
+// SubmitPhrase asks the server to create a Reminder from a phrase like
+// "call mom tomorrow at 9". If tz is not empty, the server interprets the
+// phrase in that time zone. On success, it returns the server's
+// description of the new Reminder.
+func (c *Client) SubmitPhrase(text, tz string) (string, error) {
+	var (
+		err    error
+		ores   *objects.Response
+		values = url.Values{
+			"text": []string{text},
+		}
+	)
+
+	if tz != "" {
+		values["tz"] = []string{tz}
+	}
+
+	if ores, err = c.post(quickAddPath, values); err != nil {
+		return "", err
+	}
+
+	return ores.Message, nil
+} // func (c *Client) SubmitPhrase(text, tz string) (string, error)
+
+// SetDND switches do-not-disturb on or off. It returns the server's
+// confirmation.
+func (c *Client) SetDND(on bool) (string, error) {
+	var (
+		err  error
+		ores *objects.Response
+	)
+
+	if ores, err = c.post(fmt.Sprintf(dndPath, on), url.Values{}); err != nil {
+		return "", err
+	}
+
+	return ores.Message, nil
+} // func (c *Client) SetDND(on bool) (string, error)
+
+// SetQuietHours sets the weekly schedule of quiet hours, e.g.
+// "mon-fri 22:00-07:00; sat,sun 23:00-09:00". An empty schedule removes
+// all quiet hours. It returns the schedule as understood by the server.
+func (c *Client) SetQuietHours(schedule string) (string, error) {
+	var (
+		err    error
+		ores   *objects.Response
+		values = url.Values{
+			"schedule": []string{schedule},
+		}
+	)
+
+	if ores, err = c.post(dndSchedPath, values); err != nil {
+		return "", err
+	}
+
+	return ores.Message, nil
+} // func (c *Client) SetQuietHours(schedule string) (string, error)
+
+// GetQuietStatus asks the server if quiet hours are in effect.
+func (c *Client) GetQuietStatus() (*objects.QuietStatus, error) {
+	var (
+		err    error
+		rcvBuf bytes.Buffer
+		hres   *http.Response
+		status objects.QuietStatus
+		srv    = *c.Server
+	)
+
+	srv.Path = dndStatPath
+
+	if hres, err = c.Client.Get(srv.String()); err != nil {
+		c.log.Printf("[ERROR] Failed to GET %s: %s\n",
+			srv.String(),
 			err.Error())
-		return err
-	} else if hres.StatusCode != http.StatusOK {
+		return nil, err
+	}
+
+	defer hres.Body.Close() // nolint: errcheck
+
+	if hres.StatusCode != http.StatusOK {
+		err = fmt.Errorf("Unexpected status from %s: %s",
+			srv.String(),
+			hres.Status)
+		c.log.Printf("[ERROR] %s\n", err.Error())
+		return nil, err
+	} else if _, err = io.Copy(&rcvBuf, hres.Body); err != nil {
+		c.log.Printf("[ERROR] Failed to read Response body from %s: %s\n",
+			srv.String(),
+			err.Error())
+		return nil, err
+	} else if err = ffjson.Unmarshal(rcvBuf.Bytes(), &status); err != nil {
+		c.log.Printf("[ERROR] Cannot de-serialize status from %s: %s\n",
+			srv.String(),
+			err.Error())
+		return nil, err
+	}
+
+	return &status, nil
+} // func (c *Client) GetQuietStatus() (*objects.QuietStatus, error)
+
+// post sends a form to the given path on the server and returns the
+// server's Response. It is an error if the Response indicates failure.
+func (c *Client) post(path string, values url.Values) (*objects.Response, error) {
+	var (
+		err    error
+		msg    string
+		rcvBuf bytes.Buffer
+		hres   *http.Response
+		ores   objects.Response
+		srv    = *c.Server
+	)
+
+	srv.Path = path
+
+	if hres, err = c.Client.PostForm(srv.String(), values); err != nil {
+		c.log.Printf("[ERROR] Failed to POST to %s: %s\n",
+			srv.String(),
+			err.Error())
+		return nil, err
+	}
+
+	defer hres.Body.Close() // nolint: errcheck
+
+	if hres.StatusCode != http.StatusOK {
 		msg = fmt.Sprintf("Unexpected status from %s: %s",
-			c.Server,
+			srv.String(),
 			hres.Status)
 		c.log.Printf("[ERROR] %s\n", msg)
-		return errors.New(msg)
+		return nil, errors.New(msg)
 	} else if _, err = io.Copy(&rcvBuf, hres.Body); err != nil {
 		c.log.Printf("[ERROR] Failed to read Response body from %s: %s\n",
-			c.Server,
+			srv.String(),
 			err.Error())
-		return err
+		return nil, err
 	} else if err = ffjson.Unmarshal(rcvBuf.Bytes(), &ores); err != nil {
 		c.log.Printf("[ERROR] Cannot de-serialize Response from %s: %s\n",
-			c.Server,
+			srv.String(),
 			err.Error())
-		return err
+		return nil, err
 	} else if !ores.Status {
 		err = fmt.Errorf("Request to %s failed: %s",
-			c.Server,
+			srv.String(),
 			ores.Message)
 		c.log.Printf("[ERROR] %s\n",
 			err.Error())
-		return err
+		return nil, err
 	}
 
 	c.log.Printf("[DEBUG] Request to %s was successful: %s\n",
-		c.Server,
+		srv.String(),
 		ores.Message)
 
-	return nil
-} // func (c *Client) SubmitReminder(r *objects.Reminder) error
+	return &ores, nil
+} // func (c *Client) post(path string, values url.Values) (*objects.Response, error)
@@ -0,0 +1,109 @@
+// /home/krylon/go/src/github.com/blicero/theseus/clients/dnd/main.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 17. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <>
+
+// dnd switches do-not-disturb on or off, sets the schedule of quiet hours,
+// or shows if quiet hours are in effect, e.g.
+//
+//	dnd on
+//	dnd -schedule "mon-fri 22:00-07:00; sat,sun 23:00-09:00"
+//	dnd status
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/blicero/theseus/clients/clientlib"
+	"github.com/blicero/theseus/common"
+	"github.com/blicero/theseus/objects"
+)
+
+func main() {
+	var (
+		err      error
+		srv      string
+		schedule string
+		cmd      = "status"
+		client   *clientlib.Client
+		status   *objects.QuietStatus
+		msg      string
+		defAddr  = fmt.Sprintf("http://localhost:%d", common.DefaultPort)
+		usageFmt = "Usage: %s [options] [on|off|status]\n"
+	)
+
+	flag.StringVar(&srv, "server", defAddr, "The address of the server")
+	flag.StringVar(&schedule, "schedule", "", "Set the weekly schedule of quiet hours, \"none\" removes it")
+
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, usageFmt, os.Args[0])
+		flag.PrintDefaults()
+	}
+
+	flag.Parse()
+
+	if flag.NArg() > 1 {
+		flag.Usage()
+		os.Exit(1)
+	} else if flag.NArg() == 1 {
+		cmd = flag.Arg(0)
+	}
+
+	if client, err = clientlib.NewClient(srv); err != nil {
+		fmt.Fprintf(
+			os.Stderr,
+			"Cannot create client: %s\n",
+			err.Error())
+		os.Exit(1)
+	}
+
+	if schedule != "" {
+		if schedule == "none" {
+			schedule = ""
+		}
+
+		if msg, err = client.SetQuietHours(schedule); err != nil {
+			fmt.Fprintf(
+				os.Stderr,
+				"Cannot set quiet hours: %s\n",
+				err.Error())
+			os.Exit(1)
+		}
+
+		fmt.Printf("Quiet hours: %s\n", msg)
+	}
+
+	switch cmd {
+	case "on", "off":
+		if msg, err = client.SetDND(cmd == "on"); err != nil {
+			fmt.Fprintf(
+				os.Stderr,
+				"Cannot switch do-not-disturb %s: %s\n",
+				cmd,
+				err.Error())
+			os.Exit(1)
+		}
+
+		fmt.Println(msg)
+	case "status":
+		if status, err = client.GetQuietStatus(); err != nil {
+			fmt.Fprintf(
+				os.Stderr,
+				"Cannot get status: %s\n",
+				err.Error())
+			os.Exit(1)
+		}
+
+		fmt.Printf("Active:      %t\nManual:      %t\nSchedule:    %s\nDeferred:    %d\n",
+			status.Active,
+			status.Manual,
+			status.Schedule,
+			status.Deferred)
+	default:
+		flag.Usage()
+		os.Exit(1)
+	}
+}
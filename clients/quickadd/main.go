@@ -0,0 +1,97 @@
+// /home/krylon/go/src/github.com/blicero/theseus/clients/quickadd/main.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 17. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <>
+
+// quickadd creates a Reminder from a phrase given on the command line, e.g.
+//
+//	quickadd call mom tomorrow at 9
+//	quickadd jeden Montag um 7 Uhr Müll rausbringen
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/blicero/theseus/clients/clientlib"
+	"github.com/blicero/theseus/common"
+	"github.com/blicero/theseus/objects"
+)
+
+func main() {
+	var (
+		err      error
+		srv, tz  string
+		dryRun   bool
+		text     string
+		client   *clientlib.Client
+		rem      *objects.Reminder
+		msg      string
+		loc      = time.Local
+		defAddr  = fmt.Sprintf("http://localhost:%d", common.DefaultPort)
+		defZone  = common.LocalZoneName()
+		usageFmt = "Usage: %s [options] <phrase>\n"
+	)
+
+	flag.StringVar(&srv, "server", defAddr, "The address of the server")
+	flag.StringVar(&tz, "tz", defZone, "The time zone to interpret the phrase in")
+	flag.BoolVar(&dryRun, "n", false, "Only show how the phrase is understood, do not create a Reminder")
+
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, usageFmt, os.Args[0])
+		flag.PrintDefaults()
+	}
+
+	flag.Parse()
+
+	if text = strings.Join(flag.Args(), " "); text == "" {
+		flag.Usage()
+		os.Exit(1)
+	} else if tz != "" {
+		if loc, err = time.LoadLocation(tz); err != nil {
+			fmt.Fprintf(
+				os.Stderr,
+				"Invalid time zone %q: %s\n",
+				tz,
+				err.Error())
+			os.Exit(1)
+		}
+	}
+
+	if dryRun {
+		if rem, err = objects.ParseReminder(text, time.Now().In(loc)); err != nil {
+			fmt.Fprintf(
+				os.Stderr,
+				"Cannot parse %q: %s\n",
+				text,
+				err.Error())
+			os.Exit(1)
+		}
+
+		fmt.Printf("Title:  %s\nDue:    %s\nRepeat: %s\n",
+			rem.Title,
+			rem.DueNext(nil).In(loc).Format(common.TimestampFormatMinute),
+			rem.Recur.String())
+		return
+	}
+
+	if client, err = clientlib.NewClient(srv); err != nil {
+		fmt.Fprintf(
+			os.Stderr,
+			"Cannot create client: %s\n",
+			err.Error())
+		os.Exit(1)
+	} else if msg, err = client.SubmitPhrase(text, tz); err != nil {
+		fmt.Fprintf(
+			os.Stderr,
+			"Cannot create Reminder: %s\n",
+			err.Error())
+		os.Exit(1)
+	}
+
+	fmt.Println(msg)
+}
@@ -91,6 +91,12 @@ var LogPath = filepath.Join(BaseDir, fmt.Sprintf("%s.log", strings.ToLower(AppNa
 // DbPath is the filename of the database.
 var DbPath = filepath.Join(BaseDir, fmt.Sprintf("%s.db", strings.ToLower(AppName)))
 
+// HolidayDir is the folder where holiday calendars are stored.
+var HolidayDir = filepath.Join(BaseDir, "holidays")
+
+// BackupDir is the folder where backups of the database are stored.
+var BackupDir = filepath.Join(BaseDir, "backup")
+
 // InitApp performs some basic preparations for the application to run.
 // Currently, this means creating the BaseDir folder.
 func InitApp() error {
@@ -102,6 +108,14 @@ func InitApp() error {
 
 	LogPath = filepath.Join(BaseDir, fmt.Sprintf("%s.log", strings.ToLower(AppName)))
 	DbPath = filepath.Join(BaseDir, fmt.Sprintf("%s.db", strings.ToLower(AppName)))
+	HolidayDir = filepath.Join(BaseDir, "holidays")
+	BackupDir = filepath.Join(BaseDir, "backup")
+
+	if err = os.Mkdir(HolidayDir, 0700); err != nil && !os.IsExist(err) {
+		return fmt.Errorf("Error creating HolidayDir %s: %s", HolidayDir, err.Error())
+	} else if err = os.Mkdir(BackupDir, 0700); err != nil && !os.IsExist(err) {
+		return fmt.Errorf("Error creating BackupDir %s: %s", BackupDir, err.Error())
+	}
 
 	return nil
 } // func InitApp() error
@@ -121,6 +135,8 @@ func SetBaseDir(path string) error {
 	BaseDir = path
 	LogPath = filepath.Join(BaseDir, fmt.Sprintf("%s.log", strings.ToLower(AppName)))
 	DbPath = filepath.Join(BaseDir, fmt.Sprintf("%s.db", strings.ToLower(AppName)))
+	HolidayDir = filepath.Join(BaseDir, "holidays")
+	BackupDir = filepath.Join(BaseDir, "backup")
 
 	var (
 		err error
@@ -233,6 +249,31 @@ func TimeEqual(t1, t2 time.Time) bool {
 	return delta < time.Second
 } // func TimeEqual(t1, t2 time.Time) bool
 
+// LocalZoneName returns the IANA name of the system's local time zone,
+// e.g. "Europe/Berlin". If we cannot figure it out, we return an empty
+// string, which objects.Reminder takes to mean the local time zone.
+func LocalZoneName() string {
+	if tz := strings.TrimPrefix(os.Getenv("TZ"), ":"); tz != "" {
+		if _, err := time.LoadLocation(tz); err == nil {
+			return tz
+		}
+	}
+
+	if link, err := filepath.EvalSymlinks("/etc/localtime"); err == nil {
+		if idx := strings.Index(link, "zoneinfo/"); idx >= 0 {
+			return link[idx+len("zoneinfo/"):]
+		}
+	}
+
+	if raw, err := os.ReadFile("/etc/timezone"); err == nil {
+		if tz := strings.TrimSpace(string(raw)); tz != "" {
+			return tz
+		}
+	}
+
+	return ""
+} // func LocalZoneName() string
+
 // GetChecksum computes the SHA512 checksum of the given data.
 func GetChecksum(data []byte) (string, error) {
 	var err error
@@ -10,6 +10,7 @@ import (
 	"testing"
 
 	"github.com/blicero/theseus/common"
+	"github.com/blicero/theseus/database/query"
 )
 
 var db *Database
@@ -33,7 +34,10 @@ func TestPrepareQueries(t *testing.T) {
 
 	for id := range dbQueries {
 		var err error
-		if _, err = db.getQuery(id); err != nil {
+		if id == query.ReminderSearch && !db.fts {
+			// The full-text index only exists if SQLite supports FTS5.
+			continue
+		} else if _, err = db.getQuery(id); err != nil {
 			t.Errorf("Cannot prepare query %s: %s",
 				id,
 				err.Error())
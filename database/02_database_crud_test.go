@@ -14,6 +14,7 @@ import (
 
 	"github.com/blicero/theseus/common"
 	"github.com/blicero/theseus/objects"
+	"github.com/blicero/theseus/objects/repeat"
 )
 
 const (
@@ -102,3 +103,1024 @@ func TestReminderFinish(t *testing.T) {
 
 	}
 } // func TestReminderFinish(t *testing.T)
+
+func TestReminderSetRecurrence(t *testing.T) {
+	if db == nil {
+		t.SkipNow()
+	}
+
+	var (
+		err  error
+		r    = items[0]
+		stmp = time.Unix(3600*9, 0)
+		rem  *objects.Reminder
+		rec  = objects.Recurrence{
+			Repeat:   repeat.Monthly,
+			Day:      31,
+			Fallback: objects.FallbackSkip,
+			Times:    []int{3600 * 18},
+		}
+	)
+
+	if err = db.ReminderSetRecurrence(r, stmp, objects.Recurrence{Repeat: repeat.Monthly}); err == nil {
+		t.Errorf("Setting a monthly Recurrence without a day of the month should have failed")
+	} else if err = db.ReminderSetRecurrence(r, stmp, rec); err != nil {
+		t.Fatalf("Cannot set Recurrence of Reminder %q: %s",
+			r.Title,
+			err.Error())
+	} else if rem, err = db.ReminderGetByID(r.ID); err != nil {
+		t.Fatalf("Cannot look up Reminder %d: %s",
+			r.ID,
+			err.Error())
+	} else if rem.Recur.Repeat != rec.Repeat ||
+		rem.Recur.Day != rec.Day ||
+		rem.Recur.Fallback != rec.Fallback ||
+		rem.Recur.TimesString() != rec.TimesString() ||
+		!rem.Timestamp.Equal(stmp) {
+		t.Errorf("Unexpected Recurrence of Reminder %q: %s (expected %s)",
+			rem.Title,
+			&rem.Recur,
+			&rec)
+	}
+} // func TestReminderSetRecurrence(t *testing.T)
+
+func TestReminderRRule(t *testing.T) {
+	if db == nil {
+		t.SkipNow()
+	}
+
+	const rule = "FREQ=MONTHLY;INTERVAL=2;BYDAY=2TU;COUNT=12"
+
+	var (
+		err error
+		rem *objects.Reminder
+		r   = &objects.Reminder{
+			Title:     "RRule test",
+			Timestamp: time.Date(2022, 10, 11, 9, 0, 0, 0, time.UTC),
+			UUID:      common.GetUUID(),
+		}
+	)
+
+	if err = r.SetRRule(rule); err != nil {
+		t.Fatalf("Cannot set recurrence rule: %s", err.Error())
+	} else if err = db.ReminderAdd(r); err != nil {
+		t.Fatalf("Cannot add Reminder %q: %s",
+			r.Title,
+			err.Error())
+	} else if rem, err = db.ReminderGetByID(r.ID); err != nil {
+		t.Fatalf("Cannot look up Reminder %d: %s",
+			r.ID,
+			err.Error())
+	} else if rem.Recur.Repeat != repeat.RRule || rem.Recur.Rule != rule {
+		t.Errorf("Unexpected Recurrence of Reminder %q: %s",
+			rem.Title,
+			&rem.Recur)
+	} else if !rem.Timestamp.Equal(r.Timestamp) {
+		t.Errorf("Unexpected start of Reminder %q: %s (expected %s)",
+			rem.Title,
+			rem.Timestamp.Format(common.TimestampFormat),
+			r.Timestamp.Format(common.TimestampFormat))
+	}
+} // func TestReminderRRule(t *testing.T)
+
+func TestReminderSetTimeZone(t *testing.T) {
+	if db == nil {
+		t.SkipNow()
+	}
+
+	const zone = "Europe/Berlin"
+
+	var (
+		err error
+		r   = items[1]
+		rem *objects.Reminder
+	)
+
+	if err = db.ReminderSetTimeZone(r, zone); err != nil {
+		t.Fatalf("Cannot set time zone of Reminder %q: %s",
+			r.Title,
+			err.Error())
+	} else if rem, err = db.ReminderGetByID(r.ID); err != nil {
+		t.Fatalf("Cannot look up Reminder %d: %s",
+			r.ID,
+			err.Error())
+	} else if rem.TimeZone != zone {
+		t.Errorf("Unexpected time zone of Reminder %q: %q (expected %q)",
+			rem.Title,
+			rem.TimeZone,
+			zone)
+	}
+} // func TestReminderSetTimeZone(t *testing.T)
+
+func TestReminderCounter(t *testing.T) {
+	if db == nil {
+		t.SkipNow()
+	}
+
+	const limit = 3
+
+	var (
+		err error
+		r   = items[2]
+		rem *objects.Reminder
+	)
+
+	if err = db.ReminderSetLimit(r, limit); err != nil {
+		t.Fatalf("Cannot set Limit of Reminder %q: %s",
+			r.Title,
+			err.Error())
+	}
+
+	for i := 1; i <= limit; i++ {
+		if err = db.ReminderIncCounter(r); err != nil {
+			t.Fatalf("Cannot increment counter of Reminder %q: %s",
+				r.Title,
+				err.Error())
+		} else if r.Recur.Counter != i {
+			t.Errorf("Unexpected counter for Reminder %q: %d (expected %d)",
+				r.Title,
+				r.Recur.Counter,
+				i)
+		}
+	}
+
+	if err = db.ReminderIncCounter(r); err == nil {
+		t.Errorf("Incrementing the counter beyond the Limit should have failed")
+	} else if err = db.ReminderSetLimit(r, 2); err != nil {
+		t.Fatalf("Cannot lower Limit of Reminder %q: %s",
+			r.Title,
+			err.Error())
+	} else if rem, err = db.ReminderGetByID(r.ID); err != nil {
+		t.Fatalf("Cannot look up Reminder %d: %s",
+			r.ID,
+			err.Error())
+	} else if rem.Recur.Limit != 2 || rem.Recur.Counter != 2 {
+		t.Errorf("Unexpected Limit/Counter for Reminder %q: %d/%d (expected 2/2)",
+			rem.Title,
+			rem.Recur.Counter,
+			rem.Recur.Limit)
+	} else if rem.Finished != r.Finished {
+		t.Errorf("Finished flag of Reminder %q was not preserved: %t (expected %t)",
+			rem.Title,
+			rem.Finished,
+			r.Finished)
+	}
+} // func TestReminderCounter(t *testing.T)
+
+func TestException(t *testing.T) {
+	if db == nil {
+		t.SkipNow()
+	}
+
+	var (
+		err  error
+		r    = items[3]
+		occ  = time.Now().Add(time.Hour * 24).Truncate(time.Minute)
+		skip = objects.Exception{Occurrence: occ}
+		move = objects.Exception{
+			Occurrence: occ.Add(time.Hour * 24),
+			Due:        occ.Add(time.Hour * 26),
+		}
+		rem  *objects.Reminder
+		excs []objects.Exception
+	)
+
+	if err = db.ExceptionAdd(r, &skip); err != nil {
+		t.Fatalf("Cannot add %s to Reminder %q: %s",
+			skip.String(),
+			r.Title,
+			err.Error())
+	} else if err = db.ExceptionAdd(r, &move); err != nil {
+		t.Fatalf("Cannot add %s to Reminder %q: %s",
+			move.String(),
+			r.Title,
+			err.Error())
+	} else if rem, err = db.ReminderGetByID(r.ID); err != nil {
+		t.Fatalf("Cannot look up Reminder %d: %s",
+			r.ID,
+			err.Error())
+	} else if len(rem.Exceptions) != 2 {
+		t.Fatalf("Unexpected number of Exceptions for Reminder %q: %d (expected 2)",
+			rem.Title,
+			len(rem.Exceptions))
+	} else if e := rem.Exception(occ); e == nil || !e.Skip() {
+		t.Errorf("Occurrence %s of Reminder %q should be skipped",
+			occ.Format(common.TimestampFormat),
+			rem.Title)
+	} else if e = rem.Exception(move.Occurrence); e == nil || !e.Due.Equal(move.Due) {
+		t.Errorf("Occurrence %s of Reminder %q should be moved to %s",
+			move.Occurrence.Format(common.TimestampFormat),
+			rem.Title,
+			move.Due.Format(common.TimestampFormat))
+	}
+
+	// Adding an Exception for the same occurrence replaces the old one.
+	skip.Due = occ.Add(time.Hour)
+
+	if err = db.ExceptionAdd(r, &skip); err != nil {
+		t.Fatalf("Cannot replace Exception of Reminder %q: %s",
+			r.Title,
+			err.Error())
+	} else if excs, err = db.ExceptionGetByReminder(r); err != nil {
+		t.Fatalf("Cannot load Exceptions of Reminder %q: %s",
+			r.Title,
+			err.Error())
+	} else if len(excs) != 2 {
+		t.Errorf("Unexpected number of Exceptions for Reminder %q: %d (expected 2)",
+			r.Title,
+			len(excs))
+	} else if err = db.ExceptionDelete(r, &move); err != nil {
+		t.Fatalf("Cannot delete %s of Reminder %q: %s",
+			move.String(),
+			r.Title,
+			err.Error())
+	} else if excs, err = db.ExceptionGetByReminder(r); err != nil {
+		t.Fatalf("Cannot load Exceptions of Reminder %q: %s",
+			r.Title,
+			err.Error())
+	} else if len(excs) != 1 || excs[0].Skip() {
+		t.Errorf("Unexpected Exceptions for Reminder %q after deleting one: %v",
+			r.Title,
+			excs)
+	} else if len(r.Exceptions) != 1 {
+		t.Errorf("Exceptions of Reminder %q were not updated in memory: %v",
+			r.Title,
+			r.Exceptions)
+	}
+} // func TestException(t *testing.T)
+
+func TestNotificationGetByRange(t *testing.T) {
+	if db == nil {
+		t.SkipNow()
+	}
+
+	var (
+		err      error
+		r        = items[0]
+		base     = time.Date(2022, 9, 14, 9, 0, 0, 0, time.Local)
+		n1, n2   *objects.Notification
+		from, to = base, base.Add(time.Hour * 24)
+		notes    []objects.Notification
+	)
+
+	if n1, err = db.NotificationAdd(r, base); err != nil {
+		t.Fatalf("Cannot add Notification for Reminder %q: %s",
+			r.Title,
+			err.Error())
+	} else if n2, err = db.NotificationAdd(r, to); err != nil {
+		t.Fatalf("Cannot add Notification for Reminder %q: %s",
+			r.Title,
+			err.Error())
+	} else if err = db.NotificationDisplay(n1, base); err != nil {
+		t.Fatalf("Cannot mark Notification %d as displayed: %s",
+			n1.ID,
+			err.Error())
+	} else if err = db.NotificationAcknowledge(n1, base.Add(time.Minute)); err != nil {
+		t.Fatalf("Cannot acknowledge Notification %d: %s",
+			n1.ID,
+			err.Error())
+	} else if notes, err = db.NotificationGetByRange(from, to); err != nil {
+		t.Fatalf("Cannot load Notifications between %s and %s: %s",
+			from.Format(common.TimestampFormat),
+			to.Format(common.TimestampFormat),
+			err.Error())
+	} else if len(notes) != 1 {
+		t.Fatalf("Unexpected number of Notifications: %d (expected 1)",
+			len(notes))
+	} else if notes[0].ID != n1.ID || notes[0].ReminderID != r.ID {
+		t.Errorf("Unexpected Notification: Expected %d (Reminder %d), got %d (Reminder %d)",
+			n1.ID,
+			r.ID,
+			notes[0].ID,
+			notes[0].ReminderID)
+	} else if notes[0].Acknowledged.IsZero() {
+		t.Errorf("Notification %d should be acknowledged", n1.ID)
+	}
+
+	for _, n := range []*objects.Notification{n1, n2} {
+		if err = db.NotificationDelete(n); err != nil {
+			t.Errorf("Cannot delete Notification %d: %s",
+				n.ID,
+				err.Error())
+		}
+	}
+} // func TestNotificationGetByRange(t *testing.T)
+
+func TestPreAlert(t *testing.T) {
+	if db == nil {
+		t.SkipNow()
+	}
+
+	var (
+		err    error
+		r      = items[1]
+		rem    *objects.Reminder
+		n1, n2 *objects.Notification
+		n3     *objects.Notification
+		due    = time.Date(2022, 9, 14, 9, 0, 0, 0, time.Local)
+	)
+
+	if err = db.ReminderSetAlerts(r, []int{900, 86400, 3600}); err != nil {
+		t.Fatalf("Cannot set pre-alerts of Reminder %q: %s",
+			r.Title,
+			err.Error())
+	} else if rem, err = db.ReminderGetByID(r.ID); err != nil {
+		t.Fatalf("Cannot load Reminder %d: %s",
+			r.ID,
+			err.Error())
+	} else if rem.AlertsString() != "86400,3600,900" {
+		t.Errorf("Unexpected pre-alerts of Reminder %q: %v",
+			rem.Title,
+			rem.Alerts)
+	} else if err = db.ReminderSetAlerts(r, []int{-60}); err == nil {
+		t.Errorf("ReminderSetAlerts should reject a negative lead time")
+	}
+
+	if n1, err = db.NotificationAddPreAlert(r, due, 900); err != nil {
+		t.Fatalf("Cannot add pre-alert for Reminder %q: %s",
+			r.Title,
+			err.Error())
+	} else if !n1.IsPreAlert() || !n1.Due().Equal(due) {
+		t.Errorf("Unexpected pre-alert: %d seconds ahead of %s",
+			n1.Lead,
+			n1.Due().Format(common.TimestampFormat))
+	}
+
+	// The main occurrence may be due at the same time as a pre-alert
+	// for a different occurrence, they must not get in each other's way.
+	if n2, err = db.NotificationGetByReminderStamp(r, n1.Timestamp); err != nil {
+		t.Fatalf("Cannot look up Notification: %s", err.Error())
+	} else if n2 != nil {
+		t.Errorf("Pre-alert %d was mistaken for an occurrence", n1.ID)
+	} else if n2, err = db.NotificationAdd(r, n1.Timestamp); err != nil {
+		t.Fatalf("Cannot add Notification for Reminder %q: %s",
+			r.Title,
+			err.Error())
+	} else if n2.ID == n1.ID {
+		t.Errorf("Notification and pre-alert share the same ID %d", n1.ID)
+	}
+
+	if rem, err = db.ReminderGetByID(r.ID); err != nil {
+		t.Fatalf("Cannot load Reminder %d: %s", r.ID, err.Error())
+	} else if n3, err = db.NotificationGetPreAlert(rem, due, 900); err != nil {
+		t.Fatalf("Cannot look up pre-alert: %s", err.Error())
+	} else if n3 == nil || n3.ID != n1.ID || n3.Lead != 900 {
+		t.Errorf("Unexpected pre-alert: %v (expected %d)",
+			n3,
+			n1.ID)
+	}
+
+	for _, n := range []*objects.Notification{n1, n2} {
+		if err = db.NotificationDelete(n); err != nil {
+			t.Errorf("Cannot delete Notification %d: %s",
+				n.ID,
+				err.Error())
+		}
+	}
+} // func TestPreAlert(t *testing.T)
+
+func TestEscalation(t *testing.T) {
+	if db == nil {
+		t.SkipNow()
+	}
+
+	var (
+		err     error
+		r       = items[2]
+		rem     *objects.Reminder
+		n       *objects.Notification
+		pending []objects.Notification
+		steps   []objects.EscalationStep
+		now     = time.Now().Truncate(time.Second)
+		esc     = objects.Escalation{
+			Interval:  300,
+			Threshold: 2,
+			Channel:   objects.ChannelSound,
+		}
+	)
+
+	if err = db.ReminderSetEscalation(r, objects.Escalation{Interval: 10}); err == nil {
+		t.Errorf("ReminderSetEscalation should reject an interval of 10 seconds")
+	} else if err = db.ReminderSetEscalation(r, esc); err != nil {
+		t.Fatalf("Cannot set escalation of Reminder %q: %s",
+			r.Title,
+			err.Error())
+	} else if rem, err = db.ReminderGetByID(r.ID); err != nil {
+		t.Fatalf("Cannot load Reminder %d: %s",
+			r.ID,
+			err.Error())
+	} else if rem.Escalation != esc {
+		t.Errorf("Unexpected escalation of Reminder %q: %s (expected %s)",
+			rem.Title,
+			&rem.Escalation,
+			&esc)
+	}
+
+	if n, err = db.NotificationAdd(r, now); err != nil {
+		t.Fatalf("Cannot add Notification for Reminder %q: %s",
+			r.Title,
+			err.Error())
+	}
+
+	defer db.NotificationDelete(n) // nolint: errcheck
+
+	for i := 1; i <= 3; i++ {
+		var s = esc.Step(n, i, now.Add(time.Duration(i*esc.Interval)*time.Second))
+
+		if err = db.NotificationEscalate(n, &s); err != nil {
+			t.Fatalf("Cannot escalate Notification %d to step %d: %s",
+				n.ID,
+				i,
+				err.Error())
+		} else if s.ID == 0 {
+			t.Errorf("Escalation step %d did not get an ID", i)
+		}
+	}
+
+	if steps, err = db.EscalationGetByNotification(n); err != nil {
+		t.Fatalf("Cannot load escalation steps of Notification %d: %s",
+			n.ID,
+			err.Error())
+	} else if len(steps) != 3 {
+		t.Fatalf("Unexpected number of escalation steps: %d (expected 3)",
+			len(steps))
+	} else if steps[2].Urgency != objects.UrgencyCritical || steps[2].Channel != objects.ChannelSound {
+		t.Errorf("Unexpected last escalation step: %s, %s",
+			steps[2].Urgency,
+			steps[2].Channel)
+	} else if steps[0].Channel != objects.ChannelDesktop {
+		t.Errorf("First escalation step should use the desktop channel, not %s",
+			steps[0].Channel)
+	}
+
+	if pending, err = db.NotificationGetByReminderPending(r); err != nil {
+		t.Fatalf("Cannot load pending Notifications of Reminder %q: %s",
+			r.Title,
+			err.Error())
+	}
+
+	for _, p := range pending {
+		if p.ID == n.ID && p.Step != 3 {
+			t.Errorf("Notification %d is at step %d, expected 3",
+				p.ID,
+				p.Step)
+		}
+	}
+} // func TestEscalation(t *testing.T)
+
+func TestReminderSetPriority(t *testing.T) {
+	if db == nil {
+		t.SkipNow()
+	}
+
+	var (
+		err error
+		rem *objects.Reminder
+		r   = items[3]
+	)
+
+	if err = db.ReminderSetPriority(r, objects.Priority(3)); err == nil {
+		t.Errorf("ReminderSetPriority should reject an invalid priority")
+	}
+
+	for _, p := range objects.Priorities {
+		if err = db.ReminderSetPriority(r, p); err != nil {
+			t.Fatalf("Cannot set priority of Reminder %q to %s: %s",
+				r.Title,
+				p,
+				err.Error())
+		} else if rem, err = db.ReminderGetByID(r.ID); err != nil {
+			t.Fatalf("Cannot load Reminder %d: %s",
+				r.ID,
+				err.Error())
+		} else if rem.Priority != p {
+			t.Errorf("Unexpected priority of Reminder %q: %s (expected %s)",
+				rem.Title,
+				rem.Priority,
+				p)
+		}
+	}
+} // func TestReminderSetPriority(t *testing.T)
+
+func TestReminderSetSnooze(t *testing.T) {
+	if db == nil {
+		t.SkipNow()
+	}
+
+	var (
+		err error
+		rem *objects.Reminder
+		r   = items[4]
+	)
+
+	if err = db.ReminderSetSnooze(r, objects.DefaultSnoozes[1:]); err != nil {
+		t.Fatalf("Cannot set snooze choices of Reminder %q: %s",
+			r.Title,
+			err.Error())
+	} else if rem, err = db.ReminderGetByID(r.ID); err != nil {
+		t.Fatalf("Cannot load Reminder %d: %s",
+			r.ID,
+			err.Error())
+	} else if rem.SnoozeString() != "15m, 1h, tomorrow morning" {
+		t.Errorf("Unexpected snooze choices of Reminder %q: %q",
+			rem.Title,
+			rem.SnoozeString())
+	}
+} // func TestReminderSetSnooze(t *testing.T)
+
+func TestSetting(t *testing.T) {
+	if db == nil {
+		t.SkipNow()
+	}
+
+	const key = "snooze"
+	var (
+		err   error
+		ok    bool
+		value string
+	)
+
+	if _, ok, err = db.SettingGet(key); err != nil {
+		t.Fatalf("Cannot get setting %q: %s", key, err.Error())
+	} else if ok {
+		t.Errorf("Setting %q should not exist, yet", key)
+	}
+
+	for _, v := range []string{"5m, 1h", "tomorrow morning"} {
+		if err = db.SettingSet(key, v); err != nil {
+			t.Fatalf("Cannot set %q to %q: %s", key, v, err.Error())
+		} else if value, ok, err = db.SettingGet(key); err != nil {
+			t.Fatalf("Cannot get setting %q: %s", key, err.Error())
+		} else if !ok || value != v {
+			t.Errorf("Setting %q is %q (%t), expected %q",
+				key,
+				value,
+				ok,
+				v)
+		}
+	}
+} // func TestSetting(t *testing.T)
+
+func TestNotificationDeferred(t *testing.T) {
+	if db == nil {
+		t.SkipNow()
+	}
+
+	var (
+		err      error
+		n        *objects.Notification
+		list     []objects.Notification
+		r        = items[2]
+		deferred = time.Date(2022, 9, 15, 23, 30, 0, 0, time.Local)
+	)
+
+	if n, err = db.NotificationAdd(r, deferred.Add(-time.Minute)); err != nil {
+		t.Fatalf("Cannot add Notification for Reminder %q: %s",
+			r.Title,
+			err.Error())
+	} else if err = db.NotificationSetDeferred(n, deferred); err != nil {
+		t.Fatalf("Cannot defer Notification %d: %s",
+			n.ID,
+			err.Error())
+	} else if list, err = db.NotificationGetDeferred(); err != nil {
+		t.Fatalf("Cannot load deferred Notifications: %s", err.Error())
+	} else if len(list) != 1 || list[0].ID != n.ID {
+		t.Errorf("Unexpected deferred Notifications: %v (expected %d)",
+			list,
+			n.ID)
+	} else if !list[0].Deferred.Equal(deferred) {
+		t.Errorf("Notification %d was deferred at %s, expected %s",
+			n.ID,
+			list[0].Deferred.Format(common.TimestampFormat),
+			deferred.Format(common.TimestampFormat))
+	}
+
+	if err = db.NotificationSetDeferred(n, time.Time{}); err != nil {
+		t.Fatalf("Cannot release Notification %d: %s",
+			n.ID,
+			err.Error())
+	} else if list, err = db.NotificationGetDeferred(); err != nil {
+		t.Fatalf("Cannot load deferred Notifications: %s", err.Error())
+	} else if len(list) != 0 {
+		t.Errorf("No Notifications should be deferred, but got %d",
+			len(list))
+	} else if err = db.NotificationDelete(n); err != nil {
+		t.Errorf("Cannot delete Notification %d: %s",
+			n.ID,
+			err.Error())
+	}
+} // func TestNotificationDeferred(t *testing.T)
+
+func TestTags(t *testing.T) {
+	if db == nil {
+		t.SkipNow()
+	}
+
+	var (
+		err       error
+		rem       *objects.Reminder
+		reminders []objects.Reminder
+		tags      []objects.Tag
+		r         = items[5]
+		tag       = objects.Tag{Name: "work", Priority: objects.PriorityHigh, QuietExempt: true}
+	)
+
+	if err = db.ReminderSetTags(r, []string{"family", "work"}); err != nil {
+		t.Fatalf("Cannot set tags of Reminder %q: %s",
+			r.Title,
+			err.Error())
+	} else if rem, err = db.ReminderGetByID(r.ID); err != nil {
+		t.Fatalf("Cannot load Reminder %d: %s",
+			r.ID,
+			err.Error())
+	} else if rem.TagsString() != "family, work" {
+		t.Errorf("Unexpected tags of Reminder %q: %q",
+			rem.Title,
+			rem.TagsString())
+	} else if err = db.TagSet(&tag); err != nil {
+		t.Fatalf("Cannot set Tag %q: %s", tag.Name, err.Error())
+	} else if tags, err = db.TagGetAll(); err != nil {
+		t.Fatalf("Cannot load Tags: %s", err.Error())
+	} else if len(tags) != 2 {
+		t.Fatalf("Expected 2 Tags, got %d", len(tags))
+	} else if tags[1] != tag {
+		t.Errorf("Unexpected Tag: %#v (expected %#v)", tags[1], tag)
+	} else if err = db.TagSet(&objects.Tag{Name: "Not Valid"}); err == nil {
+		t.Errorf("TagSet should reject an invalid name")
+	}
+
+	if err = db.TagDelete("family"); err != nil {
+		t.Fatalf("Cannot delete Tag: %s", err.Error())
+	} else if reminders, err = db.ReminderGetAll(); err != nil {
+		t.Fatalf("Cannot load Reminders: %s", err.Error())
+	}
+
+	for _, x := range reminders {
+		if x.ID == r.ID && x.TagsString() != "work" {
+			t.Errorf("Unexpected tags of Reminder %q after deleting a Tag: %q",
+				x.Title,
+				x.TagsString())
+		} else if x.ID != r.ID && len(x.Tags) != 0 {
+			t.Errorf("Reminder %q should not have any tags: %q",
+				x.Title,
+				x.TagsString())
+		}
+	}
+
+	if err = db.ReminderSetTags(r, nil); err != nil {
+		t.Errorf("Cannot clear tags of Reminder %q: %s",
+			r.Title,
+			err.Error())
+	}
+} // func TestTags(t *testing.T)
+
+func TestChecklist(t *testing.T) {
+	if db == nil {
+		t.SkipNow()
+	}
+
+	var (
+		err       error
+		rem       *objects.Reminder
+		reminders []objects.Reminder
+		r         = items[6]
+		list      = []objects.ChecklistItem{
+			{Text: "Passport"},
+			{Text: "Tickets", Done: true},
+			{Text: "Charger"},
+		}
+	)
+
+	if err = db.ReminderSetChecklist(r, list); err != nil {
+		t.Fatalf("Cannot set checklist of Reminder %q: %s",
+			r.Title,
+			err.Error())
+	} else if rem, err = db.ReminderGetByID(r.ID); err != nil {
+		t.Fatalf("Cannot load Reminder %d: %s",
+			r.ID,
+			err.Error())
+	} else if rem.ChecklistString() != objects.FormatChecklist(list) {
+		t.Errorf("Unexpected checklist of Reminder %q: %q",
+			rem.Title,
+			rem.ChecklistString())
+	} else if err = db.ChecklistSetDone(rem, 2, true); err != nil {
+		t.Fatalf("Cannot check off item: %s", err.Error())
+	} else if err = db.ChecklistSetDone(rem, 3, true); err == nil {
+		t.Errorf("ChecklistSetDone should reject an invalid position")
+	} else if reminders, err = db.ReminderGetAll(); err != nil {
+		t.Fatalf("Cannot load Reminders: %s", err.Error())
+	}
+
+	for _, x := range reminders {
+		if x.ID != r.ID {
+			if len(x.Checklist) != 0 {
+				t.Errorf("Reminder %q should not have a checklist: %q",
+					x.Title,
+					x.ChecklistString())
+			}
+		} else if done, total := x.ChecklistProgress(); done != 2 || total != 3 {
+			t.Errorf("Unexpected progress of Reminder %q: %d/%d",
+				x.Title,
+				done,
+				total)
+		} else if x.Changed.Unix() != rem.Changed.Unix() {
+			t.Errorf("Checking off an item should update the change stamp of Reminder %q",
+				x.Title)
+		}
+	}
+
+	if err = db.ReminderSetChecklist(r, nil); err != nil {
+		t.Errorf("Cannot clear checklist of Reminder %q: %s",
+			r.Title,
+			err.Error())
+	}
+} // func TestChecklist(t *testing.T)
+
+func TestDependencies(t *testing.T) {
+	if db == nil {
+		t.SkipNow()
+	}
+
+	var (
+		err       error
+		rem       *objects.Reminder
+		ids       []int64
+		reminders []objects.Reminder
+		a, b, c   = items[7], items[8], items[9]
+		now       = time.Now().Truncate(time.Second)
+	)
+
+	if err = db.ReminderSetDepends(b, []objects.Dependency{{Prereq: a.UUID}}); err != nil {
+		t.Fatalf("Cannot set dependencies of Reminder %q: %s",
+			b.Title,
+			err.Error())
+	} else if err = db.ReminderSetDepends(c, []objects.Dependency{{Prereq: a.UUID, Kind: objects.DepFollowUp, Delay: 86400}}); err != nil {
+		t.Fatalf("Cannot set dependencies of Reminder %q: %s",
+			c.Title,
+			err.Error())
+	} else if rem, err = db.ReminderGetByUUID(b.UUID); err != nil {
+		t.Fatalf("Cannot load Reminder %s: %s",
+			b.UUID,
+			err.Error())
+	} else if rem == nil || rem.ID != b.ID {
+		t.Fatalf("ReminderGetByUUID(%q) returned the wrong Reminder: %v",
+			b.UUID,
+			rem)
+	} else if !objects.SameDepends(rem.Depends, b.Depends) {
+		t.Errorf("Unexpected dependencies of Reminder %q: %v",
+			rem.Title,
+			rem.Depends)
+	} else if rem, err = db.ReminderGetByUUID("no-such-uuid"); err != nil {
+		t.Errorf("Cannot look up unknown UUID: %s", err.Error())
+	} else if rem != nil {
+		t.Errorf("ReminderGetByUUID returned a Reminder for an unknown UUID")
+	} else if ids, err = db.DependencyGetFollowUps(a); err != nil {
+		t.Fatalf("Cannot load follow-ups of Reminder %q: %s",
+			a.Title,
+			err.Error())
+	} else if len(ids) != 1 || ids[0] != c.ID {
+		t.Errorf("Unexpected follow-ups of Reminder %q: %v",
+			a.Title,
+			ids)
+	} else if err = db.DependencyTrigger(c, a.UUID, now); err != nil {
+		t.Fatalf("Cannot trigger follow-up %q: %s",
+			c.Title,
+			err.Error())
+	} else if reminders, err = db.ReminderGetAll(); err != nil {
+		t.Fatalf("Cannot load Reminders: %s", err.Error())
+	}
+
+	for _, x := range reminders {
+		switch x.ID {
+		case b.ID:
+			if len(x.Depends) != 1 || x.Depends[0].Prereq != a.UUID {
+				t.Errorf("Unexpected dependencies of Reminder %q: %v",
+					x.Title,
+					x.Depends)
+			}
+		case c.ID:
+			if len(x.Depends) != 1 || !x.Depends[0].Triggered.Equal(now) {
+				t.Errorf("Follow-up %q should have been triggered at %s: %v",
+					x.Title,
+					now,
+					x.Depends)
+			}
+		default:
+			if len(x.Depends) != 0 {
+				t.Errorf("Reminder %q should not have any dependencies: %v",
+					x.Title,
+					x.Depends)
+			}
+		}
+	}
+
+	for _, r := range []*objects.Reminder{b, c} {
+		if err = db.ReminderSetDepends(r, nil); err != nil {
+			t.Errorf("Cannot clear dependencies of Reminder %q: %s",
+				r.Title,
+				err.Error())
+		}
+	}
+} // func TestDependencies(t *testing.T)
+
+func TestHistory(t *testing.T) {
+	if db == nil {
+		t.SkipNow()
+	}
+
+	var (
+		err     error
+		n       *objects.Notification
+		notes   []objects.Notification
+		snoozes []objects.SnoozeEvent
+		hist    []objects.HistoryItem
+		r       = items[10]
+		base    = time.Date(2022, 9, 20, 8, 0, 0, 0, time.Local)
+		stamps  = []time.Time{
+			base,
+			base.Add(time.Hour),
+			base.Add(2 * time.Hour),
+			base.Add(3 * time.Hour),
+		}
+		list = make([]*objects.Notification, len(stamps))
+	)
+
+	for i, s := range stamps {
+		if list[i], err = db.NotificationAdd(r, s); err != nil {
+			t.Fatalf("Cannot add Notification for Reminder %q at %s: %s",
+				r.Title,
+				s.Format(common.TimestampFormat),
+				err.Error())
+		}
+	}
+
+	// The first one goes stale, the second one is displayed twice and
+	// acknowledged, the third one is snoozed until the fourth.
+	if _, err = db.NotificationSkipStale(r, stamps[1]); err != nil {
+		t.Fatalf("Cannot skip stale Notifications: %s", err.Error())
+	} else if err = db.NotificationDisplay(list[1], stamps[1].Add(time.Minute)); err != nil {
+		t.Fatalf("Cannot display Notification %d: %s", list[1].ID, err.Error())
+	} else if err = db.NotificationDisplay(list[1], stamps[1].Add(10*time.Minute)); err != nil {
+		t.Fatalf("Cannot display Notification %d: %s", list[1].ID, err.Error())
+	} else if err = db.NotificationAcknowledge(list[1], stamps[1].Add(11*time.Minute)); err != nil {
+		t.Fatalf("Cannot acknowledge Notification %d: %s", list[1].ID, err.Error())
+	} else if _, err = db.SnoozeAdd(list[2], stamps[2].Add(time.Minute), stamps[3]); err != nil {
+		t.Fatalf("Cannot snooze Notification %d: %s", list[2].ID, err.Error())
+	} else if notes, err = db.NotificationGetHistory(r, base); err != nil {
+		t.Fatalf("Cannot load history of Reminder %q: %s", r.Title, err.Error())
+	} else if snoozes, err = db.SnoozeGetByReminder(r, base); err != nil {
+		t.Fatalf("Cannot load snoozes of Reminder %q: %s", r.Title, err.Error())
+	} else if len(notes) != len(stamps) || len(snoozes) != 1 {
+		t.Fatalf("Expected %d Notifications and 1 snooze, got %d and %d",
+			len(stamps),
+			len(notes),
+			len(snoozes))
+	} else if !notes[0].Skipped || notes[1].Skipped {
+		t.Errorf("Only the first Notification should have been skipped: %v", notes)
+	} else if !notes[1].Shown.Equal(stamps[1].Add(time.Minute)) {
+		t.Errorf("Notification %d was first shown at %s, expected %s",
+			notes[1].ID,
+			notes[1].Shown.Format(common.TimestampFormat),
+			stamps[1].Add(time.Minute).Format(common.TimestampFormat))
+	}
+
+	if hist = objects.BuildHistory(notes, snoozes); len(hist) != 3 {
+		t.Errorf("Expected 3 occurrences in history, got %d: %v",
+			len(hist),
+			hist)
+	} else if !hist[0].Missed || hist[1].AckLatency() != 10*time.Minute {
+		t.Errorf("Unexpected history: %v", hist)
+	} else if !hist[2].Due.Equal(stamps[2]) || hist[2].Snoozes != 1 {
+		t.Errorf("The last occurrence should have been snoozed from %s: %v",
+			stamps[2].Format(common.TimestampFormat),
+			hist[2])
+	}
+
+	for _, n = range list {
+		if err = db.NotificationDelete(n); err != nil {
+			t.Errorf("Cannot delete Notification %d: %s",
+				n.ID,
+				err.Error())
+		}
+	}
+} // func TestHistory(t *testing.T)
+
+func TestTombstone(t *testing.T) {
+	if db == nil {
+		t.SkipNow()
+	}
+
+	var (
+		err   error
+		cnt   int64
+		tomb  *objects.Tombstone
+		all   []objects.Tombstone
+		peers []objects.PeerState
+		uuid  = common.GetUUID()
+		now   = time.Now().Truncate(time.Second)
+	)
+
+	if err = db.TombstoneAdd(uuid, now); err != nil {
+		t.Fatalf("Cannot add Tombstone: %s", err.Error())
+	} else if err = db.TombstoneAdd(uuid, now.Add(-time.Hour)); err != nil {
+		t.Fatalf("Cannot add older Tombstone: %s", err.Error())
+	} else if tomb, err = db.TombstoneGetByUUID(uuid); err != nil {
+		t.Fatalf("Cannot load Tombstone: %s", err.Error())
+	} else if tomb == nil {
+		t.Fatalf("Tombstone for %s was not found", uuid)
+	} else if !tomb.Deleted.Equal(now) {
+		t.Errorf("An older deletion replaced a newer one: %s", tomb)
+	} else if err = db.TombstoneAdd(uuid, now.Add(time.Minute)); err != nil {
+		t.Fatalf("Cannot add newer Tombstone: %s", err.Error())
+	} else if tomb, err = db.TombstoneGetByUUID(uuid); err != nil {
+		t.Fatalf("Cannot load Tombstone: %s", err.Error())
+	} else if !tomb.Deleted.Equal(now.Add(time.Minute)) {
+		t.Errorf("A newer deletion did not replace the older one: %s", tomb)
+	} else if all, err = db.TombstoneGetAll(); err != nil {
+		t.Fatalf("Cannot load all Tombstones: %s", err.Error())
+	} else if len(all) != 1 || all[0].UUID != uuid {
+		t.Errorf("Unexpected list of Tombstones: %v", all)
+	}
+
+	if err = db.PeerSetSeen("alpha", now); err != nil {
+		t.Fatalf("Cannot add Peer: %s", err.Error())
+	} else if err = db.PeerSetSeen("alpha", now.Add(-time.Hour)); err != nil {
+		t.Fatalf("Cannot update Peer: %s", err.Error())
+	} else if err = db.PeerSetSeen("beta", now.Add(-48*time.Hour)); err != nil {
+		t.Fatalf("Cannot add Peer: %s", err.Error())
+	} else if err = db.PeerPurge(now.Add(-24 * time.Hour)); err != nil {
+		t.Fatalf("Cannot purge Peers: %s", err.Error())
+	} else if peers, err = db.PeerGetAll(); err != nil {
+		t.Fatalf("Cannot load Peers: %s", err.Error())
+	} else if len(peers) != 1 || peers[0].Name != "alpha" || !peers[0].Seen.Equal(now) {
+		t.Errorf("Unexpected list of Peers: %v", peers)
+	}
+
+	if cnt, err = db.TombstonePurge(now.Add(-time.Hour)); err != nil {
+		t.Fatalf("Cannot purge Tombstones: %s", err.Error())
+	} else if cnt != 0 {
+		t.Errorf("Purged %d Tombstones recorded before they were", cnt)
+	} else if cnt, err = db.TombstonePurge(now.Add(time.Hour)); err != nil {
+		t.Fatalf("Cannot purge Tombstones: %s", err.Error())
+	} else if cnt != 1 {
+		t.Errorf("Purged %d Tombstones, expected 1", cnt)
+	} else if err = db.TombstoneDelete(uuid); err != nil {
+		t.Errorf("Cannot delete missing Tombstone: %s", err.Error())
+	}
+} // func TestTombstone(t *testing.T)
+
+func TestRevision(t *testing.T) {
+	if db == nil {
+		t.SkipNow()
+	}
+
+	var (
+		err       error
+		op, undo  int64
+		ops       []int64
+		rev       *objects.Revision
+		revs, all []objects.Revision
+		uuid      = common.GetUUID()
+	)
+
+	revs = []objects.Revision{
+		{UUID: uuid, Field: "Title", Old: `"a"`, New: `"b"`},
+		{UUID: uuid, Field: "Finished", Old: "false", New: "true"},
+	}
+
+	if op, err = db.RevisionAdd("", 0, revs); err != nil {
+		t.Fatalf("Cannot add Revisions: %s", err.Error())
+	} else if op == 0 || revs[0].Op != op || revs[1].ID <= revs[0].ID {
+		t.Fatalf("Revisions were not filled in properly: %v", revs)
+	} else if ops, err = db.RevisionGetUndoable(10); err != nil {
+		t.Fatalf("Cannot load operations to undo: %s", err.Error())
+	} else if len(ops) == 0 || ops[0] != op {
+		t.Fatalf("Operation %d should be the first to undo: %v", op, ops)
+	} else if undo, err = db.RevisionAdd("", op, []objects.Revision{
+		{UUID: uuid, Field: "Title", Old: `"b"`, New: `"a"`},
+	}); err != nil {
+		t.Fatalf("Cannot add undo operation: %s", err.Error())
+	} else if _, err = db.RevisionAdd("alpha", 0, []objects.Revision{
+		{UUID: uuid, Field: "Title", Old: `"a"`, New: `"c"`},
+	}); err != nil {
+		t.Fatalf("Cannot add remote operation: %s", err.Error())
+	} else if ops, err = db.RevisionGetUndoable(10); err != nil {
+		t.Fatalf("Cannot load operations to undo: %s", err.Error())
+	} else if len(ops) != 0 {
+		t.Errorf("Nothing should be left to undo: %v", ops)
+	} else if all, err = db.RevisionGetByUUID(uuid); err != nil {
+		t.Fatalf("Cannot load Revisions of %s: %s", uuid, err.Error())
+	} else if len(all) != 4 {
+		t.Fatalf("Expected 4 Revisions, got %d", len(all))
+	} else if all[2].Op != undo || all[2].Undoes != op || all[3].Who() != "alpha" {
+		t.Errorf("Unexpected Revisions: %v", all)
+	} else if rev, err = db.RevisionGetByID(revs[1].ID); err != nil {
+		t.Fatalf("Cannot load Revision %d: %s", revs[1].ID, err.Error())
+	} else if rev == nil || rev.Field != "Finished" || rev.New != "true" {
+		t.Errorf("Unexpected Revision: %v", rev)
+	} else if all, err = db.RevisionGetByOp(op); err != nil {
+		t.Fatalf("Cannot load Revisions of operation %d: %s", op, err.Error())
+	} else if len(all) != 2 {
+		t.Errorf("Expected 2 Revisions in operation %d, got %d", op, len(all))
+	}
+} // func TestRevision(t *testing.T)
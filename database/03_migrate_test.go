@@ -0,0 +1,234 @@
+// /home/krylon/go/src/github.com/blicero/theseus/database/03_migrate_test.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 17. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <>
+
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/blicero/theseus/common"
+)
+
+// The files in testdata hold the schema of the database as it was at each
+// version, so we can check that databases created by older versions of the
+// application are upgraded properly.
+
+var spaceRe = regexp.MustCompile(`\s+`)
+
+// dumpSchema returns the statements that create the tables and indices of
+// the database, normalized so that they can be compared.
+func dumpSchema(h *sql.DB) (map[string]string, error) {
+	var (
+		err    error
+		rows   *sql.Rows
+		schema = make(map[string]string)
+	)
+
+	if rows, err = h.Query("SELECT name, sql FROM sqlite_master WHERE sql IS NOT NULL AND name NOT LIKE 'sqlite_%'"); err != nil {
+		return nil, err
+	}
+
+	defer rows.Close() // nolint: errcheck
+
+	for rows.Next() {
+		var name, stmt string
+
+		if err = rows.Scan(&name, &stmt); err != nil {
+			return nil, err
+		}
+
+		stmt = strings.ReplaceAll(stmt, `"`, "")
+		schema[name] = strings.TrimSpace(spaceRe.ReplaceAllString(stmt, " "))
+	}
+
+	return schema, rows.Err()
+} // func dumpSchema(h *sql.DB) (map[string]string, error)
+
+// mkFixture creates a database from the given schema file and fills in a
+// Reminder with a Notification.
+func mkFixture(path, schemaFile string) error {
+	var (
+		err    error
+		script []byte
+		h      *sql.DB
+		due    = time.Now().Add(time.Hour).Unix()
+	)
+
+	if script, err = os.ReadFile(schemaFile); err != nil {
+		return err
+	} else if h, err = sql.Open("sqlite3", path); err != nil {
+		return err
+	}
+
+	defer h.Close() // nolint: errcheck
+
+	if _, err = h.Exec(string(script)); err != nil {
+		return fmt.Errorf("Cannot create schema: %w", err)
+	} else if _, err = h.Exec(
+		"INSERT INTO reminder (title, description, due, uuid, changed) VALUES ('Fixture', 'Old data', ?, 'fixture-uuid', ?)",
+		due,
+		due); err != nil {
+		return fmt.Errorf("Cannot add Reminder: %w", err)
+	} else if _, err = h.Exec(
+		"INSERT INTO notification (reminder_id, timestamp, displayed, acknowledged) VALUES (1, ?, ?, ?)",
+		due,
+		due,
+		due); err != nil {
+		return fmt.Errorf("Cannot add Notification: %w", err)
+	}
+
+	return nil
+} // func mkFixture(path, schemaFile string) error
+
+func TestMigrate(t *testing.T) {
+	var (
+		err            error
+		files          []string
+		fresh          *Database
+		expect         map[string]string
+		freshPath      = filepath.Join(common.BaseDir, "migrate_fresh.db")
+		versionPattern = regexp.MustCompile(`schema_v(\d+)\.sql$`)
+	)
+
+	if fresh, err = Open(freshPath); err != nil {
+		t.Fatalf("Cannot create fresh database: %s", err.Error())
+	}
+
+	defer fresh.Close() // nolint: errcheck
+
+	if expect, err = dumpSchema(fresh.db); err != nil {
+		t.Fatalf("Cannot read schema of fresh database: %s", err.Error())
+	} else if files, err = filepath.Glob("testdata/schema_v*.sql"); err != nil {
+		t.Fatalf("Cannot list schema files: %s", err.Error())
+	} else if len(files) != schemaVersion+1 {
+		t.Errorf("There are %d schema files, expected %d",
+			len(files),
+			schemaVersion+1)
+	}
+
+	for _, file := range files {
+		var (
+			mdb      *Database
+			h        *sql.DB
+			schema   map[string]string
+			backups  []string
+			version  int
+			tracked  bool
+			upgraded bool
+			title    string
+			ackCnt   int
+			ver      = versionPattern.FindStringSubmatch(file)[1]
+			path     = filepath.Join(common.BaseDir, fmt.Sprintf("migrate_v%s.db", ver))
+		)
+
+		if err = mkFixture(path, file); err != nil {
+			t.Errorf("Cannot create database from %s: %s", file, err.Error())
+			continue
+		} else if h, err = sql.Open("sqlite3", path); err != nil {
+			t.Errorf("Cannot open database from %s: %s", file, err.Error())
+			continue
+		} else if version, tracked, err = getSchemaVersion(context.Background(), h); err != nil {
+			t.Errorf("Cannot get version of database from %s: %s", file, err.Error())
+		}
+
+		h.Close() // nolint: errcheck,gosec
+
+		// A database that is up to date is left alone.
+		upgraded = version != schemaVersion || !tracked
+
+		if mdb, err = Open(path); err != nil {
+			t.Errorf("Cannot open database from %s: %s", file, err.Error())
+			continue
+		}
+
+		if schema, err = dumpSchema(mdb.db); err != nil {
+			t.Errorf("Cannot read schema of upgraded database v%s: %s", ver, err.Error())
+		} else {
+			for name, stmt := range expect {
+				if schema[name] != stmt {
+					t.Errorf("Schema of %s in database v%s differs:\nexpected: %s\ngot:      %s",
+						name,
+						ver,
+						stmt,
+						schema[name])
+				}
+			}
+
+			for name := range schema {
+				if _, ok := expect[name]; !ok {
+					t.Errorf("Upgraded database v%s contains unexpected object %s",
+						ver,
+						name)
+				}
+			}
+		}
+
+		if err = mdb.db.QueryRow("SELECT title FROM reminder WHERE uuid = 'fixture-uuid'").Scan(&title); err != nil {
+			t.Errorf("Cannot find Reminder in database v%s: %s", ver, err.Error())
+		} else if title != "Fixture" {
+			t.Errorf("Unexpected title of Reminder in database v%s: %q", ver, title)
+		} else if err = mdb.db.QueryRow("SELECT COUNT(*) FROM notification WHERE acknowledged IS NOT NULL").Scan(&ackCnt); err != nil {
+			t.Errorf("Cannot count Notifications in database v%s: %s", ver, err.Error())
+		} else if ackCnt != 1 {
+			t.Errorf("Database v%s has %d acknowledged Notifications, expected 1", ver, ackCnt)
+		} else if version, tracked, err = getSchemaVersion(context.Background(), mdb.db); err != nil {
+			t.Errorf("Cannot get version of database v%s: %s", ver, err.Error())
+		} else if !tracked || version != schemaVersion {
+			t.Errorf("Database v%s has version %d (tracked: %t), expected %d",
+				ver,
+				version,
+				tracked,
+				schemaVersion)
+		} else if backups, err = filepath.Glob(path + ".v*.bak"); err != nil || (len(backups) == 1) != upgraded {
+			t.Errorf("Unexpected backups of database v%s (upgraded: %t): %v", ver, upgraded, backups)
+		}
+
+		mdb.Close() // nolint: errcheck,gosec
+
+		// Opening the database again must leave it alone.
+		if mdb, err = Open(path); err != nil {
+			t.Errorf("Cannot re-open upgraded database v%s: %s", ver, err.Error())
+		} else {
+			mdb.Close() // nolint: errcheck,gosec
+		}
+	}
+} // func TestMigrate(t *testing.T)
+
+func TestMigrateTooNew(t *testing.T) {
+	var (
+		err  error
+		h    *sql.DB
+		path = filepath.Join(common.BaseDir, "migrate_future.db")
+	)
+
+	if err = mkFixture(path, fmt.Sprintf("testdata/schema_v%02d.sql", schemaVersion)); err != nil {
+		t.Fatalf("Cannot create database: %s", err.Error())
+	} else if h, err = sql.Open("sqlite3", path); err != nil {
+		t.Fatalf("Cannot open database: %s", err.Error())
+	} else if _, err = h.Exec("CREATE TABLE IF NOT EXISTS schema_version (version INTEGER PRIMARY KEY, timestamp INTEGER NOT NULL) STRICT"); err != nil {
+		t.Fatalf("Cannot create schema_version: %s", err.Error())
+	} else if _, err = h.Exec("INSERT INTO schema_version (version, timestamp) VALUES (?, ?)", schemaVersion+1, time.Now().Unix()); err != nil {
+		t.Fatalf("Cannot set schema version: %s", err.Error())
+	}
+
+	h.Close() // nolint: errcheck,gosec
+
+	if _, err = Open(path); err == nil {
+		t.Errorf("Opening a database from the future should have failed")
+	} else if !errors.Is(err, ErrSchemaTooNew) {
+		t.Errorf("Unexpected error opening a database from the future: %s",
+			err.Error())
+	}
+} // func TestMigrateTooNew(t *testing.T)
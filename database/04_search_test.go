@@ -0,0 +1,125 @@
+// /home/krylon/go/src/github.com/blicero/theseus/database/04_search_test.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 17. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <>
+
+package database
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/blicero/theseus/common"
+	"github.com/blicero/theseus/objects"
+)
+
+func TestFTSQuery(t *testing.T) {
+	type testCase struct {
+		input  string
+		expect string
+	}
+
+	var cases = []testCase{
+		{input: "", expect: ""},
+		{input: "   ", expect: ""},
+		{input: "dent", expect: `"dent"*`},
+		{input: "dent*  appoint", expect: `"dent"* "appoint"*`},
+		{input: `"call the dentist"`, expect: `"call the dentist"`},
+		{input: `"call the dent"* tomorrow`, expect: `"call the dent"* "tomorrow"*`},
+		{input: `milk "half open`, expect: `"milk"* "half open"*`},
+		{input: `NOT x OR - ( ^`, expect: `"NOT"* "x"* "OR"*`},
+		{input: `a"b"`, expect: `"a"* "b"`},
+	}
+
+	for _, c := range cases {
+		if q := ftsQuery(c.input); q != c.expect {
+			t.Errorf("ftsQuery(%q) = %q, expected %q",
+				c.input,
+				q,
+				c.expect)
+		}
+	}
+} // func TestFTSQuery(t *testing.T)
+
+func TestReminderSearch(t *testing.T) {
+	var (
+		err     error
+		sdb     *Database
+		results []objects.SearchResult
+		path    = filepath.Join(common.BaseDir, "search.db")
+		due     = time.Now().Add(time.Hour)
+		rems    = []*objects.Reminder{
+			{Title: "Dentist", Description: "Call the dentist about the appointment on Friday"},
+			{Title: "Groceries", Description: "Milk, eggs, and something for the dentist's waiting room"},
+			{Title: "Taxes", Description: "Send the papers to the tax advisor"},
+		}
+	)
+
+	if sdb, err = Open(path); err != nil {
+		t.Fatalf("Cannot open database %s: %s", path, err.Error())
+	}
+
+	defer sdb.Close() // nolint: errcheck
+
+	if !sdb.fts {
+		if _, err = sdb.ReminderSearch("dentist", 10); err != ErrNoFTS {
+			t.Errorf("ReminderSearch without FTS5 should fail with ErrNoFTS, not %v", err)
+		}
+		t.Skip("SQLite was built without FTS5")
+	}
+
+	for i, r := range rems {
+		r.Timestamp = due.Add(time.Duration(i) * time.Minute)
+		r.UUID = common.GetUUID()
+
+		if err = sdb.ReminderAdd(r); err != nil {
+			t.Fatalf("Cannot add Reminder %q: %s", r.Title, err.Error())
+		}
+	}
+
+	if results, err = sdb.ReminderSearch("dentist", 10); err != nil {
+		t.Fatalf("Cannot search for dentist: %s", err.Error())
+	} else if len(results) != 2 {
+		t.Fatalf("Search for dentist returned %d results, expected 2", len(results))
+	} else if results[0].Reminder.ID != rems[0].ID {
+		t.Errorf("Match in title should be ranked first, got %q", results[0].Reminder.Title)
+	} else if results[0].Title != objects.MatchStart+"Dentist"+objects.MatchEnd {
+		t.Errorf("Unexpected highlighted title %q", results[0].Title)
+	} else if !strings.Contains(results[1].Snippet, objects.MatchStart+"dentist"+objects.MatchEnd) {
+		t.Errorf("Snippet does not highlight the match: %q", results[1].Snippet)
+	}
+
+	if results, err = sdb.ReminderSearch("appoint fri", 10); err != nil {
+		t.Fatalf("Cannot search for prefixes: %s", err.Error())
+	} else if len(results) != 1 || results[0].Reminder.ID != rems[0].ID {
+		t.Errorf("Prefix search returned unexpected results: %v", results)
+	}
+
+	if results, err = sdb.ReminderSearch(`"the tax advisor"`, 10); err != nil {
+		t.Fatalf("Cannot search for phrase: %s", err.Error())
+	} else if len(results) != 1 || results[0].Reminder.ID != rems[2].ID {
+		t.Errorf("Phrase search returned unexpected results: %v", results)
+	} else if results, err = sdb.ReminderSearch(`"advisor the tax"`, 10); err != nil {
+		t.Fatalf("Cannot search for phrase: %s", err.Error())
+	} else if len(results) != 0 {
+		t.Errorf("Phrase search should not have found anything: %v", results)
+	}
+
+	// The triggers have to keep the index up to date.
+	if err = sdb.ReminderSetTitle(rems[2], "Tax return"); err != nil {
+		t.Fatalf("Cannot set title: %s", err.Error())
+	} else if err = sdb.ReminderDelete(rems[0]); err != nil {
+		t.Fatalf("Cannot delete Reminder: %s", err.Error())
+	} else if results, err = sdb.ReminderSearch("return", 10); err != nil {
+		t.Fatalf("Cannot search for new title: %s", err.Error())
+	} else if len(results) != 1 || results[0].Reminder.ID != rems[2].ID {
+		t.Errorf("Search for new title returned unexpected results: %v", results)
+	} else if results, err = sdb.ReminderSearch("dentist", 10); err != nil {
+		t.Fatalf("Cannot search for dentist: %s", err.Error())
+	} else if len(results) != 1 || results[0].Reminder.ID != rems[1].ID {
+		t.Errorf("Deleted Reminder is still found: %v", results)
+	}
+} // func TestReminderSearch(t *testing.T)
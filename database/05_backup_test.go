@@ -0,0 +1,112 @@
+// /home/krylon/go/src/github.com/blicero/theseus/database/05_backup_test.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 17. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <>
+
+package database
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/blicero/theseus/common"
+	"github.com/blicero/theseus/objects"
+)
+
+func TestBackup(t *testing.T) {
+	if db == nil {
+		t.SkipNow()
+	}
+
+	var (
+		err    error
+		path   = filepath.Join(common.BackupDir, "test-backup.db")
+		broken = filepath.Join(common.BackupDir, "broken.db")
+	)
+
+	if err = db.Backup(path); err != nil {
+		t.Fatalf("Cannot back up database: %s", err.Error())
+	} else if err = CheckBackup(path); err != nil {
+		t.Errorf("Backup failed the integrity check: %s", err.Error())
+	} else if _, err = os.Stat(path + ".tmp"); !os.IsNotExist(err) {
+		t.Errorf("Temporary file of backup was left behind")
+	} else if err = db.Backup(path); err == nil {
+		t.Errorf("Backup overwrote an existing file")
+	} else if err = os.WriteFile(broken, []byte("This is not a database"), 0600); err != nil {
+		t.Fatalf("Cannot create broken backup: %s", err.Error())
+	} else if err = CheckBackup(broken); !errors.Is(err, ErrBackupInvalid) {
+		t.Errorf("Broken backup passed the integrity check: %v", err)
+	}
+} // func TestBackup(t *testing.T)
+
+func TestRestore(t *testing.T) {
+	if db == nil {
+		t.SkipNow()
+	}
+
+	var (
+		err    error
+		pool   *Pool
+		c      *Database
+		r      *objects.Reminder
+		path   = filepath.Join(common.BackupDir, "test-restore.db")
+		before = &objects.Reminder{
+			Title:     "Before backup",
+			Timestamp: time.Now().Add(time.Hour).Truncate(time.Second),
+			UUID:      common.GetUUID(),
+		}
+		after = &objects.Reminder{
+			Title:     "After backup",
+			Timestamp: time.Now().Add(time.Hour).Truncate(time.Second),
+			UUID:      common.GetUUID(),
+		}
+	)
+
+	if pool, err = NewPool(2); err != nil {
+		t.Fatalf("Cannot create Pool: %s", err.Error())
+	}
+
+	defer pool.Close() // nolint: errcheck
+
+	c = pool.Get()
+
+	if err = c.ReminderAdd(before); err != nil {
+		t.Fatalf("Cannot add Reminder: %s", err.Error())
+	} else if err = c.Backup(path); err != nil {
+		t.Fatalf("Cannot back up database: %s", err.Error())
+	} else if err = c.ReminderAdd(after); err != nil {
+		t.Fatalf("Cannot add Reminder: %s", err.Error())
+	}
+
+	// While a connection is in use, Restore must give up eventually.
+	restoreTimeout = time.Millisecond * 100
+	if err = pool.Restore(path); !errors.Is(err, ErrRestoreTimeout) {
+		t.Errorf("Restore should have timed out, but returned %v", err)
+	}
+	restoreTimeout = time.Second * 30
+
+	pool.Put(c)
+
+	if err = pool.Restore(filepath.Join(common.BackupDir, "broken.db")); err == nil {
+		t.Fatal("Restoring a broken backup should have failed")
+	} else if err = pool.Restore(path); err != nil {
+		t.Fatalf("Cannot restore backup: %s", err.Error())
+	}
+
+	c = pool.Get()
+	defer pool.Put(c)
+
+	if r, err = c.ReminderGetByUUID(before.UUID); err != nil {
+		t.Fatalf("Cannot look up Reminder: %s", err.Error())
+	} else if r == nil {
+		t.Errorf("Reminder %q is missing from the restored database", before.Title)
+	} else if r, err = c.ReminderGetByUUID(after.UUID); err != nil {
+		t.Fatalf("Cannot look up Reminder: %s", err.Error())
+	} else if r != nil {
+		t.Errorf("Reminder %q was added after the backup, it should be gone", after.Title)
+	}
+} // func TestRestore(t *testing.T)
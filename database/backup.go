@@ -0,0 +1,200 @@
+// /home/krylon/go/src/github.com/blicero/theseus/database/backup.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 17. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <>
+
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	sqlite3 "github.com/mattn/go-sqlite3"
+)
+
+// Copying the database file while the Daemon is running is not safe, since
+// part of the data may still be in the write-ahead log, and a write may
+// happen while we copy. So we use SQLite's online backup API, which copies
+// a consistent snapshot of the database page by page. If another
+// connection writes to the database in between, SQLite notices and starts
+// over.
+
+// backupPages is the number of pages we copy in one step. Between steps,
+// other connections get a chance to access the database.
+const backupPages = 256
+
+// backupPause is the time we wait between two steps of a backup.
+const backupPause = time.Millisecond * 10
+
+// ErrBackupInvalid is returned if a backup fails the integrity check.
+var ErrBackupInvalid = errors.New("backup is not a valid database")
+
+// Backup writes a copy of the database to the given path, which must not
+// exist yet. The copy is written to a temporary file first and checked for
+// integrity before it is moved to its final location, so if Backup fails,
+// no broken backup is left behind.
+func (db *Database) Backup(path string) error {
+	var (
+		err      error
+		exists   bool
+		ctx      = context.Background()
+		tmp      = path + ".tmp"
+		dst      *sql.DB
+		src, out *sql.Conn
+	)
+
+	if _, err = os.Stat(path); err == nil {
+		exists = true
+	} else if !os.IsNotExist(err) {
+		db.log.Printf("[ERROR] Cannot check if %s exists: %s\n",
+			path,
+			err.Error())
+		return err
+	}
+
+	if exists {
+		return fmt.Errorf("Backup %s already exists", path)
+	} else if err = os.Remove(tmp); err != nil && !os.IsNotExist(err) {
+		db.log.Printf("[ERROR] Cannot remove stale temporary file %s: %s\n",
+			tmp,
+			err.Error())
+		return err
+	} else if dst, err = sql.Open("sqlite3", tmp); err != nil {
+		db.log.Printf("[ERROR] Cannot open %s: %s\n",
+			tmp,
+			err.Error())
+		return err
+	}
+
+	defer func() {
+		if dst != nil {
+			dst.Close() // nolint: errcheck
+		}
+		if err != nil {
+			os.Remove(tmp) // nolint: errcheck
+		}
+	}()
+
+	if src, err = db.db.Conn(ctx); err != nil {
+		db.log.Printf("[ERROR] Cannot get connection to database: %s\n",
+			err.Error())
+		return err
+	}
+
+	defer src.Close() // nolint: errcheck
+
+	if out, err = dst.Conn(ctx); err != nil {
+		db.log.Printf("[ERROR] Cannot get connection to %s: %s\n",
+			tmp,
+			err.Error())
+		return err
+	}
+
+	err = out.Raw(func(dconn any) error {
+		return src.Raw(func(sconn any) error {
+			return db.backupCopy(dconn.(*sqlite3.SQLiteConn), sconn.(*sqlite3.SQLiteConn))
+		})
+	})
+
+	out.Close() // nolint: errcheck
+
+	if err != nil {
+		db.log.Printf("[ERROR] Cannot back up database to %s: %s\n",
+			path,
+			err.Error())
+		return err
+	} else if err = dst.Close(); err != nil {
+		db.log.Printf("[ERROR] Cannot close %s: %s\n",
+			tmp,
+			err.Error())
+		return err
+	}
+
+	dst = nil
+
+	if err = CheckBackup(tmp); err != nil {
+		db.log.Printf("[ERROR] Backup %s is broken: %s\n",
+			tmp,
+			err.Error())
+		return err
+	} else if err = os.Rename(tmp, path); err != nil {
+		db.log.Printf("[ERROR] Cannot rename %s to %s: %s\n",
+			tmp,
+			path,
+			err.Error())
+		return err
+	}
+
+	return nil
+} // func (db *Database) Backup(path string) error
+
+func (db *Database) backupCopy(dst, src *sqlite3.SQLiteConn) error {
+	var (
+		err  error
+		done bool
+		bak  *sqlite3.SQLiteBackup
+	)
+
+	if bak, err = dst.Backup("main", src, "main"); err != nil {
+		return err
+	}
+
+	for !done {
+		if done, err = bak.Step(backupPages); err != nil {
+			if worthARetry(err) {
+				waitForRetry()
+				continue
+			}
+
+			bak.Close() // nolint: errcheck
+			return err
+		} else if !done {
+			time.Sleep(backupPause)
+		}
+	}
+
+	return bak.Finish()
+} // func (db *Database) backupCopy(dst, src *sqlite3.SQLiteConn) error
+
+// CheckBackup opens the database at the given path read-only and checks
+// that it is a database we can use: SQLite's integrity check must pass,
+// and the schema must not be newer than the one we know.
+func CheckBackup(path string) error {
+	var (
+		err     error
+		h       *sql.DB
+		ok      bool
+		version int
+		result  string
+		ctx     = context.Background()
+	)
+
+	if _, err = os.Stat(path); err != nil {
+		return err
+	} else if h, err = sql.Open("sqlite3", fmt.Sprintf("file:%s?mode=ro", path)); err != nil {
+		return err
+	}
+
+	defer h.Close() // nolint: errcheck
+
+	if err = h.QueryRowContext(ctx, "PRAGMA integrity_check").Scan(&result); err != nil {
+		return fmt.Errorf("%w: %s", ErrBackupInvalid, err.Error())
+	} else if result != "ok" {
+		return fmt.Errorf("%w: %s", ErrBackupInvalid, result)
+	} else if ok, err = hasTable(ctx, h, "reminder"); err != nil {
+		return err
+	} else if !ok {
+		return fmt.Errorf("%w: no reminder table", ErrBackupInvalid)
+	} else if version, _, err = getSchemaVersion(ctx, h); err != nil {
+		return err
+	} else if version > schemaVersion {
+		return ErrSchemaTooNew
+	}
+
+	return nil
+} // func CheckBackup(path string) error
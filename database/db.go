@@ -86,6 +86,7 @@ type Database struct {
 	spNameCounter int
 	spNameCache   map[string]string
 	queries       map[query.ID]*sql.Stmt
+	fts           bool
 }
 
 // Open opens a Database. If the database specified by the path does not exist,
@@ -144,6 +145,20 @@ func Open(path string) (*Database, error) {
 		}
 		db.log.Printf("[INFO] Database at %s has been initialized\n",
 			path)
+	} else if err = db.migrate(); err != nil {
+		if e2 := db.db.Close(); e2 != nil {
+			db.log.Printf("[CRITICAL] Failed to close database: %s\n",
+				e2.Error())
+		}
+		return nil, err
+	}
+
+	if err = db.ensureFTS(); err != nil {
+		if e2 := db.db.Close(); e2 != nil {
+			db.log.Printf("[CRITICAL] Failed to close database: %s\n",
+				e2.Error())
+		}
+		return nil, err
 	}
 
 	return db, nil
@@ -180,7 +195,19 @@ func (db *Database) initialize() error {
 		}
 	}
 
-	if err = tx.Commit(); err != nil {
+	if _, err = tx.Exec(
+		"INSERT INTO schema_version (version, timestamp) VALUES (?, ?)",
+		schemaVersion,
+		time.Now().Unix()); err != nil {
+		db.log.Printf("[ERROR] Cannot record schema version: %s\n",
+			err.Error())
+		if rbErr := tx.Rollback(); rbErr != nil {
+			db.log.Printf("[CANTHAPPEN] Cannot rollback transaction: %s\n",
+				rbErr.Error())
+			return rbErr
+		}
+		return err
+	} else if err = tx.Commit(); err != nil {
 		db.log.Printf("[CANTHAPPEN] Failed to commit init transaction: %s\n",
 			err.Error())
 		return err
@@ -609,10 +636,27 @@ EXEC_QUERY:
 		r.Title,
 		r.Description,
 		r.Timestamp.Unix(),
+		r.Recur.TimesString(),
 		r.Recur.Repeat,
 		r.Recur.Weekdays(),
-		0,
-		0,
+		r.Recur.Day,
+		r.Recur.Week,
+		r.Recur.Fallback,
+		r.Recur.Month,
+		r.Recur.Period,
+		r.Recur.Rule,
+		r.TimeZone,
+		r.Recur.Counter,
+		r.Recur.Limit,
+		r.Recur.UntilStamp(),
+		r.Recur.Calendar,
+		r.Recur.Holidays,
+		r.AlertsString(),
+		r.Escalation.Interval,
+		r.Escalation.Threshold,
+		r.Escalation.Channel,
+		r.Priority,
+		r.SnoozeString(),
 		r.UniqueID(),
 		now.Unix(),
 	); err != nil {
@@ -636,8 +680,27 @@ EXEC_QUERY:
 			return err
 		}
 
-		status = true
 		r.ID = programID
+
+		if len(r.Tags) > 0 {
+			if err = db.linkTags(tx, r, r.Tags); err != nil {
+				return err
+			}
+		}
+
+		if len(r.Checklist) > 0 {
+			if err = db.storeChecklist(tx, r, r.Checklist); err != nil {
+				return err
+			}
+		}
+
+		if len(r.Depends) > 0 {
+			if err = db.storeDepends(tx, r, r.Depends); err != nil {
+				return err
+			}
+		}
+
+		status = true
 		r.Changed = now
 		return nil
 	}
@@ -711,6 +774,19 @@ EXEC_QUERY:
 	return nil
 } // func (db *Database) ReminderDelete(r *objects.Reminder) error
 
+// checkRule logs it if a Reminder we loaded has a recurrence rule we cannot
+// parse. Such a Reminder never goes off, but the other ones in the same
+// batch should still work, so this is not treated as a failure.
+func (db *Database) checkRule(r *objects.Reminder) {
+	if _, err := r.RRule(); err != nil {
+		db.log.Printf("[ERROR] Cannot parse recurrence rule %q of Reminder %s (%d): %s\n",
+			r.Recur.Rule,
+			r.Title,
+			r.ID,
+			err.Error())
+	}
+} // func (db *Database) checkRule(r *objects.Reminder)
+
 // ReminderGetPending fetches all Reminder entries from the database
 // that have not been marked as finished.
 func (db *Database) ReminderGetPending(t time.Time) ([]objects.Reminder, error) {
@@ -751,8 +827,9 @@ EXEC_QUERY:
 
 	for rows.Next() {
 		var (
-			stamp, changed, days int64
-			r                    objects.Reminder
+			stamp, changed, days, until int64
+			times, alerts, snooze       string
+			r                           objects.Reminder
 		)
 
 		if err = rows.Scan(
@@ -760,10 +837,27 @@ EXEC_QUERY:
 			&r.Title,
 			&r.Description,
 			&stamp,
+			&times,
 			&r.Recur.Repeat,
 			&days,
+			&r.Recur.Day,
+			&r.Recur.Week,
+			&r.Recur.Fallback,
+			&r.Recur.Month,
+			&r.Recur.Period,
+			&r.Recur.Rule,
+			&r.TimeZone,
 			&r.Recur.Counter,
 			&r.Recur.Limit,
+			&until,
+			&r.Recur.Calendar,
+			&r.Recur.Holidays,
+			&alerts,
+			&r.Escalation.Interval,
+			&r.Escalation.Threshold,
+			&r.Escalation.Channel,
+			&r.Priority,
+			&snooze,
 			&r.UUID,
 			&changed); err != nil {
 			db.log.Printf("[ERROR] Cannot scan row: %s\n", err.Error())
@@ -772,17 +866,54 @@ EXEC_QUERY:
 
 		r.Timestamp = time.Unix(stamp, 0)
 		r.Changed = time.Unix(changed, 0)
+		if until != 0 {
+			r.Recur.Until = time.Unix(until, 0)
+		}
 		for i := 0; i < 7; i++ {
 			r.Recur.Days[i] = (days & (1 << i)) != 0
 		}
 
 		r.Recur.Offset = int(stamp)
 
-		if r.Recur.Repeat != repeat.Once || r.DueNext(&now).Before(t) {
+		if err = r.Recur.SetTimes(times); err != nil {
+			db.log.Printf("[ERROR] Cannot parse times of day for Reminder %d: %s\n",
+				r.ID,
+				err.Error())
+			return nil, err
+		}
+
+		if err = r.SetAlerts(alerts); err != nil {
+			db.log.Printf("[ERROR] Cannot parse pre-alerts for Reminder %d: %s\n",
+				r.ID,
+				err.Error())
+			return nil, err
+		}
+
+		if err = r.SetSnooze(snooze); err != nil {
+			db.log.Printf("[ERROR] Cannot parse snooze choices for Reminder %d: %s\n",
+				r.ID,
+				err.Error())
+			return nil, err
+		}
+
+		db.checkRule(&r)
+
+		// Pre-alerts are due before the Reminder itself.
+		if r.Recur.Repeat != repeat.Once || r.DueNext(&now).Add(-r.MaxLead()).Before(t) {
 			items = append(items, r)
 		}
 	}
 
+	if err = db.attachExceptions(items); err != nil {
+		return nil, err
+	} else if err = db.attachTags(items); err != nil {
+		return nil, err
+	} else if err = db.attachChecklists(items); err != nil {
+		return nil, err
+	} else if err = db.attachDepends(items); err != nil {
+		return nil, err
+	}
+
 	return items, nil
 } // func (db *Database) ReminderGetPending(t time.Time) ([]objects.Reminder, error)
 
@@ -826,8 +957,9 @@ EXEC_QUERY:
 
 	for rows.Next() {
 		var (
-			stamp, changed, days int64
-			r                    objects.Reminder
+			stamp, changed, days, until int64
+			times, alerts, snooze       string
+			r                           objects.Reminder
 		)
 
 		if err = rows.Scan(
@@ -835,10 +967,27 @@ EXEC_QUERY:
 			&r.Title,
 			&r.Description,
 			&stamp,
+			&times,
 			&r.Recur.Repeat,
 			&days,
+			&r.Recur.Day,
+			&r.Recur.Week,
+			&r.Recur.Fallback,
+			&r.Recur.Month,
+			&r.Recur.Period,
+			&r.Recur.Rule,
+			&r.TimeZone,
 			&r.Recur.Counter,
 			&r.Recur.Limit,
+			&until,
+			&r.Recur.Calendar,
+			&r.Recur.Holidays,
+			&alerts,
+			&r.Escalation.Interval,
+			&r.Escalation.Threshold,
+			&r.Escalation.Channel,
+			&r.Priority,
+			&snooze,
 			&r.UUID,
 			&changed); err != nil {
 			db.log.Printf("[ERROR] Cannot scan row: %s\n", err.Error())
@@ -847,17 +996,54 @@ EXEC_QUERY:
 
 		r.Timestamp = time.Unix(stamp, 0)
 		r.Changed = time.Unix(changed, 0)
+		if until != 0 {
+			r.Recur.Until = time.Unix(until, 0)
+		}
 		for i := 0; i < 7; i++ {
 			r.Recur.Days[i] = (days & (1 << i)) != 0
 		}
 
 		r.Recur.Offset = int(stamp)
 
-		if r.Recur.Repeat != repeat.Once || r.DueNext(&now).Before(t) {
+		if err = r.Recur.SetTimes(times); err != nil {
+			db.log.Printf("[ERROR] Cannot parse times of day for Reminder %d: %s\n",
+				r.ID,
+				err.Error())
+			return nil, err
+		}
+
+		if err = r.SetAlerts(alerts); err != nil {
+			db.log.Printf("[ERROR] Cannot parse pre-alerts for Reminder %d: %s\n",
+				r.ID,
+				err.Error())
+			return nil, err
+		}
+
+		if err = r.SetSnooze(snooze); err != nil {
+			db.log.Printf("[ERROR] Cannot parse snooze choices for Reminder %d: %s\n",
+				r.ID,
+				err.Error())
+			return nil, err
+		}
+
+		db.checkRule(&r)
+
+		// Pre-alerts are due before the Reminder itself.
+		if r.Recur.Repeat != repeat.Once || r.DueNext(&now).Add(-r.MaxLead()).Before(t) {
 			items = append(items, r)
 		}
 	}
 
+	if err = db.attachExceptions(items); err != nil {
+		return nil, err
+	} else if err = db.attachTags(items); err != nil {
+		return nil, err
+	} else if err = db.attachChecklists(items); err != nil {
+		return nil, err
+	} else if err = db.attachDepends(items); err != nil {
+		return nil, err
+	}
+
 	return items, nil
 } // func (db *Database) ReminderGetPendingWithNotifications(t time.Time) ([]objects.Reminder, error)
 
@@ -898,8 +1084,9 @@ EXEC_QUERY:
 
 	for rows.Next() {
 		var (
-			stamp, changed, days int64
-			r                    objects.Reminder
+			stamp, changed, days, until int64
+			times, alerts, snooze       string
+			r                           objects.Reminder
 		)
 
 		if err = rows.Scan(
@@ -907,10 +1094,27 @@ EXEC_QUERY:
 			&r.Title,
 			&r.Description,
 			&stamp,
+			&times,
 			&r.Recur.Repeat,
 			&days,
+			&r.Recur.Day,
+			&r.Recur.Week,
+			&r.Recur.Fallback,
+			&r.Recur.Month,
+			&r.Recur.Period,
+			&r.Recur.Rule,
+			&r.TimeZone,
 			&r.Recur.Counter,
 			&r.Recur.Limit,
+			&until,
+			&r.Recur.Calendar,
+			&r.Recur.Holidays,
+			&alerts,
+			&r.Escalation.Interval,
+			&r.Escalation.Threshold,
+			&r.Escalation.Channel,
+			&r.Priority,
+			&snooze,
 			&r.Finished,
 			&r.UUID,
 			&changed); err != nil {
@@ -920,7 +1124,33 @@ EXEC_QUERY:
 
 		r.Timestamp = time.Unix(stamp, 0)
 		r.Changed = time.Unix(changed, 0)
+		if until != 0 {
+			r.Recur.Until = time.Unix(until, 0)
+		}
 		r.Recur.Offset = int(stamp)
+
+		if err = r.Recur.SetTimes(times); err != nil {
+			db.log.Printf("[ERROR] Cannot parse times of day for Reminder %d: %s\n",
+				r.ID,
+				err.Error())
+			return nil, err
+		}
+
+		if err = r.SetAlerts(alerts); err != nil {
+			db.log.Printf("[ERROR] Cannot parse pre-alerts for Reminder %d: %s\n",
+				r.ID,
+				err.Error())
+			return nil, err
+		}
+
+		if err = r.SetSnooze(snooze); err != nil {
+			db.log.Printf("[ERROR] Cannot parse snooze choices for Reminder %d: %s\n",
+				r.ID,
+				err.Error())
+			return nil, err
+		}
+
+		db.checkRule(&r)
 		for i := 0; i < 7; i++ {
 			r.Recur.Days[i] = (days & (1 << i)) != 0
 		}
@@ -928,6 +1158,16 @@ EXEC_QUERY:
 		items = append(items, r)
 	}
 
+	if err = db.attachExceptions(items); err != nil {
+		return nil, err
+	} else if err = db.attachTags(items); err != nil {
+		return nil, err
+	} else if err = db.attachChecklists(items); err != nil {
+		return nil, err
+	} else if err = db.attachDepends(items); err != nil {
+		return nil, err
+	}
+
 	return items, nil
 } // func (db *Database) ReminderGetAll() ([]objects.Reminder, error)
 
@@ -967,8 +1207,9 @@ EXEC_QUERY:
 
 	for rows.Next() {
 		var (
-			stamp, changed, days int64
-			r                    objects.Reminder
+			stamp, changed, days, until int64
+			times, alerts, snooze       string
+			r                           objects.Reminder
 		)
 
 		if err = rows.Scan(
@@ -976,6 +1217,27 @@ EXEC_QUERY:
 			&r.Title,
 			&r.Description,
 			&stamp,
+			&times,
+			&r.Recur.Repeat,
+			&days,
+			&r.Recur.Day,
+			&r.Recur.Week,
+			&r.Recur.Fallback,
+			&r.Recur.Month,
+			&r.Recur.Period,
+			&r.Recur.Rule,
+			&r.TimeZone,
+			&r.Recur.Counter,
+			&r.Recur.Limit,
+			&until,
+			&r.Recur.Calendar,
+			&r.Recur.Holidays,
+			&alerts,
+			&r.Escalation.Interval,
+			&r.Escalation.Threshold,
+			&r.Escalation.Channel,
+			&r.Priority,
+			&snooze,
 			&r.UUID,
 			&changed); err != nil {
 			db.log.Printf("[ERROR] Cannot scan row: %s\n", err.Error())
@@ -984,7 +1246,33 @@ EXEC_QUERY:
 
 		r.Timestamp = time.Unix(stamp, 0)
 		r.Changed = time.Unix(changed, 0)
+		if until != 0 {
+			r.Recur.Until = time.Unix(until, 0)
+		}
 		r.Recur.Offset = int(stamp)
+
+		if err = r.Recur.SetTimes(times); err != nil {
+			db.log.Printf("[ERROR] Cannot parse times of day for Reminder %d: %s\n",
+				r.ID,
+				err.Error())
+			return nil, err
+		}
+
+		if err = r.SetAlerts(alerts); err != nil {
+			db.log.Printf("[ERROR] Cannot parse pre-alerts for Reminder %d: %s\n",
+				r.ID,
+				err.Error())
+			return nil, err
+		}
+
+		if err = r.SetSnooze(snooze); err != nil {
+			db.log.Printf("[ERROR] Cannot parse snooze choices for Reminder %d: %s\n",
+				r.ID,
+				err.Error())
+			return nil, err
+		}
+
+		db.checkRule(&r)
 		for i := 0; i < 7; i++ {
 			r.Recur.Days[i] = (days & (1 << i)) != 0
 		}
@@ -993,6 +1281,16 @@ EXEC_QUERY:
 		items = append(items, r)
 	}
 
+	if err = db.attachExceptions(items); err != nil {
+		return nil, err
+	} else if err = db.attachTags(items); err != nil {
+		return nil, err
+	} else if err = db.attachChecklists(items); err != nil {
+		return nil, err
+	} else if err = db.attachDepends(items); err != nil {
+		return nil, err
+	}
+
 	return items, nil
 } // func (db *Database) ReminderGetFinished() ([]objects.Reminder, error)
 
@@ -1029,18 +1327,36 @@ EXEC_QUERY:
 
 	if rows.Next() {
 		var (
-			stamp, changed, days int64
-			r                    = &objects.Reminder{ID: id}
+			stamp, changed, days, until int64
+			times, alerts, snooze       string
+			r                           = &objects.Reminder{ID: id}
 		)
 
 		if err = rows.Scan(
 			&r.Title,
 			&r.Description,
 			&stamp,
+			&times,
 			&r.Recur.Repeat,
 			&days,
+			&r.Recur.Day,
+			&r.Recur.Week,
+			&r.Recur.Fallback,
+			&r.Recur.Month,
+			&r.Recur.Period,
+			&r.Recur.Rule,
+			&r.TimeZone,
 			&r.Recur.Counter,
 			&r.Recur.Limit,
+			&until,
+			&r.Recur.Calendar,
+			&r.Recur.Holidays,
+			&alerts,
+			&r.Escalation.Interval,
+			&r.Escalation.Threshold,
+			&r.Escalation.Channel,
+			&r.Priority,
+			&snooze,
 			&r.Finished,
 			&r.UUID,
 			&changed); err != nil {
@@ -1050,11 +1366,46 @@ EXEC_QUERY:
 
 		r.Timestamp = time.Unix(stamp, 0)
 		r.Recur.Offset = int(stamp)
+
+		if err = r.Recur.SetTimes(times); err != nil {
+			db.log.Printf("[ERROR] Cannot parse times of day for Reminder %d: %s\n",
+				r.ID,
+				err.Error())
+			return nil, err
+		}
+
+		if err = r.SetAlerts(alerts); err != nil {
+			db.log.Printf("[ERROR] Cannot parse pre-alerts for Reminder %d: %s\n",
+				r.ID,
+				err.Error())
+			return nil, err
+		}
+
+		if err = r.SetSnooze(snooze); err != nil {
+			db.log.Printf("[ERROR] Cannot parse snooze choices for Reminder %d: %s\n",
+				r.ID,
+				err.Error())
+			return nil, err
+		}
+
+		db.checkRule(r)
 		r.Changed = time.Unix(changed, 0)
+		if until != 0 {
+			r.Recur.Until = time.Unix(until, 0)
+		}
 		for i := 0; i < 7; i++ {
 			r.Recur.Days[i] = (days & (1 << i)) != 0
 		}
-		r.Finished = true
+
+		if r.Exceptions, err = db.ExceptionGetByReminder(r); err != nil {
+			return nil, err
+		} else if r.Tags, err = db.TagGetByReminder(r); err != nil {
+			return nil, err
+		} else if r.Checklist, err = db.ChecklistGetByReminder(r); err != nil {
+			return nil, err
+		} else if r.Depends, err = db.DependencyGetByReminder(r); err != nil {
+			return nil, err
+		}
 
 		return r, nil
 	}
@@ -1062,6 +1413,144 @@ EXEC_QUERY:
 	return nil, nil
 } // func (db *Database) ReminderGetByID(id int64) (*objects.Reminder, error)
 
+// ReminderSearch looks for Reminders whose title or description match the
+// given query, best matches first, and returns up to max of them. See
+// ftsQuery for the syntax of queries.
+func (db *Database) ReminderSearch(q string, max int) ([]objects.SearchResult, error) {
+	const qid query.ID = query.ReminderSearch
+	var (
+		err   error
+		stmt  *sql.Stmt
+		rows  *sql.Rows
+		match = ftsQuery(q)
+	)
+
+	if !db.fts {
+		return nil, ErrNoFTS
+	} else if match == "" {
+		return make([]objects.SearchResult, 0), nil
+	} else if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid,
+			err.Error())
+		return nil, err
+	} else if db.tx != nil {
+		stmt = db.tx.Stmt(stmt)
+	}
+
+EXEC_QUERY:
+	if rows, err = stmt.Query(
+		objects.MatchStart,
+		objects.MatchEnd,
+		objects.MatchStart,
+		objects.MatchEnd,
+		match,
+		max); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		}
+
+		db.log.Printf("[ERROR] Failed to search for %q: %s\n",
+			match,
+			err.Error())
+		return nil, err
+	}
+
+	var results = make([]objects.SearchResult, 0)
+
+	for rows.Next() {
+		var res objects.SearchResult
+
+		if err = rows.Scan(
+			&res.Reminder.ID,
+			&res.Rank,
+			&res.Title,
+			&res.Snippet); err != nil {
+			db.log.Printf("[ERROR] Cannot scan row: %s\n",
+				err.Error())
+			rows.Close() // nolint: errcheck,gosec
+			return nil, err
+		}
+
+		results = append(results, res)
+	}
+
+	if err = rows.Close(); err != nil {
+		db.log.Printf("[ERROR] Cannot close result set: %s\n",
+			err.Error())
+		return nil, err
+	}
+
+	for idx := range results {
+		var r *objects.Reminder
+
+		if r, err = db.ReminderGetByID(results[idx].Reminder.ID); err != nil {
+			return nil, err
+		} else if r == nil {
+			return nil, fmt.Errorf("Reminder %d is in the full-text index, but not in the database",
+				results[idx].Reminder.ID)
+		}
+
+		results[idx].Reminder = *r
+	}
+
+	return results, nil
+} // func (db *Database) ReminderSearch(q string, max int) ([]objects.SearchResult, error)
+
+// ReminderGetByUUID loads the Reminder with the given UUID. If there is no
+// such Reminder, it returns nil.
+func (db *Database) ReminderGetByUUID(uuid string) (*objects.Reminder, error) {
+	const qid query.ID = query.ReminderGetIDByUUID
+	var (
+		err  error
+		stmt *sql.Stmt
+	)
+
+	if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid,
+			err.Error())
+		return nil, err
+	} else if db.tx != nil {
+		stmt = db.tx.Stmt(stmt)
+	}
+
+	var (
+		id   int64
+		rows *sql.Rows
+	)
+
+EXEC_QUERY:
+	if rows, err = stmt.Query(uuid); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		}
+
+		db.log.Printf("[ERROR] Failed to look up Reminder %s: %s\n",
+			uuid,
+			err.Error())
+		return nil, err
+	}
+
+	// We close the result set before we load the Reminder, rather than
+	// defer it, so we don't hold on to it while running more queries.
+	if !rows.Next() {
+		rows.Close() // nolint: errcheck,gosec
+		return nil, nil
+	} else if err = rows.Scan(&id); err != nil {
+		rows.Close() // nolint: errcheck,gosec
+		db.log.Printf("[ERROR] Cannot scan Row: %s\n",
+			err.Error())
+		return nil, err
+	}
+
+	rows.Close() // nolint: errcheck,gosec
+
+	return db.ReminderGetByID(id)
+} // func (db *Database) ReminderGetByUUID(uuid string) (*objects.Reminder, error)
+
 // ReminderSetFinished sets the Finished-flag of the given Reminder entry to the
 // given state.
 func (db *Database) ReminderSetFinished(r *objects.Reminder, flag bool) error {
@@ -1348,9 +1837,11 @@ EXEC_QUERY:
 	return nil
 } // func (db *Database) ReminderSetDescription(r *objects.Reminder, desc string) error
 
-// ReminderReactivate updates a Reminder's timestamp to the given value.
-func (db *Database) ReminderReactivate(r *objects.Reminder, t time.Time) error {
-	const qid query.ID = query.ReminderReactivate
+// ReminderSetTimeZone sets the time zone a Reminder is evaluated in.
+// An empty string means the local time zone of whichever machine looks
+// at the Reminder.
+func (db *Database) ReminderSetTimeZone(r *objects.Reminder, zone string) error {
+	const qid query.ID = query.ReminderSetTimeZone
 	var (
 		err    error
 		msg    string
@@ -1400,36 +1891,36 @@ func (db *Database) ReminderReactivate(r *objects.Reminder, t time.Time) error {
 	now = time.Now()
 
 EXEC_QUERY:
-	if _, err = stmt.Exec(t.Unix(), now.Unix(), r.ID); err != nil {
+	if _, err = stmt.Exec(zone, now.Unix(), r.ID); err != nil {
 		if worthARetry(err) {
 			waitForRetry()
 			goto EXEC_QUERY
 		} else {
-			err = fmt.Errorf("Cannot add Reminder %q to database: %s",
+			err = fmt.Errorf("Cannot set time zone of Reminder %q to %q: %s",
 				r.Title,
+				zone,
 				err.Error())
 			db.log.Printf("[ERROR] %s\n", err.Error())
 			return err
 		}
 	}
 
-	r.Timestamp = t
+	r.TimeZone = zone
 	r.Changed = now
-	r.Finished = false
 	status = true
 	return nil
-} // func (db *Database) ReminderReactivate(r *objects.Reminder, t time.Time) error
+} // func (db *Database) ReminderSetTimeZone(r *objects.Reminder, zone string) error
 
-// ReminderSetChanged sets the Reminder's ctime to a specific point in time,
-// used for synchronization.
-func (db *Database) ReminderSetChanged(r *objects.Reminder, t time.Time) error {
-	const qid query.ID = query.ReminderSetChanged
+// ReminderReactivate updates a Reminder's timestamp to the given value.
+func (db *Database) ReminderReactivate(r *objects.Reminder, t time.Time) error {
+	const qid query.ID = query.ReminderReactivate
 	var (
 		err    error
 		msg    string
 		stmt   *sql.Stmt
 		tx     *sql.Tx
 		status bool
+		now    time.Time
 	)
 
 	if stmt, err = db.getQuery(qid); err != nil {
@@ -1469,9 +1960,10 @@ func (db *Database) ReminderSetChanged(r *objects.Reminder, t time.Time) error {
 	}
 
 	stmt = tx.Stmt(stmt)
+	now = time.Now()
 
 EXEC_QUERY:
-	if _, err = stmt.Exec(t.Unix(), r.ID); err != nil {
+	if _, err = stmt.Exec(t.Unix(), now.Unix(), r.ID); err != nil {
 		if worthARetry(err) {
 			waitForRetry()
 			goto EXEC_QUERY
@@ -1484,17 +1976,88 @@ EXEC_QUERY:
 		}
 	}
 
-	r.Changed = t
+	r.Timestamp = t
+	r.Changed = now
+	r.Finished = false
 	status = true
 	return nil
-} // func (db *Database) ReminderSetChanged(r *objects.Reminder, t time.Time) error
+} // func (db *Database) ReminderReactivate(r *objects.Reminder, t time.Time) error
 
-// ReminderSetRepeat sets the Reminder's Repeat mode
-func (db *Database) ReminderSetRepeat(r *objects.Reminder, c repeat.Repeat) error {
-	const qid query.ID = query.ReminderSetRepeat
-	var (
-		err    error
-		msg    string
+// ReminderSetChanged sets the Reminder's ctime to a specific point in time,
+// used for synchronization.
+func (db *Database) ReminderSetChanged(r *objects.Reminder, t time.Time) error {
+	const qid query.ID = query.ReminderSetChanged
+	var (
+		err    error
+		msg    string
+		stmt   *sql.Stmt
+		tx     *sql.Tx
+		status bool
+	)
+
+	if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid.String(),
+			err.Error())
+		return err
+	} else if db.tx != nil {
+		tx = db.tx
+	} else {
+	BEGIN_AD_HOC:
+		if tx, err = db.db.Begin(); err != nil {
+			if worthARetry(err) {
+				waitForRetry()
+				goto BEGIN_AD_HOC
+			} else {
+				msg = fmt.Sprintf("Error starting transaction: %s",
+					err.Error())
+				db.log.Printf("[ERROR] %s\n", msg)
+				return errors.New(msg)
+			}
+
+		} else {
+			defer func() {
+				var err2 error
+				if status {
+					if err2 = tx.Commit(); err2 != nil {
+						db.log.Printf("[ERROR] Failed to commit ad-hoc transaction: %s\n",
+							err2.Error())
+					}
+				} else if err2 = tx.Rollback(); err2 != nil {
+					db.log.Printf("[ERROR] Rollback of ad-hoc transaction failed: %s\n",
+						err2.Error())
+				}
+			}()
+		}
+	}
+
+	stmt = tx.Stmt(stmt)
+
+EXEC_QUERY:
+	if _, err = stmt.Exec(t.Unix(), r.ID); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		} else {
+			err = fmt.Errorf("Cannot add Reminder %q to database: %s",
+				r.Title,
+				err.Error())
+			db.log.Printf("[ERROR] %s\n", err.Error())
+			return err
+		}
+	}
+
+	r.Changed = t
+	status = true
+	return nil
+} // func (db *Database) ReminderSetChanged(r *objects.Reminder, t time.Time) error
+
+// ReminderSetRepeat sets the Reminder's Repeat mode
+func (db *Database) ReminderSetRepeat(r *objects.Reminder, c repeat.Repeat) error {
+	const qid query.ID = query.ReminderSetRepeat
+	var (
+		err    error
+		msg    string
 		stmt   *sql.Stmt
 		tx     *sql.Tx
 		status bool
@@ -1630,9 +2193,12 @@ EXEC_QUERY:
 	return nil
 } // func (db *Database) ReminderSetWeekdays(r *objects.Reminder, days objects.Weekdays) error
 
-// ReminderSetLimit sets the repeat limit to the speficied value.
-func (db *Database) ReminderSetLimit(r *objects.Reminder, limit int) error {
-	const qid query.ID = query.ReminderSetLimit
+// ReminderSetRecurrence updates the due time and all fields of the Reminder's
+// Recurrence, except for the Counter, in one go. Since the database checks that the due time and the
+// fields required by the repeat mode are consistent, changing the repeat mode
+// of a Reminder generally requires updating them together.
+func (db *Database) ReminderSetRecurrence(r *objects.Reminder, t time.Time, rec objects.Recurrence) error {
+	const qid query.ID = query.ReminderSetRecurrence
 	var (
 		err    error
 		msg    string
@@ -1681,12 +2247,28 @@ func (db *Database) ReminderSetLimit(r *objects.Reminder, limit int) error {
 	var now = time.Now()
 
 EXEC_QUERY:
-	if _, err = stmt.Exec(limit, now.Unix(), r.ID); err != nil {
+	if _, err = stmt.Exec(
+		t.Unix(),
+		rec.Repeat,
+		rec.Weekdays(),
+		rec.Day,
+		rec.Week,
+		rec.Fallback,
+		rec.Month,
+		rec.Period,
+		rec.Rule,
+		rec.Limit,
+		rec.UntilStamp(),
+		rec.TimesString(),
+		rec.Calendar,
+		rec.Holidays,
+		now.Unix(),
+		r.ID); err != nil {
 		if worthARetry(err) {
 			waitForRetry()
 			goto EXEC_QUERY
 		} else {
-			err = fmt.Errorf("Cannot add Reminder %q to database: %s",
+			err = fmt.Errorf("Cannot set Recurrence of Reminder %q: %s",
 				r.Title,
 				err.Error())
 			db.log.Printf("[ERROR] %s\n", err.Error())
@@ -1694,15 +2276,32 @@ EXEC_QUERY:
 		}
 	}
 
-	r.Recur.Limit = limit
+	r.Timestamp = t
+	r.Recur.Offset = int(t.Unix())
+	r.Recur.Repeat = rec.Repeat
+	r.Recur.Days = rec.Days
+	r.Recur.Day = rec.Day
+	r.Recur.Week = rec.Week
+	r.Recur.Fallback = rec.Fallback
+	r.Recur.Month = rec.Month
+	r.Recur.Period = rec.Period
+	r.Recur.Rule = rec.Rule
+	r.Recur.Limit = rec.Limit
+	r.Recur.Until = rec.Until
+	r.Recur.Times = rec.Times
+	r.Recur.Calendar = rec.Calendar
+	r.Recur.Holidays = rec.Holidays
+	if rec.Limit > 0 && r.Recur.Counter > rec.Limit {
+		r.Recur.Counter = rec.Limit
+	}
 	r.Changed = now
 	status = true
 	return nil
-} // func (db *Database) ReminderSetLimit(r *objects.Reminder, limit int) error
+} // func (db *Database) ReminderSetRecurrence(r *objects.Reminder, t time.Time, rec objects.Recurrence) error
 
-// ReminderResetCounter resets a Reminder's counter to zero.
-func (db *Database) ReminderResetCounter(r *objects.Reminder) error {
-	const qid query.ID = query.ReminderResetCounter
+// ReminderSetLimit sets the repeat limit to the speficied value.
+func (db *Database) ReminderSetLimit(r *objects.Reminder, limit int) error {
+	const qid query.ID = query.ReminderSetLimit
 	var (
 		err    error
 		msg    string
@@ -1751,7 +2350,7 @@ func (db *Database) ReminderResetCounter(r *objects.Reminder) error {
 	var now = time.Now()
 
 EXEC_QUERY:
-	if _, err = stmt.Exec(now.Unix(), r.ID); err != nil {
+	if _, err = stmt.Exec(limit, now.Unix(), r.ID); err != nil {
 		if worthARetry(err) {
 			waitForRetry()
 			goto EXEC_QUERY
@@ -1764,15 +2363,19 @@ EXEC_QUERY:
 		}
 	}
 
-	r.Recur.Counter = 0
+	r.Recur.Limit = limit
+	if limit > 0 && r.Recur.Counter > limit {
+		r.Recur.Counter = limit
+	}
 	r.Changed = now
 	status = true
 	return nil
-} // func (db *Database) ReminderResetCounter(r *objects.Reminder) error
+} // func (db *Database) ReminderSetLimit(r *objects.Reminder, limit int) error
 
-// ReminderIncCounter increments the Reminder's counter by 1.
-func (db *Database) ReminderIncCounter(r *objects.Reminder) error {
-	const qid query.ID = query.ReminderIncCounter
+// ReminderSetAlerts sets the pre-alerts of a Reminder, given as the number
+// of seconds before the due time.
+func (db *Database) ReminderSetAlerts(r *objects.Reminder, alerts []int) error {
+	const qid query.ID = query.ReminderSetAlerts
 	var (
 		err    error
 		msg    string
@@ -1817,19 +2420,23 @@ func (db *Database) ReminderIncCounter(r *objects.Reminder) error {
 		}
 	}
 
+	var rem = objects.Reminder{Alerts: alerts}
+
+	if err = rem.SetAlerts(rem.AlertsString()); err != nil {
+		db.log.Printf("[ERROR] %s\n", err.Error())
+		return err
+	}
+
 	stmt = tx.Stmt(stmt)
-	var (
-		now  = time.Now()
-		rows *sql.Rows
-	)
+	var now = time.Now()
 
 EXEC_QUERY:
-	if rows, err = stmt.Query(now.Unix(), r.ID); err != nil {
+	if _, err = stmt.Exec(rem.AlertsString(), now.Unix(), r.ID); err != nil {
 		if worthARetry(err) {
 			waitForRetry()
 			goto EXEC_QUERY
 		} else {
-			err = fmt.Errorf("Cannot add Reminder %q to database: %s",
+			err = fmt.Errorf("Cannot set pre-alerts of Reminder %q: %s",
 				r.Title,
 				err.Error())
 			db.log.Printf("[ERROR] %s\n", err.Error())
@@ -1837,25 +2444,15 @@ EXEC_QUERY:
 		}
 	}
 
-	defer rows.Close() // nolint: errcheck
-
-	rows.Next()
-
-	if err = rows.Scan(&r.Recur.Counter); err != nil {
-		db.log.Printf("[ERROR] Cannot scan Counter from Rows: %s\n",
-			err.Error())
-		return err
-	}
-
+	r.Alerts = rem.Alerts
 	r.Changed = now
 	status = true
 	return nil
-} // func (db *Database) ReminderIncCounter(r *objects.Reminder) error
+} // func (db *Database) ReminderSetAlerts(r *objects.Reminder, alerts []int) error
 
-// NotificationAdd creates a new Notification to be displayed for a recurring Reminder
-// at a certain point in time.
-func (db *Database) NotificationAdd(r *objects.Reminder, t time.Time) (*objects.Notification, error) {
-	const qid query.ID = query.NotificationAdd
+// ReminderSetPriority sets the Priority of a Reminder.
+func (db *Database) ReminderSetPriority(r *objects.Reminder, p objects.Priority) error {
+	const qid query.ID = query.ReminderSetPriority
 	var (
 		err    error
 		msg    string
@@ -1864,11 +2461,18 @@ func (db *Database) NotificationAdd(r *objects.Reminder, t time.Time) (*objects.
 		status bool
 	)
 
-	if stmt, err = db.getQuery(qid); err != nil {
+	if !p.Valid() {
+		err = fmt.Errorf("Invalid priority for Reminder %d (%q): %s",
+			r.ID,
+			r.Title,
+			p)
+		db.log.Printf("[ERROR] %s\n", err.Error())
+		return err
+	} else if stmt, err = db.getQuery(qid); err != nil {
 		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
 			qid.String(),
 			err.Error())
-		return nil, err
+		return err
 	} else if db.tx != nil {
 		tx = db.tx
 	} else {
@@ -1881,7 +2485,7 @@ func (db *Database) NotificationAdd(r *objects.Reminder, t time.Time) (*objects.
 				msg = fmt.Sprintf("Error starting transaction: %s",
 					err.Error())
 				db.log.Printf("[ERROR] %s\n", msg)
-				return nil, errors.New(msg)
+				return errors.New(msg)
 			}
 
 		} else {
@@ -1901,39 +2505,33 @@ func (db *Database) NotificationAdd(r *objects.Reminder, t time.Time) (*objects.
 	}
 
 	stmt = tx.Stmt(stmt)
-	var (
-		res sql.Result
-		not = &objects.Notification{
-			ReminderID: r.ID,
-			Timestamp:  t,
-		}
-	)
+	var now = time.Now()
 
 EXEC_QUERY:
-	if res, err = stmt.Exec(r.ID, not.Timestamp.Unix()); err != nil {
+	if _, err = stmt.Exec(p, now.Unix(), r.ID); err != nil {
 		if worthARetry(err) {
 			waitForRetry()
 			goto EXEC_QUERY
 		} else {
-			err = fmt.Errorf("Cannot add Notification for %q to database: %s",
+			err = fmt.Errorf("Cannot set priority of Reminder %q: %s",
 				r.Title,
 				err.Error())
 			db.log.Printf("[ERROR] %s\n", err.Error())
-			return nil, err
+			return err
 		}
-	} else if not.ID, err = res.LastInsertId(); err != nil {
-		db.log.Printf("[ERROR] Cannot get ID of newly added Notification: %s\n",
-			err.Error())
-		return nil, err
 	}
 
+	r.Priority = p
+	r.Changed = now
 	status = true
-	return not, nil
-} // func NotificationAdd(r *Reminder, t time.Time) (*objects.Notification, error)
+	return nil
+} // func (db *Database) ReminderSetPriority(r *objects.Reminder, p objects.Priority) error
 
-// NotificationDisplay stores the time when a Notification has been last displayed.
-func (db *Database) NotificationDisplay(n *objects.Notification, t time.Time) error {
-	const qid query.ID = query.NotificationDisplay
+// ReminderSetSnooze sets the choices the user has for putting off
+// Notifications of the Reminder. An empty list means the global default is
+// used.
+func (db *Database) ReminderSetSnooze(r *objects.Reminder, items []objects.Snooze) error {
+	const qid query.ID = query.ReminderSetSnooze
 	var (
 		err    error
 		msg    string
@@ -1979,31 +2577,32 @@ func (db *Database) NotificationDisplay(n *objects.Notification, t time.Time) er
 	}
 
 	stmt = tx.Stmt(stmt)
+	var now = time.Now()
 
 EXEC_QUERY:
-	if _, err = stmt.Exec(t.Unix(), n.ID); err != nil {
+	if _, err = stmt.Exec(objects.FormatSnoozes(items), now.Unix(), r.ID); err != nil {
 		if worthARetry(err) {
 			waitForRetry()
 			goto EXEC_QUERY
 		} else {
-			err = fmt.Errorf("Cannot add Notification %d for Reminder %d to database: %s",
-				n.ID,
-				n.ReminderID,
+			err = fmt.Errorf("Cannot set snooze choices of Reminder %q: %s",
+				r.Title,
 				err.Error())
 			db.log.Printf("[ERROR] %s\n", err.Error())
 			return err
 		}
 	}
 
-	n.Displayed = t
+	r.Snooze = items
+	r.Changed = now
 	status = true
 	return nil
-} // func (db *Database) NotificationDisplay(n *objects.Notification) error
+} // func (db *Database) ReminderSetSnooze(r *objects.Reminder, items []objects.Snooze) error
 
-// NotificationAcknowledge stores the time when a Notification has been
-// acknowledged by the user and is thus completed.
-func (db *Database) NotificationAcknowledge(n *objects.Notification, t time.Time) error {
-	const qid query.ID = query.NotificationAcknowledge
+// ReminderSetEscalation sets the policy for re-notifying the user about
+// Notifications of the Reminder they have not acknowledged.
+func (db *Database) ReminderSetEscalation(r *objects.Reminder, e objects.Escalation) error {
+	const qid query.ID = query.ReminderSetEscalation
 	var (
 		err    error
 		msg    string
@@ -2012,7 +2611,13 @@ func (db *Database) NotificationAcknowledge(n *objects.Notification, t time.Time
 		status bool
 	)
 
-	if stmt, err = db.getQuery(qid); err != nil {
+	if err = e.Validate(); err != nil {
+		db.log.Printf("[ERROR] Invalid escalation for Reminder %d (%q): %s\n",
+			r.ID,
+			r.Title,
+			err.Error())
+		return err
+	} else if stmt, err = db.getQuery(qid); err != nil {
 		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
 			qid.String(),
 			err.Error())
@@ -2049,104 +2654,3684 @@ func (db *Database) NotificationAcknowledge(n *objects.Notification, t time.Time
 	}
 
 	stmt = tx.Stmt(stmt)
+	var now = time.Now()
 
 EXEC_QUERY:
-	if _, err = stmt.Exec(t.Unix(), n.ID); err != nil {
+	if _, err = stmt.Exec(
+		e.Interval,
+		e.Threshold,
+		e.Channel,
+		now.Unix(),
+		r.ID); err != nil {
 		if worthARetry(err) {
 			waitForRetry()
 			goto EXEC_QUERY
 		} else {
-			err = fmt.Errorf("Cannot acknowledge Notification %d for Reminder %d: %s",
-				n.ID,
-				n.ReminderID,
+			err = fmt.Errorf("Cannot set escalation of Reminder %q: %s",
+				r.Title,
 				err.Error())
 			db.log.Printf("[ERROR] %s\n", err.Error())
 			return err
 		}
 	}
 
-	n.Acknowledged = t
+	r.Escalation = e
+	r.Changed = now
 	status = true
 	return nil
-} // func (db *Database) NotificationAcknowledge(n *objects.Notification) error
+} // func (db *Database) ReminderSetEscalation(r *objects.Reminder, e objects.Escalation) error
 
-// NotificationGetByID fetches a Notification by its database ID.
-func (db *Database) NotificationGetByID(id int64) (*objects.Notification, error) {
-	const qid query.ID = query.NotificationGetByID
+// ReminderResetCounter resets a Reminder's counter to zero.
+func (db *Database) ReminderResetCounter(r *objects.Reminder) error {
+	const qid query.ID = query.ReminderResetCounter
 	var (
-		err  error
-		stmt *sql.Stmt
+		err    error
+		msg    string
+		stmt   *sql.Stmt
+		tx     *sql.Tx
+		status bool
 	)
 
 	if stmt, err = db.getQuery(qid); err != nil {
 		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
-			qid,
+			qid.String(),
 			err.Error())
-		return nil, err
+		return err
 	} else if db.tx != nil {
-		stmt = db.tx.Stmt(stmt)
+		tx = db.tx
+	} else {
+	BEGIN_AD_HOC:
+		if tx, err = db.db.Begin(); err != nil {
+			if worthARetry(err) {
+				waitForRetry()
+				goto BEGIN_AD_HOC
+			} else {
+				msg = fmt.Sprintf("Error starting transaction: %s",
+					err.Error())
+				db.log.Printf("[ERROR] %s\n", msg)
+				return errors.New(msg)
+			}
+
+		} else {
+			defer func() {
+				var err2 error
+				if status {
+					if err2 = tx.Commit(); err2 != nil {
+						db.log.Printf("[ERROR] Failed to commit ad-hoc transaction: %s\n",
+							err2.Error())
+					}
+				} else if err2 = tx.Rollback(); err2 != nil {
+					db.log.Printf("[ERROR] Rollback of ad-hoc transaction failed: %s\n",
+						err2.Error())
+				}
+			}()
+		}
 	}
 
-	var rows *sql.Rows
+	stmt = tx.Stmt(stmt)
+	var now = time.Now()
 
 EXEC_QUERY:
-	if rows, err = stmt.Query(id); err != nil {
+	if _, err = stmt.Exec(now.Unix(), r.ID); err != nil {
 		if worthARetry(err) {
 			waitForRetry()
 			goto EXEC_QUERY
+		} else {
+			err = fmt.Errorf("Cannot add Reminder %q to database: %s",
+				r.Title,
+				err.Error())
+			db.log.Printf("[ERROR] %s\n", err.Error())
+			return err
 		}
-
-		db.log.Printf("[ERROR] Failed to load all Reminders: %s\n",
-			err.Error())
-		return nil, err
 	}
 
-	defer rows.Close() // nolint: errcheck,gosec
-
-	if rows.Next() {
-		var (
-			item           = &objects.Notification{ID: id}
-			tstamp         int64
-			dstamp, astamp *int64
-		)
+	r.Recur.Counter = 0
+	r.Changed = now
+	status = true
+	return nil
+} // func (db *Database) ReminderResetCounter(r *objects.Reminder) error
 
-		if err = rows.Scan(&item.ReminderID, &tstamp, &dstamp, &astamp); err != nil {
-			db.log.Printf("[ERROR] Cannot scan Row: %s\n",
-				err.Error())
-			return nil, err
+// ReminderSetCounter sets a Reminder's counter to the given value.
+// This is used when synchronizing with other instances, otherwise the
+// counter is advanced by ReminderIncCounter.
+func (db *Database) ReminderSetCounter(r *objects.Reminder, cnt int) error {
+	const qid query.ID = query.ReminderSetCounter
+	var (
+		err    error
+		msg    string
+		stmt   *sql.Stmt
+		tx     *sql.Tx
+		status bool
+		now    time.Time
+	)
+
+	if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid.String(),
+			err.Error())
+		return err
+	} else if db.tx != nil {
+		tx = db.tx
+	} else {
+	BEGIN_AD_HOC:
+		if tx, err = db.db.Begin(); err != nil {
+			if worthARetry(err) {
+				waitForRetry()
+				goto BEGIN_AD_HOC
+			} else {
+				msg = fmt.Sprintf("Error starting transaction: %s",
+					err.Error())
+				db.log.Printf("[ERROR] %s\n", msg)
+				return errors.New(msg)
+			}
+
+		} else {
+			defer func() {
+				var err2 error
+				if status {
+					if err2 = tx.Commit(); err2 != nil {
+						db.log.Printf("[ERROR] Failed to commit ad-hoc transaction: %s\n",
+							err2.Error())
+					}
+				} else if err2 = tx.Rollback(); err2 != nil {
+					db.log.Printf("[ERROR] Rollback of ad-hoc transaction failed: %s\n",
+						err2.Error())
+				}
+			}()
+		}
+	}
+
+	stmt = tx.Stmt(stmt)
+	now = time.Now()
+
+EXEC_QUERY:
+	if _, err = stmt.Exec(cnt, now.Unix(), r.ID); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		} else {
+			err = fmt.Errorf("Cannot set counter of Reminder %q to %d: %s",
+				r.Title,
+				cnt,
+				err.Error())
+			db.log.Printf("[ERROR] %s\n", err.Error())
+			return err
+		}
+	}
+
+	r.Recur.Counter = cnt
+	r.Changed = now
+	status = true
+	return nil
+} // func (db *Database) ReminderSetCounter(r *objects.Reminder, cnt int) error
+
+// ReminderIncCounter increments the Reminder's counter by 1.
+func (db *Database) ReminderIncCounter(r *objects.Reminder) error {
+	const qid query.ID = query.ReminderIncCounter
+	var (
+		err    error
+		msg    string
+		stmt   *sql.Stmt
+		tx     *sql.Tx
+		status bool
+	)
+
+	if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid.String(),
+			err.Error())
+		return err
+	} else if db.tx != nil {
+		tx = db.tx
+	} else {
+	BEGIN_AD_HOC:
+		if tx, err = db.db.Begin(); err != nil {
+			if worthARetry(err) {
+				waitForRetry()
+				goto BEGIN_AD_HOC
+			} else {
+				msg = fmt.Sprintf("Error starting transaction: %s",
+					err.Error())
+				db.log.Printf("[ERROR] %s\n", msg)
+				return errors.New(msg)
+			}
+
+		} else {
+			defer func() {
+				var err2 error
+				if status {
+					if err2 = tx.Commit(); err2 != nil {
+						db.log.Printf("[ERROR] Failed to commit ad-hoc transaction: %s\n",
+							err2.Error())
+					}
+				} else if err2 = tx.Rollback(); err2 != nil {
+					db.log.Printf("[ERROR] Rollback of ad-hoc transaction failed: %s\n",
+						err2.Error())
+				}
+			}()
+		}
+	}
+
+	stmt = tx.Stmt(stmt)
+	var (
+		now  = time.Now()
+		rows *sql.Rows
+	)
+
+EXEC_QUERY:
+	if rows, err = stmt.Query(now.Unix(), r.ID); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		} else {
+			err = fmt.Errorf("Cannot add Reminder %q to database: %s",
+				r.Title,
+				err.Error())
+			db.log.Printf("[ERROR] %s\n", err.Error())
+			return err
+		}
+	}
+
+	defer rows.Close() // nolint: errcheck
+
+	rows.Next()
+
+	if err = rows.Scan(&r.Recur.Counter); err != nil {
+		db.log.Printf("[ERROR] Cannot scan Counter from Rows: %s\n",
+			err.Error())
+		return err
+	}
+
+	r.Changed = now
+	status = true
+	return nil
+} // func (db *Database) ReminderIncCounter(r *objects.Reminder) error
+
+// NotificationAdd creates a new Notification to be displayed for a recurring Reminder
+// at a certain point in time.
+func (db *Database) NotificationAdd(r *objects.Reminder, t time.Time) (*objects.Notification, error) {
+	return db.notificationAdd(r, t, 0)
+} // func (db *Database) NotificationAdd(r *objects.Reminder, t time.Time) (*objects.Notification, error)
+
+// NotificationAddPreAlert creates a Notification that warns about the
+// occurrence of a Reminder due at the given time, lead seconds in advance.
+func (db *Database) NotificationAddPreAlert(r *objects.Reminder, due time.Time, lead int) (*objects.Notification, error) {
+	if lead <= 0 {
+		var err = fmt.Errorf("Invalid lead time for pre-alert: %d", lead)
+		db.log.Printf("[ERROR] %s\n", err.Error())
+		return nil, err
+	}
+
+	return db.notificationAdd(r, due.Add(time.Duration(-lead)*time.Second), lead)
+} // func (db *Database) NotificationAddPreAlert(r *objects.Reminder, due time.Time, lead int) (*objects.Notification, error)
+
+func (db *Database) notificationAdd(r *objects.Reminder, t time.Time, lead int) (*objects.Notification, error) {
+	const qid query.ID = query.NotificationAdd
+	var (
+		err    error
+		msg    string
+		stmt   *sql.Stmt
+		tx     *sql.Tx
+		status bool
+	)
+
+	if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid.String(),
+			err.Error())
+		return nil, err
+	} else if db.tx != nil {
+		tx = db.tx
+	} else {
+	BEGIN_AD_HOC:
+		if tx, err = db.db.Begin(); err != nil {
+			if worthARetry(err) {
+				waitForRetry()
+				goto BEGIN_AD_HOC
+			} else {
+				msg = fmt.Sprintf("Error starting transaction: %s",
+					err.Error())
+				db.log.Printf("[ERROR] %s\n", msg)
+				return nil, errors.New(msg)
+			}
+
+		} else {
+			defer func() {
+				var err2 error
+				if status {
+					if err2 = tx.Commit(); err2 != nil {
+						db.log.Printf("[ERROR] Failed to commit ad-hoc transaction: %s\n",
+							err2.Error())
+					}
+				} else if err2 = tx.Rollback(); err2 != nil {
+					db.log.Printf("[ERROR] Rollback of ad-hoc transaction failed: %s\n",
+						err2.Error())
+				}
+			}()
+		}
+	}
+
+	stmt = tx.Stmt(stmt)
+	var (
+		res sql.Result
+		not = &objects.Notification{
+			ReminderID: r.ID,
+			Timestamp:  t,
+			Lead:       lead,
+		}
+	)
+
+EXEC_QUERY:
+	if res, err = stmt.Exec(r.ID, not.Timestamp.Unix(), lead); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		} else {
+			err = fmt.Errorf("Cannot add Notification for %q to database: %s",
+				r.Title,
+				err.Error())
+			db.log.Printf("[ERROR] %s\n", err.Error())
+			return nil, err
+		}
+	} else if not.ID, err = res.LastInsertId(); err != nil {
+		db.log.Printf("[ERROR] Cannot get ID of newly added Notification: %s\n",
+			err.Error())
+		return nil, err
+	}
+
+	status = true
+	return not, nil
+} // func (db *Database) notificationAdd(r *objects.Reminder, t time.Time, lead int) (*objects.Notification, error)
+
+// NotificationDisplay stores the time when a Notification has been last displayed.
+// The first time a Notification is displayed is kept for the history.
+func (db *Database) NotificationDisplay(n *objects.Notification, t time.Time) error {
+	const qid query.ID = query.NotificationDisplay
+	var (
+		err    error
+		msg    string
+		stmt   *sql.Stmt
+		tx     *sql.Tx
+		status bool
+	)
+
+	if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid.String(),
+			err.Error())
+		return err
+	} else if db.tx != nil {
+		tx = db.tx
+	} else {
+	BEGIN_AD_HOC:
+		if tx, err = db.db.Begin(); err != nil {
+			if worthARetry(err) {
+				waitForRetry()
+				goto BEGIN_AD_HOC
+			} else {
+				msg = fmt.Sprintf("Error starting transaction: %s",
+					err.Error())
+				db.log.Printf("[ERROR] %s\n", msg)
+				return errors.New(msg)
+			}
+
+		} else {
+			defer func() {
+				var err2 error
+				if status {
+					if err2 = tx.Commit(); err2 != nil {
+						db.log.Printf("[ERROR] Failed to commit ad-hoc transaction: %s\n",
+							err2.Error())
+					}
+				} else if err2 = tx.Rollback(); err2 != nil {
+					db.log.Printf("[ERROR] Rollback of ad-hoc transaction failed: %s\n",
+						err2.Error())
+				}
+			}()
+		}
+	}
+
+	stmt = tx.Stmt(stmt)
+
+EXEC_QUERY:
+	if _, err = stmt.Exec(t.Unix(), t.Unix(), n.ID); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		} else {
+			err = fmt.Errorf("Cannot add Notification %d for Reminder %d to database: %s",
+				n.ID,
+				n.ReminderID,
+				err.Error())
+			db.log.Printf("[ERROR] %s\n", err.Error())
+			return err
+		}
+	}
+
+	n.Displayed = t
+	if n.Shown.IsZero() {
+		n.Shown = t
+	}
+	status = true
+	return nil
+} // func (db *Database) NotificationDisplay(n *objects.Notification) error
+
+// NotificationSetDeferred records that a Notification has been held back
+// during quiet hours, starting at the given time. The zero Time releases
+// the Notification again.
+func (db *Database) NotificationSetDeferred(n *objects.Notification, t time.Time) error {
+	const qid query.ID = query.NotificationSetDeferred
+	var (
+		err    error
+		msg    string
+		stmt   *sql.Stmt
+		tx     *sql.Tx
+		status bool
+	)
+
+	if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid.String(),
+			err.Error())
+		return err
+	} else if db.tx != nil {
+		tx = db.tx
+	} else {
+	BEGIN_AD_HOC:
+		if tx, err = db.db.Begin(); err != nil {
+			if worthARetry(err) {
+				waitForRetry()
+				goto BEGIN_AD_HOC
+			} else {
+				msg = fmt.Sprintf("Error starting transaction: %s",
+					err.Error())
+				db.log.Printf("[ERROR] %s\n", msg)
+				return errors.New(msg)
+			}
+
+		} else {
+			defer func() {
+				var err2 error
+				if status {
+					if err2 = tx.Commit(); err2 != nil {
+						db.log.Printf("[ERROR] Failed to commit ad-hoc transaction: %s\n",
+							err2.Error())
+					}
+				} else if err2 = tx.Rollback(); err2 != nil {
+					db.log.Printf("[ERROR] Rollback of ad-hoc transaction failed: %s\n",
+						err2.Error())
+				}
+			}()
+		}
+	}
+
+	stmt = tx.Stmt(stmt)
+	var stamp *int64
+
+	if !t.IsZero() {
+		var u = t.Unix()
+		stamp = &u
+	}
+
+EXEC_QUERY:
+	if _, err = stmt.Exec(stamp, n.ID); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		} else {
+			err = fmt.Errorf("Cannot set deferred-stamp on Notification %d for Reminder %d: %s",
+				n.ID,
+				n.ReminderID,
+				err.Error())
+			db.log.Printf("[ERROR] %s\n", err.Error())
+			return err
+		}
+	}
+
+	n.Deferred = t
+	status = true
+	return nil
+} // func (db *Database) NotificationSetDeferred(n *objects.Notification, t time.Time) error
+
+// NotificationAcknowledge stores the time when a Notification has been
+// acknowledged by the user and is thus completed.
+func (db *Database) NotificationAcknowledge(n *objects.Notification, t time.Time) error {
+	const qid query.ID = query.NotificationAcknowledge
+	var (
+		err    error
+		msg    string
+		stmt   *sql.Stmt
+		tx     *sql.Tx
+		status bool
+	)
+
+	if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid.String(),
+			err.Error())
+		return err
+	} else if db.tx != nil {
+		tx = db.tx
+	} else {
+	BEGIN_AD_HOC:
+		if tx, err = db.db.Begin(); err != nil {
+			if worthARetry(err) {
+				waitForRetry()
+				goto BEGIN_AD_HOC
+			} else {
+				msg = fmt.Sprintf("Error starting transaction: %s",
+					err.Error())
+				db.log.Printf("[ERROR] %s\n", msg)
+				return errors.New(msg)
+			}
+
+		} else {
+			defer func() {
+				var err2 error
+				if status {
+					if err2 = tx.Commit(); err2 != nil {
+						db.log.Printf("[ERROR] Failed to commit ad-hoc transaction: %s\n",
+							err2.Error())
+					}
+				} else if err2 = tx.Rollback(); err2 != nil {
+					db.log.Printf("[ERROR] Rollback of ad-hoc transaction failed: %s\n",
+						err2.Error())
+				}
+			}()
+		}
+	}
+
+	stmt = tx.Stmt(stmt)
+
+EXEC_QUERY:
+	if _, err = stmt.Exec(t.Unix(), n.ID); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		} else {
+			err = fmt.Errorf("Cannot acknowledge Notification %d for Reminder %d: %s",
+				n.ID,
+				n.ReminderID,
+				err.Error())
+			db.log.Printf("[ERROR] %s\n", err.Error())
+			return err
+		}
+	}
+
+	n.Acknowledged = t
+	status = true
+	return nil
+} // func (db *Database) NotificationAcknowledge(n *objects.Notification) error
+
+// NotificationSkipStale acknowledges all pending Notifications for the given
+// Reminder that are due before the given time, without them ever being shown.
+// It returns the number of Notifications that were skipped.
+func (db *Database) NotificationSkipStale(r *objects.Reminder, before time.Time) (int64, error) {
+	const qid query.ID = query.NotificationSkipStale
+	var (
+		err    error
+		msg    string
+		stmt   *sql.Stmt
+		tx     *sql.Tx
+		res    sql.Result
+		cnt    int64
+		status bool
+	)
+
+	if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid.String(),
+			err.Error())
+		return 0, err
+	} else if db.tx != nil {
+		tx = db.tx
+	} else {
+	BEGIN_AD_HOC:
+		if tx, err = db.db.Begin(); err != nil {
+			if worthARetry(err) {
+				waitForRetry()
+				goto BEGIN_AD_HOC
+			} else {
+				msg = fmt.Sprintf("Error starting transaction: %s",
+					err.Error())
+				db.log.Printf("[ERROR] %s\n", msg)
+				return 0, errors.New(msg)
+			}
+
+		} else {
+			defer func() {
+				var err2 error
+				if status {
+					if err2 = tx.Commit(); err2 != nil {
+						db.log.Printf("[ERROR] Failed to commit ad-hoc transaction: %s\n",
+							err2.Error())
+					}
+				} else if err2 = tx.Rollback(); err2 != nil {
+					db.log.Printf("[ERROR] Rollback of ad-hoc transaction failed: %s\n",
+						err2.Error())
+				}
+			}()
+		}
+	}
+
+	stmt = tx.Stmt(stmt)
+	var now = time.Now()
+
+EXEC_QUERY:
+	if res, err = stmt.Exec(now.Unix(), now.Unix(), r.ID, before.Unix()); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		} else {
+			err = fmt.Errorf("Cannot skip stale Notifications for Reminder %d: %s",
+				r.ID,
+				err.Error())
+			db.log.Printf("[ERROR] %s\n", err.Error())
+			return 0, err
+		}
+	} else if cnt, err = res.RowsAffected(); err != nil {
+		db.log.Printf("[ERROR] Cannot get number of affected rows: %s\n",
+			err.Error())
+		return 0, err
+	}
+
+	status = true
+	return cnt, nil
+} // func (db *Database) NotificationSkipStale(r *objects.Reminder, before time.Time) (int64, error)
+
+// NotificationGetByID fetches a Notification by its database ID.
+func (db *Database) NotificationGetByID(id int64) (*objects.Notification, error) {
+	const qid query.ID = query.NotificationGetByID
+	var (
+		err  error
+		stmt *sql.Stmt
+	)
+
+	if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid,
+			err.Error())
+		return nil, err
+	} else if db.tx != nil {
+		stmt = db.tx.Stmt(stmt)
+	}
+
+	var rows *sql.Rows
+
+EXEC_QUERY:
+	if rows, err = stmt.Query(id); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		}
+
+		db.log.Printf("[ERROR] Failed to load all Reminders: %s\n",
+			err.Error())
+		return nil, err
+	}
+
+	defer rows.Close() // nolint: errcheck,gosec
+
+	if rows.Next() {
+		var (
+			item                   = &objects.Notification{ID: id}
+			tstamp                 int64
+			dstamp, astamp, fstamp *int64
+		)
+
+		if err = rows.Scan(&item.ReminderID, &tstamp, &dstamp, &astamp, &item.Lead, &item.Step, &fstamp); err != nil {
+			db.log.Printf("[ERROR] Cannot scan Row: %s\n",
+				err.Error())
+			return nil, err
+		}
+
+		item.Timestamp = time.Unix(tstamp, 0)
+		if dstamp != nil {
+			item.Displayed = time.Unix(*dstamp, 0)
+		}
+		if fstamp != nil {
+			item.Deferred = time.Unix(*fstamp, 0)
+		}
+		if astamp != nil {
+			item.Acknowledged = time.Unix(*astamp, 0)
+		}
+
+		return item, nil
+	}
+
+	return nil, nil
+} // func (db *Database) NotificationGetByID(id int64) (n *objects.Notification, error)
+
+// NotificationGetByReminder fetches all Notifications stored for the given
+// Reminder, in reverse chronological order, up to the specified limit.
+// If the limit is a negative number, all Notifications will be fetched.
+func (db *Database) NotificationGetByReminder(r *objects.Reminder, max int) ([]objects.Notification, error) {
+	const qid query.ID = query.NotificationGetByReminder
+	var (
+		err  error
+		stmt *sql.Stmt
+		size int
+	)
+
+	if max > 0 {
+		size = max
+	} else {
+		size = 32
+	}
+
+	if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid,
+			err.Error())
+		return nil, err
+	} else if db.tx != nil {
+		stmt = db.tx.Stmt(stmt)
+	}
+
+	var rows *sql.Rows
+
+EXEC_QUERY:
+	if rows, err = stmt.Query(r.ID, max); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		}
+
+		db.log.Printf("[ERROR] Failed to load all Reminders: %s\n",
+			err.Error())
+		return nil, err
+	}
+
+	defer rows.Close() // nolint: errcheck,gosec
+
+	var items = make([]objects.Notification, 0, size)
+
+	for rows.Next() {
+		var (
+			n                      = objects.Notification{ReminderID: r.ID}
+			tstamp                 int64
+			dstamp, astamp, fstamp *int64
+		)
+
+		if err = rows.Scan(&n.ID, &tstamp, &dstamp, &astamp, &n.Lead, &n.Step, &fstamp); err != nil {
+			db.log.Printf("[ERROR] Cannot scan Row: %s\n",
+				err.Error())
+			return nil, err
+		}
+
+		n.Timestamp = time.Unix(tstamp, 0)
+		if dstamp != nil {
+			n.Displayed = time.Unix(*dstamp, 0)
+		}
+		if fstamp != nil {
+			n.Deferred = time.Unix(*fstamp, 0)
+		}
+		if astamp != nil {
+			n.Acknowledged = time.Unix(*astamp, 0)
+		}
+
+		items = append(items, n)
+	}
+
+	return items, nil
+} // func (db *Database) NotificationGetByReminder(r *objects.Reminder) ([]objects.Notification, error)
+
+// NotificationGetByReminderStamp fetches a Notification by the given Reminder
+// and Timestamp.
+func (db *Database) NotificationGetByReminderStamp(r *objects.Reminder, t time.Time) (*objects.Notification, error) {
+	return db.notificationGetByStamp(r, t, 0)
+} // func (db *Database) NotificationGetByReminderStamp(r *objects.Reminder, t time.Time) (*objects.Notification, error)
+
+// NotificationGetPreAlert fetches the pre-alert that warns about the
+// occurrence of the given Reminder at due, lead seconds in advance.
+func (db *Database) NotificationGetPreAlert(r *objects.Reminder, due time.Time, lead int) (*objects.Notification, error) {
+	return db.notificationGetByStamp(r, due.Add(time.Duration(-lead)*time.Second), lead)
+} // func (db *Database) NotificationGetPreAlert(r *objects.Reminder, due time.Time, lead int) (*objects.Notification, error)
+
+func (db *Database) notificationGetByStamp(r *objects.Reminder, t time.Time, lead int) (*objects.Notification, error) {
+	const qid query.ID = query.NotificationGetByReminderStamp
+	var (
+		err  error
+		stmt *sql.Stmt
+	)
+
+	if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid,
+			err.Error())
+		return nil, err
+	} else if db.tx != nil {
+		stmt = db.tx.Stmt(stmt)
+	}
+
+	var rows *sql.Rows
+
+EXEC_QUERY:
+	if rows, err = stmt.Query(r.ID, t.Unix(), lead); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		}
+
+		db.log.Printf("[ERROR] Failed to load all Reminders: %s\n",
+			err.Error())
+		return nil, err
+	}
+
+	defer rows.Close() // nolint: errcheck,gosec
+
+	if rows.Next() {
+		var (
+			item = &objects.Notification{
+				ReminderID: r.ID,
+				Timestamp:  t,
+				Lead:       lead,
+			}
+			dstamp, astamp *int64
+		)
+
+		if err = rows.Scan(&item.ID, &dstamp, &astamp); err != nil {
+			db.log.Printf("[ERROR] Cannot scan Row: %s\n",
+				err.Error())
+			return nil, err
+		}
+
+		if dstamp != nil {
+			item.Displayed = time.Unix(*dstamp, 0)
+		}
+		if astamp != nil {
+			item.Acknowledged = time.Unix(*astamp, 0)
+		}
+
+		return item, nil
+	}
+
+	return nil, nil
+} // func (db *Database) notificationGetByStamp(r *objects.Reminder, t time.Time, lead int) (*objects.Notification, error)
+
+// NotificationGetByReminderPending fetches all Notifications for the
+// given Reminder that have not been acknowledged.
+func (db *Database) NotificationGetByReminderPending(r *objects.Reminder) ([]objects.Notification, error) {
+	const qid query.ID = query.NotificationGetByReminderPending
+	var (
+		err  error
+		stmt *sql.Stmt
+	)
+
+	if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid,
+			err.Error())
+		return nil, err
+	} else if db.tx != nil {
+		stmt = db.tx.Stmt(stmt)
+	}
+
+	var rows *sql.Rows
+
+EXEC_QUERY:
+	if rows, err = stmt.Query(r.ID); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		}
+
+		db.log.Printf("[ERROR] Failed to load all Reminders: %s\n",
+			err.Error())
+		return nil, err
+	}
+
+	defer rows.Close() // nolint: errcheck,gosec
+
+	var items = make([]objects.Notification, 0, 32)
+
+	for rows.Next() {
+		var (
+			n              = objects.Notification{ReminderID: r.ID}
+			tstamp         int64
+			dstamp, fstamp *int64
+		)
+
+		if err = rows.Scan(&n.ID, &tstamp, &dstamp, &n.Lead, &n.Step, &fstamp); err != nil {
+			db.log.Printf("[ERROR] Cannot scan Row: %s\n",
+				err.Error())
+			return nil, err
+		}
+
+		n.Timestamp = time.Unix(tstamp, 0)
+		if dstamp != nil {
+			n.Displayed = time.Unix(*dstamp, 0)
+		}
+		if fstamp != nil {
+			n.Deferred = time.Unix(*fstamp, 0)
+		}
+
+		items = append(items, n)
+	}
+
+	return items, nil
+} // func (db *Database) NotificationGetByReminderPending(r *objects.Reminder) ([]objects.Notification, error)
+
+// NotificationGetPending fetches all Notifications that have not been
+// acknowledged, yet.
+func (db *Database) NotificationGetPending() ([]objects.Notification, error) {
+	const qid query.ID = query.NotificationGetPending
+	var (
+		err  error
+		stmt *sql.Stmt
+	)
+
+	if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid,
+			err.Error())
+		return nil, err
+	} else if db.tx != nil {
+		stmt = db.tx.Stmt(stmt)
+	}
+
+	var rows *sql.Rows
+
+EXEC_QUERY:
+	if rows, err = stmt.Query(); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		}
+
+		db.log.Printf("[ERROR] Failed to load all Reminders: %s\n",
+			err.Error())
+		return nil, err
+	}
+
+	defer rows.Close() // nolint: errcheck,gosec
+
+	var items = make([]objects.Notification, 0, 32)
+
+	for rows.Next() {
+		var (
+			n              objects.Notification
+			tstamp         int64
+			dstamp, fstamp *int64
+		)
+
+		if err = rows.Scan(&n.ID, &n.ReminderID, &tstamp, &dstamp, &n.Lead, &n.Step, &fstamp); err != nil {
+			db.log.Printf("[ERROR] Cannot scan Row: %s\n",
+				err.Error())
+			return nil, err
+		}
+
+		n.Timestamp = time.Unix(tstamp, 0)
+		if dstamp != nil {
+			n.Displayed = time.Unix(*dstamp, 0)
+		}
+		if fstamp != nil {
+			n.Deferred = time.Unix(*fstamp, 0)
+		}
+
+		items = append(items, n)
+	}
+
+	return items, nil
+} // func (db *Database) NotificationGetPending() ([]objects.Notification, error)
+
+// NotificationGetDeferred fetches all Notifications that have been held back
+// during quiet hours and not been acknowledged, yet.
+func (db *Database) NotificationGetDeferred() ([]objects.Notification, error) {
+	const qid query.ID = query.NotificationGetDeferred
+	var (
+		err  error
+		stmt *sql.Stmt
+	)
+
+	if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid,
+			err.Error())
+		return nil, err
+	} else if db.tx != nil {
+		stmt = db.tx.Stmt(stmt)
+	}
+
+	var rows *sql.Rows
+
+EXEC_QUERY:
+	if rows, err = stmt.Query(); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		}
+
+		db.log.Printf("[ERROR] Failed to load deferred Notifications: %s\n",
+			err.Error())
+		return nil, err
+	}
+
+	defer rows.Close() // nolint: errcheck,gosec
+
+	var items = make([]objects.Notification, 0, 32)
+
+	for rows.Next() {
+		var (
+			n              objects.Notification
+			tstamp         int64
+			dstamp, fstamp *int64
+		)
+
+		if err = rows.Scan(&n.ID, &n.ReminderID, &tstamp, &dstamp, &n.Lead, &n.Step, &fstamp); err != nil {
+			db.log.Printf("[ERROR] Cannot scan Row: %s\n",
+				err.Error())
+			return nil, err
+		}
+
+		n.Timestamp = time.Unix(tstamp, 0)
+		if dstamp != nil {
+			n.Displayed = time.Unix(*dstamp, 0)
+		}
+		if fstamp != nil {
+			n.Deferred = time.Unix(*fstamp, 0)
+		}
+
+		items = append(items, n)
+	}
+
+	return items, nil
+} // func (db *Database) NotificationGetDeferred() ([]objects.Notification, error)
+
+// NotificationCleanup removes stale notifications from the database
+// that are more than a week old and have either been acknowledged
+// at least a week ago or never displayed in the first place.
+func (db *Database) NotificationCleanup() (int64, error) {
+	const qid query.ID = query.NotificationCleanup
+	var (
+		err    error
+		msg    string
+		stmt   *sql.Stmt
+		tx     *sql.Tx
+		status bool
+	)
+
+	if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid.String(),
+			err.Error())
+		return 0, err
+	} else if db.tx != nil {
+		tx = db.tx
+	} else {
+	BEGIN_AD_HOC:
+		if tx, err = db.db.Begin(); err != nil {
+			if worthARetry(err) {
+				waitForRetry()
+				goto BEGIN_AD_HOC
+			} else {
+				msg = fmt.Sprintf("Error starting transaction: %s",
+					err.Error())
+				db.log.Printf("[ERROR] %s\n", msg)
+				return 0, errors.New(msg)
+			}
+
+		} else {
+			defer func() {
+				var err2 error
+				if status {
+					if err2 = tx.Commit(); err2 != nil {
+						db.log.Printf("[ERROR] Failed to commit ad-hoc transaction: %s\n",
+							err2.Error())
+					}
+				} else if err2 = tx.Rollback(); err2 != nil {
+					db.log.Printf("[ERROR] Rollback of ad-hoc transaction failed: %s\n",
+						err2.Error())
+				}
+			}()
+		}
+	}
+
+	stmt = tx.Stmt(stmt)
+	var rows *sql.Rows
+
+EXEC_QUERY:
+	if rows, err = stmt.Query(); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		} else {
+			err = fmt.Errorf("Cannot clean up Notifications: %s",
+				err.Error())
+			db.log.Printf("[ERROR] %s\n", err.Error())
+			return 0, err
+		}
+	}
+
+	defer rows.Close() // nolint: errcheck
+	var cnt int64
+
+	for rows.Next() {
+		var n int64
+
+		if err = rows.Scan(&n); err != nil {
+			db.log.Printf("[ERROR] Cannot scan Counter from Rows: %s\n",
+				err.Error())
+			return 0, err
+		} else if n > 0 {
+			cnt += n
+		}
+	}
+
+	status = true
+	return cnt, nil
+} // func (db *Database) NotificationCleanup() (int64, error)
+
+// NotificationDelete removes a Notification from the database.
+func (db *Database) NotificationDelete(n *objects.Notification) error {
+	const qid query.ID = query.NotificationDelete
+	var (
+		err    error
+		msg    string
+		stmt   *sql.Stmt
+		tx     *sql.Tx
+		status bool
+	)
+
+	if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid.String(),
+			err.Error())
+		return err
+	} else if db.tx != nil {
+		tx = db.tx
+	} else {
+	BEGIN_AD_HOC:
+		if tx, err = db.db.Begin(); err != nil {
+			if worthARetry(err) {
+				waitForRetry()
+				goto BEGIN_AD_HOC
+			} else {
+				msg = fmt.Sprintf("Error starting transaction: %s",
+					err.Error())
+				db.log.Printf("[ERROR] %s\n", msg)
+				return errors.New(msg)
+			}
+
+		} else {
+			defer func() {
+				var err2 error
+				if status {
+					if err2 = tx.Commit(); err2 != nil {
+						db.log.Printf("[ERROR] Failed to commit ad-hoc transaction: %s\n",
+							err2.Error())
+					}
+				} else if err2 = tx.Rollback(); err2 != nil {
+					db.log.Printf("[ERROR] Rollback of ad-hoc transaction failed: %s\n",
+						err2.Error())
+				}
+			}()
+		}
+	}
+
+	stmt = tx.Stmt(stmt)
+
+EXEC_QUERY:
+	if _, err = stmt.Exec(n.ID); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		} else {
+			err = fmt.Errorf("Cannot delete Notification %d: %s",
+				n.ID,
+				err.Error())
+			db.log.Printf("[ERROR] %s\n", err.Error())
+			return err
+		}
+	}
+
+	status = true
+	return nil
+} // func (db *Database) NotificationDelete(n *objects.Notification) error
+
+// NotificationGetByRange fetches all Notifications with a Timestamp in the
+// interval [from, to).
+func (db *Database) NotificationGetByRange(from, to time.Time) ([]objects.Notification, error) {
+	const qid query.ID = query.NotificationGetByRange
+	var (
+		err  error
+		stmt *sql.Stmt
+	)
+
+	if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid,
+			err.Error())
+		return nil, err
+	} else if db.tx != nil {
+		stmt = db.tx.Stmt(stmt)
+	}
+
+	var rows *sql.Rows
+
+EXEC_QUERY:
+	if rows, err = stmt.Query(from.Unix(), to.Unix()); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		}
+
+		db.log.Printf("[ERROR] Failed to load Notifications between %s and %s: %s\n",
+			from.Format(common.TimestampFormat),
+			to.Format(common.TimestampFormat),
+			err.Error())
+		return nil, err
+	}
+
+	defer rows.Close() // nolint: errcheck,gosec
+
+	var items = make([]objects.Notification, 0, 32)
+
+	for rows.Next() {
+		var (
+			n                      objects.Notification
+			tstamp                 int64
+			dstamp, astamp, fstamp *int64
+		)
+
+		if err = rows.Scan(&n.ID, &n.ReminderID, &tstamp, &dstamp, &astamp, &n.Lead, &n.Step, &fstamp); err != nil {
+			db.log.Printf("[ERROR] Cannot scan Row: %s\n",
+				err.Error())
+			return nil, err
+		}
+
+		n.Timestamp = time.Unix(tstamp, 0)
+		if dstamp != nil {
+			n.Displayed = time.Unix(*dstamp, 0)
+		}
+		if fstamp != nil {
+			n.Deferred = time.Unix(*fstamp, 0)
+		}
+		if astamp != nil {
+			n.Acknowledged = time.Unix(*astamp, 0)
+		}
+
+		items = append(items, n)
+	}
+
+	return items, nil
+} // func (db *Database) NotificationGetByRange(from, to time.Time) ([]objects.Notification, error)
+
+// NotificationEscalate records that a Notification has been posted again
+// because the user did not acknowledge it.
+func (db *Database) NotificationEscalate(n *objects.Notification, step *objects.EscalationStep) error {
+	var (
+		err         error
+		msg         string
+		stepQ, escQ *sql.Stmt
+		tx          *sql.Tx
+		res         sql.Result
+		status      bool
+	)
+
+	if stepQ, err = db.getQuery(query.NotificationSetStep); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			query.NotificationSetStep,
+			err.Error())
+		return err
+	} else if escQ, err = db.getQuery(query.EscalationAdd); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			query.EscalationAdd,
+			err.Error())
+		return err
+	} else if db.tx != nil {
+		tx = db.tx
+	} else {
+	BEGIN_AD_HOC:
+		if tx, err = db.db.Begin(); err != nil {
+			if worthARetry(err) {
+				waitForRetry()
+				goto BEGIN_AD_HOC
+			} else {
+				msg = fmt.Sprintf("Error starting transaction: %s",
+					err.Error())
+				db.log.Printf("[ERROR] %s\n", msg)
+				return errors.New(msg)
+			}
+		}
+
+		defer func() {
+			var err2 error
+			if status {
+				if err2 = tx.Commit(); err2 != nil {
+					db.log.Printf("[ERROR] Failed to commit ad-hoc transaction: %s\n",
+						err2.Error())
+				}
+			} else if err2 = tx.Rollback(); err2 != nil {
+				db.log.Printf("[ERROR] Rollback of ad-hoc transaction failed: %s\n",
+					err2.Error())
+			}
+		}()
+	}
+
+	stepQ = tx.Stmt(stepQ)
+	escQ = tx.Stmt(escQ)
+
+EXEC_STEP:
+	if _, err = stepQ.Exec(step.Step, n.ID); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_STEP
+		}
+
+		db.log.Printf("[ERROR] Cannot set escalation step of Notification %d to %d: %s\n",
+			n.ID,
+			step.Step,
+			err.Error())
+		return err
+	}
+
+EXEC_ESC:
+	if res, err = escQ.Exec(
+		n.ID,
+		step.Step,
+		step.Timestamp.Unix(),
+		step.Urgency,
+		step.Channel,
+	); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_ESC
+		}
+
+		db.log.Printf("[ERROR] Cannot record escalation step %d of Notification %d: %s\n",
+			step.Step,
+			n.ID,
+			err.Error())
+		return err
+	} else if step.ID, err = res.LastInsertId(); err != nil {
+		db.log.Printf("[ERROR] Cannot get ID of escalation step: %s\n",
+			err.Error())
+		return err
+	}
+
+	step.NotificationID = n.ID
+	n.Step = step.Step
+	status = true
+	return nil
+} // func (db *Database) NotificationEscalate(n *objects.Notification, step *objects.EscalationStep) error
+
+// EscalationGetByNotification returns the escalation steps of a Notification,
+// in order.
+func (db *Database) EscalationGetByNotification(n *objects.Notification) ([]objects.EscalationStep, error) {
+	const qid query.ID = query.EscalationGetByNotification
+	var (
+		err  error
+		stmt *sql.Stmt
+	)
+
+	if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid,
+			err.Error())
+		return nil, err
+	} else if db.tx != nil {
+		stmt = db.tx.Stmt(stmt)
+	}
+
+	var rows *sql.Rows
+
+EXEC_QUERY:
+	if rows, err = stmt.Query(n.ID); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		}
+
+		db.log.Printf("[ERROR] Failed to load escalation steps for Notification %d: %s\n",
+			n.ID,
+			err.Error())
+		return nil, err
+	}
+
+	defer rows.Close() // nolint: errcheck,gosec
+
+	var items []objects.EscalationStep
+
+	for rows.Next() {
+		var (
+			step   = objects.EscalationStep{NotificationID: n.ID}
+			tstamp int64
+		)
+
+		if err = rows.Scan(&step.ID, &step.Step, &tstamp, &step.Urgency, &step.Channel); err != nil {
+			db.log.Printf("[ERROR] Cannot scan Row: %s\n",
+				err.Error())
+			return nil, err
+		}
+
+		step.Timestamp = time.Unix(tstamp, 0)
+		items = append(items, step)
+	}
+
+	return items, nil
+} // func (db *Database) EscalationGetByNotification(n *objects.Notification) ([]objects.EscalationStep, error)
+
+// NotificationGetHistory fetches the Notifications for the occurrences of the
+// given Reminder that were due since the given time, in chronological
+// order. Pre-alerts are left out.
+func (db *Database) NotificationGetHistory(r *objects.Reminder, since time.Time) ([]objects.Notification, error) {
+	const qid query.ID = query.NotificationGetHistory
+	var (
+		err  error
+		stmt *sql.Stmt
+	)
+
+	if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid,
+			err.Error())
+		return nil, err
+	} else if db.tx != nil {
+		stmt = db.tx.Stmt(stmt)
+	}
+
+	var rows *sql.Rows
+
+EXEC_QUERY:
+	if rows, err = stmt.Query(r.ID, since.Unix()); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		}
+
+		db.log.Printf("[ERROR] Failed to load history of Reminder %d: %s\n",
+			r.ID,
+			err.Error())
+		return nil, err
+	}
+
+	defer rows.Close() // nolint: errcheck,gosec
+
+	var items = make([]objects.Notification, 0, 32)
+
+	for rows.Next() {
+		var (
+			n                              = objects.Notification{ReminderID: r.ID}
+			tstamp                         int64
+			dstamp, astamp, fstamp, sstamp *int64
+		)
+
+		if err = rows.Scan(&n.ID, &tstamp, &dstamp, &astamp, &n.Step, &fstamp, &sstamp, &n.Skipped); err != nil {
+			db.log.Printf("[ERROR] Cannot scan Row: %s\n",
+				err.Error())
+			return nil, err
+		}
+
+		n.Timestamp = time.Unix(tstamp, 0)
+		if dstamp != nil {
+			n.Displayed = time.Unix(*dstamp, 0)
+		}
+		if astamp != nil {
+			n.Acknowledged = time.Unix(*astamp, 0)
+		}
+		if fstamp != nil {
+			n.Deferred = time.Unix(*fstamp, 0)
+		}
+		if sstamp != nil {
+			n.Shown = time.Unix(*sstamp, 0)
+		}
+
+		items = append(items, n)
+	}
+
+	return items, nil
+} // func (db *Database) NotificationGetHistory(r *objects.Reminder, since time.Time) ([]objects.Notification, error)
+
+// SnoozeAdd records that the user snoozed the given Notification until the
+// given time.
+func (db *Database) SnoozeAdd(n *objects.Notification, t, until time.Time) (*objects.SnoozeEvent, error) {
+	const qid query.ID = query.SnoozeAdd
+	var (
+		err    error
+		msg    string
+		stmt   *sql.Stmt
+		tx     *sql.Tx
+		res    sql.Result
+		status bool
+		ev     = &objects.SnoozeEvent{
+			ReminderID: n.ReminderID,
+			Occurrence: n.Timestamp,
+			Timestamp:  t,
+			Until:      until,
+		}
+	)
+
+	if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid.String(),
+			err.Error())
+		return nil, err
+	} else if db.tx != nil {
+		tx = db.tx
+	} else {
+	BEGIN_AD_HOC:
+		if tx, err = db.db.Begin(); err != nil {
+			if worthARetry(err) {
+				waitForRetry()
+				goto BEGIN_AD_HOC
+			} else {
+				msg = fmt.Sprintf("Error starting transaction: %s",
+					err.Error())
+				db.log.Printf("[ERROR] %s\n", msg)
+				return nil, errors.New(msg)
+			}
+		}
+
+		defer func() {
+			var err2 error
+			if status {
+				if err2 = tx.Commit(); err2 != nil {
+					db.log.Printf("[ERROR] Failed to commit ad-hoc transaction: %s\n",
+						err2.Error())
+				}
+			} else if err2 = tx.Rollback(); err2 != nil {
+				db.log.Printf("[ERROR] Rollback of ad-hoc transaction failed: %s\n",
+					err2.Error())
+			}
+		}()
+	}
+
+	stmt = tx.Stmt(stmt)
+
+EXEC_QUERY:
+	if res, err = stmt.Exec(n.ReminderID, n.Timestamp.Unix(), t.Unix(), until.Unix()); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		}
+
+		db.log.Printf("[ERROR] Cannot record snooze of Notification %d: %s\n",
+			n.ID,
+			err.Error())
+		return nil, err
+	} else if ev.ID, err = res.LastInsertId(); err != nil {
+		db.log.Printf("[ERROR] Cannot get ID of snooze: %s\n",
+			err.Error())
+		return nil, err
+	}
+
+	status = true
+	return ev, nil
+} // func (db *Database) SnoozeAdd(n *objects.Notification, t, until time.Time) (*objects.SnoozeEvent, error)
+
+// SnoozeGetByReminder fetches the snoozes of the given Reminder since the
+// given time, in chronological order.
+func (db *Database) SnoozeGetByReminder(r *objects.Reminder, since time.Time) ([]objects.SnoozeEvent, error) {
+	const qid query.ID = query.SnoozeGetByReminder
+	var (
+		err  error
+		stmt *sql.Stmt
+	)
+
+	if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid,
+			err.Error())
+		return nil, err
+	} else if db.tx != nil {
+		stmt = db.tx.Stmt(stmt)
+	}
+
+	var rows *sql.Rows
+
+EXEC_QUERY:
+	if rows, err = stmt.Query(r.ID, since.Unix()); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		}
+
+		db.log.Printf("[ERROR] Failed to load snoozes of Reminder %d: %s\n",
+			r.ID,
+			err.Error())
+		return nil, err
+	}
+
+	defer rows.Close() // nolint: errcheck,gosec
+
+	var items []objects.SnoozeEvent
+
+	for rows.Next() {
+		var (
+			ev                    = objects.SnoozeEvent{ReminderID: r.ID}
+			ostamp, tstamp, until int64
+		)
+
+		if err = rows.Scan(&ev.ID, &ostamp, &tstamp, &until); err != nil {
+			db.log.Printf("[ERROR] Cannot scan Row: %s\n",
+				err.Error())
+			return nil, err
+		}
+
+		ev.Occurrence = time.Unix(ostamp, 0)
+		ev.Timestamp = time.Unix(tstamp, 0)
+		ev.Until = time.Unix(until, 0)
+		items = append(items, ev)
+	}
+
+	return items, nil
+} // func (db *Database) SnoozeGetByReminder(r *objects.Reminder, since time.Time) ([]objects.SnoozeEvent, error)
+
+// ExceptionAdd stores an Exception for an occurrence of the given Reminder.
+// If there already is an Exception for that occurrence, it is replaced.
+func (db *Database) ExceptionAdd(r *objects.Reminder, e *objects.Exception) error {
+	const qid query.ID = query.ExceptionAdd
+	var (
+		err    error
+		msg    string
+		stmt   *sql.Stmt
+		tx     *sql.Tx
+		status bool
+	)
+
+	if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid.String(),
+			err.Error())
+		return err
+	} else if db.tx != nil {
+		tx = db.tx
+	} else {
+	BEGIN_AD_HOC:
+		if tx, err = db.db.Begin(); err != nil {
+			if worthARetry(err) {
+				waitForRetry()
+				goto BEGIN_AD_HOC
+			} else {
+				msg = fmt.Sprintf("Error starting transaction: %s",
+					err.Error())
+				db.log.Printf("[ERROR] %s\n", msg)
+				return errors.New(msg)
+			}
+
+		} else {
+			defer func() {
+				var err2 error
+				if status {
+					if err2 = tx.Commit(); err2 != nil {
+						db.log.Printf("[ERROR] Failed to commit ad-hoc transaction: %s\n",
+							err2.Error())
+					}
+				} else if err2 = tx.Rollback(); err2 != nil {
+					db.log.Printf("[ERROR] Rollback of ad-hoc transaction failed: %s\n",
+						err2.Error())
+				}
+			}()
+		}
+	}
+
+	stmt = tx.Stmt(stmt)
+	var (
+		rows *sql.Rows
+		due  *int64
+	)
+
+	if e.Changed.IsZero() {
+		e.Changed = time.Now()
+	}
+
+	if !e.Skip() {
+		var stamp = e.Due.Unix()
+		due = &stamp
+	}
+
+EXEC_QUERY:
+	if rows, err = stmt.Query(r.ID, e.Occurrence.Unix(), due, e.Changed.Unix()); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		} else {
+			err = fmt.Errorf("Cannot add %s for Reminder %q: %s",
+				e,
+				r.Title,
+				err.Error())
+			db.log.Printf("[ERROR] %s\n", err.Error())
+			return err
+		}
+	}
+
+	defer rows.Close() // nolint: errcheck
+
+	if !rows.Next() {
+		err = fmt.Errorf("Adding %s for Reminder %q did not return an ID",
+			e,
+			r.Title)
+		db.log.Printf("[ERROR] %s\n", err.Error())
+		return err
+	} else if err = rows.Scan(&e.ID); err != nil {
+		db.log.Printf("[ERROR] Cannot scan ID of Exception: %s\n",
+			err.Error())
+		return err
+	}
+
+	e.ReminderID = r.ID
+
+	if x := r.Exception(e.Occurrence); x != nil {
+		*x = *e
+	} else {
+		r.Exceptions = append(r.Exceptions, *e)
+	}
+
+	status = true
+	return nil
+} // func (db *Database) ExceptionAdd(r *objects.Reminder, e *objects.Exception) error
+
+// ExceptionDelete removes an Exception, restoring the occurrence it applied to.
+func (db *Database) ExceptionDelete(r *objects.Reminder, e *objects.Exception) error {
+	const qid query.ID = query.ExceptionDelete
+	var (
+		err    error
+		msg    string
+		stmt   *sql.Stmt
+		tx     *sql.Tx
+		status bool
+	)
+
+	if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid.String(),
+			err.Error())
+		return err
+	} else if db.tx != nil {
+		tx = db.tx
+	} else {
+	BEGIN_AD_HOC:
+		if tx, err = db.db.Begin(); err != nil {
+			if worthARetry(err) {
+				waitForRetry()
+				goto BEGIN_AD_HOC
+			} else {
+				msg = fmt.Sprintf("Error starting transaction: %s",
+					err.Error())
+				db.log.Printf("[ERROR] %s\n", msg)
+				return errors.New(msg)
+			}
+
+		} else {
+			defer func() {
+				var err2 error
+				if status {
+					if err2 = tx.Commit(); err2 != nil {
+						db.log.Printf("[ERROR] Failed to commit ad-hoc transaction: %s\n",
+							err2.Error())
+					}
+				} else if err2 = tx.Rollback(); err2 != nil {
+					db.log.Printf("[ERROR] Rollback of ad-hoc transaction failed: %s\n",
+						err2.Error())
+				}
+			}()
+		}
+	}
+
+	stmt = tx.Stmt(stmt)
+
+EXEC_QUERY:
+	if _, err = stmt.Exec(e.ID); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		} else {
+			err = fmt.Errorf("Cannot delete %s of Reminder %q: %s",
+				e,
+				r.Title,
+				err.Error())
+			db.log.Printf("[ERROR] %s\n", err.Error())
+			return err
+		}
+	}
+
+	for i := range r.Exceptions {
+		if r.Exceptions[i].ID == e.ID {
+			r.Exceptions = append(r.Exceptions[:i], r.Exceptions[i+1:]...)
+			break
+		}
+	}
+
+	status = true
+	return nil
+} // func (db *Database) ExceptionDelete(r *objects.Reminder, e *objects.Exception) error
+
+// ExceptionGetByReminder loads the Exceptions for the given Reminder.
+func (db *Database) ExceptionGetByReminder(r *objects.Reminder) ([]objects.Exception, error) {
+	const qid query.ID = query.ExceptionGetByReminder
+	var (
+		err  error
+		stmt *sql.Stmt
+	)
+
+	if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid,
+			err.Error())
+		return nil, err
+	} else if db.tx != nil {
+		stmt = db.tx.Stmt(stmt)
+	}
+
+	var rows *sql.Rows
+
+EXEC_QUERY:
+	if rows, err = stmt.Query(r.ID); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		}
+
+		db.log.Printf("[ERROR] Failed to load Exceptions for Reminder %d: %s\n",
+			r.ID,
+			err.Error())
+		return nil, err
+	}
+
+	defer rows.Close() // nolint: errcheck,gosec
+
+	var items []objects.Exception
+
+	for rows.Next() {
+		var (
+			e               = objects.Exception{ReminderID: r.ID}
+			occurrence, chg int64
+			due             *int64
+		)
+
+		if err = rows.Scan(&e.ID, &occurrence, &due, &chg); err != nil {
+			db.log.Printf("[ERROR] Cannot scan Row: %s\n",
+				err.Error())
+			return nil, err
+		}
+
+		e.Occurrence = time.Unix(occurrence, 0)
+		e.Changed = time.Unix(chg, 0)
+		if due != nil {
+			e.Due = time.Unix(*due, 0)
+		}
+
+		items = append(items, e)
+	}
+
+	return items, nil
+} // func (db *Database) ExceptionGetByReminder(r *objects.Reminder) ([]objects.Exception, error)
+
+// ExceptionGetAll loads all Exceptions, grouped by the ID of the Reminder
+// they belong to.
+func (db *Database) ExceptionGetAll() (map[int64][]objects.Exception, error) {
+	const qid query.ID = query.ExceptionGetAll
+	var (
+		err  error
+		stmt *sql.Stmt
+	)
+
+	if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid,
+			err.Error())
+		return nil, err
+	} else if db.tx != nil {
+		stmt = db.tx.Stmt(stmt)
+	}
+
+	var rows *sql.Rows
+
+EXEC_QUERY:
+	if rows, err = stmt.Query(); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		}
+
+		db.log.Printf("[ERROR] Failed to load all Exceptions: %s\n",
+			err.Error())
+		return nil, err
+	}
+
+	defer rows.Close() // nolint: errcheck,gosec
+
+	var items = make(map[int64][]objects.Exception)
+
+	for rows.Next() {
+		var (
+			e               objects.Exception
+			occurrence, chg int64
+			due             *int64
+		)
+
+		if err = rows.Scan(&e.ID, &e.ReminderID, &occurrence, &due, &chg); err != nil {
+			db.log.Printf("[ERROR] Cannot scan Row: %s\n",
+				err.Error())
+			return nil, err
+		}
+
+		e.Occurrence = time.Unix(occurrence, 0)
+		e.Changed = time.Unix(chg, 0)
+		if due != nil {
+			e.Due = time.Unix(*due, 0)
+		}
+
+		items[e.ReminderID] = append(items[e.ReminderID], e)
+	}
+
+	return items, nil
+} // func (db *Database) ExceptionGetAll() (map[int64][]objects.Exception, error)
+
+// attachExceptions loads the Exceptions for the given Reminders.
+func (db *Database) attachExceptions(items []objects.Reminder) error {
+	var (
+		err  error
+		excs map[int64][]objects.Exception
+	)
+
+	if len(items) == 0 {
+		return nil
+	} else if excs, err = db.ExceptionGetAll(); err != nil {
+		return err
+	}
+
+	for i := range items {
+		items[i].Exceptions = excs[items[i].ID]
+	}
+
+	return nil
+} // func (db *Database) attachExceptions(items []objects.Reminder) error
+
+// SettingGet returns the value of the given setting. If the setting has
+// never been set, the second return value is false.
+func (db *Database) SettingGet(key string) (string, bool, error) {
+	const qid query.ID = query.SettingGet
+	var (
+		err  error
+		stmt *sql.Stmt
+	)
+
+	if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid,
+			err.Error())
+		return "", false, err
+	} else if db.tx != nil {
+		stmt = db.tx.Stmt(stmt)
+	}
+
+	var rows *sql.Rows
+
+EXEC_QUERY:
+	if rows, err = stmt.Query(key); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		}
+
+		db.log.Printf("[ERROR] Failed to load setting %q: %s\n",
+			key,
+			err.Error())
+		return "", false, err
+	}
+
+	defer rows.Close() // nolint: errcheck,gosec
+
+	if rows.Next() {
+		var value string
+
+		if err = rows.Scan(&value); err != nil {
+			db.log.Printf("[ERROR] Cannot scan Row: %s\n",
+				err.Error())
+			return "", false, err
+		}
+
+		return value, true, nil
+	}
+
+	return "", false, nil
+} // func (db *Database) SettingGet(key string) (string, bool, error)
+
+// SettingSet stores the value of the given setting, replacing any previous
+// value.
+func (db *Database) SettingSet(key, value string) error {
+	const qid query.ID = query.SettingSet
+	var (
+		err    error
+		msg    string
+		stmt   *sql.Stmt
+		tx     *sql.Tx
+		status bool
+	)
+
+	if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid.String(),
+			err.Error())
+		return err
+	} else if db.tx != nil {
+		tx = db.tx
+	} else {
+	BEGIN_AD_HOC:
+		if tx, err = db.db.Begin(); err != nil {
+			if worthARetry(err) {
+				waitForRetry()
+				goto BEGIN_AD_HOC
+			} else {
+				msg = fmt.Sprintf("Error starting transaction: %s",
+					err.Error())
+				db.log.Printf("[ERROR] %s\n", msg)
+				return errors.New(msg)
+			}
+
+		} else {
+			defer func() {
+				var err2 error
+				if status {
+					if err2 = tx.Commit(); err2 != nil {
+						db.log.Printf("[ERROR] Failed to commit ad-hoc transaction: %s\n",
+							err2.Error())
+					}
+				} else if err2 = tx.Rollback(); err2 != nil {
+					db.log.Printf("[ERROR] Rollback of ad-hoc transaction failed: %s\n",
+						err2.Error())
+				}
+			}()
+		}
+	}
+
+	stmt = tx.Stmt(stmt)
+
+EXEC_QUERY:
+	if _, err = stmt.Exec(key, value, time.Now().Unix()); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		} else {
+			err = fmt.Errorf("Cannot set %q to %q: %s",
+				key,
+				value,
+				err.Error())
+			db.log.Printf("[ERROR] %s\n", err.Error())
+			return err
+		}
+	}
+
+	status = true
+	return nil
+} // func (db *Database) SettingSet(key, value string) error
+
+// linkTags replaces the Tags of the given Reminder, creating any Tags that
+// do not exist, yet. It does the work for ReminderAdd and ReminderSetTags,
+// within the transaction they use.
+func (db *Database) linkTags(tx *sql.Tx, r *objects.Reminder, tags []string) error {
+	var (
+		err                  error
+		unlink, ensure, link *sql.Stmt
+		now                  = time.Now().Unix()
+	)
+
+	if unlink, err = db.getQuery(query.TagLinkClear); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			query.TagLinkClear,
+			err.Error())
+		return err
+	} else if ensure, err = db.getQuery(query.TagEnsure); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			query.TagEnsure,
+			err.Error())
+		return err
+	} else if link, err = db.getQuery(query.TagLinkAdd); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			query.TagLinkAdd,
+			err.Error())
+		return err
+	}
+
+	unlink = tx.Stmt(unlink)
+	ensure = tx.Stmt(ensure)
+	link = tx.Stmt(link)
+
+EXEC_UNLINK:
+	if _, err = unlink.Exec(r.ID); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_UNLINK
+		}
+
+		err = fmt.Errorf("Cannot unlink Tags of Reminder %q: %s",
+			r.Title,
+			err.Error())
+		db.log.Printf("[ERROR] %s\n", err.Error())
+		return err
+	}
+
+	for _, name := range tags {
+	EXEC_ENSURE:
+		if _, err = ensure.Exec(name, now); err != nil {
+			if worthARetry(err) {
+				waitForRetry()
+				goto EXEC_ENSURE
+			}
+
+			err = fmt.Errorf("Cannot create Tag %q: %s",
+				name,
+				err.Error())
+			db.log.Printf("[ERROR] %s\n", err.Error())
+			return err
+		}
+
+	EXEC_LINK:
+		if _, err = link.Exec(r.ID, name); err != nil {
+			if worthARetry(err) {
+				waitForRetry()
+				goto EXEC_LINK
+			}
+
+			err = fmt.Errorf("Cannot attach Tag %q to Reminder %q: %s",
+				name,
+				r.Title,
+				err.Error())
+			db.log.Printf("[ERROR] %s\n", err.Error())
+			return err
+		}
+	}
+
+	r.Tags = tags
+	return nil
+} // func (db *Database) linkTags(tx *sql.Tx, r *objects.Reminder, tags []string) error
+
+// ReminderSetTags sets the Tags of the given Reminder. Tags that do not
+// exist, yet, are created with default settings.
+func (db *Database) ReminderSetTags(r *objects.Reminder, tags []string) error {
+	const qid query.ID = query.ReminderSetChanged
+	var (
+		err    error
+		msg    string
+		stmt   *sql.Stmt
+		tx     *sql.Tx
+		status bool
+	)
+
+	if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid.String(),
+			err.Error())
+		return err
+	} else if db.tx != nil {
+		tx = db.tx
+	} else {
+	BEGIN_AD_HOC:
+		if tx, err = db.db.Begin(); err != nil {
+			if worthARetry(err) {
+				waitForRetry()
+				goto BEGIN_AD_HOC
+			} else {
+				msg = fmt.Sprintf("Error starting transaction: %s",
+					err.Error())
+				db.log.Printf("[ERROR] %s\n", msg)
+				return errors.New(msg)
+			}
+
+		} else {
+			defer func() {
+				var err2 error
+				if status {
+					if err2 = tx.Commit(); err2 != nil {
+						db.log.Printf("[ERROR] Failed to commit ad-hoc transaction: %s\n",
+							err2.Error())
+					}
+				} else if err2 = tx.Rollback(); err2 != nil {
+					db.log.Printf("[ERROR] Rollback of ad-hoc transaction failed: %s\n",
+						err2.Error())
+				}
+			}()
+		}
+	}
+
+	stmt = tx.Stmt(stmt)
+	var now = time.Now()
+
+	if err = db.linkTags(tx, r, tags); err != nil {
+		return err
+	}
+
+EXEC_QUERY:
+	if _, err = stmt.Exec(now.Unix(), r.ID); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		} else {
+			err = fmt.Errorf("Cannot update change stamp of Reminder %q: %s",
+				r.Title,
+				err.Error())
+			db.log.Printf("[ERROR] %s\n", err.Error())
+			return err
+		}
+	}
+
+	r.Changed = now
+	status = true
+	return nil
+} // func (db *Database) ReminderSetTags(r *objects.Reminder, tags []string) error
+
+// TagGetByReminder returns the names of the Tags of the given Reminder.
+func (db *Database) TagGetByReminder(r *objects.Reminder) ([]string, error) {
+	const qid query.ID = query.TagLinkGetByReminder
+	var (
+		err  error
+		stmt *sql.Stmt
+	)
+
+	if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid,
+			err.Error())
+		return nil, err
+	} else if db.tx != nil {
+		stmt = db.tx.Stmt(stmt)
+	}
+
+	var rows *sql.Rows
+
+EXEC_QUERY:
+	if rows, err = stmt.Query(r.ID); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		}
+
+		db.log.Printf("[ERROR] Failed to load Tags for Reminder %d: %s\n",
+			r.ID,
+			err.Error())
+		return nil, err
+	}
+
+	defer rows.Close() // nolint: errcheck,gosec
+
+	var tags []string
+
+	for rows.Next() {
+		var name string
+
+		if err = rows.Scan(&name); err != nil {
+			db.log.Printf("[ERROR] Cannot scan Row: %s\n",
+				err.Error())
+			return nil, err
+		}
+
+		tags = append(tags, name)
+	}
+
+	return tags, nil
+} // func (db *Database) TagGetByReminder(r *objects.Reminder) ([]string, error)
+
+// TagLinkGetAll returns the names of the Tags of all Reminders, grouped by
+// the Reminder's ID.
+func (db *Database) TagLinkGetAll() (map[int64][]string, error) {
+	const qid query.ID = query.TagLinkGetAll
+	var (
+		err  error
+		stmt *sql.Stmt
+	)
+
+	if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid,
+			err.Error())
+		return nil, err
+	} else if db.tx != nil {
+		stmt = db.tx.Stmt(stmt)
+	}
+
+	var rows *sql.Rows
+
+EXEC_QUERY:
+	if rows, err = stmt.Query(); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		}
+
+		db.log.Printf("[ERROR] Failed to load all Tags of Reminders: %s\n",
+			err.Error())
+		return nil, err
+	}
+
+	defer rows.Close() // nolint: errcheck,gosec
+
+	var tags = make(map[int64][]string)
+
+	for rows.Next() {
+		var (
+			rid  int64
+			name string
+		)
+
+		if err = rows.Scan(&rid, &name); err != nil {
+			db.log.Printf("[ERROR] Cannot scan Row: %s\n",
+				err.Error())
+			return nil, err
+		}
+
+		tags[rid] = append(tags[rid], name)
+	}
+
+	return tags, nil
+} // func (db *Database) TagLinkGetAll() (map[int64][]string, error)
+
+// attachTags loads the Tags for the given Reminders.
+func (db *Database) attachTags(items []objects.Reminder) error {
+	var (
+		err  error
+		tags map[int64][]string
+	)
+
+	if len(items) == 0 {
+		return nil
+	} else if tags, err = db.TagLinkGetAll(); err != nil {
+		return err
+	}
+
+	for i := range items {
+		items[i].Tags = tags[items[i].ID]
+	}
+
+	return nil
+} // func (db *Database) attachTags(items []objects.Reminder) error
+
+// TagGetAll returns all Tags, ordered by name.
+func (db *Database) TagGetAll() ([]objects.Tag, error) {
+	const qid query.ID = query.TagGetAll
+	var (
+		err  error
+		stmt *sql.Stmt
+	)
+
+	if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid,
+			err.Error())
+		return nil, err
+	} else if db.tx != nil {
+		stmt = db.tx.Stmt(stmt)
+	}
+
+	var rows *sql.Rows
+
+EXEC_QUERY:
+	if rows, err = stmt.Query(); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		}
+
+		db.log.Printf("[ERROR] Failed to load all Tags: %s\n",
+			err.Error())
+		return nil, err
+	}
+
+	defer rows.Close() // nolint: errcheck,gosec
+
+	var tags = make([]objects.Tag, 0)
+
+	for rows.Next() {
+		var t objects.Tag
+
+		if err = rows.Scan(&t.ID, &t.Name, &t.Priority, &t.Channel, &t.QuietExempt); err != nil {
+			db.log.Printf("[ERROR] Cannot scan Row: %s\n",
+				err.Error())
+			return nil, err
+		}
+
+		tags = append(tags, t)
+	}
+
+	return tags, nil
+} // func (db *Database) TagGetAll() ([]objects.Tag, error)
+
+// TagSet stores the given Tag's settings, creating the Tag if it does not
+// exist, yet.
+func (db *Database) TagSet(t *objects.Tag) error {
+	const qid query.ID = query.TagSet
+	var (
+		err    error
+		msg    string
+		stmt   *sql.Stmt
+		tx     *sql.Tx
+		status bool
+	)
+
+	if err = t.Validate(); err != nil {
+		db.log.Printf("[ERROR] %s\n", err.Error())
+		return err
+	} else if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid.String(),
+			err.Error())
+		return err
+	} else if db.tx != nil {
+		tx = db.tx
+	} else {
+	BEGIN_AD_HOC:
+		if tx, err = db.db.Begin(); err != nil {
+			if worthARetry(err) {
+				waitForRetry()
+				goto BEGIN_AD_HOC
+			} else {
+				msg = fmt.Sprintf("Error starting transaction: %s",
+					err.Error())
+				db.log.Printf("[ERROR] %s\n", msg)
+				return errors.New(msg)
+			}
+
+		} else {
+			defer func() {
+				var err2 error
+				if status {
+					if err2 = tx.Commit(); err2 != nil {
+						db.log.Printf("[ERROR] Failed to commit ad-hoc transaction: %s\n",
+							err2.Error())
+					}
+				} else if err2 = tx.Rollback(); err2 != nil {
+					db.log.Printf("[ERROR] Rollback of ad-hoc transaction failed: %s\n",
+						err2.Error())
+				}
+			}()
+		}
+	}
+
+	stmt = tx.Stmt(stmt)
+	var rows *sql.Rows
+
+EXEC_QUERY:
+	if rows, err = stmt.Query(
+		t.Name,
+		t.Priority,
+		t.Channel,
+		t.QuietExempt,
+		time.Now().Unix()); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		} else {
+			err = fmt.Errorf("Cannot store Tag %q: %s",
+				t.Name,
+				err.Error())
+			db.log.Printf("[ERROR] %s\n", err.Error())
+			return err
+		}
+	}
+
+	defer rows.Close() // nolint: errcheck
+
+	if !rows.Next() {
+		err = fmt.Errorf("Storing Tag %q did not return an ID", t.Name)
+		db.log.Printf("[ERROR] %s\n", err.Error())
+		return err
+	} else if err = rows.Scan(&t.ID); err != nil {
+		db.log.Printf("[ERROR] Cannot scan ID of Tag: %s\n",
+			err.Error())
+		return err
+	}
+
+	status = true
+	return nil
+} // func (db *Database) TagSet(t *objects.Tag) error
+
+// TagDelete removes the Tag with the given name from all Reminders, and
+// from the database.
+func (db *Database) TagDelete(name string) error {
+	const qid query.ID = query.TagDelete
+	var (
+		err         error
+		msg         string
+		stmt, touch *sql.Stmt
+		tx          *sql.Tx
+		status      bool
+	)
+
+	if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid.String(),
+			err.Error())
+		return err
+	} else if touch, err = db.getQuery(query.TagTouchReminders); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			query.TagTouchReminders,
+			err.Error())
+		return err
+	} else if db.tx != nil {
+		tx = db.tx
+	} else {
+	BEGIN_AD_HOC:
+		if tx, err = db.db.Begin(); err != nil {
+			if worthARetry(err) {
+				waitForRetry()
+				goto BEGIN_AD_HOC
+			} else {
+				msg = fmt.Sprintf("Error starting transaction: %s",
+					err.Error())
+				db.log.Printf("[ERROR] %s\n", msg)
+				return errors.New(msg)
+			}
+
+		} else {
+			defer func() {
+				var err2 error
+				if status {
+					if err2 = tx.Commit(); err2 != nil {
+						db.log.Printf("[ERROR] Failed to commit ad-hoc transaction: %s\n",
+							err2.Error())
+					}
+				} else if err2 = tx.Rollback(); err2 != nil {
+					db.log.Printf("[ERROR] Rollback of ad-hoc transaction failed: %s\n",
+						err2.Error())
+				}
+			}()
+		}
+	}
+
+	stmt = tx.Stmt(stmt)
+	touch = tx.Stmt(touch)
+
+	// The Reminders lose a Tag, so they have changed, as far as
+	// synchronization is concerned.
+EXEC_TOUCH:
+	if _, err = touch.Exec(time.Now().Unix(), name); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_TOUCH
+		}
+
+		err = fmt.Errorf("Cannot update Reminders tagged %q: %s",
+			name,
+			err.Error())
+		db.log.Printf("[ERROR] %s\n", err.Error())
+		return err
+	}
+
+EXEC_QUERY:
+	if _, err = stmt.Exec(name); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		} else {
+			err = fmt.Errorf("Cannot delete Tag %q: %s",
+				name,
+				err.Error())
+			db.log.Printf("[ERROR] %s\n", err.Error())
+			return err
+		}
+	}
+
+	status = true
+	return nil
+} // func (db *Database) TagDelete(name string) error
+
+// storeChecklist replaces the checklist of the given Reminder. It does the
+// work for ReminderAdd and ReminderSetChecklist, within the transaction they
+// use.
+func (db *Database) storeChecklist(tx *sql.Tx, r *objects.Reminder, items []objects.ChecklistItem) error {
+	var (
+		err         error
+		unlink, add *sql.Stmt
+	)
+
+	if unlink, err = db.getQuery(query.ChecklistClear); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			query.ChecklistClear,
+			err.Error())
+		return err
+	} else if add, err = db.getQuery(query.ChecklistAdd); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			query.ChecklistAdd,
+			err.Error())
+		return err
+	}
+
+	unlink = tx.Stmt(unlink)
+	add = tx.Stmt(add)
+
+EXEC_UNLINK:
+	if _, err = unlink.Exec(r.ID); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_UNLINK
+		}
+
+		err = fmt.Errorf("Cannot clear checklist of Reminder %q: %s",
+			r.Title,
+			err.Error())
+		db.log.Printf("[ERROR] %s\n", err.Error())
+		return err
+	}
+
+	for idx, item := range items {
+	EXEC_ADD:
+		if _, err = add.Exec(r.ID, idx, item.Text, item.Done); err != nil {
+			if worthARetry(err) {
+				waitForRetry()
+				goto EXEC_ADD
+			}
+
+			err = fmt.Errorf("Cannot add checklist item %q to Reminder %q: %s",
+				item.Text,
+				r.Title,
+				err.Error())
+			db.log.Printf("[ERROR] %s\n", err.Error())
+			return err
+		}
+	}
+
+	r.Checklist = items
+	return nil
+} // func (db *Database) storeChecklist(tx *sql.Tx, r *objects.Reminder, items []objects.ChecklistItem) error
+
+// ReminderSetChecklist replaces the checklist of the given Reminder.
+func (db *Database) ReminderSetChecklist(r *objects.Reminder, items []objects.ChecklistItem) error {
+	const qid query.ID = query.ReminderSetChanged
+	var (
+		err    error
+		msg    string
+		stmt   *sql.Stmt
+		tx     *sql.Tx
+		status bool
+	)
+
+	if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid.String(),
+			err.Error())
+		return err
+	} else if db.tx != nil {
+		tx = db.tx
+	} else {
+	BEGIN_AD_HOC:
+		if tx, err = db.db.Begin(); err != nil {
+			if worthARetry(err) {
+				waitForRetry()
+				goto BEGIN_AD_HOC
+			} else {
+				msg = fmt.Sprintf("Error starting transaction: %s",
+					err.Error())
+				db.log.Printf("[ERROR] %s\n", msg)
+				return errors.New(msg)
+			}
+
+		} else {
+			defer func() {
+				var err2 error
+				if status {
+					if err2 = tx.Commit(); err2 != nil {
+						db.log.Printf("[ERROR] Failed to commit ad-hoc transaction: %s\n",
+							err2.Error())
+					}
+				} else if err2 = tx.Rollback(); err2 != nil {
+					db.log.Printf("[ERROR] Rollback of ad-hoc transaction failed: %s\n",
+						err2.Error())
+				}
+			}()
+		}
+	}
+
+	stmt = tx.Stmt(stmt)
+	var now = time.Now()
+
+	if err = db.storeChecklist(tx, r, items); err != nil {
+		return err
+	}
+
+EXEC_QUERY:
+	if _, err = stmt.Exec(now.Unix(), r.ID); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		} else {
+			err = fmt.Errorf("Cannot update change stamp of Reminder %q: %s",
+				r.Title,
+				err.Error())
+			db.log.Printf("[ERROR] %s\n", err.Error())
+			return err
+		}
+	}
+
+	r.Changed = now
+	status = true
+	return nil
+} // func (db *Database) ReminderSetChecklist(r *objects.Reminder, items []objects.ChecklistItem) error
+
+// ChecklistSetDone checks or unchecks the checklist item at the given
+// position.
+func (db *Database) ChecklistSetDone(r *objects.Reminder, idx int, done bool) error {
+	const qid query.ID = query.ChecklistSetDone
+	var (
+		err         error
+		msg         string
+		stmt, touch *sql.Stmt
+		tx          *sql.Tx
+		status      bool
+	)
+
+	if idx < 0 || idx >= len(r.Checklist) {
+		err = fmt.Errorf("Reminder %q has no checklist item #%d",
+			r.Title,
+			idx)
+		db.log.Printf("[ERROR] %s\n", err.Error())
+		return err
+	} else if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid.String(),
+			err.Error())
+		return err
+	} else if touch, err = db.getQuery(query.ReminderSetChanged); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			query.ReminderSetChanged,
+			err.Error())
+		return err
+	} else if db.tx != nil {
+		tx = db.tx
+	} else {
+	BEGIN_AD_HOC:
+		if tx, err = db.db.Begin(); err != nil {
+			if worthARetry(err) {
+				waitForRetry()
+				goto BEGIN_AD_HOC
+			} else {
+				msg = fmt.Sprintf("Error starting transaction: %s",
+					err.Error())
+				db.log.Printf("[ERROR] %s\n", msg)
+				return errors.New(msg)
+			}
+
+		} else {
+			defer func() {
+				var err2 error
+				if status {
+					if err2 = tx.Commit(); err2 != nil {
+						db.log.Printf("[ERROR] Failed to commit ad-hoc transaction: %s\n",
+							err2.Error())
+					}
+				} else if err2 = tx.Rollback(); err2 != nil {
+					db.log.Printf("[ERROR] Rollback of ad-hoc transaction failed: %s\n",
+						err2.Error())
+				}
+			}()
+		}
+	}
+
+	stmt = tx.Stmt(stmt)
+	touch = tx.Stmt(touch)
+	var now = time.Now()
+
+EXEC_QUERY:
+	if _, err = stmt.Exec(done, r.ID, idx); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		} else {
+			err = fmt.Errorf("Cannot update checklist item #%d of Reminder %q: %s",
+				idx,
+				r.Title,
+				err.Error())
+			db.log.Printf("[ERROR] %s\n", err.Error())
+			return err
+		}
+	}
+
+EXEC_TOUCH:
+	if _, err = touch.Exec(now.Unix(), r.ID); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_TOUCH
+		}
+
+		err = fmt.Errorf("Cannot update change stamp of Reminder %q: %s",
+			r.Title,
+			err.Error())
+		db.log.Printf("[ERROR] %s\n", err.Error())
+		return err
+	}
+
+	r.Checklist[idx].Done = done
+	r.Changed = now
+	status = true
+	return nil
+} // func (db *Database) ChecklistSetDone(r *objects.Reminder, idx int, done bool) error
+
+// ChecklistGetByReminder loads the checklist of the given Reminder.
+func (db *Database) ChecklistGetByReminder(r *objects.Reminder) ([]objects.ChecklistItem, error) {
+	const qid query.ID = query.ChecklistGetByReminder
+	var (
+		err  error
+		stmt *sql.Stmt
+	)
+
+	if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid,
+			err.Error())
+		return nil, err
+	} else if db.tx != nil {
+		stmt = db.tx.Stmt(stmt)
+	}
+
+	var rows *sql.Rows
+
+EXEC_QUERY:
+	if rows, err = stmt.Query(r.ID); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		}
+
+		db.log.Printf("[ERROR] Failed to load checklist for Reminder %d: %s\n",
+			r.ID,
+			err.Error())
+		return nil, err
+	}
+
+	defer rows.Close() // nolint: errcheck,gosec
+
+	var items []objects.ChecklistItem
+
+	for rows.Next() {
+		var item objects.ChecklistItem
+
+		if err = rows.Scan(&item.Text, &item.Done); err != nil {
+			db.log.Printf("[ERROR] Cannot scan Row: %s\n",
+				err.Error())
+			return nil, err
+		}
+
+		items = append(items, item)
+	}
+
+	return items, nil
+} // func (db *Database) ChecklistGetByReminder(r *objects.Reminder) ([]objects.ChecklistItem, error)
+
+// ChecklistGetAll loads the checklists of all Reminders, grouped by the
+// Reminder's ID.
+func (db *Database) ChecklistGetAll() (map[int64][]objects.ChecklistItem, error) {
+	const qid query.ID = query.ChecklistGetAll
+	var (
+		err  error
+		stmt *sql.Stmt
+	)
+
+	if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid,
+			err.Error())
+		return nil, err
+	} else if db.tx != nil {
+		stmt = db.tx.Stmt(stmt)
+	}
+
+	var rows *sql.Rows
+
+EXEC_QUERY:
+	if rows, err = stmt.Query(); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		}
+
+		db.log.Printf("[ERROR] Failed to load all checklists: %s\n",
+			err.Error())
+		return nil, err
+	}
+
+	defer rows.Close() // nolint: errcheck,gosec
+
+	var items = make(map[int64][]objects.ChecklistItem)
+
+	for rows.Next() {
+		var (
+			rid  int64
+			item objects.ChecklistItem
+		)
+
+		if err = rows.Scan(&rid, &item.Text, &item.Done); err != nil {
+			db.log.Printf("[ERROR] Cannot scan Row: %s\n",
+				err.Error())
+			return nil, err
+		}
+
+		items[rid] = append(items[rid], item)
+	}
+
+	return items, nil
+} // func (db *Database) ChecklistGetAll() (map[int64][]objects.ChecklistItem, error)
+
+// attachChecklists loads the checklists for the given Reminders.
+func (db *Database) attachChecklists(items []objects.Reminder) error {
+	var (
+		err   error
+		lists map[int64][]objects.ChecklistItem
+	)
+
+	if len(items) == 0 {
+		return nil
+	} else if lists, err = db.ChecklistGetAll(); err != nil {
+		return err
+	}
+
+	for i := range items {
+		items[i].Checklist = lists[items[i].ID]
+	}
+
+	return nil
+} // func (db *Database) attachChecklists(items []objects.Reminder) error
+
+// storeDepends replaces the Dependencies of the given Reminder. It does the
+// work for ReminderAdd and ReminderSetDepends, within the transaction they
+// use.
+func (db *Database) storeDepends(tx *sql.Tx, r *objects.Reminder, deps []objects.Dependency) error {
+	var (
+		err         error
+		unlink, add *sql.Stmt
+	)
+
+	if unlink, err = db.getQuery(query.DependencyClear); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			query.DependencyClear,
+			err.Error())
+		return err
+	} else if add, err = db.getQuery(query.DependencyAdd); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			query.DependencyAdd,
+			err.Error())
+		return err
+	}
+
+	unlink = tx.Stmt(unlink)
+	add = tx.Stmt(add)
+
+EXEC_UNLINK:
+	if _, err = unlink.Exec(r.ID); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_UNLINK
+		}
+
+		err = fmt.Errorf("Cannot clear dependencies of Reminder %q: %s",
+			r.Title,
+			err.Error())
+		db.log.Printf("[ERROR] %s\n", err.Error())
+		return err
+	}
+
+	for _, d := range deps {
+		var triggered int64
+
+		if !d.Triggered.IsZero() {
+			triggered = d.Triggered.Unix()
+		}
+
+	EXEC_ADD:
+		if _, err = add.Exec(r.ID, d.Prereq, d.Kind, d.Delay, triggered); err != nil {
+			if worthARetry(err) {
+				waitForRetry()
+				goto EXEC_ADD
+			}
+
+			err = fmt.Errorf("Cannot add dependency %s to Reminder %q: %s",
+				d.String(),
+				r.Title,
+				err.Error())
+			db.log.Printf("[ERROR] %s\n", err.Error())
+			return err
+		}
+	}
+
+	r.Depends = deps
+	return nil
+} // func (db *Database) storeDepends(tx *sql.Tx, r *objects.Reminder, deps []objects.Dependency) error
+
+// ReminderSetDepends replaces the Dependencies of the given Reminder.
+func (db *Database) ReminderSetDepends(r *objects.Reminder, deps []objects.Dependency) error {
+	const qid query.ID = query.ReminderSetChanged
+	var (
+		err    error
+		msg    string
+		stmt   *sql.Stmt
+		tx     *sql.Tx
+		status bool
+	)
+
+	if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid.String(),
+			err.Error())
+		return err
+	} else if db.tx != nil {
+		tx = db.tx
+	} else {
+	BEGIN_AD_HOC:
+		if tx, err = db.db.Begin(); err != nil {
+			if worthARetry(err) {
+				waitForRetry()
+				goto BEGIN_AD_HOC
+			} else {
+				msg = fmt.Sprintf("Error starting transaction: %s",
+					err.Error())
+				db.log.Printf("[ERROR] %s\n", msg)
+				return errors.New(msg)
+			}
+
+		} else {
+			defer func() {
+				var err2 error
+				if status {
+					if err2 = tx.Commit(); err2 != nil {
+						db.log.Printf("[ERROR] Failed to commit ad-hoc transaction: %s\n",
+							err2.Error())
+					}
+				} else if err2 = tx.Rollback(); err2 != nil {
+					db.log.Printf("[ERROR] Rollback of ad-hoc transaction failed: %s\n",
+						err2.Error())
+				}
+			}()
+		}
+	}
+
+	stmt = tx.Stmt(stmt)
+	var now = time.Now()
+
+	if err = db.storeDepends(tx, r, deps); err != nil {
+		return err
+	}
+
+EXEC_QUERY:
+	if _, err = stmt.Exec(now.Unix(), r.ID); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		} else {
+			err = fmt.Errorf("Cannot update change stamp of Reminder %q: %s",
+				r.Title,
+				err.Error())
+			db.log.Printf("[ERROR] %s\n", err.Error())
+			return err
+		}
+	}
+
+	r.Changed = now
+	status = true
+	return nil
+} // func (db *Database) ReminderSetDepends(r *objects.Reminder, deps []objects.Dependency) error
+
+// DependencyTrigger records that the follow-up Reminder r has been
+// scheduled, because its prerequisite has come up.
+func (db *Database) DependencyTrigger(r *objects.Reminder, prereq string, t time.Time) error {
+	const qid query.ID = query.DependencyTrigger
+	var (
+		err    error
+		msg    string
+		stmt   *sql.Stmt
+		tx     *sql.Tx
+		status bool
+	)
+
+	if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid.String(),
+			err.Error())
+		return err
+	} else if db.tx != nil {
+		tx = db.tx
+	} else {
+	BEGIN_AD_HOC:
+		if tx, err = db.db.Begin(); err != nil {
+			if worthARetry(err) {
+				waitForRetry()
+				goto BEGIN_AD_HOC
+			} else {
+				msg = fmt.Sprintf("Error starting transaction: %s",
+					err.Error())
+				db.log.Printf("[ERROR] %s\n", msg)
+				return errors.New(msg)
+			}
+
+		} else {
+			defer func() {
+				var err2 error
+				if status {
+					if err2 = tx.Commit(); err2 != nil {
+						db.log.Printf("[ERROR] Failed to commit ad-hoc transaction: %s\n",
+							err2.Error())
+					}
+				} else if err2 = tx.Rollback(); err2 != nil {
+					db.log.Printf("[ERROR] Rollback of ad-hoc transaction failed: %s\n",
+						err2.Error())
+				}
+			}()
+		}
+	}
+
+	stmt = tx.Stmt(stmt)
+
+EXEC_QUERY:
+	if _, err = stmt.Exec(t.Unix(), r.ID, prereq); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		} else {
+			err = fmt.Errorf("Cannot trigger follow-up Reminder %q: %s",
+				r.Title,
+				err.Error())
+			db.log.Printf("[ERROR] %s\n", err.Error())
+			return err
+		}
+	}
+
+	for i := range r.Depends {
+		if r.Depends[i].Prereq == prereq {
+			r.Depends[i].Triggered = t
+		}
+	}
+
+	status = true
+	return nil
+} // func (db *Database) DependencyTrigger(r *objects.Reminder, prereq string, t time.Time) error
+
+// DependencyGetByReminder loads the Dependencies of the given Reminder.
+func (db *Database) DependencyGetByReminder(r *objects.Reminder) ([]objects.Dependency, error) {
+	const qid query.ID = query.DependencyGetByReminder
+	var (
+		err  error
+		stmt *sql.Stmt
+	)
+
+	if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid,
+			err.Error())
+		return nil, err
+	} else if db.tx != nil {
+		stmt = db.tx.Stmt(stmt)
+	}
+
+	var rows *sql.Rows
+
+EXEC_QUERY:
+	if rows, err = stmt.Query(r.ID); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		}
+
+		db.log.Printf("[ERROR] Failed to load dependencies for Reminder %d: %s\n",
+			r.ID,
+			err.Error())
+		return nil, err
+	}
+
+	defer rows.Close() // nolint: errcheck,gosec
+
+	var deps []objects.Dependency
+
+	for rows.Next() {
+		var (
+			triggered int64
+			d         objects.Dependency
+		)
+
+		if err = rows.Scan(&d.Prereq, &d.Kind, &d.Delay, &triggered); err != nil {
+			db.log.Printf("[ERROR] Cannot scan Row: %s\n",
+				err.Error())
+			return nil, err
+		} else if triggered != 0 {
+			d.Triggered = time.Unix(triggered, 0)
+		}
+
+		deps = append(deps, d)
+	}
+
+	return deps, nil
+} // func (db *Database) DependencyGetByReminder(r *objects.Reminder) ([]objects.Dependency, error)
+
+// DependencyGetAll loads the Dependencies of all Reminders, grouped by the
+// Reminder's ID.
+func (db *Database) DependencyGetAll() (map[int64][]objects.Dependency, error) {
+	const qid query.ID = query.DependencyGetAll
+	var (
+		err  error
+		stmt *sql.Stmt
+	)
+
+	if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid,
+			err.Error())
+		return nil, err
+	} else if db.tx != nil {
+		stmt = db.tx.Stmt(stmt)
+	}
+
+	var rows *sql.Rows
+
+EXEC_QUERY:
+	if rows, err = stmt.Query(); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		}
+
+		db.log.Printf("[ERROR] Failed to load all dependencies: %s\n",
+			err.Error())
+		return nil, err
+	}
+
+	defer rows.Close() // nolint: errcheck,gosec
+
+	var deps = make(map[int64][]objects.Dependency)
+
+	for rows.Next() {
+		var (
+			rid, triggered int64
+			d              objects.Dependency
+		)
+
+		if err = rows.Scan(&rid, &d.Prereq, &d.Kind, &d.Delay, &triggered); err != nil {
+			db.log.Printf("[ERROR] Cannot scan Row: %s\n",
+				err.Error())
+			return nil, err
+		} else if triggered != 0 {
+			d.Triggered = time.Unix(triggered, 0)
+		}
+
+		deps[rid] = append(deps[rid], d)
+	}
+
+	return deps, nil
+} // func (db *Database) DependencyGetAll() (map[int64][]objects.Dependency, error)
+
+// attachDepends loads the Dependencies for the given Reminders.
+func (db *Database) attachDepends(items []objects.Reminder) error {
+	var (
+		err  error
+		deps map[int64][]objects.Dependency
+	)
+
+	if len(items) == 0 {
+		return nil
+	} else if deps, err = db.DependencyGetAll(); err != nil {
+		return err
+	}
+
+	for i := range items {
+		items[i].Depends = deps[items[i].ID]
+	}
+
+	return nil
+} // func (db *Database) attachDepends(items []objects.Reminder) error
+
+// DependencyGetFollowUps returns the IDs of the Reminders that are
+// follow-ups of the given Reminder.
+func (db *Database) DependencyGetFollowUps(r *objects.Reminder) ([]int64, error) {
+	const qid query.ID = query.DependencyGetFollowUps
+	var (
+		err  error
+		stmt *sql.Stmt
+	)
+
+	if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid,
+			err.Error())
+		return nil, err
+	} else if db.tx != nil {
+		stmt = db.tx.Stmt(stmt)
+	}
+
+	var rows *sql.Rows
+
+EXEC_QUERY:
+	if rows, err = stmt.Query(r.UUID); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		}
+
+		db.log.Printf("[ERROR] Failed to load follow-ups of Reminder %d: %s\n",
+			r.ID,
+			err.Error())
+		return nil, err
+	}
+
+	defer rows.Close() // nolint: errcheck,gosec
+
+	var ids []int64
+
+	for rows.Next() {
+		var id int64
+
+		if err = rows.Scan(&id); err != nil {
+			db.log.Printf("[ERROR] Cannot scan Row: %s\n",
+				err.Error())
+			return nil, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+} // func (db *Database) DependencyGetFollowUps(r *objects.Reminder) ([]int64, error)
+
+// TombstoneAdd records that the Reminder with the given UUID was deleted at
+// the given time. If there already is a Tombstone for the UUID, the more
+// recent deletion time is kept.
+func (db *Database) TombstoneAdd(uuid string, deleted time.Time) error {
+	const qid query.ID = query.TombstoneAdd
+	var (
+		err    error
+		msg    string
+		stmt   *sql.Stmt
+		tx     *sql.Tx
+		status bool
+	)
+
+	if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid.String(),
+			err.Error())
+		return err
+	} else if db.tx != nil {
+		tx = db.tx
+	} else {
+	BEGIN_AD_HOC:
+		if tx, err = db.db.Begin(); err != nil {
+			if worthARetry(err) {
+				waitForRetry()
+				goto BEGIN_AD_HOC
+			} else {
+				msg = fmt.Sprintf("Error starting transaction: %s",
+					err.Error())
+				db.log.Printf("[ERROR] %s\n", msg)
+				return errors.New(msg)
+			}
+
+		} else {
+			defer func() {
+				var err2 error
+				if status {
+					if err2 = tx.Commit(); err2 != nil {
+						db.log.Printf("[ERROR] Failed to commit ad-hoc transaction: %s\n",
+							err2.Error())
+					}
+				} else if err2 = tx.Rollback(); err2 != nil {
+					db.log.Printf("[ERROR] Rollback of ad-hoc transaction failed: %s\n",
+						err2.Error())
+				}
+			}()
+		}
+	}
+
+	stmt = tx.Stmt(stmt)
+
+EXEC_QUERY:
+	if _, err = stmt.Exec(uuid, deleted.Unix(), time.Now().Unix()); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		} else {
+			err = fmt.Errorf("Cannot add Tombstone for %s: %s",
+				uuid,
+				err.Error())
+			db.log.Printf("[ERROR] %s\n", err.Error())
+			return err
+		}
+	}
+
+	status = true
+	return nil
+} // func (db *Database) TombstoneAdd(uuid string, deleted time.Time) error
+
+// TombstoneDelete removes the Tombstone for the given UUID, if there is one.
+func (db *Database) TombstoneDelete(uuid string) error {
+	const qid query.ID = query.TombstoneDelete
+	var (
+		err    error
+		msg    string
+		stmt   *sql.Stmt
+		tx     *sql.Tx
+		status bool
+	)
+
+	if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid.String(),
+			err.Error())
+		return err
+	} else if db.tx != nil {
+		tx = db.tx
+	} else {
+	BEGIN_AD_HOC:
+		if tx, err = db.db.Begin(); err != nil {
+			if worthARetry(err) {
+				waitForRetry()
+				goto BEGIN_AD_HOC
+			} else {
+				msg = fmt.Sprintf("Error starting transaction: %s",
+					err.Error())
+				db.log.Printf("[ERROR] %s\n", msg)
+				return errors.New(msg)
+			}
+
+		} else {
+			defer func() {
+				var err2 error
+				if status {
+					if err2 = tx.Commit(); err2 != nil {
+						db.log.Printf("[ERROR] Failed to commit ad-hoc transaction: %s\n",
+							err2.Error())
+					}
+				} else if err2 = tx.Rollback(); err2 != nil {
+					db.log.Printf("[ERROR] Rollback of ad-hoc transaction failed: %s\n",
+						err2.Error())
+				}
+			}()
+		}
+	}
+
+	stmt = tx.Stmt(stmt)
+
+EXEC_QUERY:
+	if _, err = stmt.Exec(uuid); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		} else {
+			err = fmt.Errorf("Cannot delete Tombstone for %s: %s",
+				uuid,
+				err.Error())
+			db.log.Printf("[ERROR] %s\n", err.Error())
+			return err
+		}
+	}
+
+	status = true
+	return nil
+} // func (db *Database) TombstoneDelete(uuid string) error
+
+// TombstonePurge removes all Tombstones recorded before the given time and
+// returns how many it removed.
+func (db *Database) TombstonePurge(before time.Time) (int64, error) {
+	const qid query.ID = query.TombstonePurge
+	var (
+		err    error
+		msg    string
+		stmt   *sql.Stmt
+		tx     *sql.Tx
+		res    sql.Result
+		cnt    int64
+		status bool
+	)
+
+	if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid.String(),
+			err.Error())
+		return 0, err
+	} else if db.tx != nil {
+		tx = db.tx
+	} else {
+	BEGIN_AD_HOC:
+		if tx, err = db.db.Begin(); err != nil {
+			if worthARetry(err) {
+				waitForRetry()
+				goto BEGIN_AD_HOC
+			} else {
+				msg = fmt.Sprintf("Error starting transaction: %s",
+					err.Error())
+				db.log.Printf("[ERROR] %s\n", msg)
+				return 0, errors.New(msg)
+			}
+
+		} else {
+			defer func() {
+				var err2 error
+				if status {
+					if err2 = tx.Commit(); err2 != nil {
+						db.log.Printf("[ERROR] Failed to commit ad-hoc transaction: %s\n",
+							err2.Error())
+					}
+				} else if err2 = tx.Rollback(); err2 != nil {
+					db.log.Printf("[ERROR] Rollback of ad-hoc transaction failed: %s\n",
+						err2.Error())
+				}
+			}()
+		}
+	}
+
+	stmt = tx.Stmt(stmt)
+
+EXEC_QUERY:
+	if res, err = stmt.Exec(before.Unix()); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		} else {
+			err = fmt.Errorf("Cannot purge Tombstones recorded before %s: %s",
+				before.Format(common.TimestampFormat),
+				err.Error())
+			db.log.Printf("[ERROR] %s\n", err.Error())
+			return 0, err
+		}
+	} else if cnt, err = res.RowsAffected(); err != nil {
+		db.log.Printf("[ERROR] Cannot get number of purged Tombstones: %s\n",
+			err.Error())
+		return 0, err
+	}
+
+	status = true
+	return cnt, nil
+} // func (db *Database) TombstonePurge(before time.Time) (int64, error)
+
+// PeerSetSeen records that the Peer with the given name has seen our
+// Tombstones up to the given time.
+func (db *Database) PeerSetSeen(name string, seen time.Time) error {
+	const qid query.ID = query.PeerSetSeen
+	var (
+		err    error
+		msg    string
+		stmt   *sql.Stmt
+		tx     *sql.Tx
+		status bool
+	)
+
+	if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid.String(),
+			err.Error())
+		return err
+	} else if db.tx != nil {
+		tx = db.tx
+	} else {
+	BEGIN_AD_HOC:
+		if tx, err = db.db.Begin(); err != nil {
+			if worthARetry(err) {
+				waitForRetry()
+				goto BEGIN_AD_HOC
+			} else {
+				msg = fmt.Sprintf("Error starting transaction: %s",
+					err.Error())
+				db.log.Printf("[ERROR] %s\n", msg)
+				return errors.New(msg)
+			}
+
+		} else {
+			defer func() {
+				var err2 error
+				if status {
+					if err2 = tx.Commit(); err2 != nil {
+						db.log.Printf("[ERROR] Failed to commit ad-hoc transaction: %s\n",
+							err2.Error())
+					}
+				} else if err2 = tx.Rollback(); err2 != nil {
+					db.log.Printf("[ERROR] Rollback of ad-hoc transaction failed: %s\n",
+						err2.Error())
+				}
+			}()
+		}
+	}
+
+	stmt = tx.Stmt(stmt)
+
+EXEC_QUERY:
+	if _, err = stmt.Exec(name, seen.Unix()); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		} else {
+			err = fmt.Errorf("Cannot update Peer %s: %s",
+				name,
+				err.Error())
+			db.log.Printf("[ERROR] %s\n", err.Error())
+			return err
+		}
+	}
+
+	status = true
+	return nil
+} // func (db *Database) PeerSetSeen(name string, seen time.Time) error
+
+// PeerPurge forgets about all Peers we have not synchronized with since the
+// given time.
+func (db *Database) PeerPurge(before time.Time) error {
+	const qid query.ID = query.PeerPurge
+	var (
+		err    error
+		msg    string
+		stmt   *sql.Stmt
+		tx     *sql.Tx
+		status bool
+	)
+
+	if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid.String(),
+			err.Error())
+		return err
+	} else if db.tx != nil {
+		tx = db.tx
+	} else {
+	BEGIN_AD_HOC:
+		if tx, err = db.db.Begin(); err != nil {
+			if worthARetry(err) {
+				waitForRetry()
+				goto BEGIN_AD_HOC
+			} else {
+				msg = fmt.Sprintf("Error starting transaction: %s",
+					err.Error())
+				db.log.Printf("[ERROR] %s\n", msg)
+				return errors.New(msg)
+			}
+
+		} else {
+			defer func() {
+				var err2 error
+				if status {
+					if err2 = tx.Commit(); err2 != nil {
+						db.log.Printf("[ERROR] Failed to commit ad-hoc transaction: %s\n",
+							err2.Error())
+					}
+				} else if err2 = tx.Rollback(); err2 != nil {
+					db.log.Printf("[ERROR] Rollback of ad-hoc transaction failed: %s\n",
+						err2.Error())
+				}
+			}()
 		}
+	}
 
-		item.Timestamp = time.Unix(tstamp, 0)
-		if dstamp != nil {
-			item.Displayed = time.Unix(*dstamp, 0)
-		}
-		if astamp != nil {
-			item.Acknowledged = time.Unix(*astamp, 0)
-		}
+	stmt = tx.Stmt(stmt)
 
-		return item, nil
+EXEC_QUERY:
+	if _, err = stmt.Exec(before.Unix()); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		} else {
+			err = fmt.Errorf("Cannot purge Peers not seen since %s: %s",
+				before.Format(common.TimestampFormat),
+				err.Error())
+			db.log.Printf("[ERROR] %s\n", err.Error())
+			return err
+		}
 	}
 
-	return nil, nil
-} // func (db *Database) NotificationGetByID(id int64) (n *objects.Notification, error)
+	status = true
+	return nil
+} // func (db *Database) PeerPurge(before time.Time) error
 
-// NotificationGetByReminder fetches all Notifications stored for the given
-// Reminder, in reverse chronological order, up to the specified limit.
-// If the limit is a negative number, all Notifications will be fetched.
-func (db *Database) NotificationGetByReminder(r *objects.Reminder, max int) ([]objects.Notification, error) {
-	const qid query.ID = query.NotificationGetByReminder
+// TombstoneGetByUUID returns the Tombstone for the given UUID. If the
+// Reminder with that UUID has not been deleted, it returns nil.
+func (db *Database) TombstoneGetByUUID(uuid string) (*objects.Tombstone, error) {
+	const qid query.ID = query.TombstoneGetByUUID
 	var (
 		err  error
 		stmt *sql.Stmt
-		size int
 	)
 
-	if max > 0 {
-		size = max
-	} else {
-		size = 32
-	}
-
 	if stmt, err = db.getQuery(qid); err != nil {
 		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
 			qid,
@@ -2159,52 +6344,43 @@ func (db *Database) NotificationGetByReminder(r *objects.Reminder, max int) ([]o
 	var rows *sql.Rows
 
 EXEC_QUERY:
-	if rows, err = stmt.Query(r.ID, max); err != nil {
+	if rows, err = stmt.Query(uuid); err != nil {
 		if worthARetry(err) {
 			waitForRetry()
 			goto EXEC_QUERY
 		}
 
-		db.log.Printf("[ERROR] Failed to load all Reminders: %s\n",
+		db.log.Printf("[ERROR] Failed to load Tombstone for %s: %s\n",
+			uuid,
 			err.Error())
 		return nil, err
 	}
 
 	defer rows.Close() // nolint: errcheck,gosec
 
-	var items = make([]objects.Notification, 0, size)
-
-	for rows.Next() {
+	if rows.Next() {
 		var (
-			n              = objects.Notification{ReminderID: r.ID}
-			tstamp         int64
-			dstamp, astamp *int64
+			deleted, recorded int64
+			t                 = &objects.Tombstone{UUID: uuid}
 		)
 
-		if err = rows.Scan(&n.ID, &tstamp, &dstamp, &astamp); err != nil {
+		if err = rows.Scan(&t.ID, &deleted, &recorded); err != nil {
 			db.log.Printf("[ERROR] Cannot scan Row: %s\n",
 				err.Error())
 			return nil, err
 		}
 
-		n.Timestamp = time.Unix(tstamp, 0)
-		if dstamp != nil {
-			n.Displayed = time.Unix(*dstamp, 0)
-		}
-		if astamp != nil {
-			n.Acknowledged = time.Unix(*astamp, 0)
-		}
-
-		items = append(items, n)
+		t.Deleted = time.Unix(deleted, 0)
+		t.Recorded = time.Unix(recorded, 0)
+		return t, nil
 	}
 
-	return items, nil
-} // func (db *Database) NotificationGetByReminder(r *objects.Reminder) ([]objects.Notification, error)
+	return nil, nil
+} // func (db *Database) TombstoneGetByUUID(uuid string) (*objects.Tombstone, error)
 
-// NotificationGetByReminderStamp fetches a Notification by the given Reminder
-// and Timestamp.
-func (db *Database) NotificationGetByReminderStamp(r *objects.Reminder, t time.Time) (*objects.Notification, error) {
-	const qid query.ID = query.NotificationGetByReminderStamp
+// TombstoneGetAll returns all Tombstones, in the order they were recorded.
+func (db *Database) TombstoneGetAll() ([]objects.Tombstone, error) {
+	const qid query.ID = query.TombstoneGetAll
 	var (
 		err  error
 		stmt *sql.Stmt
@@ -2222,53 +6398,44 @@ func (db *Database) NotificationGetByReminderStamp(r *objects.Reminder, t time.T
 	var rows *sql.Rows
 
 EXEC_QUERY:
-	if rows, err = stmt.Query(r.ID, t.Unix()); err != nil {
+	if rows, err = stmt.Query(); err != nil {
 		if worthARetry(err) {
 			waitForRetry()
 			goto EXEC_QUERY
 		}
 
-		db.log.Printf("[ERROR] Failed to load all Reminders: %s\n",
+		db.log.Printf("[ERROR] Failed to load Tombstones: %s\n",
 			err.Error())
 		return nil, err
 	}
 
 	defer rows.Close() // nolint: errcheck,gosec
 
-	if rows.Next() {
+	var items = make([]objects.Tombstone, 0)
+
+	for rows.Next() {
 		var (
-			item = &objects.Notification{
-				ReminderID: r.ID,
-				Timestamp:  t,
-			}
-			tstamp         int64
-			dstamp, astamp *int64
+			deleted, recorded int64
+			t                 objects.Tombstone
 		)
 
-		if err = rows.Scan(&item.ID, &dstamp, &astamp); err != nil {
+		if err = rows.Scan(&t.ID, &t.UUID, &deleted, &recorded); err != nil {
 			db.log.Printf("[ERROR] Cannot scan Row: %s\n",
 				err.Error())
 			return nil, err
 		}
 
-		item.Timestamp = time.Unix(tstamp, 0)
-		if dstamp != nil {
-			item.Displayed = time.Unix(*dstamp, 0)
-		}
-		if astamp != nil {
-			item.Acknowledged = time.Unix(*astamp, 0)
-		}
-
-		return item, nil
+		t.Deleted = time.Unix(deleted, 0)
+		t.Recorded = time.Unix(recorded, 0)
+		items = append(items, t)
 	}
 
-	return nil, nil
-} // func (db *Database) NotificationGetByReminderStamp(r *objects.Reminder, t time.Time) (*objects.Notification, error)
+	return items, nil
+} // func (db *Database) TombstoneGetAll() ([]objects.Tombstone, error)
 
-// NotificationGetByReminderPending fetches all Notifications for the
-// given Reminder that have not been acknowledged.
-func (db *Database) NotificationGetByReminderPending(r *objects.Reminder) ([]objects.Notification, error) {
-	const qid query.ID = query.NotificationGetByReminderPending
+// PeerGetAll returns all the Peers we have synchronized with.
+func (db *Database) PeerGetAll() ([]objects.PeerState, error) {
+	const qid query.ID = query.PeerGetAll
 	var (
 		err  error
 		stmt *sql.Stmt
@@ -2286,49 +6453,157 @@ func (db *Database) NotificationGetByReminderPending(r *objects.Reminder) ([]obj
 	var rows *sql.Rows
 
 EXEC_QUERY:
-	if rows, err = stmt.Query(r.ID); err != nil {
+	if rows, err = stmt.Query(); err != nil {
 		if worthARetry(err) {
 			waitForRetry()
 			goto EXEC_QUERY
 		}
 
-		db.log.Printf("[ERROR] Failed to load all Reminders: %s\n",
+		db.log.Printf("[ERROR] Failed to load Peers: %s\n",
 			err.Error())
 		return nil, err
 	}
 
 	defer rows.Close() // nolint: errcheck,gosec
 
-	var items = make([]objects.Notification, 0, 32)
+	var items []objects.PeerState
 
 	for rows.Next() {
 		var (
-			n      = objects.Notification{ReminderID: r.ID}
-			tstamp int64
-			dstamp *int64
+			seen int64
+			p    objects.PeerState
 		)
 
-		if err = rows.Scan(&n.ID, &tstamp, &dstamp); err != nil {
+		if err = rows.Scan(&p.ID, &p.Name, &seen); err != nil {
 			db.log.Printf("[ERROR] Cannot scan Row: %s\n",
 				err.Error())
 			return nil, err
 		}
 
-		n.Timestamp = time.Unix(tstamp, 0)
-		if dstamp != nil {
-			n.Displayed = time.Unix(*dstamp, 0)
+		p.Seen = time.Unix(seen, 0)
+		items = append(items, p)
+	}
+
+	return items, nil
+} // func (db *Database) PeerGetAll() ([]objects.PeerState, error)
+
+// RevisionAdd records the given Revisions as a single operation and fills
+// in their IDs, the operation, the Peer and the time stamp. peer is the
+// Peer the changes came from, empty for local changes. If the operation
+// reverts an earlier one, undoes is the ID of the latter, otherwise 0.
+// It returns the ID of the operation, or 0 if there are no Revisions.
+// Undoing an operation is recorded even if there is nothing to change.
+func (db *Database) RevisionAdd(peer string, undoes int64, revs []objects.Revision) (int64, error) {
+	var (
+		err       error
+		msg       string
+		opQ, revQ *sql.Stmt
+		tx        *sql.Tx
+		res       sql.Result
+		op        int64
+		undoID    any
+		status    bool
+		now       = time.Now()
+	)
+
+	if len(revs) == 0 && undoes == 0 {
+		return 0, nil
+	} else if undoes != 0 {
+		undoID = undoes
+	}
+
+	if opQ, err = db.getQuery(query.RevisionOpAdd); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			query.RevisionOpAdd,
+			err.Error())
+		return 0, err
+	} else if revQ, err = db.getQuery(query.RevisionAdd); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			query.RevisionAdd,
+			err.Error())
+		return 0, err
+	} else if db.tx != nil {
+		tx = db.tx
+	} else {
+	BEGIN_AD_HOC:
+		if tx, err = db.db.Begin(); err != nil {
+			if worthARetry(err) {
+				waitForRetry()
+				goto BEGIN_AD_HOC
+			} else {
+				msg = fmt.Sprintf("Error starting transaction: %s",
+					err.Error())
+				db.log.Printf("[ERROR] %s\n", msg)
+				return 0, errors.New(msg)
+			}
 		}
 
-		items = append(items, n)
+		defer func() {
+			var err2 error
+			if status {
+				if err2 = tx.Commit(); err2 != nil {
+					db.log.Printf("[ERROR] Failed to commit ad-hoc transaction: %s\n",
+						err2.Error())
+				}
+			} else if err2 = tx.Rollback(); err2 != nil {
+				db.log.Printf("[ERROR] Rollback of ad-hoc transaction failed: %s\n",
+					err2.Error())
+			}
+		}()
 	}
 
-	return items, nil
-} // func (db *Database) NotificationGetByReminderPending(r *objects.Reminder) ([]objects.Notification, error)
+	opQ = tx.Stmt(opQ)
+	revQ = tx.Stmt(revQ)
 
-// NotificationGetPending fetches all Notifications that have not been
-// acknowledged, yet.
-func (db *Database) NotificationGetPending() ([]objects.Notification, error) {
-	const qid query.ID = query.NotificationGetPending
+EXEC_OP:
+	if res, err = opQ.Exec(now.Unix(), peer, undoID); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_OP
+		}
+
+		db.log.Printf("[ERROR] Cannot record operation: %s\n",
+			err.Error())
+		return 0, err
+	} else if op, err = res.LastInsertId(); err != nil {
+		db.log.Printf("[ERROR] Cannot get ID of operation: %s\n",
+			err.Error())
+		return 0, err
+	}
+
+	for i := range revs {
+		var rev = &revs[i]
+
+	EXEC_REV:
+		if res, err = revQ.Exec(op, rev.UUID, rev.Field, rev.Old, rev.New); err != nil {
+			if worthARetry(err) {
+				waitForRetry()
+				goto EXEC_REV
+			}
+
+			db.log.Printf("[ERROR] Cannot record change of %s on Reminder %s: %s\n",
+				rev.Field,
+				rev.UUID,
+				err.Error())
+			return 0, err
+		} else if rev.ID, err = res.LastInsertId(); err != nil {
+			db.log.Printf("[ERROR] Cannot get ID of Revision: %s\n",
+				err.Error())
+			return 0, err
+		}
+
+		rev.Op = op
+		rev.Peer = peer
+		rev.Stamp = now
+		rev.Undoes = undoes
+	}
+
+	status = true
+	return op, nil
+} // func (db *Database) RevisionAdd(peer string, undoes int64, revs []objects.Revision) (int64, error)
+
+// revisionGet runs one of the queries that load Revisions.
+func (db *Database) revisionGet(qid query.ID, arg any) ([]objects.Revision, error) {
 	var (
 		err  error
 		stmt *sql.Stmt
@@ -2346,125 +6621,128 @@ func (db *Database) NotificationGetPending() ([]objects.Notification, error) {
 	var rows *sql.Rows
 
 EXEC_QUERY:
-	if rows, err = stmt.Query(); err != nil {
+	if rows, err = stmt.Query(arg); err != nil {
 		if worthARetry(err) {
 			waitForRetry()
 			goto EXEC_QUERY
 		}
 
-		db.log.Printf("[ERROR] Failed to load all Reminders: %s\n",
+		db.log.Printf("[ERROR] Failed to load Revisions for %v: %s\n",
+			arg,
 			err.Error())
 		return nil, err
 	}
 
 	defer rows.Close() // nolint: errcheck,gosec
 
-	var items = make([]objects.Notification, 0, 32)
+	var items = make([]objects.Revision, 0)
 
 	for rows.Next() {
 		var (
-			n      objects.Notification
-			tstamp int64
-			dstamp *int64
+			stamp int64
+			rev   objects.Revision
 		)
 
-		if err = rows.Scan(&n.ID, &n.ReminderID, &tstamp, &dstamp); err != nil {
+		if err = rows.Scan(
+			&rev.ID,
+			&rev.Op,
+			&rev.UUID,
+			&rev.Peer,
+			&stamp,
+			&rev.Undoes,
+			&rev.Field,
+			&rev.Old,
+			&rev.New,
+		); err != nil {
 			db.log.Printf("[ERROR] Cannot scan Row: %s\n",
 				err.Error())
 			return nil, err
 		}
 
-		n.Timestamp = time.Unix(tstamp, 0)
-		if dstamp != nil {
-			n.Displayed = time.Unix(*dstamp, 0)
-		}
-
-		items = append(items, n)
+		rev.Stamp = time.Unix(stamp, 0)
+		items = append(items, rev)
 	}
 
 	return items, nil
-} // func (db *Database) NotificationGetPending() ([]objects.Notification, error)
+} // func (db *Database) revisionGet(qid query.ID, arg any) ([]objects.Revision, error)
 
-// NotificationCleanup removes stale notifications from the database
-// that are more than a week old and have either been acknowledged
-// at least a week ago or never displayed in the first place.
-func (db *Database) NotificationCleanup() (int64, error) {
-	const qid query.ID = query.NotificationCleanup
+// RevisionGetByID loads the Revision with the given ID. If there is no
+// such Revision, it returns nil.
+func (db *Database) RevisionGetByID(id int64) (*objects.Revision, error) {
 	var (
-		err    error
-		msg    string
-		stmt   *sql.Stmt
-		tx     *sql.Tx
-		status bool
+		err  error
+		revs []objects.Revision
+	)
+
+	if revs, err = db.revisionGet(query.RevisionGetByID, id); err != nil {
+		return nil, err
+	} else if len(revs) == 0 {
+		return nil, nil
+	}
+
+	return &revs[0], nil
+} // func (db *Database) RevisionGetByID(id int64) (*objects.Revision, error)
+
+// RevisionGetByUUID returns the Revisions of the Reminder with the given
+// UUID, oldest first.
+func (db *Database) RevisionGetByUUID(uuid string) ([]objects.Revision, error) {
+	return db.revisionGet(query.RevisionGetByUUID, uuid)
+} // func (db *Database) RevisionGetByUUID(uuid string) ([]objects.Revision, error)
+
+// RevisionGetByOp returns the Revisions that belong to the given
+// operation, in the order they were made.
+func (db *Database) RevisionGetByOp(op int64) ([]objects.Revision, error) {
+	return db.revisionGet(query.RevisionGetByOp, op)
+} // func (db *Database) RevisionGetByOp(op int64) ([]objects.Revision, error)
+
+// RevisionGetUndoable returns the IDs of the last max local operations
+// that can be undone, most recent first. Operations that undo another one,
+// and operations that have been undone already, do not count.
+func (db *Database) RevisionGetUndoable(max int) ([]int64, error) {
+	const qid query.ID = query.RevisionGetUndoable
+	var (
+		err  error
+		stmt *sql.Stmt
 	)
 
 	if stmt, err = db.getQuery(qid); err != nil {
 		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
-			qid.String(),
+			qid,
 			err.Error())
-		return 0, err
+		return nil, err
 	} else if db.tx != nil {
-		tx = db.tx
-	} else {
-	BEGIN_AD_HOC:
-		if tx, err = db.db.Begin(); err != nil {
-			if worthARetry(err) {
-				waitForRetry()
-				goto BEGIN_AD_HOC
-			} else {
-				msg = fmt.Sprintf("Error starting transaction: %s",
-					err.Error())
-				db.log.Printf("[ERROR] %s\n", msg)
-				return 0, errors.New(msg)
-			}
-
-		} else {
-			defer func() {
-				var err2 error
-				if status {
-					if err2 = tx.Commit(); err2 != nil {
-						db.log.Printf("[ERROR] Failed to commit ad-hoc transaction: %s\n",
-							err2.Error())
-					}
-				} else if err2 = tx.Rollback(); err2 != nil {
-					db.log.Printf("[ERROR] Rollback of ad-hoc transaction failed: %s\n",
-						err2.Error())
-				}
-			}()
-		}
+		stmt = db.tx.Stmt(stmt)
 	}
 
-	stmt = tx.Stmt(stmt)
 	var rows *sql.Rows
 
 EXEC_QUERY:
-	if rows, err = stmt.Query(); err != nil {
+	if rows, err = stmt.Query(max); err != nil {
 		if worthARetry(err) {
 			waitForRetry()
 			goto EXEC_QUERY
-		} else {
-			err = fmt.Errorf("Cannot clean up Notifications: %s",
-				err.Error())
-			db.log.Printf("[ERROR] %s\n", err.Error())
-			return 0, err
 		}
+
+		db.log.Printf("[ERROR] Failed to load operations to undo: %s\n",
+			err.Error())
+		return nil, err
 	}
 
-	defer rows.Close() // nolint: errcheck
-	var cnt int64
+	defer rows.Close() // nolint: errcheck,gosec
+
+	var ops = make([]int64, 0, max)
 
 	for rows.Next() {
-		var n int64
+		var op int64
 
-		if err = rows.Scan(&n); err != nil {
-			db.log.Printf("[ERROR] Cannot scan Counter from Rows: %s\n",
+		if err = rows.Scan(&op); err != nil {
+			db.log.Printf("[ERROR] Cannot scan Row: %s\n",
 				err.Error())
-			return 0, err
-		} else if n > 0 {
-			cnt += n
+			return nil, err
 		}
+
+		ops = append(ops, op)
 	}
 
-	status = true
-	return cnt, nil
-} // func (db *Database) NotificationCleanup() (int64, error)
+	return ops, nil
+} // func (db *Database) RevisionGetUndoable(max int) ([]int64, error)
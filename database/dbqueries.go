@@ -10,8 +10,8 @@ import "github.com/blicero/theseus/database/query"
 
 var dbQueries = map[query.ID]string{
 	query.ReminderAdd: `
-INSERT INTO reminder (title, description, due, repeat, weekdays, counter, counter_max, uuid, changed)
-VALUES               (    ?,           ?,   ?,      ?,        ?,       ?,           ?,    ?,       ?)
+INSERT INTO reminder (title, description, due, times, repeat, weekdays, mday, nth, fallback, month, period, rrule, tz, counter, counter_max, until, calendar, holidays, alerts, esc_interval, esc_threshold, esc_channel, priority, snooze, uuid, changed)
+VALUES               (    ?,           ?,   ?,     ?,      ?,        ?,    ?,   ?,        ?,     ?,      ?,     ?,  ?,       ?,           ?,     ?,        ?,        ?,      ?,            ?,             ?,           ?,        ?,      ?,    ?,       ?)
 `,
 	query.ReminderDelete: "DELETE FROM reminder WHERE id = ?",
 	query.ReminderGetPending: `
@@ -20,10 +20,27 @@ SELECT
     title,
     description,
     due,
+    times,
     repeat,
     weekdays,
+    mday,
+    nth,
+    fallback,
+    month,
+    period,
+    rrule,
+    tz,
     counter,
     counter_max,
+    until,
+    calendar,
+    holidays,
+    alerts,
+    esc_interval,
+    esc_threshold,
+    esc_channel,
+    priority,
+    snooze,
     uuid,
     changed
 FROM reminder
@@ -45,10 +62,27 @@ SELECT
     r.title,
     r.description,
     r.due,
+    r.times,
     r.repeat,
     r.weekdays,
+    r.mday,
+    r.nth,
+    r.fallback,
+    r.month,
+    r.period,
+    r.rrule,
+    r.tz,
     r.counter,
     r.counter_max,
+    r.until,
+    r.calendar,
+    r.holidays,
+    r.alerts,
+    r.esc_interval,
+    r.esc_threshold,
+    r.esc_channel,
+    r.priority,
+    r.snooze,
     r.uuid,
     r.changed
 FROM reminder r
@@ -62,15 +96,44 @@ SELECT
     title,
     description,
     due,
+    times,
     repeat,
     weekdays,
+    mday,
+    nth,
+    fallback,
+    month,
+    period,
+    rrule,
+    tz,
     counter,
     counter_max,
+    until,
+    calendar,
+    holidays,
+    alerts,
+    esc_interval,
+    esc_threshold,
+    esc_channel,
+    priority,
+    snooze,
     uuid,
     changed
 FROM reminder
 WHERE finished
 ORDER BY due, title
+`,
+	query.ReminderGetIDByUUID: "SELECT id FROM reminder WHERE uuid = ?",
+	query.ReminderSearch: `
+SELECT
+    rowid,
+    bm25(reminder_fts, 10.0, 1.0) AS rank,
+    highlight(reminder_fts, 0, ?, ?),
+    snippet(reminder_fts, 1, ?, ?, '…', 16)
+FROM reminder_fts
+WHERE reminder_fts MATCH ?
+ORDER BY rank
+LIMIT ?
 `,
 	query.ReminderGetAll: `
 SELECT
@@ -78,10 +141,27 @@ SELECT
     title,
     description,
     due,
+    times,
     repeat,
     weekdays,
+    mday,
+    nth,
+    fallback,
+    month,
+    period,
+    rrule,
+    tz,
     counter,
     counter_max,
+    until,
+    calendar,
+    holidays,
+    alerts,
+    esc_interval,
+    esc_threshold,
+    esc_channel,
+    priority,
+    snooze,
     finished,
     uuid,
     changed
@@ -93,10 +173,27 @@ SELECT
     title,
     description,
     due,
+    times,
     repeat,
     weekdays,
+    mday,
+    nth,
+    fallback,
+    month,
+    period,
+    rrule,
+    tz,
     counter,
     counter_max,
+    until,
+    calendar,
+    holidays,
+    alerts,
+    esc_interval,
+    esc_threshold,
+    esc_channel,
+    priority,
+    snooze,
     finished,
     uuid,
     changed
@@ -122,6 +219,10 @@ WHERE id = ?`,
 	query.ReminderReactivate: `
 UPDATE reminder
 SET finished = 0, due = ?, changed = ?
+WHERE id = ?`,
+	query.ReminderSetTimeZone: `
+UPDATE reminder
+SET tz = ?, changed = ?
 WHERE id = ?`,
 	query.ReminderSetRepeat: `
 UPDATE reminder
@@ -133,18 +234,64 @@ UPDATE reminder
 SET weekdays = ?, changed = ?
 WHERE id = ?
 `,
-	query.ReminderSetLimit: `
+	query.ReminderSetRecurrence: `
 UPDATE reminder
 SET
-    counter_max = ?,
-    counter = MIN(counter, counter_max),
-    changed = ?
+    due = ?1,
+    repeat = ?2,
+    weekdays = ?3,
+    mday = ?4,
+    nth = ?5,
+    fallback = ?6,
+    month = ?7,
+    period = ?8,
+    rrule = ?9,
+    counter_max = ?10,
+    counter = CASE WHEN ?10 = 0 THEN counter ELSE MIN(counter, ?10) END,
+    until = ?11,
+    times = ?12,
+    calendar = ?13,
+    holidays = ?14,
+    changed = ?15
+WHERE id = ?16
+`,
+	query.ReminderSetAlerts: `
+UPDATE reminder
+SET alerts = ?, changed = ?
+WHERE id = ?
+`,
+	query.ReminderSetEscalation: `
+UPDATE reminder
+SET esc_interval = ?, esc_threshold = ?, esc_channel = ?, changed = ?
+WHERE id = ?
+`,
+	query.ReminderSetPriority: `
+UPDATE reminder
+SET priority = ?, changed = ?
+WHERE id = ?
+`,
+	query.ReminderSetSnooze: `
+UPDATE reminder
+SET snooze = ?, changed = ?
 WHERE id = ?
+`,
+	query.ReminderSetLimit: `
+UPDATE reminder
+SET
+    counter_max = ?1,
+    counter = CASE WHEN ?1 = 0 THEN counter ELSE MIN(counter, ?1) END,
+    changed = ?2
+WHERE id = ?3
 `,
 	query.ReminderResetCounter: `
 UPDATE reminder
 SET counter = 0, changed = ?
 WHERE id = ?
+`,
+	query.ReminderSetCounter: `
+UPDATE reminder
+SET counter = ?, changed = ?
+WHERE id = ?
 `,
 	query.ReminderIncCounter: `
 UPDATE reminder
@@ -158,37 +305,76 @@ SET changed = ?
 WHERE id = ?
 `,
 	query.NotificationAdd: `
-INSERT INTO notification (reminder_id, timestamp)
-                  VALUES (          ?,         ?)
+INSERT INTO notification (reminder_id, timestamp, lead)
+                  VALUES (          ?,         ?,    ?)
 ON CONFLICT DO NOTHING
 `,
 	query.NotificationDisplay: `
 UPDATE notification
-SET displayed = ?
+SET displayed = ?, shown = COALESCE(shown, ?)
 WHERE id = ?
 `,
 	query.NotificationAcknowledge: `
 UPDATE notification
 SET acknowledged = ?
 WHERE id = ?
+`,
+	query.NotificationSkipStale: `
+UPDATE notification
+SET displayed = COALESCE(displayed, ?), acknowledged = ?, skipped = 1
+WHERE reminder_id = ? AND acknowledged IS NULL AND timestamp + lead < ?
 `,
 	query.NotificationGetByReminder: `
 SELECT
     id,
     timestamp,
     displayed,
-    acknowledged
+    acknowledged,
+    lead,
+    step,
+    deferred
 FROM notification
 WHERE reminder_id = ?
 ORDER BY timestamp
 LIMIT ?
+`,
+	query.NotificationGetByRange: `
+SELECT
+    id,
+    reminder_id,
+    timestamp,
+    displayed,
+    acknowledged,
+    lead,
+    step,
+    deferred
+FROM notification
+WHERE timestamp >= ? AND timestamp < ?
+ORDER BY timestamp, reminder_id
+`,
+	query.NotificationGetHistory: `
+SELECT
+    id,
+    timestamp,
+    displayed,
+    acknowledged,
+    step,
+    deferred,
+    shown,
+    skipped
+FROM notification
+WHERE reminder_id = ? AND lead = 0 AND timestamp >= ?
+ORDER BY timestamp
 `,
 	query.NotificationGetByID: `
 SELECT
     reminder_id,
     timestamp,
     displayed,
-    acknowledged
+    acknowledged,
+    lead,
+    step,
+    deferred
 FROM notification
 WHERE id = ?
 `,
@@ -198,23 +384,43 @@ SELECT
     displayed,
     acknowledged
 FROM notification
-WHERE reminder_id = ? AND timestamp = ?
+WHERE reminder_id = ? AND timestamp = ? AND lead = ?
 `,
 	query.NotificationGetByReminderPending: `
 SELECT
     id,
     timestamp,
-    displayed
+    displayed,
+    lead,
+    step,
+    deferred
 FROM notification
 WHERE reminder_id = ? AND acknowledged IS NULL
 ORDER BY timestamp
 `,
+	query.NotificationGetDeferred: `
+SELECT
+    id,
+    reminder_id,
+    timestamp,
+    displayed,
+    lead,
+    step,
+    deferred
+FROM notification
+WHERE acknowledged IS NULL AND deferred IS NOT NULL
+ORDER BY timestamp
+`,
+	query.NotificationSetDeferred: "UPDATE notification SET deferred = ? WHERE id = ?",
 	query.NotificationGetPending: `
 SELECT
     id,
     reminder_id,
     timestamp,
-    displayed
+    displayed,
+    lead,
+    step,
+    deferred
 FROM notification
 WHERE acknowledged IS NULL
 ORDER BY timestamp
@@ -225,5 +431,291 @@ WHERE (timestamp < unixepoch() - 86400 * 7) AND
       ((acknowledged < unixepoch() - 86400 * 7) OR
        (displayed IS NULL AND acknowledged IS NULL))
 RETURNING 1
+`,
+	query.NotificationDelete: "DELETE FROM notification WHERE id = ?",
+	query.NotificationSetStep: `
+UPDATE notification
+SET step = ?
+WHERE id = ?
+`,
+	query.SnoozeAdd: `
+INSERT INTO snooze (reminder_id, occurrence, timestamp, until)
+            VALUES (          ?,          ?,         ?,     ?)
+`,
+	query.SnoozeGetByReminder: `
+SELECT
+    id,
+    occurrence,
+    timestamp,
+    until
+FROM snooze
+WHERE reminder_id = ? AND timestamp >= ?
+ORDER BY timestamp
+`,
+	query.TombstoneAdd: `
+INSERT INTO tombstone (uuid, deleted, recorded)
+               VALUES (   ?,       ?,        ?)
+ON CONFLICT (uuid) DO UPDATE
+SET deleted = excluded.deleted,
+    recorded = excluded.recorded
+WHERE excluded.deleted > tombstone.deleted
+`,
+	query.TombstoneDelete: "DELETE FROM tombstone WHERE uuid = ?",
+	query.TombstoneGetByUUID: `
+SELECT
+    id,
+    deleted,
+    recorded
+FROM tombstone
+WHERE uuid = ?
+`,
+	query.TombstoneGetAll: `
+SELECT
+    id,
+    uuid,
+    deleted,
+    recorded
+FROM tombstone
+ORDER BY recorded
+`,
+	query.TombstonePurge: "DELETE FROM tombstone WHERE recorded < ?",
+	query.PeerSetSeen: `
+INSERT INTO peer (name, seen) VALUES (?, ?)
+ON CONFLICT (name) DO UPDATE
+SET seen = excluded.seen
+WHERE excluded.seen > peer.seen
+`,
+	query.PeerGetAll: "SELECT id, name, seen FROM peer ORDER BY name",
+	query.PeerPurge:  "DELETE FROM peer WHERE seen < ?",
+	query.RevisionOpAdd: `
+INSERT INTO revision_op (timestamp, peer, undoes)
+                 VALUES (        ?,    ?,      ?)
+`,
+	query.RevisionAdd: `
+INSERT INTO revision (op_id, uuid, field, old, new)
+              VALUES (    ?,    ?,     ?,   ?,   ?)
+`,
+	query.RevisionGetByID: `
+SELECT
+    r.id,
+    r.op_id,
+    r.uuid,
+    o.peer,
+    o.timestamp,
+    COALESCE(o.undoes, 0),
+    r.field,
+    r.old,
+    r.new
+FROM revision r
+INNER JOIN revision_op o ON r.op_id = o.id
+WHERE r.id = ?
+`,
+	query.RevisionGetByUUID: `
+SELECT
+    r.id,
+    r.op_id,
+    r.uuid,
+    o.peer,
+    o.timestamp,
+    COALESCE(o.undoes, 0),
+    r.field,
+    r.old,
+    r.new
+FROM revision r
+INNER JOIN revision_op o ON r.op_id = o.id
+WHERE r.uuid = ?
+ORDER BY r.id
+`,
+	query.RevisionGetByOp: `
+SELECT
+    r.id,
+    r.op_id,
+    r.uuid,
+    o.peer,
+    o.timestamp,
+    COALESCE(o.undoes, 0),
+    r.field,
+    r.old,
+    r.new
+FROM revision r
+INNER JOIN revision_op o ON r.op_id = o.id
+WHERE r.op_id = ?
+ORDER BY r.id
+`,
+	query.RevisionGetUndoable: `
+SELECT o.id
+FROM revision_op o
+WHERE o.peer = ''
+  AND o.undoes IS NULL
+  AND NOT EXISTS (SELECT 1 FROM revision_op u WHERE u.undoes = o.id)
+ORDER BY o.id DESC
+LIMIT ?
+`,
+	query.EscalationAdd: `
+INSERT INTO escalation (notification_id, step, timestamp, urgency, channel)
+                VALUES (              ?,    ?,         ?,       ?,       ?)
+`,
+	query.EscalationGetByNotification: `
+SELECT
+    id,
+    step,
+    timestamp,
+    urgency,
+    channel
+FROM escalation
+WHERE notification_id = ?
+ORDER BY step
+`,
+	query.ExceptionAdd: `
+INSERT INTO exception (reminder_id, occurrence, due, changed)
+VALUES                (          ?,          ?,   ?,       ?)
+ON CONFLICT (reminder_id, occurrence) DO
+UPDATE SET due = excluded.due, changed = excluded.changed
+RETURNING id
+`,
+	query.ExceptionDelete: "DELETE FROM exception WHERE id = ?",
+	query.ExceptionGetByReminder: `
+SELECT
+    id,
+    occurrence,
+    due,
+    changed
+FROM exception
+WHERE reminder_id = ?
+ORDER BY occurrence
+`,
+	query.ExceptionGetAll: `
+SELECT
+    id,
+    reminder_id,
+    occurrence,
+    due,
+    changed
+FROM exception
+ORDER BY reminder_id, occurrence
+`,
+	query.SettingGet: "SELECT value FROM setting WHERE key = ?",
+	query.SettingSet: `
+INSERT INTO setting (key, value, changed)
+VALUES (?, ?, ?)
+ON CONFLICT (key) DO UPDATE
+SET value = excluded.value, changed = excluded.changed
+`,
+	query.TagSet: `
+INSERT INTO tag (name, priority, channel, quiet_exempt, changed)
+VALUES (?, ?, ?, ?, ?)
+ON CONFLICT (name) DO UPDATE
+SET priority = excluded.priority,
+    channel = excluded.channel,
+    quiet_exempt = excluded.quiet_exempt,
+    changed = excluded.changed
+RETURNING id
+`,
+	query.TagEnsure: `
+INSERT INTO tag (name, changed)
+VALUES (?, ?)
+ON CONFLICT (name) DO NOTHING
+`,
+	query.TagDelete: "DELETE FROM tag WHERE name = ?",
+	query.TagTouchReminders: `
+UPDATE reminder
+SET changed = ?
+WHERE id IN (SELECT l.reminder_id
+             FROM tag_link l
+             INNER JOIN tag t ON l.tag_id = t.id
+             WHERE t.name = ?)
+`,
+	query.TagGetAll: `
+SELECT
+    id,
+    name,
+    priority,
+    channel,
+    quiet_exempt
+FROM tag
+ORDER BY name
+`,
+	query.TagLinkClear: "DELETE FROM tag_link WHERE reminder_id = ?",
+	query.TagLinkAdd: `
+INSERT INTO tag_link (tag_id, reminder_id)
+SELECT id, ? FROM tag WHERE name = ?
+`,
+	query.TagLinkGetAll: `
+SELECT
+    l.reminder_id,
+    t.name
+FROM tag_link l
+INNER JOIN tag t ON l.tag_id = t.id
+ORDER BY l.reminder_id, t.name
+`,
+	query.TagLinkGetByReminder: `
+SELECT
+    t.name
+FROM tag_link l
+INNER JOIN tag t ON l.tag_id = t.id
+WHERE l.reminder_id = ?
+ORDER BY t.name
+`,
+	query.ChecklistClear: "DELETE FROM checklist WHERE reminder_id = ?",
+	query.ChecklistAdd: `
+INSERT INTO checklist (reminder_id, position, text, done)
+VALUES (?, ?, ?, ?)
+`,
+	query.ChecklistSetDone: `
+UPDATE checklist
+SET done = ?
+WHERE reminder_id = ? AND position = ?
+`,
+	query.ChecklistGetAll: `
+SELECT
+    reminder_id,
+    text,
+    done
+FROM checklist
+ORDER BY reminder_id, position
+`,
+	query.ChecklistGetByReminder: `
+SELECT
+    text,
+    done
+FROM checklist
+WHERE reminder_id = ?
+ORDER BY position
+`,
+	query.DependencyClear: "DELETE FROM dependency WHERE reminder_id = ?",
+	query.DependencyAdd: `
+INSERT INTO dependency (reminder_id, prereq, kind, delay, triggered)
+                VALUES (          ?,      ?,    ?,     ?,         ?)
+`,
+	query.DependencyTrigger: `
+UPDATE dependency
+SET triggered = ?
+WHERE reminder_id = ? AND prereq = ?
+`,
+	query.DependencyGetAll: `
+SELECT
+    reminder_id,
+    prereq,
+    kind,
+    delay,
+    triggered
+FROM dependency
+ORDER BY reminder_id, id
+`,
+	query.DependencyGetByReminder: `
+SELECT
+    prereq,
+    kind,
+    delay,
+    triggered
+FROM dependency
+WHERE reminder_id = ?
+ORDER BY id
+`,
+	query.DependencyGetFollowUps: `
+SELECT reminder_id
+FROM dependency
+WHERE prereq = ? AND kind = 1
+ORDER BY reminder_id
 `,
 }
@@ -0,0 +1,181 @@
+// /home/krylon/go/src/github.com/blicero/theseus/database/fts.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 17. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <>
+
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"strings"
+	"unicode"
+)
+
+// The full-text index over the titles and descriptions of Reminders uses
+// the FTS5 extension of SQLite, which go-sqlite3 only includes when built
+// with the sqlite_fts5 tag. The index is derived from the reminder table,
+// so it is not part of the versioned schema: Whenever we open a database
+// with a library that supports FTS5, we make sure the index and the
+// triggers that keep it up to date are there. Rebuilding the reminder
+// table during a migration drops the triggers, in that case we re-create
+// them and rebuild the index.
+
+// ErrNoFTS is returned when searching a database while the SQLite library
+// does not support FTS5.
+var ErrNoFTS = errors.New("full-text search is not available, build with the sqlite_fts5 tag to enable it")
+
+var ftsQueries = []string{
+	`
+CREATE VIRTUAL TABLE IF NOT EXISTS reminder_fts USING fts5 (
+    title,
+    description,
+    content = 'reminder',
+    content_rowid = 'id',
+    tokenize = 'unicode61 remove_diacritics 2'
+)
+`,
+	`
+CREATE TRIGGER IF NOT EXISTS reminder_fts_add AFTER INSERT ON reminder
+BEGIN
+    INSERT INTO reminder_fts (rowid, title, description)
+    VALUES (new.id, new.title, new.description);
+END
+`,
+	`
+CREATE TRIGGER IF NOT EXISTS reminder_fts_del AFTER DELETE ON reminder
+BEGIN
+    INSERT INTO reminder_fts (reminder_fts, rowid, title, description)
+    VALUES ('delete', old.id, old.title, old.description);
+END
+`,
+	`
+CREATE TRIGGER IF NOT EXISTS reminder_fts_upd AFTER UPDATE OF title, description ON reminder
+BEGIN
+    INSERT INTO reminder_fts (reminder_fts, rowid, title, description)
+    VALUES ('delete', old.id, old.title, old.description);
+    INSERT INTO reminder_fts (rowid, title, description)
+    VALUES (new.id, new.title, new.description);
+END
+`,
+	"INSERT INTO reminder_fts (reminder_fts) VALUES ('rebuild')",
+}
+
+// ftsObjects is the number of tables and triggers created by ftsQueries.
+const ftsObjects = 4
+
+// ensureFTS creates the full-text index if the SQLite library supports it
+// and the index or any of its triggers is missing.
+func (db *Database) ensureFTS() error {
+	var (
+		err error
+		cnt int
+		tx  *sql.Tx
+	)
+
+	if err = db.db.QueryRow("SELECT sqlite_compileoption_used('ENABLE_FTS5')").Scan(&cnt); err != nil {
+		db.log.Printf("[ERROR] Cannot check if SQLite supports FTS5: %s\n",
+			err.Error())
+		return err
+	} else if cnt == 0 {
+		db.log.Printf("[INFO] SQLite was built without FTS5, full-text search is not available\n")
+		return nil
+	} else if err = db.db.QueryRow(
+		"SELECT COUNT(*) FROM sqlite_master WHERE name IN ('reminder_fts', 'reminder_fts_add', 'reminder_fts_del', 'reminder_fts_upd')").Scan(&cnt); err != nil {
+		db.log.Printf("[ERROR] Cannot check for full-text index: %s\n",
+			err.Error())
+		return err
+	} else if cnt == ftsObjects {
+		db.fts = true
+		return nil
+	}
+
+	db.log.Printf("[INFO] Build full-text index in %s\n", db.path)
+
+	if tx, err = db.db.Begin(); err != nil {
+		db.log.Printf("[ERROR] Cannot begin transaction: %s\n",
+			err.Error())
+		return err
+	}
+
+	for _, q := range ftsQueries {
+		if _, err = tx.Exec(q); err != nil {
+			db.log.Printf("[ERROR] Cannot execute query to build full-text index: %s\n%s\n",
+				err.Error(),
+				q)
+			if rbErr := tx.Rollback(); rbErr != nil {
+				db.log.Printf("[CANTHAPPEN] Cannot rollback transaction: %s\n",
+					rbErr.Error())
+				return rbErr
+			}
+			return err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		db.log.Printf("[ERROR] Cannot commit transaction: %s\n",
+			err.Error())
+		return err
+	}
+
+	db.fts = true
+	return nil
+} // func (db *Database) ensureFTS() error
+
+// ftsQuery turns what the user typed into a query for the full-text index.
+// Words are matched as prefixes, so "dent" finds "dentist", text in double
+// quotes is matched as a phrase, "call the dentist", unless it is followed
+// by an asterisk, which makes the last word a prefix, too. A Reminder
+// matches only if it matches all words and phrases. Everything else,
+// FTS5 operators included, is taken literally.
+func ftsQuery(input string) string {
+	var (
+		terms []string
+		rest  = input
+	)
+
+	for {
+		var (
+			term   string
+			prefix bool
+		)
+
+		if rest = strings.TrimLeftFunc(rest, unicode.IsSpace); rest == "" {
+			break
+		} else if rest[0] == '"' {
+			var end int
+
+			if end = strings.IndexByte(rest[1:], '"'); end < 0 {
+				// An unterminated phrase, the user may still be typing.
+				term, rest, prefix = rest[1:], "", true
+			} else {
+				term, rest = rest[1:end+1], rest[end+2:]
+				if strings.HasPrefix(rest, "*") {
+					rest, prefix = rest[1:], true
+				}
+			}
+		} else {
+			var end = strings.IndexFunc(rest, func(c rune) bool { return unicode.IsSpace(c) || c == '"' })
+
+			if end < 0 {
+				end = len(rest)
+			}
+
+			term, rest, prefix = strings.TrimRight(rest[:end], "*"), rest[end:], true
+		}
+
+		if strings.IndexFunc(term, func(c rune) bool { return unicode.IsLetter(c) || unicode.IsDigit(c) }) < 0 {
+			continue
+		}
+
+		term = `"` + strings.ReplaceAll(term, `"`, `""`) + `"`
+		if prefix {
+			term += "*"
+		}
+
+		terms = append(terms, term)
+	}
+
+	return strings.Join(terms, " ")
+} // func ftsQuery(input string) string
@@ -13,19 +13,49 @@ CREATE TABLE reminder (
     title       TEXT NOT NULL,
     description TEXT NOT NULL DEFAULT '',
     due         INTEGER NOT NULL,
+    times       TEXT NOT NULL DEFAULT '',
     finished    INTEGER NOT NULL DEFAULT 0,
     repeat      INTEGER NOT NULL DEFAULT 0,
     weekdays    INTEGER NOT NULL DEFAULT 0,
+    mday        INTEGER NOT NULL DEFAULT 0,
+    nth         INTEGER NOT NULL DEFAULT 0,
+    fallback    INTEGER NOT NULL DEFAULT 0,
+    month       INTEGER NOT NULL DEFAULT 0,
+    period      INTEGER NOT NULL DEFAULT 0,
+    rrule       TEXT NOT NULL DEFAULT '',
+    tz          TEXT NOT NULL DEFAULT '',
     counter     INTEGER NOT NULL DEFAULT 0,
     counter_max INTEGER NOT NULL DEFAULT 0,
+    until       INTEGER NOT NULL DEFAULT 0,
+    calendar    TEXT NOT NULL DEFAULT '',
+    holidays    INTEGER NOT NULL DEFAULT 0,
+    alerts      TEXT NOT NULL DEFAULT '',
+    esc_interval  INTEGER NOT NULL DEFAULT 0,
+    esc_threshold INTEGER NOT NULL DEFAULT 0,
+    esc_channel   INTEGER NOT NULL DEFAULT 0,
+    priority    INTEGER NOT NULL DEFAULT 0,
+    snooze      TEXT NOT NULL DEFAULT '',
     uuid        TEXT UNIQUE NOT NULL,
     changed     INTEGER NOT NULL DEFAULT 0,
     UNIQUE (title, due),
     -- CHECK (due > 1656624376), -- 2022-06-30, ~23:26
-    CHECK ((repeat = 0 AND due > 1656624376)
-           OR ((repeat = 1 OR repeat = 2) AND
+    CHECK ((repeat IN (0, 6, 7) AND due > 1656624376)
+           OR ((repeat BETWEEN 1 AND 5) AND
                (due BETWEEN 0 AND 86400))),
-    CHECK (counter >= 0 AND counter_max >= 0 AND counter <= counter_max)
+    CHECK (repeat <> 6 OR period >= 60),
+    CHECK (repeat <> 7 OR rrule <> ''),
+    CHECK (repeat <> 3 OR mday BETWEEN 1 AND 31),
+    CHECK (repeat <> 4 OR ((nth BETWEEN 1 AND 5 OR nth = -1) AND weekdays <> 0)),
+    CHECK (repeat <> 5 OR (month BETWEEN 1 AND 12 AND mday BETWEEN 1 AND 31)),
+    CHECK (fallback IN (0, 1)),
+    CHECK (counter >= 0 AND counter_max >= 0),
+    CHECK (counter_max = 0 OR counter <= counter_max),
+    CHECK (until >= 0),
+    CHECK (holidays IN (0, 1, 2)),
+    CHECK (esc_interval = 0 OR esc_interval >= 60),
+    CHECK (esc_threshold >= 0),
+    CHECK (esc_channel IN (0, 1)),
+    CHECK (priority BETWEEN -1 AND 2)
 
 ) STRICT
 `,
@@ -33,6 +63,7 @@ CREATE TABLE reminder (
 	"CREATE INDEX reminder_finished_idx ON reminder (finished)",
 	"CREATE INDEX reminder_uuid_idx ON reminder (uuid)",
 	"CREATE INDEX reminder_changed_idx ON reminder (changed)",
+	"CREATE INDEX reminder_priority_idx ON reminder (priority)",
 
 	`
 CREATE TABLE notification (
@@ -41,7 +72,14 @@ CREATE TABLE notification (
     timestamp		INTEGER NOT NULL,
     displayed		INTEGER,
     acknowledged	INTEGER,
-    UNIQUE (reminder_id, timestamp),
+    lead		INTEGER NOT NULL DEFAULT 0,
+    step		INTEGER NOT NULL DEFAULT 0,
+    deferred		INTEGER,
+    shown		INTEGER,
+    skipped		INTEGER NOT NULL DEFAULT 0,
+    UNIQUE (reminder_id, timestamp, lead),
+    CHECK (lead >= 0),
+    CHECK (skipped IN (0, 1)),
     CHECK (NOT (displayed IS NULL AND acknowledged IS NOT NULL)),
     FOREIGN KEY (reminder_id) REFERENCES reminder (id)
         ON UPDATE RESTRICT
@@ -51,4 +89,184 @@ CREATE TABLE notification (
 	"CREATE INDEX rec_rem_idx ON notification (reminder_id)",
 	"CREATE INDEX rec_time_idx ON notification (timestamp)",
 	"CREATE INDEX rec_ack_idx ON notification (acknowledged)",
+
+	`
+CREATE TABLE escalation (
+    id              INTEGER PRIMARY KEY,
+    notification_id INTEGER NOT NULL,
+    step            INTEGER NOT NULL,
+    timestamp       INTEGER NOT NULL,
+    urgency         INTEGER NOT NULL,
+    channel         INTEGER NOT NULL,
+    UNIQUE (notification_id, step),
+    CHECK (step > 0),
+    CHECK (urgency IN (0, 1, 2)),
+    CHECK (channel IN (0, 1)),
+    FOREIGN KEY (notification_id) REFERENCES notification (id)
+        ON UPDATE RESTRICT
+        ON DELETE CASCADE
+) STRICT
+`,
+	"CREATE INDEX esc_not_idx ON escalation (notification_id)",
+
+	`
+CREATE TABLE snooze (
+    id          INTEGER PRIMARY KEY,
+    reminder_id INTEGER NOT NULL,
+    occurrence  INTEGER NOT NULL,
+    timestamp   INTEGER NOT NULL,
+    until       INTEGER NOT NULL,
+    FOREIGN KEY (reminder_id) REFERENCES reminder (id)
+        ON UPDATE RESTRICT
+        ON DELETE CASCADE
+) STRICT
+`,
+	"CREATE INDEX snooze_rem_idx ON snooze (reminder_id)",
+
+	`
+CREATE TABLE exception (
+    id          INTEGER PRIMARY KEY,
+    reminder_id INTEGER NOT NULL,
+    occurrence  INTEGER NOT NULL,
+    due         INTEGER,
+    changed     INTEGER NOT NULL DEFAULT 0,
+    UNIQUE (reminder_id, occurrence),
+    FOREIGN KEY (reminder_id) REFERENCES reminder (id)
+        ON UPDATE RESTRICT
+        ON DELETE CASCADE
+) STRICT
+`,
+	"CREATE INDEX exc_rem_idx ON exception (reminder_id)",
+
+	`
+CREATE TABLE setting (
+    key     TEXT PRIMARY KEY,
+    value   TEXT NOT NULL,
+    changed INTEGER NOT NULL DEFAULT 0
+) STRICT
+`,
+
+	`
+CREATE TABLE tag (
+    id           INTEGER PRIMARY KEY,
+    name         TEXT UNIQUE NOT NULL,
+    priority     INTEGER NOT NULL DEFAULT 0,
+    channel      INTEGER NOT NULL DEFAULT 0,
+    quiet_exempt INTEGER NOT NULL DEFAULT 0,
+    changed      INTEGER NOT NULL DEFAULT 0,
+    CHECK (name <> '' AND name = lower(name)),
+    CHECK (priority BETWEEN -1 AND 2),
+    CHECK (channel IN (0, 1)),
+    CHECK (quiet_exempt IN (0, 1))
+) STRICT
+`,
+
+	`
+CREATE TABLE tag_link (
+    id          INTEGER PRIMARY KEY,
+    tag_id      INTEGER NOT NULL,
+    reminder_id INTEGER NOT NULL,
+    UNIQUE (tag_id, reminder_id),
+    FOREIGN KEY (tag_id) REFERENCES tag (id)
+        ON UPDATE RESTRICT
+        ON DELETE CASCADE,
+    FOREIGN KEY (reminder_id) REFERENCES reminder (id)
+        ON UPDATE RESTRICT
+        ON DELETE CASCADE
+) STRICT
+`,
+	"CREATE INDEX tag_link_tag_idx ON tag_link (tag_id)",
+	"CREATE INDEX tag_link_rem_idx ON tag_link (reminder_id)",
+
+	`
+CREATE TABLE checklist (
+    id          INTEGER PRIMARY KEY,
+    reminder_id INTEGER NOT NULL,
+    position    INTEGER NOT NULL,
+    text        TEXT NOT NULL,
+    done        INTEGER NOT NULL DEFAULT 0,
+    UNIQUE (reminder_id, position),
+    CHECK (position >= 0),
+    CHECK (text <> ''),
+    CHECK (done IN (0, 1)),
+    FOREIGN KEY (reminder_id) REFERENCES reminder (id)
+        ON UPDATE RESTRICT
+        ON DELETE CASCADE
+) STRICT
+`,
+	"CREATE INDEX checklist_rem_idx ON checklist (reminder_id)",
+
+	`
+CREATE TABLE dependency (
+    id          INTEGER PRIMARY KEY,
+    reminder_id INTEGER NOT NULL,
+    prereq      TEXT NOT NULL,
+    kind        INTEGER NOT NULL DEFAULT 0,
+    delay       INTEGER NOT NULL DEFAULT 0,
+    triggered   INTEGER NOT NULL DEFAULT 0,
+    UNIQUE (reminder_id, prereq),
+    CHECK (kind IN (0, 1)),
+    CHECK (delay >= 0),
+    FOREIGN KEY (reminder_id) REFERENCES reminder (id)
+        ON UPDATE RESTRICT
+        ON DELETE CASCADE
+) STRICT
+`,
+	"CREATE INDEX dep_rem_idx ON dependency (reminder_id)",
+	"CREATE INDEX dep_prereq_idx ON dependency (prereq)",
+
+	`
+CREATE TABLE tombstone (
+    id          INTEGER PRIMARY KEY,
+    uuid        TEXT UNIQUE NOT NULL,
+    deleted     INTEGER NOT NULL,
+    recorded    INTEGER NOT NULL
+) STRICT
+`,
+	"CREATE INDEX tombstone_rec_idx ON tombstone (recorded)",
+
+	`
+CREATE TABLE peer (
+    id          INTEGER PRIMARY KEY,
+    name        TEXT UNIQUE NOT NULL,
+    seen        INTEGER NOT NULL,
+    CHECK (name <> '')
+) STRICT
+`,
+
+	`
+CREATE TABLE revision_op (
+    id          INTEGER PRIMARY KEY,
+    timestamp   INTEGER NOT NULL,
+    peer        TEXT NOT NULL DEFAULT '',
+    undoes      INTEGER,
+    FOREIGN KEY (undoes) REFERENCES revision_op (id)
+        ON UPDATE RESTRICT
+        ON DELETE RESTRICT
+) STRICT
+`,
+	"CREATE INDEX rev_op_undo_idx ON revision_op (undoes)",
+
+	`
+CREATE TABLE revision (
+    id          INTEGER PRIMARY KEY,
+    op_id       INTEGER NOT NULL,
+    uuid        TEXT NOT NULL,
+    field       TEXT NOT NULL,
+    old         TEXT NOT NULL,
+    new         TEXT NOT NULL,
+    FOREIGN KEY (op_id) REFERENCES revision_op (id)
+        ON UPDATE RESTRICT
+        ON DELETE CASCADE
+) STRICT
+`,
+	"CREATE INDEX rev_uuid_idx ON revision (uuid)",
+	"CREATE INDEX rev_op_idx ON revision (op_id)",
+
+	`
+CREATE TABLE schema_version (
+    version     INTEGER PRIMARY KEY,
+    timestamp   INTEGER NOT NULL
+) STRICT
+`,
 }
@@ -0,0 +1,648 @@
+// /home/krylon/go/src/github.com/blicero/theseus/database/migrate.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 17. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <>
+
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Each time we change the schema of the database, we add a migration step
+// to the end of the list below. The version of a database is the number of
+// steps that have been applied to it, a fresh database is created at the
+// current version right away.
+//
+// Databases created before we kept track of the version in the
+// schema_version table are recognized by the tables and columns the steps
+// add, each step names one in its marker.
+//
+// A step only needs to add the columns and tables it introduces, in the
+// shape they have *now*: Tables are created from initQueries, and adding
+// a column that is already there is not an error. SQLite cannot change
+// constraints in place, so once all steps have been applied, the tables
+// that gained columns are re-created from initQueries, and their data is
+// copied over.
+//
+// Before we touch a database, we make a copy of it next to the original.
+
+// ErrSchemaTooNew is returned when opening a database that was created or
+// upgraded by a newer version of the application than this one.
+var ErrSchemaTooNew = errors.New("database schema is newer than this version of the application")
+
+// migration is a single step in the evolution of the database schema.
+// marker is the table ("table") or column ("table.column") that the step
+// adds, so we can recognize it has been applied already.
+type migration struct {
+	desc   string
+	marker string
+	apply  func(m *migrator) error
+}
+
+var migrations = []migration{
+	{
+		desc:   "monthly recurrence",
+		marker: "reminder.mday",
+		apply: func(m *migrator) error {
+			return m.addColumns("reminder",
+				"mday INTEGER NOT NULL DEFAULT 0",
+				"nth INTEGER NOT NULL DEFAULT 0",
+				"fallback INTEGER NOT NULL DEFAULT 0")
+		},
+	},
+	{
+		desc:   "yearly recurrence",
+		marker: "reminder.month",
+		apply: func(m *migrator) error {
+			return m.addColumns("reminder", "month INTEGER NOT NULL DEFAULT 0")
+		},
+	},
+	{
+		desc:   "fixed intervals",
+		marker: "reminder.period",
+		apply: func(m *migrator) error {
+			return m.addColumns("reminder", "period INTEGER NOT NULL DEFAULT 0")
+		},
+	},
+	{
+		desc:   "RRULE recurrence",
+		marker: "reminder.rrule",
+		apply: func(m *migrator) error {
+			return m.addColumns("reminder", "rrule TEXT NOT NULL DEFAULT ''")
+		},
+	},
+	{
+		desc:   "time zones",
+		marker: "reminder.tz",
+		apply: func(m *migrator) error {
+			return m.addColumns("reminder", "tz TEXT NOT NULL DEFAULT ''")
+		},
+	},
+	{
+		desc:   "recurrence limits and end dates",
+		marker: "reminder.until",
+		apply: func(m *migrator) error {
+			return m.addColumns("reminder", "until INTEGER NOT NULL DEFAULT 0")
+		},
+	},
+	{
+		desc:   "exceptions",
+		marker: "exception",
+		apply: func(m *migrator) error {
+			return m.createTables("exception")
+		},
+	},
+	{
+		desc:   "several times of day",
+		marker: "reminder.times",
+		apply: func(m *migrator) error {
+			return m.addColumns("reminder", "times TEXT NOT NULL DEFAULT ''")
+		},
+	},
+	{
+		desc:   "holiday calendars",
+		marker: "reminder.calendar",
+		apply: func(m *migrator) error {
+			return m.addColumns("reminder",
+				"calendar TEXT NOT NULL DEFAULT ''",
+				"holidays INTEGER NOT NULL DEFAULT 0")
+		},
+	},
+	{
+		desc:   "pre-alerts",
+		marker: "notification.lead",
+		apply: func(m *migrator) error {
+			if err := m.addColumns("reminder", "alerts TEXT NOT NULL DEFAULT ''"); err != nil {
+				return err
+			}
+
+			return m.addColumns("notification", "lead INTEGER NOT NULL DEFAULT 0")
+		},
+	},
+	{
+		desc:   "escalation",
+		marker: "escalation",
+		apply: func(m *migrator) error {
+			if err := m.addColumns("reminder",
+				"esc_interval INTEGER NOT NULL DEFAULT 0",
+				"esc_threshold INTEGER NOT NULL DEFAULT 0",
+				"esc_channel INTEGER NOT NULL DEFAULT 0"); err != nil {
+				return err
+			} else if err = m.addColumns("notification", "step INTEGER NOT NULL DEFAULT 0"); err != nil {
+				return err
+			}
+
+			return m.createTables("escalation")
+		},
+	},
+	{
+		desc:   "priorities",
+		marker: "reminder.priority",
+		apply: func(m *migrator) error {
+			return m.addColumns("reminder", "priority INTEGER NOT NULL DEFAULT 0")
+		},
+	},
+	{
+		desc:   "snooze choices and settings",
+		marker: "reminder.snooze",
+		apply: func(m *migrator) error {
+			if err := m.addColumns("reminder", "snooze TEXT NOT NULL DEFAULT ''"); err != nil {
+				return err
+			}
+
+			return m.createTables("setting")
+		},
+	},
+	{
+		desc:   "quiet hours",
+		marker: "notification.deferred",
+		apply: func(m *migrator) error {
+			return m.addColumns("notification", "deferred INTEGER")
+		},
+	},
+	{
+		desc:   "tags",
+		marker: "tag",
+		apply: func(m *migrator) error {
+			return m.createTables("tag", "tag_link")
+		},
+	},
+	{
+		desc:   "checklists",
+		marker: "checklist",
+		apply: func(m *migrator) error {
+			return m.createTables("checklist")
+		},
+	},
+	{
+		desc:   "dependencies",
+		marker: "dependency",
+		apply: func(m *migrator) error {
+			return m.createTables("dependency")
+		},
+	},
+	{
+		desc:   "history",
+		marker: "snooze",
+		apply: func(m *migrator) error {
+			if err := m.addColumns("notification",
+				"shown INTEGER",
+				"skipped INTEGER NOT NULL DEFAULT 0"); err != nil {
+				return err
+			}
+
+			return m.createTables("snooze")
+		},
+	},
+	{
+		desc:   "deletion tombstones",
+		marker: "tombstone",
+		apply: func(m *migrator) error {
+			return m.createTables("tombstone", "peer")
+		},
+	},
+	{
+		desc:   "change history",
+		marker: "revision",
+		apply: func(m *migrator) error {
+			return m.createTables("revision_op", "revision")
+		},
+	},
+}
+
+// schemaVersion is the version of the schema in initQueries.
+var schemaVersion = len(migrations)
+
+// dbHandle is what sql.Conn and sql.Tx have in common, as far as we are
+// concerned.
+type dbHandle interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+func hasTable(ctx context.Context, h dbHandle, table string) (bool, error) {
+	var (
+		err error
+		cnt int
+	)
+
+	if err = h.QueryRowContext(
+		ctx,
+		"SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = ?",
+		table).Scan(&cnt); err != nil {
+		return false, err
+	}
+
+	return cnt > 0, nil
+} // func hasTable(ctx context.Context, h dbHandle, table string) (bool, error)
+
+func hasColumn(ctx context.Context, h dbHandle, table, column string) (bool, error) {
+	var (
+		err error
+		cnt int
+	)
+
+	if err = h.QueryRowContext(
+		ctx,
+		"SELECT COUNT(*) FROM pragma_table_info(?) WHERE name = ?",
+		table,
+		column).Scan(&cnt); err != nil {
+		return false, err
+	}
+
+	return cnt > 0, nil
+} // func hasColumn(ctx context.Context, h dbHandle, table, column string) (bool, error)
+
+// tableColumns returns the names of the columns of the given table, in
+// order.
+func tableColumns(ctx context.Context, h dbHandle, table string) ([]string, error) {
+	var (
+		err  error
+		rows *sql.Rows
+		cols []string
+	)
+
+	if rows, err = h.QueryContext(ctx, "SELECT name FROM pragma_table_info(?) ORDER BY cid", table); err != nil {
+		return nil, err
+	}
+
+	defer rows.Close() // nolint: errcheck
+
+	for rows.Next() {
+		var name string
+
+		if err = rows.Scan(&name); err != nil {
+			return nil, err
+		}
+
+		cols = append(cols, name)
+	}
+
+	return cols, rows.Err()
+} // func tableColumns(ctx context.Context, h dbHandle, table string) ([]string, error)
+
+// getSchemaVersion returns the version of the database schema. If the
+// database does not keep track of its version yet, tracked is false, and
+// we guess the version from the tables and columns that are present.
+// A database without any tables has version -1.
+func getSchemaVersion(ctx context.Context, h dbHandle) (version int, tracked bool, err error) {
+	var ok bool
+
+	if tracked, err = hasTable(ctx, h, "schema_version"); err != nil {
+		return 0, false, err
+	} else if tracked {
+		err = h.QueryRowContext(ctx, "SELECT COALESCE(MAX(version), 0) FROM schema_version").Scan(&version)
+		return version, tracked, err
+	} else if ok, err = hasTable(ctx, h, "reminder"); err != nil {
+		return 0, false, err
+	} else if !ok {
+		return -1, false, nil
+	}
+
+	for _, step := range migrations {
+		var table, column, _ = strings.Cut(step.marker, ".")
+
+		if column == "" {
+			ok, err = hasTable(ctx, h, table)
+		} else {
+			ok, err = hasColumn(ctx, h, table, column)
+		}
+
+		if err != nil {
+			return 0, false, err
+		} else if !ok {
+			break
+		}
+
+		version++
+	}
+
+	return version, false, nil
+} // func getSchemaVersion(ctx context.Context, h dbHandle) (int, bool, error)
+
+// tableDDL returns the statement from initQueries that creates the given
+// table.
+func tableDDL(table string) (string, error) {
+	var prefix = fmt.Sprintf("CREATE TABLE %s (", table)
+
+	for _, q := range initQueries {
+		if strings.HasPrefix(strings.TrimSpace(q), prefix) {
+			return q, nil
+		}
+	}
+
+	return "", fmt.Errorf("Did not find definition of table %s", table)
+} // func tableDDL(table string) (string, error)
+
+// tableExtras returns the statements from initQueries that create indices
+// or triggers on the given table.
+func tableExtras(table string) []string {
+	var (
+		pat    = regexp.MustCompile(`(?s)^CREATE\s+(?:UNIQUE\s+)?(?:INDEX|TRIGGER)\s+\w+\s+[^(]*?\bON\s+` + table + `\b`)
+		extras []string
+	)
+
+	for _, q := range initQueries {
+		if pat.MatchString(strings.TrimSpace(q)) {
+			extras = append(extras, q)
+		}
+	}
+
+	return extras
+} // func tableExtras(table string) []string
+
+// migrator applies the migration steps to a database inside a transaction.
+type migrator struct {
+	ctx     context.Context
+	tx      *sql.Tx
+	rebuild map[string]bool
+}
+
+func (m *migrator) exec(q string, args ...any) error {
+	if _, err := m.tx.ExecContext(m.ctx, q, args...); err != nil {
+		return fmt.Errorf("Cannot execute %q: %s", strings.TrimSpace(q), err.Error())
+	}
+
+	return nil
+} // func (m *migrator) exec(q string, args ...any) error
+
+// addColumns adds the given columns to a table, unless it has them
+// already. ALTER TABLE appends new columns at the end and cannot add the
+// constraints that come with them, so the table is rebuilt afterwards.
+func (m *migrator) addColumns(table string, defs ...string) error {
+	m.rebuild[table] = true
+
+	for _, def := range defs {
+		var (
+			err  error
+			ok   bool
+			name = strings.Fields(def)[0]
+		)
+
+		if ok, err = hasColumn(m.ctx, m.tx, table, name); err != nil {
+			return err
+		} else if ok {
+			continue
+		} else if err = m.exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s", table, def)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+} // func (m *migrator) addColumns(table string, defs ...string) error
+
+// createTables creates the given tables along with their indices, unless
+// they exist already.
+func (m *migrator) createTables(tables ...string) error {
+	for _, table := range tables {
+		var (
+			err error
+			ok  bool
+			ddl string
+		)
+
+		if ok, err = hasTable(m.ctx, m.tx, table); err != nil {
+			return err
+		} else if ok {
+			continue
+		} else if ddl, err = tableDDL(table); err != nil {
+			return err
+		}
+
+		for _, q := range append([]string{ddl}, tableExtras(table)...) {
+			if err = m.exec(q); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+} // func (m *migrator) createTables(tables ...string) error
+
+// rebuildTable re-creates a table from its definition in initQueries and
+// copies the data over. Foreign keys must be turned off while we do this,
+// or dropping the old table would take the rows that refer to it along.
+func (m *migrator) rebuildTable(table string) error {
+	var (
+		err            error
+		ddl            string
+		oldCols, cols  []string
+		tmp            = table + "_new"
+		have           = make(map[string]bool)
+		copied         []string
+		prefix, tmpPfx = fmt.Sprintf("CREATE TABLE %s (", table), fmt.Sprintf("CREATE TABLE %s (", tmp)
+	)
+
+	if ddl, err = tableDDL(table); err != nil {
+		return err
+	} else if err = m.exec(strings.Replace(ddl, prefix, tmpPfx, 1)); err != nil {
+		return err
+	} else if oldCols, err = tableColumns(m.ctx, m.tx, table); err != nil {
+		return err
+	} else if cols, err = tableColumns(m.ctx, m.tx, tmp); err != nil {
+		return err
+	}
+
+	for _, c := range oldCols {
+		have[c] = true
+	}
+
+	for _, c := range cols {
+		if have[c] {
+			copied = append(copied, c)
+		}
+	}
+
+	var list = strings.Join(copied, ", ")
+
+	if err = m.exec(fmt.Sprintf("INSERT INTO %s (%s) SELECT %s FROM %s", tmp, list, list, table)); err != nil {
+		return err
+	} else if err = m.exec(fmt.Sprintf("DROP TABLE %s", table)); err != nil {
+		return err
+	} else if err = m.exec(fmt.Sprintf("ALTER TABLE %s RENAME TO %s", tmp, table)); err != nil {
+		return err
+	}
+
+	for _, q := range tableExtras(table) {
+		if err = m.exec(q); err != nil {
+			return err
+		}
+	}
+
+	return nil
+} // func (m *migrator) rebuildTable(table string) error
+
+// migrate brings the schema of an existing database up to date.
+func (db *Database) migrate() error {
+	var (
+		err     error
+		conn    *sql.Conn
+		tx      *sql.Tx
+		version int
+		tracked bool
+		backup  string
+		ctx     = context.Background()
+		now     = time.Now()
+	)
+
+	if conn, err = db.db.Conn(ctx); err != nil {
+		db.log.Printf("[ERROR] Cannot get connection to database %s: %s\n",
+			db.path,
+			err.Error())
+		return err
+	}
+
+	defer conn.Close() // nolint: errcheck
+
+	if version, tracked, err = getSchemaVersion(ctx, conn); err != nil {
+		db.log.Printf("[ERROR] Cannot determine schema version of %s: %s\n",
+			db.path,
+			err.Error())
+		return err
+	} else if version < 0 {
+		// An empty file, we treat it like one that is not there.
+		conn.Close() // nolint: errcheck,gosec
+		return db.initialize()
+	} else if version > schemaVersion {
+		err = fmt.Errorf("%w: %s has version %d, we know up to version %d",
+			ErrSchemaTooNew,
+			db.path,
+			version,
+			schemaVersion)
+		db.log.Printf("[ERROR] %s\n", err.Error())
+		return err
+	} else if version == schemaVersion && tracked {
+		return nil
+	}
+
+	backup = fmt.Sprintf("%s.v%d-%s.bak",
+		db.path,
+		version,
+		now.Format("20060102_150405"))
+
+	db.log.Printf("[INFO] Upgrade database %s from version %d to %d, backup is at %s\n",
+		db.path,
+		version,
+		schemaVersion,
+		backup)
+
+	if _, err = conn.ExecContext(ctx, "VACUUM INTO ?", backup); err != nil {
+		db.log.Printf("[ERROR] Cannot back up database %s to %s: %s\n",
+			db.path,
+			backup,
+			err.Error())
+		return err
+	} else if _, err = conn.ExecContext(ctx, "PRAGMA foreign_keys = OFF"); err != nil {
+		db.log.Printf("[ERROR] Cannot turn off foreign keys: %s\n",
+			err.Error())
+		return err
+	}
+
+	defer conn.ExecContext(ctx, "PRAGMA foreign_keys = ON") // nolint: errcheck
+
+	if tx, err = conn.BeginTx(ctx, nil); err != nil {
+		db.log.Printf("[ERROR] Cannot begin transaction: %s\n",
+			err.Error())
+		return err
+	} else if err = db.migrateTx(ctx, tx, version, now); err != nil {
+		db.log.Printf("[ERROR] Upgrade of %s failed, the database was left as it was: %s\n",
+			db.path,
+			err.Error())
+		if rbErr := tx.Rollback(); rbErr != nil {
+			db.log.Printf("[CRITICAL] Cannot rollback transaction: %s\n",
+				rbErr.Error())
+		}
+		return err
+	} else if err = tx.Commit(); err != nil {
+		db.log.Printf("[CRITICAL] Failed to commit upgrade of %s, restore it from %s: %s\n",
+			db.path,
+			backup,
+			err.Error())
+		return err
+	}
+
+	db.log.Printf("[INFO] Database %s has been upgraded to version %d\n",
+		db.path,
+		schemaVersion)
+
+	return nil
+} // func (db *Database) migrate() error
+
+// migrateTx applies the migration steps after the given version.
+func (db *Database) migrateTx(ctx context.Context, tx *sql.Tx, version int, now time.Time) error {
+	var (
+		err    error
+		ok     bool
+		rows   *sql.Rows
+		tables []string
+		m      = &migrator{
+			ctx:     ctx,
+			tx:      tx,
+			rebuild: make(map[string]bool),
+		}
+	)
+
+	for i := version; i < schemaVersion; i++ {
+		db.log.Printf("[INFO] Upgrade database to version %d: %s\n",
+			i+1,
+			migrations[i].desc)
+
+		if err = migrations[i].apply(m); err != nil {
+			return fmt.Errorf("Upgrade to version %d (%s) failed: %s",
+				i+1,
+				migrations[i].desc,
+				err.Error())
+		}
+	}
+
+	for t := range m.rebuild {
+		tables = append(tables, t)
+	}
+
+	sort.Strings(tables)
+
+	for _, t := range tables {
+		db.log.Printf("[DEBUG] Rebuild table %s\n", t)
+
+		if err = m.rebuildTable(t); err != nil {
+			return fmt.Errorf("Cannot rebuild table %s: %s", t, err.Error())
+		}
+	}
+
+	if rows, err = tx.QueryContext(ctx, "PRAGMA foreign_key_check"); err != nil {
+		return err
+	}
+
+	ok = !rows.Next()
+	rows.Close() // nolint: errcheck,gosec
+
+	if !ok {
+		return errors.New("Foreign key constraints are violated after the upgrade")
+	} else if err = m.createTables("schema_version"); err != nil {
+		return err
+	}
+
+	// A database that did not keep track of its version yet gets a record
+	// of the version it had, too.
+	if version == 0 {
+		version = 1
+	}
+
+	for v := version; v <= schemaVersion; v++ {
+		if err = m.exec("INSERT OR IGNORE INTO schema_version (version, timestamp) VALUES (?, ?)", v, now.Unix()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+} // func (db *Database) migrateTx(ctx context.Context, tx *sql.Tx, version int, now time.Time) error
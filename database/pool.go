@@ -7,14 +7,26 @@
 package database
 
 import (
+	"errors"
 	"fmt"
+	"io"
 	"log"
+	"os"
 	"sync"
+	"time"
 
 	"github.com/blicero/theseus/common"
 	"github.com/blicero/theseus/logdomain"
 )
 
+// restoreTimeout is how long Restore waits for all connections to be
+// returned to the Pool.
+var restoreTimeout = time.Second * 30
+
+// ErrRestoreTimeout is returned by Restore if connections from the Pool
+// remain in use for too long.
+var ErrRestoreTimeout = errors.New("timed out waiting for database connections to be returned")
+
 type dblink struct {
 	db   *Database
 	next *dblink
@@ -24,12 +36,13 @@ type dblink struct {
 //
 // *Maybe* I should make this a "singleton"?
 type Pool struct {
-	cnt     int
-	initCnt int
-	log     *log.Logger
-	link    *dblink
-	lock    sync.RWMutex
-	empty   *sync.Cond
+	cnt       int
+	initCnt   int
+	log       *log.Logger
+	link      *dblink
+	lock      sync.RWMutex
+	empty     *sync.Cond
+	restoring bool
 }
 
 // NewPool creates a Pool of database connections.
@@ -74,14 +87,7 @@ func NewPool(cnt int) (*Pool, error) {
 // time Close is called are unaffected.
 func (pool *Pool) Close() error {
 	pool.lock.Lock()
-
-	for link := pool.link; link != nil; link = link.next {
-		link.db.Close() // nolint: errcheck,gosec
-		link.db = nil
-	}
-
-	pool.link = nil
-	pool.cnt = 0
+	pool.closeLinks()
 	pool.lock.Unlock()
 	return nil
 } // func (pool *Pool) Close() error
@@ -99,7 +105,7 @@ func (pool *Pool) Get() *Database {
 	}
 
 WAIT_FOR_LINK:
-	if pool.link != nil {
+	if pool.link != nil && !pool.restoring {
 		link = pool.link
 		pool.link = link.next
 		pool.cnt--
@@ -122,6 +128,10 @@ func (pool *Pool) GetNoWait() (*Database, error) {
 	pool.lock.Lock()
 	defer pool.lock.Unlock()
 
+	for pool.restoring {
+		pool.empty.Wait()
+	}
+
 	if common.Debug && pool.cnt < pool.initCnt {
 		pool.log.Printf("[DEBUG] Pool holds %d connections\n", pool.cnt)
 	}
@@ -159,9 +169,185 @@ func (pool *Pool) Put(db *Database) {
 	pool.link = link
 	pool.cnt++
 	pool.lock.Unlock()
-	pool.empty.Signal()
+	// Restore may be waiting for the Pool to fill up, so we need to wake
+	// up everyone.
+	pool.empty.Broadcast()
 } // func (pool *Pool) Put(db *Database)
 
+// Restore replaces the database with the backup at the given path.
+// The backup is copied and checked for integrity first, then Restore waits
+// for all connections to be returned to the Pool, closes them, moves the
+// current database file aside and the copy of the backup into its place.
+// The previous database is kept next to the current one, with the suffix
+// .pre-restore and a timestamp.
+//
+// The caller must not hold a connection from the Pool. If not all
+// connections are returned within restoreTimeout, Restore gives up and
+// returns ErrRestoreTimeout.
+func (pool *Pool) Restore(backup string) error {
+	var (
+		err      error
+		timer    *time.Timer
+		deadline = time.Now().Add(restoreTimeout)
+		tmp      = common.DbPath + ".restore"
+		saved    = fmt.Sprintf("%s.pre-restore-%s",
+			common.DbPath,
+			time.Now().Format("20060102-150405"))
+	)
+
+	if err = CheckBackup(backup); err != nil {
+		pool.log.Printf("[ERROR] Cannot restore %s: %s\n",
+			backup,
+			err.Error())
+		return err
+	} else if err = copyFile(backup, tmp); err != nil {
+		pool.log.Printf("[ERROR] Cannot copy %s to %s: %s\n",
+			backup,
+			tmp,
+			err.Error())
+		return err
+	} else if err = CheckBackup(tmp); err != nil {
+		pool.log.Printf("[ERROR] Copy of %s is broken: %s\n",
+			backup,
+			err.Error())
+		os.Remove(tmp) // nolint: errcheck
+		return err
+	}
+
+	pool.lock.Lock()
+	defer pool.lock.Unlock()
+
+	pool.restoring = true
+	defer func() {
+		pool.restoring = false
+		pool.empty.Broadcast()
+	}()
+
+	// sync.Cond has no timeout, so we wake ourselves up when the deadline
+	// has passed.
+	timer = time.AfterFunc(restoreTimeout, func() {
+		pool.lock.Lock()
+		pool.empty.Broadcast()
+		pool.lock.Unlock()
+	})
+	defer timer.Stop()
+
+	for pool.cnt < pool.initCnt {
+		if !time.Now().Before(deadline) {
+			pool.log.Printf("[ERROR] Cannot restore %s: %d of %d connections are still in use after %s\n",
+				backup,
+				pool.initCnt-pool.cnt,
+				pool.initCnt,
+				restoreTimeout)
+			os.Remove(tmp) // nolint: errcheck
+			return ErrRestoreTimeout
+		}
+		pool.empty.Wait()
+	}
+
+	pool.log.Printf("[INFO] Restore database from %s\n", backup)
+
+	pool.closeLinks()
+
+	if err = moveDB(common.DbPath, saved); err != nil {
+		pool.log.Printf("[ERROR] Cannot move %s to %s: %s\n",
+			common.DbPath,
+			saved,
+			err.Error())
+	} else if err = os.Rename(tmp, common.DbPath); err != nil {
+		pool.log.Printf("[ERROR] Cannot move %s to %s: %s\n",
+			tmp,
+			common.DbPath,
+			err.Error())
+		moveDB(saved, common.DbPath) // nolint: errcheck
+	} else if err = pool.openLinks(); err != nil {
+		pool.log.Printf("[ERROR] Cannot open restored database, going back to %s: %s\n",
+			saved,
+			err.Error())
+		pool.closeLinks()
+		os.Remove(common.DbPath)     // nolint: errcheck
+		moveDB(saved, common.DbPath) // nolint: errcheck
+	} else {
+		return nil
+	}
+
+	if e2 := pool.openLinks(); e2 != nil {
+		pool.log.Printf("[CRITICAL] Cannot re-open database: %s\n",
+			e2.Error())
+	}
+
+	return err
+} // func (pool *Pool) Restore(backup string) error
+
+// closeLinks closes all connections in the Pool. The caller must hold the
+// lock.
+func (pool *Pool) closeLinks() {
+	for link := pool.link; link != nil; link = link.next {
+		link.db.Close() // nolint: errcheck,gosec
+		link.db = nil
+	}
+
+	pool.link = nil
+	pool.cnt = 0
+} // func (pool *Pool) closeLinks()
+
+// openLinks fills the Pool with fresh connections. The caller must hold the
+// lock.
+func (pool *Pool) openLinks() error {
+	var err error
+
+	for pool.cnt < pool.initCnt {
+		var link = &dblink{next: pool.link}
+
+		if link.db, err = Open(common.DbPath); err != nil {
+			return err
+		}
+
+		pool.link = link
+		pool.cnt++
+	}
+
+	return nil
+} // func (pool *Pool) openLinks() error
+
+// moveDB renames a database file along with its write-ahead log and shared
+// memory file, if they exist.
+func moveDB(from, to string) error {
+	for _, suffix := range []string{"", "-wal", "-shm"} {
+		if err := os.Rename(from+suffix, to+suffix); err != nil && !(suffix != "" && os.IsNotExist(err)) {
+			return err
+		}
+	}
+
+	return nil
+} // func moveDB(from, to string) error
+
+func copyFile(from, to string) error {
+	var (
+		err     error
+		in, out *os.File
+	)
+
+	if in, err = os.Open(from); err != nil {
+		return err
+	}
+
+	defer in.Close() // nolint: errcheck
+
+	if out, err = os.Create(to); err != nil {
+		return err
+	} else if _, err = io.Copy(out, in); err != nil {
+		out.Close()   // nolint: errcheck
+		os.Remove(to) // nolint: errcheck
+		return err
+	} else if err = out.Sync(); err != nil {
+		out.Close() // nolint: errcheck
+		return err
+	}
+
+	return out.Close()
+} // func copyFile(from, to string) error
+
 // IsEmpty returns true if the pool is currently empty.
 func (pool *Pool) IsEmpty() bool {
 	pool.lock.RLock()
@@ -18,10 +18,17 @@ const (
 	ReminderSetTitle
 	ReminderSetDescription
 	ReminderSetTimestamp
+	ReminderSetTimeZone
 	ReminderSetChanged
 	ReminderSetRepeat
 	ReminderSetWeekdays
+	ReminderSetRecurrence
 	ReminderSetLimit
+	ReminderSetAlerts
+	ReminderSetEscalation
+	ReminderSetPriority
+	ReminderSetSnooze
+	ReminderSetCounter
 	ReminderIncCounter
 	ReminderResetCounter
 	ReminderReactivate
@@ -30,13 +37,66 @@ const (
 	ReminderGetFinished
 	ReminderGetByID
 	ReminderGetAll
+	ReminderGetIDByUUID
+	ReminderSearch
 	NotificationAdd
 	NotificationDisplay
 	NotificationAcknowledge
+	NotificationSkipStale
 	NotificationGetByID
 	NotificationGetByReminder
 	NotificationGetByReminderStamp
 	NotificationGetByReminderPending
 	NotificationGetPending
+	NotificationGetDeferred
+	NotificationSetDeferred
 	NotificationCleanup
+	NotificationDelete
+	NotificationGetByRange
+	NotificationGetHistory
+	ExceptionAdd
+	ExceptionDelete
+	ExceptionGetByReminder
+	ExceptionGetAll
+	NotificationSetStep
+	EscalationAdd
+	EscalationGetByNotification
+	SettingGet
+	SettingSet
+	TagSet
+	TagEnsure
+	TagDelete
+	TagTouchReminders
+	TagGetAll
+	TagLinkClear
+	TagLinkAdd
+	TagLinkGetAll
+	TagLinkGetByReminder
+	ChecklistClear
+	ChecklistAdd
+	ChecklistSetDone
+	ChecklistGetAll
+	ChecklistGetByReminder
+	DependencyClear
+	DependencyAdd
+	DependencyTrigger
+	DependencyGetAll
+	DependencyGetByReminder
+	DependencyGetFollowUps
+	SnoozeAdd
+	SnoozeGetByReminder
+	TombstoneAdd
+	TombstoneDelete
+	TombstoneGetByUUID
+	TombstoneGetAll
+	TombstonePurge
+	PeerSetSeen
+	PeerGetAll
+	PeerPurge
+	RevisionOpAdd
+	RevisionAdd
+	RevisionGetByID
+	RevisionGetByUUID
+	RevisionGetByOp
+	RevisionGetUndoable
 )
@@ -0,0 +1,57 @@
+-- Schema version 2: yearly recurrence
+
+CREATE TABLE reminder (
+    id          INTEGER PRIMARY KEY,
+    title       TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    due         INTEGER NOT NULL,
+    finished    INTEGER NOT NULL DEFAULT 0,
+    repeat      INTEGER NOT NULL DEFAULT 0,
+    weekdays    INTEGER NOT NULL DEFAULT 0,
+    mday        INTEGER NOT NULL DEFAULT 0,
+    nth         INTEGER NOT NULL DEFAULT 0,
+    fallback    INTEGER NOT NULL DEFAULT 0,
+    month       INTEGER NOT NULL DEFAULT 0,
+    counter     INTEGER NOT NULL DEFAULT 0,
+    counter_max INTEGER NOT NULL DEFAULT 0,
+    uuid        TEXT UNIQUE NOT NULL,
+    changed     INTEGER NOT NULL DEFAULT 0,
+    UNIQUE (title, due),
+    -- CHECK (due > 1656624376), -- 2022-06-30, ~23:26
+    CHECK ((repeat = 0 AND due > 1656624376)
+           OR ((repeat BETWEEN 1 AND 5) AND
+               (due BETWEEN 0 AND 86400))),
+    CHECK (repeat <> 3 OR mday BETWEEN 1 AND 31),
+    CHECK (repeat <> 4 OR ((nth BETWEEN 1 AND 5 OR nth = -1) AND weekdays <> 0)),
+    CHECK (repeat <> 5 OR (month BETWEEN 1 AND 12 AND mday BETWEEN 1 AND 31)),
+    CHECK (fallback IN (0, 1)),
+    CHECK (counter >= 0 AND counter_max >= 0 AND counter <= counter_max)
+
+) STRICT;
+
+CREATE INDEX reminder_due_idx ON reminder (due);
+
+CREATE INDEX reminder_finished_idx ON reminder (finished);
+
+CREATE INDEX reminder_uuid_idx ON reminder (uuid);
+
+CREATE INDEX reminder_changed_idx ON reminder (changed);
+
+CREATE TABLE notification (
+    id			INTEGER PRIMARY KEY,
+    reminder_id		INTEGER NOT NULL,
+    timestamp		INTEGER NOT NULL,
+    displayed		INTEGER,
+    acknowledged	INTEGER,
+    UNIQUE (reminder_id, timestamp),
+    CHECK (NOT (displayed IS NULL AND acknowledged IS NOT NULL)),
+    FOREIGN KEY (reminder_id) REFERENCES reminder (id)
+        ON UPDATE RESTRICT
+        ON DELETE CASCADE
+) STRICT;
+
+CREATE INDEX rec_rem_idx ON notification (reminder_id);
+
+CREATE INDEX rec_time_idx ON notification (timestamp);
+
+CREATE INDEX rec_ack_idx ON notification (acknowledged);
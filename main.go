@@ -16,6 +16,7 @@ import (
 
 	"github.com/blicero/theseus/backend"
 	"github.com/blicero/theseus/common"
+	"github.com/blicero/theseus/objects"
 	"github.com/blicero/theseus/ui"
 )
 
@@ -29,6 +30,7 @@ func main() {
 		err                error
 		daemon             *backend.Daemon
 		appDir, mode, addr string
+		holidays           string
 	)
 
 	flag.StringVar(
@@ -51,8 +53,31 @@ func main() {
 		"Address to either listen on (backend) or connect to (frontend)",
 	)
 
+	flag.StringVar(
+		&holidays,
+		"holidays",
+		"",
+		"Import holidays from an ICS file or a list of dates and exit",
+	)
+
 	flag.Parse()
 
+	if holidays != "" {
+		var name string
+
+		if name, err = objects.ImportCalendar(holidays, common.HolidayDir); err != nil {
+			fmt.Fprintf(
+				os.Stderr,
+				"Cannot import holidays from %s: %s\n",
+				holidays,
+				err.Error())
+			os.Exit(1)
+		}
+
+		fmt.Printf("Imported holiday calendar %q\n", name)
+		return
+	}
+
 	if mode == "backend" {
 		if daemon, err = backend.Summon(addr); err != nil {
 			fmt.Fprintf(
@@ -32,6 +32,7 @@ func TestDue(t *testing.T) {
 			r: Reminder{
 				Title:     "Test01",
 				Timestamp: zero.Add(time.Hour * 14),
+				TimeZone:  "UTC",
 				Recur: Recurrence{
 					Repeat: repeat.Daily,
 				},
@@ -43,6 +44,7 @@ func TestDue(t *testing.T) {
 			r: Reminder{
 				Title:     "Test02",
 				Timestamp: zero.Add(time.Second * 27000), // 07:30
+				TimeZone:  "UTC",
 				Recur: Recurrence{
 					Repeat: repeat.Custom,
 					Days: Weekdays{
@@ -63,6 +65,7 @@ func TestDue(t *testing.T) {
 			r: Reminder{
 				Title:     "Test03",
 				Timestamp: now.Add(time.Hour * 24),
+				TimeZone:  "UTC",
 				Recur: Recurrence{
 					Repeat: repeat.Once,
 				},
@@ -74,6 +77,7 @@ func TestDue(t *testing.T) {
 			r: Reminder{
 				Title:     "Test04",
 				Timestamp: zero.Add(time.Hour * 8),
+				TimeZone:  "UTC",
 				Recur: Recurrence{
 					Repeat: repeat.Custom,
 					Days: Weekdays{
@@ -109,3 +113,201 @@ Delta:          %s
 		}
 	}
 } // func TestDue(t *testing.T)
+
+func TestDueInterval(t *testing.T) {
+	var (
+		anchor = time.Date(2022, 9, 14, 8, 0, 0, 0, time.UTC)
+		r      = Reminder{
+			Title:     "Stretch",
+			Timestamp: anchor,
+			TimeZone:  "UTC",
+			Recur: Recurrence{
+				Repeat: repeat.Interval,
+				Period: 45 * 60,
+			},
+		}
+		cases = []struct {
+			ref, next, prev time.Time
+		}{
+			{
+				ref:  anchor.Add(-time.Hour),
+				next: anchor,
+				prev: anchor,
+			},
+			{
+				ref:  anchor,
+				next: anchor,
+				prev: anchor,
+			},
+			{
+				ref:  anchor.Add(time.Minute * 50),
+				next: anchor.Add(time.Minute * 90),
+				prev: anchor.Add(time.Minute * 45),
+			},
+			{
+				ref:  anchor.Add(time.Hour * 24 * 10),
+				next: anchor.Add(time.Hour * 24 * 10),
+				prev: anchor.Add(time.Hour * 24 * 10),
+			},
+			{
+				ref:  anchor.Add(time.Hour*24*10 + time.Minute),
+				next: anchor.Add(time.Hour*24*10 + time.Minute*45),
+				prev: anchor.Add(time.Hour * 24 * 10),
+			},
+		}
+	)
+
+	for i, c := range cases {
+		var (
+			next = r.DueNext(&c.ref)
+			prev = r.DuePrev(&c.ref)
+		)
+
+		if !next.Equal(c.next) {
+			t.Errorf("Case %d: Unexpected next due time: Expected %s, got %s",
+				i,
+				c.next.Format(common.TimestampFormat),
+				next.Format(common.TimestampFormat))
+		}
+
+		if !prev.Equal(c.prev) {
+			t.Errorf("Case %d: Unexpected previous due time: Expected %s, got %s",
+				i,
+				c.prev.Format(common.TimestampFormat),
+				prev.Format(common.TimestampFormat))
+		}
+	}
+} // func TestDueInterval(t *testing.T)
+
+func TestDueLimit(t *testing.T) {
+	var (
+		ref = time.Date(2022, 9, 14, 12, 0, 0, 0, time.UTC)
+		r   = Reminder{
+			Title:     "Water plants",
+			Timestamp: time.Unix(3600*9, 0),
+			TimeZone:  "UTC",
+			Recur: Recurrence{
+				Repeat:  repeat.Daily,
+				Offset:  3600 * 9,
+				Limit:   5,
+				Counter: 4,
+			},
+		}
+		next time.Time
+	)
+
+	if next = r.DueNext(&ref); !next.Equal(time.Date(2022, 9, 15, 9, 0, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected next due time: %s",
+			next.Format(common.TimestampFormat))
+	} else if r.Exhausted(&ref) {
+		t.Errorf("Reminder %q should not be exhausted after %d of %d occurrences",
+			r.Title,
+			r.Recur.Counter,
+			r.Recur.Limit)
+	}
+
+	r.Recur.Counter++
+
+	if next = r.DueNext(&ref); !next.IsZero() {
+		t.Errorf("Reminder %q should not be due after reaching its Limit, but is due at %s",
+			r.Title,
+			next.Format(common.TimestampFormat))
+	} else if !r.Exhausted(&ref) {
+		t.Errorf("Reminder %q should be exhausted", r.Title)
+	}
+
+	r.Recur.Limit = 0
+	r.Recur.Until = time.Date(2022, 9, 15, 23, 59, 59, 0, time.UTC)
+
+	if next = r.DueNext(&ref); !next.Equal(time.Date(2022, 9, 15, 9, 0, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected next due time before Until: %s",
+			next.Format(common.TimestampFormat))
+	}
+
+	ref = ref.Add(time.Hour * 24)
+
+	if next = r.DueNext(&ref); !next.IsZero() {
+		t.Errorf("Reminder %q should not be due after %s, but is due at %s",
+			r.Title,
+			r.Recur.Until.Format(common.TimestampFormat),
+			next.Format(common.TimestampFormat))
+	}
+
+	ref = ref.Add(time.Hour * 24 * 7)
+
+	if prev := r.DuePrev(&ref); !prev.Equal(time.Date(2022, 9, 15, 9, 0, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected previous due time after Until: %s",
+			prev.Format(common.TimestampFormat))
+	}
+} // func TestDueLimit(t *testing.T)
+
+func TestDueTimes(t *testing.T) {
+	var (
+		r = Reminder{
+			Title:     "Drink water",
+			Timestamp: time.Unix(3600*8, 0),
+			TimeZone:  "UTC",
+			Recur: Recurrence{
+				Repeat: repeat.Custom,
+				Offset: 3600 * 8,
+				Times:  []int{3600 * 12, 3600*17 + 1800},
+				Days:   Weekdays{true, true, true, true, true, false, false},
+			},
+		}
+		cases = []struct {
+			ref, next, prev time.Time
+		}{
+			{
+				ref:  time.Date(2022, 9, 14, 7, 0, 0, 0, time.UTC), // Wednesday
+				next: time.Date(2022, 9, 14, 8, 0, 0, 0, time.UTC),
+				prev: time.Date(2022, 9, 13, 17, 30, 0, 0, time.UTC),
+			},
+			{
+				ref:  time.Date(2022, 9, 14, 9, 0, 0, 0, time.UTC),
+				next: time.Date(2022, 9, 14, 12, 0, 0, 0, time.UTC),
+				prev: time.Date(2022, 9, 14, 8, 0, 0, 0, time.UTC),
+			},
+			{
+				ref:  time.Date(2022, 9, 14, 12, 0, 0, 0, time.UTC),
+				next: time.Date(2022, 9, 14, 12, 0, 0, 0, time.UTC),
+				prev: time.Date(2022, 9, 14, 12, 0, 0, 0, time.UTC),
+			},
+			{
+				ref:  time.Date(2022, 9, 16, 18, 0, 0, 0, time.UTC), // Friday
+				next: time.Date(2022, 9, 19, 8, 0, 0, 0, time.UTC),
+				prev: time.Date(2022, 9, 16, 17, 30, 0, 0, time.UTC),
+			},
+		}
+	)
+
+	for i, c := range cases {
+		var (
+			next = r.DueNext(&c.ref)
+			prev = r.DuePrev(&c.ref)
+		)
+
+		if !next.Equal(c.next) {
+			t.Errorf("Case %d: Unexpected next due time: Expected %s, got %s",
+				i,
+				c.next.Format(common.TimestampFormat),
+				next.Format(common.TimestampFormat))
+		}
+
+		if !prev.Equal(c.prev) {
+			t.Errorf("Case %d: Unexpected previous due time: Expected %s, got %s",
+				i,
+				c.prev.Format(common.TimestampFormat),
+				prev.Format(common.TimestampFormat))
+		}
+	}
+
+	if str := r.Recur.TimesString(); str != "43200,63000" {
+		t.Errorf("Unexpected string representation of times: %q", str)
+	} else if err := r.Recur.SetTimes("63000, 43200"); err != nil {
+		t.Errorf("Cannot parse times %q: %s", str, err.Error())
+	} else if r.Recur.TimesString() != str {
+		t.Errorf("Times were not sorted: %v", r.Recur.Times)
+	} else if err = r.Recur.SetTimes("86400"); err == nil {
+		t.Errorf("Time of day beyond midnight should be rejected")
+	}
+} // func TestDueTimes(t *testing.T)
@@ -0,0 +1,182 @@
+// /home/krylon/go/src/github.com/blicero/theseus/objects/02_monthly_test.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 17. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <>
+
+package objects
+
+import (
+	"testing"
+	"time"
+
+	"github.com/blicero/theseus/common"
+	"github.com/blicero/theseus/objects/repeat"
+)
+
+func TestDueMonthly(t *testing.T) {
+	type testCase struct {
+		title      string
+		rec        Recurrence
+		offset     time.Duration
+		ref        time.Time
+		expectNext time.Time
+		expectPrev time.Time
+	}
+
+	var (
+		zero  = time.Unix(0, 0)
+		mo    = Weekdays{true, false, false, false, false, false, false}
+		thu   = Weekdays{false, false, false, true, false, false, false}
+		fri   = Weekdays{false, false, false, false, true, false, false}
+		cases = []testCase{
+			{
+				title:      "15th of each month",
+				rec:        Recurrence{Repeat: repeat.Monthly, Day: 15},
+				offset:     time.Hour * 9,
+				ref:        time.Date(2022, 9, 20, 12, 0, 0, 0, time.UTC),
+				expectNext: time.Date(2022, 10, 15, 9, 0, 0, 0, time.UTC),
+				expectPrev: time.Date(2022, 9, 15, 9, 0, 0, 0, time.UTC),
+			},
+			{
+				title:      "31st, clamped",
+				rec:        Recurrence{Repeat: repeat.Monthly, Day: 31},
+				offset:     time.Hour * 18,
+				ref:        time.Date(2022, 9, 1, 0, 0, 0, 0, time.UTC),
+				expectNext: time.Date(2022, 9, 30, 18, 0, 0, 0, time.UTC),
+				expectPrev: time.Date(2022, 8, 31, 18, 0, 0, 0, time.UTC),
+			},
+			{
+				title:      "31st, skipped",
+				rec:        Recurrence{Repeat: repeat.Monthly, Day: 31, Fallback: FallbackSkip},
+				offset:     time.Hour * 18,
+				ref:        time.Date(2022, 9, 1, 0, 0, 0, 0, time.UTC),
+				expectNext: time.Date(2022, 10, 31, 18, 0, 0, 0, time.UTC),
+				expectPrev: time.Date(2022, 8, 31, 18, 0, 0, 0, time.UTC),
+			},
+			{
+				title:      "29th in February, clamped",
+				rec:        Recurrence{Repeat: repeat.Monthly, Day: 29},
+				offset:     time.Hour * 7,
+				ref:        time.Date(2023, 2, 2, 0, 0, 0, 0, time.UTC),
+				expectNext: time.Date(2023, 2, 28, 7, 0, 0, 0, time.UTC),
+				expectPrev: time.Date(2023, 1, 29, 7, 0, 0, 0, time.UTC),
+			},
+			{
+				title:      "Second Thursday",
+				rec:        Recurrence{Repeat: repeat.MonthlyWeekday, Week: 2, Days: thu},
+				offset:     time.Hour * 14,
+				ref:        time.Date(2022, 9, 14, 0, 0, 0, 0, time.UTC),
+				expectNext: time.Date(2022, 10, 13, 14, 0, 0, 0, time.UTC),
+				expectPrev: time.Date(2022, 9, 8, 14, 0, 0, 0, time.UTC),
+			},
+			{
+				title:      "Last Monday",
+				rec:        Recurrence{Repeat: repeat.MonthlyWeekday, Week: LastWeek, Days: mo},
+				offset:     time.Hour * 8,
+				ref:        time.Date(2022, 10, 1, 0, 0, 0, 0, time.UTC),
+				expectNext: time.Date(2022, 10, 31, 8, 0, 0, 0, time.UTC),
+				expectPrev: time.Date(2022, 9, 26, 8, 0, 0, 0, time.UTC),
+			},
+			{
+				title:      "Fifth Friday, clamped",
+				rec:        Recurrence{Repeat: repeat.MonthlyWeekday, Week: 5, Days: fri},
+				offset:     time.Hour * 16,
+				ref:        time.Date(2022, 10, 1, 0, 0, 0, 0, time.UTC),
+				expectNext: time.Date(2022, 10, 28, 16, 0, 0, 0, time.UTC),
+				expectPrev: time.Date(2022, 9, 30, 16, 0, 0, 0, time.UTC),
+			},
+			{
+				title:      "Fifth Friday, skipped",
+				rec:        Recurrence{Repeat: repeat.MonthlyWeekday, Week: 5, Days: fri, Fallback: FallbackSkip},
+				offset:     time.Hour * 16,
+				ref:        time.Date(2022, 10, 1, 0, 0, 0, 0, time.UTC),
+				expectNext: time.Date(2022, 12, 30, 16, 0, 0, 0, time.UTC),
+				expectPrev: time.Date(2022, 9, 30, 16, 0, 0, 0, time.UTC),
+			},
+			{
+				title:      "Birthday",
+				rec:        Recurrence{Repeat: repeat.Yearly, Month: time.October, Day: 3},
+				offset:     time.Hour * 10,
+				ref:        time.Date(2022, 10, 3, 11, 0, 0, 0, time.UTC),
+				expectNext: time.Date(2023, 10, 3, 10, 0, 0, 0, time.UTC),
+				expectPrev: time.Date(2022, 10, 3, 10, 0, 0, 0, time.UTC),
+			},
+			{
+				title:      "February 29th, clamped",
+				rec:        Recurrence{Repeat: repeat.Yearly, Month: time.February, Day: 29},
+				offset:     time.Hour * 12,
+				ref:        time.Date(2022, 10, 1, 0, 0, 0, 0, time.UTC),
+				expectNext: time.Date(2023, 2, 28, 12, 0, 0, 0, time.UTC),
+				expectPrev: time.Date(2022, 2, 28, 12, 0, 0, 0, time.UTC),
+			},
+			{
+				title:      "February 29th, skipped",
+				rec:        Recurrence{Repeat: repeat.Yearly, Month: time.February, Day: 29, Fallback: FallbackSkip},
+				offset:     time.Hour * 12,
+				ref:        time.Date(2022, 10, 1, 0, 0, 0, 0, time.UTC),
+				expectNext: time.Date(2024, 2, 29, 12, 0, 0, 0, time.UTC),
+				expectPrev: time.Date(2020, 2, 29, 12, 0, 0, 0, time.UTC),
+			},
+		}
+	)
+
+	for _, c := range cases {
+		var (
+			r = Reminder{
+				Title:     c.title,
+				Timestamp: zero.Add(c.offset),
+				TimeZone:  "UTC",
+				Recur:     c.rec,
+			}
+			next = r.DueNext(&c.ref)
+			prev = r.DuePrev(&c.ref)
+		)
+
+		if !next.Equal(c.expectNext) {
+			t.Errorf("Unexpected next due time for %q: Expected %s, got %s",
+				c.title,
+				c.expectNext.Format(common.TimestampFormat),
+				next.Format(common.TimestampFormat))
+		}
+
+		if !prev.Equal(c.expectPrev) {
+			t.Errorf("Unexpected previous due time for %q: Expected %s, got %s",
+				c.title,
+				c.expectPrev.Format(common.TimestampFormat),
+				prev.Format(common.TimestampFormat))
+		}
+	}
+} // func TestDueMonthly(t *testing.T)
+
+func TestRecurrenceValidate(t *testing.T) {
+	type testCase struct {
+		rec   Recurrence
+		valid bool
+	}
+
+	var cases = []testCase{
+		{rec: Recurrence{Repeat: repeat.Yearly, Month: time.February, Day: 29}, valid: true},
+		{rec: Recurrence{Repeat: repeat.Yearly, Month: time.February, Day: 30}},
+		{rec: Recurrence{Repeat: repeat.Yearly, Month: time.February, Day: 31}},
+		{rec: Recurrence{Repeat: repeat.Yearly, Month: time.April, Day: 31}},
+		{rec: Recurrence{Repeat: repeat.Yearly, Month: time.December, Day: 31}, valid: true},
+		{rec: Recurrence{Repeat: repeat.Yearly, Month: 13, Day: 1}},
+		{rec: Recurrence{Repeat: repeat.Monthly, Day: 31}, valid: true},
+		{rec: Recurrence{Repeat: repeat.Monthly, Day: 32}},
+		{rec: Recurrence{Repeat: repeat.Daily}, valid: true},
+	}
+
+	for _, c := range cases {
+		var err = c.rec.Validate()
+
+		if c.valid && err != nil {
+			t.Errorf("Recurrence %s should be valid: %s", &c.rec, err.Error())
+		} else if !c.valid && err == nil {
+			t.Errorf("Recurrence %s on %s %d should be invalid",
+				&c.rec,
+				c.rec.Month,
+				c.rec.Day)
+		}
+	}
+} // func TestRecurrenceValidate(t *testing.T)
@@ -0,0 +1,419 @@
+// /home/krylon/go/src/github.com/blicero/theseus/objects/03_rrule_test.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 17. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <>
+
+package objects
+
+import (
+	"testing"
+	"time"
+
+	"github.com/blicero/theseus/common"
+	"github.com/blicero/theseus/objects/repeat"
+)
+
+func TestParseRRule(t *testing.T) {
+	var good = map[string]string{
+		"RRULE:FREQ=WEEKLY;BYDAY=MO,WE,FR":              "FREQ=WEEKLY;BYDAY=MO,WE,FR",
+		"freq=monthly;byday=-1fr":                       "FREQ=MONTHLY;BYDAY=-1FR",
+		"FREQ=DAILY;INTERVAL=3;COUNT=10":                "FREQ=DAILY;INTERVAL=3;COUNT=10",
+		"FREQ=YEARLY;BYMONTH=2;BYMONTHDAY=29":           "FREQ=YEARLY;BYMONTHDAY=29;BYMONTH=2",
+		"FREQ=MONTHLY;BYMONTHDAY=1,15;UNTIL=20230101T":  "",
+		"FREQ=HOURLY;INTERVAL=2;UNTIL=20221231T235959Z": "FREQ=HOURLY;INTERVAL=2;UNTIL=20221231T235959Z",
+	}
+
+	var bad = []string{
+		"",
+		"INTERVAL=2",
+		"FREQ=SECONDLY",
+		"FREQ=WEEKLY;BYDAY=2MO",
+		"FREQ=DAILY;COUNT=3;UNTIL=20221231",
+		"FREQ=DAILY;BYHOUR=8",
+		"FREQ=MONTHLY;BYMONTHDAY=32",
+	}
+
+	for src, expect := range good {
+		var (
+			err error
+			rr  *RRule
+		)
+
+		if rr, err = ParseRRule(src); err != nil {
+			if expect != "" {
+				t.Errorf("Cannot parse rule %q: %s", src, err.Error())
+			}
+		} else if expect == "" {
+			t.Errorf("Parsing rule %q should have failed", src)
+		} else if s := rr.String(); s != expect {
+			t.Errorf("Unexpected result for rule %q: %q (expected %q)",
+				src,
+				s,
+				expect)
+		}
+	}
+
+	for _, src := range bad {
+		if _, err := ParseRRule(src); err == nil {
+			t.Errorf("Parsing rule %q should have failed", src)
+		}
+	}
+} // func TestParseRRule(t *testing.T)
+
+func TestRRuleDue(t *testing.T) {
+	type testCase struct {
+		rule       string
+		start      time.Time
+		ref        time.Time
+		expectNext time.Time
+		expectPrev time.Time
+	}
+
+	var cases = []testCase{
+		{
+			rule:       "FREQ=WEEKLY;INTERVAL=2;BYDAY=TU,TH",
+			start:      time.Date(2022, 9, 6, 10, 0, 0, 0, time.UTC), // Tuesday
+			ref:        time.Date(2022, 9, 9, 0, 0, 0, 0, time.UTC),
+			expectNext: time.Date(2022, 9, 20, 10, 0, 0, 0, time.UTC),
+			expectPrev: time.Date(2022, 9, 8, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			rule:       "FREQ=MONTHLY;BYMONTHDAY=-1",
+			start:      time.Date(2022, 1, 1, 17, 0, 0, 0, time.UTC),
+			ref:        time.Date(2022, 2, 10, 0, 0, 0, 0, time.UTC),
+			expectNext: time.Date(2022, 2, 28, 17, 0, 0, 0, time.UTC),
+			expectPrev: time.Date(2022, 1, 31, 17, 0, 0, 0, time.UTC),
+		},
+		{
+			rule:       "FREQ=DAILY;COUNT=3",
+			start:      time.Date(2022, 9, 1, 8, 0, 0, 0, time.UTC),
+			ref:        time.Date(2022, 9, 5, 0, 0, 0, 0, time.UTC),
+			expectNext: time.Time{},
+			expectPrev: time.Date(2022, 9, 3, 8, 0, 0, 0, time.UTC),
+		},
+		{
+			rule:       "FREQ=DAILY;UNTIL=20220910T000000Z",
+			start:      time.Date(2022, 9, 1, 8, 0, 0, 0, time.UTC),
+			ref:        time.Date(2022, 9, 5, 9, 0, 0, 0, time.UTC),
+			expectNext: time.Date(2022, 9, 6, 8, 0, 0, 0, time.UTC),
+			expectPrev: time.Date(2022, 9, 5, 8, 0, 0, 0, time.UTC),
+		},
+		{
+			rule:       "FREQ=MONTHLY;INTERVAL=3;BYDAY=1MO",
+			start:      time.Date(2022, 1, 3, 9, 0, 0, 0, time.UTC),
+			ref:        time.Date(2022, 2, 1, 0, 0, 0, 0, time.UTC),
+			expectNext: time.Date(2022, 4, 4, 9, 0, 0, 0, time.UTC),
+			expectPrev: time.Date(2022, 1, 3, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			rule:       "FREQ=YEARLY;BYMONTH=11;BYDAY=4TH",
+			start:      time.Date(2020, 11, 26, 12, 0, 0, 0, time.UTC),
+			ref:        time.Date(2022, 10, 1, 0, 0, 0, 0, time.UTC),
+			expectNext: time.Date(2022, 11, 24, 12, 0, 0, 0, time.UTC),
+			expectPrev: time.Date(2021, 11, 25, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			rule:       "FREQ=HOURLY;INTERVAL=5;BYDAY=SA,SU",
+			start:      time.Date(2022, 9, 16, 0, 0, 0, 0, time.UTC), // Friday
+			ref:        time.Date(2022, 9, 16, 12, 0, 0, 0, time.UTC),
+			expectNext: time.Date(2022, 9, 17, 1, 0, 0, 0, time.UTC),
+			expectPrev: time.Date(2022, 9, 16, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, c := range cases {
+		var (
+			err  error
+			next time.Time
+			prev time.Time
+			r    = Reminder{
+				Title:     c.rule,
+				Timestamp: c.start,
+				TimeZone:  "UTC",
+			}
+		)
+
+		if err = r.SetRRule(c.rule); err != nil {
+			t.Errorf("Cannot set rule %q: %s", c.rule, err.Error())
+			continue
+		} else if r.Recur.Repeat != repeat.RRule {
+			t.Errorf("Rule %q should have been stored verbatim, got %s",
+				c.rule,
+				&r.Recur)
+			continue
+		}
+
+		next = r.DueNext(&c.ref)
+		prev = r.DuePrev(&c.ref)
+
+		if !next.Equal(c.expectNext) {
+			t.Errorf("Unexpected next due time for %q: Expected %s, got %s",
+				c.rule,
+				c.expectNext.Format(common.TimestampFormat),
+				next.Format(common.TimestampFormat))
+		}
+
+		if !prev.Equal(c.expectPrev) {
+			t.Errorf("Unexpected previous due time for %q: Expected %s, got %s",
+				c.rule,
+				c.expectPrev.Format(common.TimestampFormat),
+				prev.Format(common.TimestampFormat))
+		}
+	}
+} // func TestRRuleDue(t *testing.T)
+
+func TestRRuleNative(t *testing.T) {
+	type testCase struct {
+		rule   string
+		expect Recurrence
+	}
+
+	var (
+		start = time.Date(2022, 9, 15, 7, 30, 0, 0, time.UTC) // Thursday
+		off   = 7*3600 + 30*60
+		cases = []testCase{
+			{
+				rule:   "FREQ=DAILY",
+				expect: Recurrence{Repeat: repeat.Daily, Offset: off},
+			},
+			{
+				rule: "FREQ=WEEKLY",
+				expect: Recurrence{
+					Repeat: repeat.Custom,
+					Offset: off,
+					Days:   Weekdays{false, false, false, true, false, false, false},
+				},
+			},
+			{
+				rule: "FREQ=MONTHLY;BYDAY=3TH",
+				expect: Recurrence{
+					Repeat:   repeat.MonthlyWeekday,
+					Offset:   off,
+					Week:     3,
+					Days:     Weekdays{false, false, false, true, false, false, false},
+					Fallback: FallbackSkip,
+				},
+			},
+			{
+				rule: "FREQ=YEARLY",
+				expect: Recurrence{
+					Repeat:   repeat.Yearly,
+					Offset:   off,
+					Month:    time.September,
+					Day:      15,
+					Fallback: FallbackSkip,
+				},
+			},
+			{
+				rule: "FREQ=MINUTELY;INTERVAL=45",
+				expect: Recurrence{
+					Repeat: repeat.Interval,
+					Offset: int(start.Unix()),
+					Period: 45 * 60,
+				},
+			},
+		}
+	)
+
+	for _, c := range cases {
+		var r = Reminder{Title: c.rule, Timestamp: start, TimeZone: "UTC"}
+
+		if err := r.SetRRule(c.rule); err != nil {
+			t.Errorf("Cannot set rule %q: %s", c.rule, err.Error())
+		} else if !r.Recur.Equal(&c.expect) {
+			t.Errorf("Unexpected Recurrence for rule %q: %#v (expected %#v)",
+				c.rule,
+				r.Recur,
+				c.expect)
+		}
+	}
+} // func TestRRuleNative(t *testing.T)
+
+// TestRRuleBounds checks COUNT and UNTIL with a DTSTART that does not match
+// the rule, and a floating UNTIL in a time zone other than UTC.
+func TestRRuleBounds(t *testing.T) {
+	type testCase struct {
+		rule       string
+		zone       string
+		start      time.Time
+		ref        time.Time
+		expectNext time.Time
+		expectPrev time.Time
+	}
+
+	var (
+		berlin, _  = time.LoadLocation("Europe/Berlin")
+		newYork, _ = time.LoadLocation("America/New_York")
+		cases      = []testCase{
+			{
+				// DTSTART is a Monday, it counts as the first of two.
+				rule:       "FREQ=WEEKLY;BYDAY=TU;COUNT=2",
+				zone:       "UTC",
+				start:      time.Date(2022, 9, 5, 10, 0, 0, 0, time.UTC),
+				ref:        time.Date(2022, 9, 6, 12, 0, 0, 0, time.UTC),
+				expectNext: time.Time{},
+				expectPrev: time.Date(2022, 9, 6, 10, 0, 0, 0, time.UTC),
+			},
+			{
+				// UNTIL is 08:30 in Berlin, so there is nothing on
+				// the 31st.
+				rule:       "FREQ=DAILY;UNTIL=20261231T083000",
+				zone:       "Europe/Berlin",
+				start:      time.Date(2026, 12, 28, 9, 0, 0, 0, berlin),
+				ref:        time.Date(2026, 12, 30, 12, 0, 0, 0, berlin),
+				expectNext: time.Time{},
+				expectPrev: time.Date(2026, 12, 30, 9, 0, 0, 0, berlin),
+			},
+			{
+				// UNTIL is the end of the 30th in New York, which is
+				// after 8 PM local time.
+				rule:       "FREQ=DAILY;UNTIL=20261230",
+				zone:       "America/New_York",
+				start:      time.Date(2026, 12, 28, 20, 0, 0, 0, newYork),
+				ref:        time.Date(2026, 12, 30, 12, 0, 0, 0, newYork),
+				expectNext: time.Date(2026, 12, 30, 20, 0, 0, 0, newYork),
+				expectPrev: time.Date(2026, 12, 29, 20, 0, 0, 0, newYork),
+			},
+		}
+	)
+
+	for _, c := range cases {
+		var (
+			err  error
+			next time.Time
+			prev time.Time
+			r    = Reminder{
+				Title:     c.rule,
+				Timestamp: c.start,
+				TimeZone:  c.zone,
+			}
+		)
+
+		if err = r.SetRRule(c.rule); err != nil {
+			t.Errorf("Cannot set rule %q: %s", c.rule, err.Error())
+			continue
+		}
+
+		next = r.DueNext(&c.ref)
+		prev = r.DuePrev(&c.ref)
+
+		if !next.Equal(c.expectNext) {
+			t.Errorf("Unexpected next due time for %q: Expected %s, got %s",
+				c.rule,
+				c.expectNext.Format(common.TimestampFormat),
+				next.Format(common.TimestampFormat))
+		}
+
+		if !prev.Equal(c.expectPrev) {
+			t.Errorf("Unexpected previous due time for %q: Expected %s, got %s",
+				c.rule,
+				c.expectPrev.Format(common.TimestampFormat),
+				prev.Format(common.TimestampFormat))
+		}
+	}
+} // func TestRRuleBounds(t *testing.T)
+
+// TestRRuleStart checks that rules are kept verbatim if converting them
+// to one of the other repeat modes would lose DTSTART.
+func TestRRuleStart(t *testing.T) {
+	type testCase struct {
+		rule    string
+		start   time.Time
+		ref     time.Time
+		expect1 time.Time
+		expect2 time.Time
+	}
+
+	var (
+		now   = time.Now().UTC()
+		later = time.Date(now.Year()+1, now.Month(), 15, 9, 0, 0, 0, time.UTC)
+		cases = []testCase{
+			{
+				// DTSTART is a year from now, nothing happens
+				// before.
+				rule:    "FREQ=MONTHLY;BYMONTHDAY=15",
+				start:   later,
+				ref:     now,
+				expect1: later,
+				expect2: later.AddDate(0, 1, 0),
+			},
+			{
+				// DTSTART is a Wednesday, it is the first
+				// occurrence all the same.
+				rule:    "FREQ=WEEKLY;BYDAY=MO",
+				start:   time.Date(2022, 9, 14, 8, 0, 0, 0, time.UTC),
+				ref:     time.Date(2022, 9, 13, 0, 0, 0, 0, time.UTC),
+				expect1: time.Date(2022, 9, 14, 8, 0, 0, 0, time.UTC),
+				expect2: time.Date(2022, 9, 19, 8, 0, 0, 0, time.UTC),
+			},
+		}
+	)
+
+	for _, c := range cases {
+		var (
+			err    error
+			t1, t2 time.Time
+			r      = Reminder{
+				Title:     c.rule,
+				Timestamp: c.start,
+				TimeZone:  "UTC",
+			}
+		)
+
+		if err = r.SetRRule(c.rule); err != nil {
+			t.Errorf("Cannot set rule %q: %s", c.rule, err.Error())
+			continue
+		} else if r.Recur.Repeat != repeat.RRule {
+			t.Errorf("Rule %q should have been stored verbatim, got %s",
+				c.rule,
+				&r.Recur)
+			continue
+		}
+
+		t1 = r.DueNext(&c.ref)
+		t2 = t1.Add(time.Minute)
+		t2 = r.DueNext(&t2)
+
+		if !t1.Equal(c.expect1) {
+			t.Errorf("Unexpected first due time for %q: Expected %s, got %s",
+				c.rule,
+				c.expect1.Format(common.TimestampFormat),
+				t1.Format(common.TimestampFormat))
+		} else if !t2.Equal(c.expect2) {
+			t.Errorf("Unexpected second due time for %q: Expected %s, got %s",
+				c.rule,
+				c.expect2.Format(common.TimestampFormat),
+				t2.Format(common.TimestampFormat))
+		}
+	}
+} // func TestRRuleStart(t *testing.T)
+
+func TestRRuleCache(t *testing.T) {
+	var (
+		err    error
+		r1, r2 *RRule
+		r      = Reminder{
+			Title:     "Cache",
+			Timestamp: time.Date(2022, 9, 14, 8, 0, 0, 0, time.UTC),
+			TimeZone:  "UTC",
+			Recur:     Recurrence{Repeat: repeat.RRule, Rule: "FREQ=DAILY;BYMONTHDAY=1,15"},
+		}
+	)
+
+	if r1, err = r.RRule(); err != nil {
+		t.Fatalf("Cannot parse rule %q: %s", r.Recur.Rule, err.Error())
+	} else if r2, _ = r.RRule(); r1 != r2 {
+		t.Errorf("Rule %q was parsed twice", r.Recur.Rule)
+	}
+
+	r.Recur.Rule = "FREQ=FORTNIGHTLY"
+
+	if _, err = r.RRule(); err == nil {
+		t.Errorf("Parsing rule %q should have failed", r.Recur.Rule)
+	} else if d := r.DueNext(nil); !d.IsZero() {
+		t.Errorf("Reminder with broken rule %q should not be due, but is due at %s",
+			r.Recur.Rule,
+			d.Format(common.TimestampFormat))
+	}
+} // func TestRRuleCache(t *testing.T)
@@ -0,0 +1,159 @@
+// /home/krylon/go/src/github.com/blicero/theseus/objects/04_zone_test.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 17. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <>
+
+package objects
+
+import (
+	"testing"
+	"time"
+
+	"github.com/blicero/theseus/common"
+	"github.com/blicero/theseus/objects/repeat"
+)
+
+func TestDueTimeZone(t *testing.T) {
+	const zone = "Europe/Berlin"
+
+	type testCase struct {
+		title      string
+		offset     time.Duration
+		rec        Recurrence
+		ref        time.Time
+		expectNext time.Time
+		expectPrev time.Time
+	}
+
+	var (
+		err   error
+		loc   *time.Location
+		zero  = time.Unix(0, 0)
+		cases []testCase
+	)
+
+	if loc, err = time.LoadLocation(zone); err != nil {
+		t.Skipf("Time zone %s is not available: %s", zone, err.Error())
+	}
+
+	cases = []testCase{
+		{
+			title:      "Daily 08:00 before DST",
+			offset:     time.Hour * 8,
+			rec:        Recurrence{Repeat: repeat.Daily},
+			ref:        time.Date(2022, 3, 26, 12, 0, 0, 0, loc),
+			expectNext: time.Date(2022, 3, 27, 8, 0, 0, 0, loc),
+			expectPrev: time.Date(2022, 3, 26, 8, 0, 0, 0, loc),
+		},
+		{
+			title:      "Daily 08:00 after DST",
+			offset:     time.Hour * 8,
+			rec:        Recurrence{Repeat: repeat.Daily},
+			ref:        time.Date(2022, 10, 30, 12, 0, 0, 0, loc),
+			expectNext: time.Date(2022, 10, 31, 8, 0, 0, 0, loc),
+			expectPrev: time.Date(2022, 10, 30, 8, 0, 0, 0, loc),
+		},
+		{
+			title:      "Daily 02:30 in the gap",
+			offset:     time.Hour*2 + time.Minute*30,
+			rec:        Recurrence{Repeat: repeat.Daily},
+			ref:        time.Date(2022, 3, 27, 0, 0, 0, 0, loc),
+			expectNext: time.Date(2022, 3, 27, 1, 30, 0, 0, time.UTC), // 03:30 CEST
+			expectPrev: time.Date(2022, 3, 26, 2, 30, 0, 0, loc),
+		},
+		{
+			title:      "Daily 02:30 in the overlap",
+			offset:     time.Hour*2 + time.Minute*30,
+			rec:        Recurrence{Repeat: repeat.Daily},
+			ref:        time.Date(2022, 10, 30, 0, 0, 0, 0, loc),
+			expectNext: time.Date(2022, 10, 30, 0, 30, 0, 0, time.UTC), // 02:30 CEST
+			expectPrev: time.Date(2022, 10, 29, 2, 30, 0, 0, loc),
+		},
+		{
+			title:  "Monthly 15th 09:00",
+			offset: time.Hour * 9,
+			rec: Recurrence{
+				Repeat: repeat.Monthly,
+				Day:    15,
+			},
+			ref:        time.Date(2022, 3, 20, 0, 0, 0, 0, loc),
+			expectNext: time.Date(2022, 4, 15, 9, 0, 0, 0, loc),
+			expectPrev: time.Date(2022, 3, 15, 9, 0, 0, 0, loc),
+		},
+	}
+
+	for _, c := range cases {
+		var (
+			next, prev time.Time
+			r          = Reminder{
+				Title:     c.title,
+				Timestamp: zero.Add(c.offset),
+				Recur:     c.rec,
+				TimeZone:  zone,
+			}
+		)
+
+		r.Recur.Offset = int(c.offset / time.Second)
+
+		next = r.DueNext(&c.ref)
+		prev = r.DuePrev(&c.ref)
+
+		if !next.Equal(c.expectNext) {
+			t.Errorf("Unexpected next due time for %s: Expected %s, got %s",
+				c.title,
+				c.expectNext.Format(common.TimestampFormat),
+				next.Format(common.TimestampFormat))
+		}
+
+		if !prev.Equal(c.expectPrev) {
+			t.Errorf("Unexpected previous due time for %s: Expected %s, got %s",
+				c.title,
+				c.expectPrev.Format(common.TimestampFormat),
+				prev.Format(common.TimestampFormat))
+		}
+	}
+} // func TestDueTimeZone(t *testing.T)
+
+func TestRRuleTimeZone(t *testing.T) {
+	const zone = "America/New_York"
+
+	var (
+		err        error
+		loc        *time.Location
+		ref        time.Time
+		next, prev time.Time
+		r          Reminder
+	)
+
+	if loc, err = time.LoadLocation(zone); err != nil {
+		t.Skipf("Time zone %s is not available: %s", zone, err.Error())
+	}
+
+	r = Reminder{
+		Title:     "Weekly meeting",
+		Timestamp: time.Date(2022, 10, 31, 9, 0, 0, 0, loc), // Monday
+		TimeZone:  zone,
+	}
+	ref = time.Date(2022, 11, 8, 0, 0, 0, 0, loc)
+
+	if err = r.SetRRule("FREQ=WEEKLY;INTERVAL=1;COUNT=5;BYDAY=MO"); err != nil {
+		t.Fatalf("Cannot set rule: %s", err.Error())
+	}
+
+	// DST ends on November 6th, 2022, in New York.
+	next = r.DueNext(&ref)
+	prev = r.DuePrev(&ref)
+
+	if expect := time.Date(2022, 11, 14, 9, 0, 0, 0, loc); !next.Equal(expect) {
+		t.Errorf("Unexpected next due time: Expected %s, got %s",
+			expect.Format(common.TimestampFormat),
+			next.Format(common.TimestampFormat))
+	}
+
+	if expect := time.Date(2022, 11, 7, 9, 0, 0, 0, loc); !prev.Equal(expect) {
+		t.Errorf("Unexpected previous due time: Expected %s, got %s",
+			expect.Format(common.TimestampFormat),
+			prev.Format(common.TimestampFormat))
+	}
+} // func TestRRuleTimeZone(t *testing.T)
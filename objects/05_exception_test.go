@@ -0,0 +1,94 @@
+// /home/krylon/go/src/github.com/blicero/theseus/objects/05_exception_test.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 17. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <>
+
+package objects
+
+import (
+	"testing"
+	"time"
+
+	"github.com/blicero/theseus/common"
+	"github.com/blicero/theseus/objects/repeat"
+)
+
+func TestDueException(t *testing.T) {
+	var (
+		ref = time.Date(2022, 9, 14, 12, 0, 0, 0, time.UTC) // Wednesday
+		r   = Reminder{
+			Title:     "Standup",
+			Timestamp: time.Unix(3600*9, 0),
+			TimeZone:  "UTC",
+			Recur: Recurrence{
+				Repeat: repeat.Daily,
+				Offset: 3600 * 9,
+			},
+			Exceptions: []Exception{
+				// Skip Thursday
+				{Occurrence: time.Date(2022, 9, 15, 9, 0, 0, 0, time.UTC)},
+				// Move Friday to 10:00
+				{
+					Occurrence: time.Date(2022, 9, 16, 9, 0, 0, 0, time.UTC),
+					Due:        time.Date(2022, 9, 16, 10, 0, 0, 0, time.UTC),
+				},
+				// Move Sunday to Saturday evening
+				{
+					Occurrence: time.Date(2022, 9, 18, 9, 0, 0, 0, time.UTC),
+					Due:        time.Date(2022, 9, 17, 20, 0, 0, 0, time.UTC),
+				},
+			},
+		}
+		cases = []struct {
+			ref, next, prev time.Time
+		}{
+			{
+				ref:  ref,
+				next: time.Date(2022, 9, 16, 10, 0, 0, 0, time.UTC),
+				prev: time.Date(2022, 9, 14, 9, 0, 0, 0, time.UTC),
+			},
+			{
+				ref:  time.Date(2022, 9, 16, 9, 30, 0, 0, time.UTC),
+				next: time.Date(2022, 9, 16, 10, 0, 0, 0, time.UTC),
+				prev: time.Date(2022, 9, 14, 9, 0, 0, 0, time.UTC),
+			},
+			{
+				ref:  time.Date(2022, 9, 16, 12, 0, 0, 0, time.UTC),
+				next: time.Date(2022, 9, 17, 9, 0, 0, 0, time.UTC),
+				prev: time.Date(2022, 9, 16, 10, 0, 0, 0, time.UTC),
+			},
+			{
+				ref:  time.Date(2022, 9, 17, 12, 0, 0, 0, time.UTC),
+				next: time.Date(2022, 9, 17, 20, 0, 0, 0, time.UTC),
+				prev: time.Date(2022, 9, 17, 9, 0, 0, 0, time.UTC),
+			},
+			{
+				ref:  time.Date(2022, 9, 18, 12, 0, 0, 0, time.UTC),
+				next: time.Date(2022, 9, 19, 9, 0, 0, 0, time.UTC),
+				prev: time.Date(2022, 9, 17, 20, 0, 0, 0, time.UTC),
+			},
+		}
+	)
+
+	for i, c := range cases {
+		var (
+			next = r.DueNext(&c.ref)
+			prev = r.DuePrev(&c.ref)
+		)
+
+		if !next.Equal(c.next) {
+			t.Errorf("Case %d: Unexpected next due time: Expected %s, got %s",
+				i,
+				c.next.Format(common.TimestampFormat),
+				next.Format(common.TimestampFormat))
+		}
+
+		if !prev.Equal(c.prev) {
+			t.Errorf("Case %d: Unexpected previous due time: Expected %s, got %s",
+				i,
+				c.prev.Format(common.TimestampFormat),
+				prev.Format(common.TimestampFormat))
+		}
+	}
+} // func TestDueException(t *testing.T)
@@ -0,0 +1,155 @@
+// /home/krylon/go/src/github.com/blicero/theseus/objects/06_holiday_test.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 17. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <>
+
+package objects
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/blicero/theseus/common"
+	"github.com/blicero/theseus/objects/repeat"
+)
+
+const testHolidayList = `
+# Some German holidays
+2022-10-03	Tag der Deutschen Einheit
+12-25 1. Weihnachtstag
+12-26 2. Weihnachtstag
+`
+
+const testHolidayICS = "BEGIN:VCALENDAR\r\n" +
+	"VERSION:2.0\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"DTSTART;VALUE=DATE:20221003\r\n" +
+	"SUMMARY:Tag der Deutschen \r\n" +
+	" Einheit\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"DTSTART;VALUE=DATE:20221225\r\n" +
+	"DTEND;VALUE=DATE:20221227\r\n" +
+	"RRULE:FREQ=YEARLY\r\n" +
+	"SUMMARY:Weihnachten\r\n" +
+	"END:VEVENT\r\n" +
+	"END:VCALENDAR\r\n"
+
+func TestHolidayParse(t *testing.T) {
+	var (
+		err    error
+		list   *Calendar
+		ics    *Calendar
+		checks = []struct {
+			day     time.Time
+			holiday bool
+		}{
+			{time.Date(2022, 10, 3, 0, 0, 0, 0, time.UTC), true},
+			{time.Date(2023, 10, 3, 0, 0, 0, 0, time.UTC), false},
+			{time.Date(2022, 12, 25, 0, 0, 0, 0, time.UTC), true},
+			{time.Date(2030, 12, 26, 0, 0, 0, 0, time.UTC), true},
+			{time.Date(2030, 12, 27, 0, 0, 0, 0, time.UTC), false},
+		}
+	)
+
+	if list, err = ParseHolidayList("list", strings.NewReader(testHolidayList)); err != nil {
+		t.Fatalf("Cannot parse list of holidays: %s", err.Error())
+	} else if ics, err = ParseICS("ics", strings.NewReader(testHolidayICS)); err != nil {
+		t.Fatalf("Cannot parse ICS file: %s", err.Error())
+	} else if name, ok := ics.Holiday(checks[0].day); !ok || name != "Tag der Deutschen Einheit" {
+		t.Errorf("Unexpected name of holiday from ICS file: %q", name)
+	}
+
+	for _, cal := range []*Calendar{list, ics} {
+		for _, c := range checks {
+			if _, ok := cal.Holiday(c.day); ok != c.holiday {
+				t.Errorf("Calendar %s: Holiday(%s) = %t, expected %t",
+					cal.Name,
+					c.day.Format(common.TimestampFormat),
+					ok,
+					c.holiday)
+			}
+		}
+	}
+
+	if _, err = ParseHolidayList("broken", strings.NewReader("Christmas 12-25\n")); err == nil {
+		t.Errorf("Parsing an invalid list of holidays should have failed")
+	}
+} // func TestHolidayParse(t *testing.T)
+
+func TestDueHoliday(t *testing.T) {
+	var (
+		err error
+		cal *Calendar
+		ref = time.Date(2022, 9, 30, 12, 0, 0, 0, time.UTC) // Friday
+		r   = Reminder{
+			Title:     "Standup",
+			Timestamp: time.Unix(3600*9, 0),
+			TimeZone:  "UTC",
+			Recur: Recurrence{
+				Repeat:   repeat.Custom,
+				Offset:   3600 * 9,
+				Days:     Weekdays{true, true, true, true, true, false, false},
+				Calendar: "test-holidays",
+			},
+		}
+		monday  = time.Date(2022, 10, 3, 9, 0, 0, 0, time.UTC)
+		tuesday = time.Date(2022, 10, 4, 9, 0, 0, 0, time.UTC)
+	)
+
+	if cal, err = ParseHolidayList("test-holidays", strings.NewReader(testHolidayList)); err != nil {
+		t.Fatalf("Cannot parse list of holidays: %s", err.Error())
+	}
+
+	RegisterCalendar(cal)
+
+	if next := r.DueNext(&ref); !next.Equal(monday) {
+		t.Errorf("Holidays should be ignored by default, next due time is %s",
+			next.Format(common.TimestampFormat))
+	}
+
+	r.Recur.Holidays = HolidaySkip
+
+	if next := r.DueNext(&ref); !next.Equal(tuesday) {
+		t.Errorf("Unexpected next due time when skipping holidays: %s",
+			next.Format(common.TimestampFormat))
+	}
+
+	// With HolidayShift, Monday's occurrence is moved to Tuesday, which
+	// coincides with Tuesday's regular occurrence.
+	r.Recur.Holidays = HolidayShift
+	ref = time.Date(2022, 10, 3, 12, 0, 0, 0, time.UTC)
+
+	if next := r.DueNext(&ref); !next.Equal(tuesday) {
+		t.Errorf("Unexpected next due time when shifting holidays: %s",
+			next.Format(common.TimestampFormat))
+	} else if prev := r.DuePrev(&ref); !prev.Equal(time.Date(2022, 9, 30, 9, 0, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected previous due time when shifting holidays: %s",
+			prev.Format(common.TimestampFormat))
+	}
+
+	// A yearly Reminder on Christmas is shifted past the second holiday
+	// and the weekend.
+	r.Recur = Recurrence{
+		Repeat:   repeat.Yearly,
+		Offset:   3600 * 9,
+		Day:      25,
+		Month:    time.December,
+		Calendar: "test-holidays",
+		Holidays: HolidayShift,
+	}
+
+	if next := r.DueNext(&ref); !next.Equal(time.Date(2022, 12, 27, 9, 0, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected next due time for shifted yearly Reminder: %s",
+			next.Format(common.TimestampFormat))
+	}
+
+	r.Recur.Calendar = "no-such-calendar"
+
+	if next := r.DueNext(&ref); !next.Equal(time.Date(2022, 12, 25, 9, 0, 0, 0, time.UTC)) {
+		t.Errorf("Unknown calendars should be ignored, next due time is %s",
+			next.Format(common.TimestampFormat))
+	}
+} // func TestDueHoliday(t *testing.T)
@@ -0,0 +1,145 @@
+// /home/krylon/go/src/github.com/blicero/theseus/objects/07_occurrence_test.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 17. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <>
+
+package objects
+
+import (
+	"testing"
+	"time"
+
+	"github.com/blicero/theseus/common"
+	"github.com/blicero/theseus/objects/repeat"
+)
+
+func TestExpand(t *testing.T) {
+	var (
+		from  = time.Date(2022, 9, 14, 0, 0, 0, 0, time.UTC)
+		to    = time.Date(2022, 9, 19, 0, 0, 0, 0, time.UTC)
+		items = []Reminder{
+			{
+				ID:        1,
+				Title:     "Standup",
+				Timestamp: time.Unix(3600*9, 0),
+				TimeZone:  "UTC",
+				Recur: Recurrence{
+					Repeat: repeat.Daily,
+					Offset: 3600 * 9,
+				},
+				Exceptions: []Exception{
+					{Occurrence: time.Date(2022, 9, 15, 9, 0, 0, 0, time.UTC)},
+					{
+						Occurrence: time.Date(2022, 9, 16, 9, 0, 0, 0, time.UTC),
+						Due:        time.Date(2022, 9, 16, 10, 0, 0, 0, time.UTC),
+					},
+					{
+						Occurrence: time.Date(2022, 9, 18, 9, 0, 0, 0, time.UTC),
+						Due:        time.Date(2022, 9, 17, 20, 0, 0, 0, time.UTC),
+					},
+				},
+			},
+			{
+				ID:        2,
+				Title:     "Dentist",
+				Timestamp: time.Date(2022, 9, 15, 12, 0, 0, 0, time.UTC),
+			},
+			{
+				ID:        3,
+				Title:     "Too late",
+				Timestamp: time.Date(2022, 9, 19, 0, 0, 0, 0, time.UTC),
+			},
+			{
+				ID:        4,
+				Title:     "Water plants",
+				Timestamp: time.Date(2022, 9, 13, 9, 0, 0, 0, time.UTC),
+				TimeZone:  "UTC",
+				Recur: Recurrence{
+					Repeat: repeat.Interval,
+					Period: 2 * 86400,
+				},
+			},
+		}
+		expect = []struct {
+			id    int64
+			stamp time.Time
+		}{
+			{1, time.Date(2022, 9, 14, 9, 0, 0, 0, time.UTC)},
+			{4, time.Date(2022, 9, 15, 9, 0, 0, 0, time.UTC)},
+			{2, time.Date(2022, 9, 15, 12, 0, 0, 0, time.UTC)},
+			{1, time.Date(2022, 9, 16, 10, 0, 0, 0, time.UTC)},
+			{1, time.Date(2022, 9, 17, 9, 0, 0, 0, time.UTC)},
+			{4, time.Date(2022, 9, 17, 9, 0, 0, 0, time.UTC)},
+			{1, time.Date(2022, 9, 17, 20, 0, 0, 0, time.UTC)},
+		}
+	)
+
+	var occ = Expand(items, from, to)
+
+	if len(occ) != len(expect) {
+		t.Fatalf("Unexpected number of occurrences: Expected %d, got %d\n%v",
+			len(expect),
+			len(occ),
+			occ)
+	}
+
+	for i, e := range expect {
+		if occ[i].ReminderID != e.id || !occ[i].Timestamp.Equal(e.stamp) {
+			t.Errorf("Occurrence %d: Expected #%d at %s, got %s",
+				i,
+				e.id,
+				e.stamp.Format(common.TimestampFormat),
+				occ[i].String())
+		} else if occ[i].State != OccurrenceScheduled {
+			t.Errorf("Occurrence %d should be %s, not %s",
+				i,
+				OccurrenceScheduled,
+				occ[i].State)
+		}
+	}
+} // func TestExpand(t *testing.T)
+
+func TestOccurrencesLimit(t *testing.T) {
+	var (
+		now  = time.Now()
+		from = now.AddDate(0, 0, -10)
+		to   = now.AddDate(0, 0, 10)
+		r    = Reminder{
+			Title:     "Take pills",
+			Timestamp: time.Unix(3600*9, 0),
+			TimeZone:  "UTC",
+			Recur: Recurrence{
+				Repeat:  repeat.Daily,
+				Offset:  3600 * 9,
+				Limit:   12,
+				Counter: 9,
+			},
+		}
+		future int
+	)
+
+	for _, o := range r.Occurrences(from, to) {
+		if !o.Before(now.Truncate(time.Minute)) {
+			future++
+		}
+	}
+
+	if future != 3 {
+		t.Errorf("Expected 3 more occurrences, got %d", future)
+	}
+
+	r.Finished = true
+	future = 0
+
+	for _, o := range r.Occurrences(from, to) {
+		if !o.Before(now.Truncate(time.Minute)) {
+			future++
+		}
+	}
+
+	if future != 0 {
+		t.Errorf("Finished Reminder should not occur again, got %d more occurrences",
+			future)
+	}
+} // func TestOccurrencesLimit(t *testing.T)
@@ -0,0 +1,176 @@
+// /home/krylon/go/src/github.com/blicero/theseus/objects/08_phrase_test.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 17. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <>
+
+package objects
+
+import (
+	"testing"
+	"time"
+
+	"github.com/blicero/theseus/common"
+	"github.com/blicero/theseus/objects/repeat"
+)
+
+func TestParseReminder(t *testing.T) {
+	var (
+		loc, err = time.LoadLocation("Europe/Berlin")
+		now      time.Time
+	)
+
+	if err != nil {
+		t.Skipf("Cannot load time zone: %s", err.Error())
+	}
+
+	now = time.Date(2026, 10, 15, 10, 30, 0, 0, loc) // Thursday
+
+	type testCase struct {
+		phrase string
+		title  string
+		rep    repeat.Repeat
+		next   time.Time
+		err    bool
+	}
+
+	var cases = []testCase{
+		{
+			phrase: "in 20 minutes tea",
+			title:  "tea",
+			rep:    repeat.Once,
+			next:   time.Date(2026, 10, 15, 10, 50, 0, 0, loc),
+		},
+		{
+			phrase: "call mom tomorrow 9:00",
+			title:  "call mom",
+			rep:    repeat.Once,
+			next:   time.Date(2026, 10, 16, 9, 0, 0, 0, loc),
+		},
+		{
+			phrase: "next friday at 14:30 dentist",
+			title:  "dentist",
+			rep:    repeat.Once,
+			next:   time.Date(2026, 10, 16, 14, 30, 0, 0, loc),
+		},
+		{
+			phrase: "every weekday at 8 standup",
+			title:  "standup",
+			rep:    repeat.Custom,
+			next:   time.Date(2026, 10, 16, 8, 0, 0, 0, loc),
+		},
+		{
+			phrase: "pay rent on the 3rd of every month",
+			title:  "pay rent",
+			rep:    repeat.Monthly,
+			next:   time.Date(2026, 11, 3, 9, 0, 0, 0, loc),
+		},
+		{
+			phrase: "dinner at Mario's at 8pm",
+			title:  "dinner at Mario's",
+			rep:    repeat.Once,
+			next:   time.Date(2026, 10, 15, 20, 0, 0, 0, loc),
+		},
+		{
+			phrase: "every other monday at 9 retro",
+			title:  "retro",
+			rep:    repeat.RRule,
+			next:   time.Date(2026, 10, 19, 9, 0, 0, 0, loc),
+		},
+		{
+			phrase: "meds every day at 8, 12 and 18:00",
+			title:  "meds",
+			rep:    repeat.Daily,
+			next:   time.Date(2026, 10, 15, 12, 0, 0, 0, loc),
+		},
+		{
+			phrase: "Müll rausbringen jeden Montag um 7 Uhr",
+			title:  "Müll rausbringen",
+			rep:    repeat.Custom,
+			next:   time.Date(2026, 10, 19, 7, 0, 0, 0, loc),
+		},
+		{
+			phrase: "jeden ersten Montag im Monat um 14 Uhr Teamrunde",
+			title:  "Teamrunde",
+			rep:    repeat.MonthlyWeekday,
+			next:   time.Date(2026, 11, 2, 14, 0, 0, 0, loc),
+		},
+		{
+			phrase: "Zahnarzt am 20.10. um 14.30 Uhr",
+			title:  "Zahnarzt",
+			rep:    repeat.Once,
+			next:   time.Date(2026, 10, 20, 14, 30, 0, 0, loc),
+		},
+		{
+			phrase: "in einer halben Stunde Tee",
+			title:  "Tee",
+			rep:    repeat.Once,
+			next:   time.Date(2026, 10, 15, 11, 0, 0, 0, loc),
+		},
+		{
+			phrase: "Sport um 20 am Montag",
+			title:  "Sport",
+			rep:    repeat.Once,
+			next:   time.Date(2026, 10, 19, 20, 0, 0, 0, loc),
+		},
+		{
+			phrase: "Geburtstag am 29. Februar",
+			title:  "Geburtstag",
+			rep:    repeat.Once,
+			next:   time.Date(2028, 2, 29, 9, 0, 0, 0, loc),
+		},
+		{
+			phrase: "do laundry on Mo",
+			title:  "do laundry",
+			rep:    repeat.Once,
+			next:   time.Date(2026, 10, 19, 9, 0, 0, 0, loc),
+		},
+		{
+			phrase: "nothing to see here",
+			err:    true,
+		},
+		{
+			phrase: "tomorrow at 10",
+			err:    true,
+		},
+	}
+
+	for _, c := range cases {
+		var (
+			r    *Reminder
+			next time.Time
+		)
+
+		if r, err = ParseReminder(c.phrase, now); err != nil {
+			if !c.err {
+				t.Errorf("Cannot parse %q: %s", c.phrase, err.Error())
+			}
+			continue
+		} else if c.err {
+			t.Errorf("Parsing %q should have failed, got %q (%s)",
+				c.phrase,
+				r.Title,
+				r.Recur.String())
+			continue
+		}
+
+		next = r.DueNext(&now)
+
+		if r.Title != c.title {
+			t.Errorf("Unexpected title for %q: Expected %q, got %q",
+				c.phrase,
+				c.title,
+				r.Title)
+		} else if r.Recur.Repeat != c.rep {
+			t.Errorf("Unexpected recurrence for %q: Expected %s, got %s",
+				c.phrase,
+				c.rep,
+				r.Recur.String())
+		} else if !next.Equal(c.next) {
+			t.Errorf("Unexpected due time for %q: Expected %s, got %s",
+				c.phrase,
+				c.next.Format(common.TimestampFormat),
+				next.In(loc).Format(common.TimestampFormat))
+		}
+	}
+} // func TestParseReminder(t *testing.T)
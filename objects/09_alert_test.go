@@ -0,0 +1,115 @@
+// /home/krylon/go/src/github.com/blicero/theseus/objects/09_alert_test.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 17. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <>
+
+package objects
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseAlerts(t *testing.T) {
+	type testCase struct {
+		str    string
+		alerts []int
+		fmt    string
+		err    bool
+	}
+
+	var cases = []testCase{
+		{str: "", alerts: nil, fmt: ""},
+		{str: "-15m", alerts: []int{900}, fmt: "-15m"},
+		{str: "-1d, -1h, -15m", alerts: []int{86400, 3600, 900}, fmt: "-1d, -1h, -15m"},
+		{str: "-15m,-1d,-15m", alerts: []int{86400, 900}, fmt: "-1d, -15m"},
+		{str: "90m 1h30m", alerts: []int{5400}, fmt: "-1h30m"},
+		{str: "-1w", alerts: []int{604800}, fmt: "-1w"},
+		{str: "-0m", err: true},
+		{str: "-5w", err: true},
+		{str: "-15", err: true},
+		{str: "-1h+15m", err: true},
+	}
+
+	for _, c := range cases {
+		var (
+			err    error
+			alerts []int
+		)
+
+		if alerts, err = ParseAlerts(c.str); err != nil {
+			if !c.err {
+				t.Errorf("Error parsing %q: %s", c.str, err.Error())
+			}
+			continue
+		} else if c.err {
+			t.Errorf("ParseAlerts should have rejected %q, but returned %v",
+				c.str,
+				alerts)
+			continue
+		} else if len(alerts) != len(c.alerts) {
+			t.Errorf("Unexpected result from parsing %q: %v (expected %v)",
+				c.str,
+				alerts,
+				c.alerts)
+			continue
+		}
+
+		for i, a := range alerts {
+			if a != c.alerts[i] {
+				t.Errorf("Unexpected result from parsing %q: %v (expected %v)",
+					c.str,
+					alerts,
+					c.alerts)
+				break
+			}
+		}
+
+		if s := FormatAlerts(alerts); s != c.fmt {
+			t.Errorf("Unexpected formatting of %v: %q (expected %q)",
+				alerts,
+				s,
+				c.fmt)
+		}
+	}
+} // func TestParseAlerts(t *testing.T)
+
+func TestFormatLead(t *testing.T) {
+	var cases = map[int]string{
+		0:          "now",
+		30:         "1 minute",
+		900:        "15 minutes",
+		3600:       "1 hour",
+		5400:       "1 hour 30 minutes",
+		86400 + 60: "1 day 1 minute",
+		2 * 86400:  "2 days",
+	}
+
+	for secs, expect := range cases {
+		if s := FormatLead(secs); s != expect {
+			t.Errorf("FormatLead(%d) = %q, expected %q",
+				secs,
+				s,
+				expect)
+		}
+	}
+} // func TestFormatLead(t *testing.T)
+
+func TestAlertTimes(t *testing.T) {
+	var (
+		r     = Reminder{Alerts: []int{86400, 900}}
+		due   = time.Date(2026, 10, 20, 14, 0, 0, 0, time.UTC)
+		times = r.AlertTimes(due)
+	)
+
+	if len(times) != 2 {
+		t.Fatalf("Unexpected number of pre-alerts: %d", len(times))
+	} else if !times[0].Equal(due.AddDate(0, 0, -1)) {
+		t.Errorf("First pre-alert is at %s", times[0])
+	} else if !times[1].Equal(due.Add(-15 * time.Minute)) {
+		t.Errorf("Second pre-alert is at %s", times[1])
+	} else if r.MaxLead() != 24*time.Hour {
+		t.Errorf("Unexpected maximum lead time: %s", r.MaxLead())
+	}
+} // func TestAlertTimes(t *testing.T)
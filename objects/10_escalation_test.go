@@ -0,0 +1,75 @@
+// /home/krylon/go/src/github.com/blicero/theseus/objects/10_escalation_test.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 17. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <>
+
+package objects
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEscalation(t *testing.T) {
+	var (
+		e = Escalation{
+			Interval:  600,
+			Threshold: 3,
+			Channel:   ChannelSound,
+		}
+		n        = Notification{ID: 42}
+		disp     = time.Date(2026, 10, 20, 14, 0, 0, 0, time.UTC)
+		urgency  = []Urgency{UrgencyLow, UrgencyNormal, UrgencyCritical, UrgencyCritical, UrgencyCritical}
+		channels = []Channel{ChannelDesktop, ChannelDesktop, ChannelDesktop, ChannelSound, ChannelSound}
+	)
+
+	if err := e.Validate(); err != nil {
+		t.Fatalf("Valid Escalation was rejected: %s", err.Error())
+	} else if next := e.Next(disp); !next.Equal(disp.Add(10 * time.Minute)) {
+		t.Errorf("Unexpected time for the next step: %s", next)
+	}
+
+	for step := range urgency {
+		var s = e.Step(&n, step, disp)
+
+		if s.Urgency != urgency[step] {
+			t.Errorf("Unexpected urgency for step %d: %s (expected %s)",
+				step,
+				s.Urgency,
+				urgency[step])
+		} else if s.Channel != channels[step] {
+			t.Errorf("Unexpected channel for step %d: %s (expected %s)",
+				step,
+				s.Channel,
+				channels[step])
+		} else if s.NotificationID != n.ID {
+			t.Errorf("Step %d belongs to the wrong Notification: %d",
+				step,
+				s.NotificationID)
+		}
+	}
+
+	e.Threshold = 0
+
+	if c := e.ChannelFor(10); c != ChannelDesktop {
+		t.Errorf("Escalation without threshold switched to %s", c)
+	}
+
+	var invalid = []Escalation{
+		{Interval: -60},
+		{Interval: 30},
+		{Interval: 300, Threshold: -1},
+		{Interval: 300, Channel: Channel(7)},
+	}
+
+	for _, i := range invalid {
+		if err := i.Validate(); err == nil {
+			t.Errorf("Invalid Escalation was accepted: %s", &i)
+		}
+	}
+
+	if off := (Escalation{}); off.Enabled() || !off.Next(disp).IsZero() {
+		t.Errorf("Zero Escalation should be disabled")
+	}
+} // func TestEscalation(t *testing.T)
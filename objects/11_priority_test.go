@@ -0,0 +1,60 @@
+// /home/krylon/go/src/github.com/blicero/theseus/objects/11_priority_test.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 17. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <>
+
+package objects
+
+import "testing"
+
+func TestPriority(t *testing.T) {
+	var (
+		r       Reminder
+		urgency = map[Priority]Urgency{
+			PriorityLow:      UrgencyLow,
+			PriorityNormal:   UrgencyNormal,
+			PriorityHigh:     UrgencyNormal,
+			PriorityCritical: UrgencyCritical,
+		}
+	)
+
+	if r.Priority != PriorityNormal {
+		t.Errorf("A new Reminder should have normal priority, not %s",
+			r.Priority)
+	}
+
+	for i, p := range Priorities {
+		var (
+			err error
+			q   Priority
+		)
+
+		if q, err = ParsePriority(p.String()); err != nil {
+			t.Errorf("Cannot parse priority %q: %s",
+				p,
+				err.Error())
+		} else if q != p {
+			t.Errorf("Parsing %q returned %s", p, q)
+		} else if i > 0 && Priorities[i-1] >= p {
+			t.Errorf("Priorities are not in ascending order: %s >= %s",
+				Priorities[i-1],
+				p)
+		} else if p.Urgency() != urgency[p] {
+			t.Errorf("Unexpected urgency for priority %s: %s (expected %s)",
+				p,
+				p.Urgency(),
+				urgency[p])
+		} else if p.Icon() == "" {
+			t.Errorf("Priority %s has no icon", p)
+		}
+	}
+
+	if _, err := ParsePriority("urgent"); err == nil {
+		t.Errorf("ParsePriority should reject \"urgent\"")
+	} else if Priority(5).Valid() {
+		t.Errorf("Priority(5) should not be valid")
+	} else if PriorityNormal.Resident() || !PriorityCritical.Resident() {
+		t.Errorf("Only high and critical Reminders should be resident")
+	}
+} // func TestPriority(t *testing.T)
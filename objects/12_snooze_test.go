@@ -0,0 +1,140 @@
+// /home/krylon/go/src/github.com/blicero/theseus/objects/12_snooze_test.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 17. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <>
+
+package objects
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseSnoozes(t *testing.T) {
+	type testCase struct {
+		str    string
+		items  []Snooze
+		canon  string
+		expErr bool
+	}
+
+	var cases = []testCase{
+		{
+			str:   "5m, 15m, 1h, tomorrow morning",
+			items: DefaultSnoozes,
+			canon: "5m, 15m, 1h, tomorrow morning",
+		},
+		{
+			str:   "+1h30m,Tomorrow",
+			items: []Snooze{{Delay: 5400}, {Tomorrow: true, At: 8 * 3600}},
+			canon: "1h30m, tomorrow morning",
+		},
+		{
+			str:   "tomorrow 7:30, tomorrow evening",
+			items: []Snooze{{Tomorrow: true, At: 7*3600 + 1800}, {Tomorrow: true, At: 18 * 3600}},
+			canon: "tomorrow 07:30, tomorrow evening",
+		},
+		{
+			str: "",
+		},
+		{
+			str:    "2w",
+			expErr: true,
+		},
+		{
+			str:    "tomorrow 25:00",
+			expErr: true,
+		},
+		{
+			str:    "tomorrow at dawn",
+			expErr: true,
+		},
+		{
+			str:    "whenever",
+			expErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		var (
+			err   error
+			items []Snooze
+		)
+
+		if items, err = ParseSnoozes(c.str); err != nil {
+			if !c.expErr {
+				t.Errorf("Cannot parse snooze choices %q: %s",
+					c.str,
+					err.Error())
+			}
+			continue
+		} else if c.expErr {
+			t.Errorf("ParseSnoozes(%q) should have failed, but returned %v",
+				c.str,
+				items)
+			continue
+		} else if len(items) != len(c.items) {
+			t.Errorf("ParseSnoozes(%q) returned %d items, expected %d",
+				c.str,
+				len(items),
+				len(c.items))
+			continue
+		}
+
+		for i, z := range items {
+			if z != c.items[i] {
+				t.Errorf("Snooze #%d of %q: %#v, expected %#v",
+					i,
+					c.str,
+					z,
+					c.items[i])
+			}
+		}
+
+		if s := FormatSnoozes(items); s != c.canon {
+			t.Errorf("FormatSnoozes returned %q, expected %q",
+				s,
+				c.canon)
+		}
+	}
+} // func TestParseSnoozes(t *testing.T)
+
+func TestSnoozeUntil(t *testing.T) {
+	var (
+		err error
+		loc *time.Location
+	)
+
+	if loc, err = time.LoadLocation("Europe/Berlin"); err != nil {
+		t.Fatalf("Cannot load time zone: %s", err.Error())
+	}
+
+	var (
+		now   = time.Date(2026, 3, 28, 22, 15, 0, 0, loc)
+		delay = Snooze{Delay: 900}
+		tmrw  = Snooze{Tomorrow: true, At: 8 * 3600}
+		exp   = time.Date(2026, 3, 29, 8, 0, 0, 0, loc)
+	)
+
+	if u := delay.Until(now); !u.Equal(now.Add(15 * time.Minute)) {
+		t.Errorf("Snoozing %s at %s returned %s",
+			delay,
+			now,
+			u)
+	}
+
+	// The clocks go forward during the night, tomorrow morning is still
+	// at 08:00.
+	if u := tmrw.Until(now); !u.Equal(exp) {
+		t.Errorf("Snoozing %s at %s returned %s, expected %s",
+			tmrw,
+			now,
+			u,
+			exp)
+	} else if l := tmrw.Label(); l != "Tomorrow morning" {
+		t.Errorf("Unexpected label for %s: %q", tmrw, l)
+	} else if l = delay.Label(); l != "15 minutes" {
+		t.Errorf("Unexpected label for %s: %q", delay, l)
+	}
+} // func TestSnoozeUntil(t *testing.T)
@@ -0,0 +1,116 @@
+// /home/krylon/go/src/github.com/blicero/theseus/objects/13_quiet_test.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 17. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <>
+
+package objects
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseQuietHours(t *testing.T) {
+	type testCase struct {
+		str    string
+		canon  string
+		expErr bool
+	}
+
+	var cases = []testCase{
+		{
+			str:   "mon-fri 22:00-07:00; sat,sun 23:00-09:00",
+			canon: "mon-fri 22:00-07:00; sat,sun 23:00-09:00",
+		},
+		{
+			str:   "Weekdays 12:30-13:00;daily 1:00-5:00",
+			canon: "mon-fri 12:30-13:00; daily 01:00-05:00",
+		},
+		{
+			str:   "fri-mon 20:00-08:00",
+			canon: "mon,fri-sun 20:00-08:00",
+		},
+		{
+			str: "",
+		},
+		{
+			str:    "mon-fri",
+			expErr: true,
+		},
+		{
+			str:    "someday 22:00-07:00",
+			expErr: true,
+		},
+		{
+			str:    "mon 22:00-22:00",
+			expErr: true,
+		},
+		{
+			str:    "mon 24:00-07:00",
+			expErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		var (
+			err error
+			q   QuietHours
+		)
+
+		if q, err = ParseQuietHours(c.str); err != nil {
+			if !c.expErr {
+				t.Errorf("Cannot parse quiet hours %q: %s",
+					c.str,
+					err.Error())
+			}
+			continue
+		} else if c.expErr {
+			t.Errorf("ParseQuietHours(%q) should have failed, but returned %q",
+				c.str,
+				q.String())
+		} else if q.String() != c.canon {
+			t.Errorf("ParseQuietHours(%q) returned %q, expected %q",
+				c.str,
+				q.String(),
+				c.canon)
+		}
+	}
+} // func TestParseQuietHours(t *testing.T)
+
+func TestQuietHoursActive(t *testing.T) {
+	type testCase struct {
+		t      time.Time
+		active bool
+	}
+
+	var (
+		err error
+		q   QuietHours
+		// 2022-09-16 is a Friday.
+		cases = []testCase{
+			{t: time.Date(2022, 9, 16, 21, 59, 0, 0, time.UTC), active: false},
+			{t: time.Date(2022, 9, 16, 22, 0, 0, 0, time.UTC), active: true},
+			{t: time.Date(2022, 9, 17, 6, 59, 0, 0, time.UTC), active: true},
+			{t: time.Date(2022, 9, 17, 7, 0, 0, 0, time.UTC), active: false},
+			{t: time.Date(2022, 9, 17, 22, 30, 0, 0, time.UTC), active: false},
+			{t: time.Date(2022, 9, 18, 23, 30, 0, 0, time.UTC), active: true},
+			{t: time.Date(2022, 9, 19, 8, 30, 0, 0, time.UTC), active: true},
+			{t: time.Date(2022, 9, 19, 9, 0, 0, 0, time.UTC), active: false},
+			{t: time.Date(2022, 9, 19, 12, 0, 0, 0, time.UTC), active: false},
+		}
+	)
+
+	if q, err = ParseQuietHours("mon-fri 22:00-07:00; sun 23:00-09:00"); err != nil {
+		t.Fatalf("Cannot parse quiet hours: %s", err.Error())
+	}
+
+	for _, c := range cases {
+		if q.Active(c.t) != c.active {
+			t.Errorf("Quiet hours %q at %s: expected %t",
+				q.String(),
+				c.t.Format(time.RFC1123),
+				c.active)
+		}
+	}
+} // func TestQuietHoursActive(t *testing.T)
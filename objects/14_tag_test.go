@@ -0,0 +1,74 @@
+// /home/krylon/go/src/github.com/blicero/theseus/objects/14_tag_test.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 17. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <>
+
+package objects
+
+import "testing"
+
+func TestParseTags(t *testing.T) {
+	type testCase struct {
+		str    string
+		canon  string
+		expErr bool
+	}
+
+	var cases = []testCase{
+		{str: "work, family", canon: "family, work"},
+		{str: "Work work  home/garden", canon: "home/garden, work"},
+		{str: "", canon: ""},
+		{str: "-work", expErr: true},
+		{str: "work, #1", expErr: true},
+	}
+
+	for _, c := range cases {
+		var (
+			err  error
+			tags []string
+		)
+
+		if tags, err = ParseTags(c.str); err != nil {
+			if !c.expErr {
+				t.Errorf("Cannot parse tags %q: %s",
+					c.str,
+					err.Error())
+			}
+		} else if c.expErr {
+			t.Errorf("ParseTags(%q) should have failed, but returned %v",
+				c.str,
+				tags)
+		} else if FormatTags(tags) != c.canon {
+			t.Errorf("ParseTags(%q) returned %q, expected %q",
+				c.str,
+				FormatTags(tags),
+				c.canon)
+		}
+	}
+} // func TestParseTags(t *testing.T)
+
+func TestTagDefaults(t *testing.T) {
+	var (
+		tags = map[string]Tag{
+			"work":   {Name: "work", Priority: PriorityHigh},
+			"alarm":  {Name: "alarm", Channel: ChannelSound},
+			"urgent": {Name: "urgent", QuietExempt: true, Priority: PriorityLow},
+		}
+		r   = Reminder{Tags: []string{"alarm", "unknown", "urgent", "work"}}
+		def = r.TagDefaults(tags)
+		exp = TagDefaults{Priority: PriorityHigh, Channel: ChannelSound, QuietExempt: true}
+	)
+
+	if def != exp {
+		t.Errorf("Unexpected defaults: %#v (expected %#v)", def, exp)
+	} else if !r.HasTag("Work") || r.HasTag("home") {
+		t.Errorf("HasTag got it wrong for %v", r.Tags)
+	}
+
+	r.Tags = nil
+
+	if def = r.TagDefaults(tags); def != (TagDefaults{}) {
+		t.Errorf("A Reminder without tags should have no defaults: %#v", def)
+	}
+} // func TestTagDefaults(t *testing.T)
@@ -0,0 +1,96 @@
+// /home/krylon/go/src/github.com/blicero/theseus/objects/15_checklist_test.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 17. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <>
+
+package objects
+
+import "testing"
+
+func TestParseChecklist(t *testing.T) {
+	type testCase struct {
+		str    string
+		canon  string
+		done   int
+		total  int
+		expErr bool
+	}
+
+	var cases = []testCase{
+		{str: "Passport\n[x] Tickets\n\n[ ] Charger", canon: "[ ] Passport\n[x] Tickets\n[ ] Charger", done: 1, total: 3},
+		{str: "[X]  Keys\n[]Wallet", canon: "[x] Keys\n[ ] Wallet", done: 1, total: 2},
+		{str: "", canon: "", done: 0, total: 0},
+		{str: "Keys\n[x]", expErr: true},
+	}
+
+	for _, c := range cases {
+		var (
+			err error
+			r   Reminder
+		)
+
+		if err = r.SetChecklist(c.str); err != nil {
+			if !c.expErr {
+				t.Errorf("Cannot parse checklist %q: %s",
+					c.str,
+					err.Error())
+			}
+			continue
+		} else if c.expErr {
+			t.Errorf("ParseChecklist(%q) should have failed, but returned %v",
+				c.str,
+				r.Checklist)
+			continue
+		} else if r.ChecklistString() != c.canon {
+			t.Errorf("ParseChecklist(%q) returned %q, expected %q",
+				c.str,
+				r.ChecklistString(),
+				c.canon)
+		}
+
+		if done, total := r.ChecklistProgress(); done != c.done || total != c.total {
+			t.Errorf("Unexpected progress for %q: %d/%d (expected %d/%d)",
+				c.str,
+				done,
+				total,
+				c.done,
+				c.total)
+		} else if r.ChecklistComplete() != (done == total) {
+			t.Errorf("ChecklistComplete() for %q should be %t",
+				c.str,
+				done == total)
+		}
+	}
+} // func TestParseChecklist(t *testing.T)
+
+func TestChecklistPayload(t *testing.T) {
+	var (
+		r = Reminder{
+			Title:       "Leave for the airport",
+			Description: "Flight at 10:15",
+			Checklist: []ChecklistItem{
+				{Text: "Passport", Done: true},
+				{Text: "Tickets"},
+			},
+		}
+		exp        = "Flight at 10:15\n\n1/2 done\n☑ Passport\n☐ Tickets"
+		title, msg = r.Payload()
+	)
+
+	if title != r.Title {
+		t.Errorf("Unexpected title: %q", title)
+	} else if msg != exp {
+		t.Errorf("Unexpected body: %q (expected %q)", msg, exp)
+	} else if idx := r.NextOpenItem(); idx != 1 {
+		t.Errorf("NextOpenItem() returned %d, expected 1", idx)
+	}
+
+	r.Checklist[1].Done = true
+
+	if idx := r.NextOpenItem(); idx != -1 {
+		t.Errorf("NextOpenItem() returned %d for a complete checklist", idx)
+	} else if !r.ChecklistComplete() {
+		t.Error("Checklist should be complete")
+	}
+} // func TestChecklistPayload(t *testing.T)
@@ -0,0 +1,116 @@
+// /home/krylon/go/src/github.com/blicero/theseus/objects/16_dependency_test.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 17. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <>
+
+package objects
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFindCycle(t *testing.T) {
+	type testCase struct {
+		uuid  string
+		deps  []Dependency
+		cycle string
+	}
+
+	// c depends on b, which depends on a.
+	var (
+		reminders = []Reminder{
+			{UUID: "a"},
+			{UUID: "b", Depends: []Dependency{{Prereq: "a"}}},
+			{UUID: "c", Depends: []Dependency{{Prereq: "b", Kind: DepFollowUp}}},
+		}
+		cases = []testCase{
+			{uuid: "a", deps: nil},
+			{uuid: "a", deps: []Dependency{{Prereq: "x"}}},
+			{uuid: "a", deps: []Dependency{{Prereq: "c"}}, cycle: "a c b a"},
+			{uuid: "b", deps: []Dependency{{Prereq: "c"}}, cycle: "b c b"},
+			{uuid: "c", deps: []Dependency{{Prereq: "a"}, {Prereq: "b"}}},
+			{uuid: "d", deps: []Dependency{{Prereq: "d"}}, cycle: "d d"},
+		}
+	)
+
+	for _, c := range cases {
+		var cycle = strings.Join(FindCycle(reminders, c.uuid, c.deps), " ")
+
+		if cycle != c.cycle {
+			t.Errorf("FindCycle(%q, %v) returned %q, expected %q",
+				c.uuid,
+				c.deps,
+				cycle,
+				c.cycle)
+		}
+	}
+} // func TestFindCycle(t *testing.T)
+
+func TestDependencyHolds(t *testing.T) {
+	var (
+		prereq = Reminder{UUID: "a"}
+		after  = Dependency{Prereq: "a", Kind: DepAfter}
+		follow = Dependency{Prereq: "a", Kind: DepFollowUp, Delay: 14 * 86400}
+	)
+
+	if !after.Holds(&prereq) {
+		t.Error("Dependency should hold while the prerequisite is not finished")
+	} else if after.Holds(nil) {
+		t.Error("Dependency on an unknown Reminder should not hold")
+	} else if !follow.Holds(&prereq) {
+		t.Error("Follow-up should hold until it has been triggered")
+	}
+
+	prereq.Finished = true
+	follow.Triggered = time.Now()
+
+	if after.Holds(&prereq) {
+		t.Error("Dependency should not hold once the prerequisite is finished")
+	} else if follow.Holds(&prereq) {
+		t.Error("Follow-up should not hold once it has been triggered")
+	} else if s := follow.String(); s != "followup a +2w" {
+		t.Errorf("Unexpected string for follow-up: %q", s)
+	}
+} // func TestDependencyHolds(t *testing.T)
+
+func TestParseDelay(t *testing.T) {
+	type testCase struct {
+		str    string
+		secs   int
+		expErr bool
+	}
+
+	var cases = []testCase{
+		{str: "14d", secs: 14 * 86400},
+		{str: "+1w2d", secs: 9 * 86400},
+		{str: "", secs: 0},
+		{str: "2 weeks", expErr: true},
+	}
+
+	for _, c := range cases {
+		var (
+			err  error
+			secs int
+		)
+
+		if secs, err = ParseDelay(c.str); err != nil {
+			if !c.expErr {
+				t.Errorf("Cannot parse delay %q: %s",
+					c.str,
+					err.Error())
+			}
+		} else if c.expErr {
+			t.Errorf("ParseDelay(%q) should have failed, but returned %d",
+				c.str,
+				secs)
+		} else if secs != c.secs {
+			t.Errorf("ParseDelay(%q) returned %d, expected %d",
+				c.str,
+				secs,
+				c.secs)
+		}
+	}
+} // func TestParseDelay(t *testing.T)
@@ -0,0 +1,155 @@
+// /home/krylon/go/src/github.com/blicero/theseus/objects/17_history_test.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 17. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <>
+
+package objects
+
+import (
+	"testing"
+	"time"
+
+	"github.com/blicero/theseus/objects/repeat"
+)
+
+func TestBuildHistory(t *testing.T) {
+	var (
+		day   = time.Date(2026, 10, 12, 8, 0, 0, 0, time.UTC)
+		at    = func(d int, offset time.Duration) time.Time { return day.AddDate(0, 0, d).Add(offset) }
+		notes = []Notification{
+			// Day 0: acknowledged right away.
+			{ReminderID: 1, Timestamp: at(0, 0), Shown: at(0, 0), Acknowledged: at(0, 2*time.Minute)},
+			// Day 1: pre-alert, then snoozed twice, then acknowledged.
+			{ReminderID: 1, Timestamp: at(1, -time.Hour), Lead: 3600, Shown: at(1, -time.Hour)},
+			{ReminderID: 1, Timestamp: at(1, 30*time.Minute), Shown: at(1, 30*time.Minute), Acknowledged: at(1, time.Hour)},
+			// Day 2: skipped because it went stale.
+			{ReminderID: 1, Timestamp: at(2, 0), Acknowledged: at(3, 0), Skipped: true},
+			// Day 3: displayed, but never acknowledged.
+			{ReminderID: 1, Timestamp: at(3, 0), Displayed: at(3, 0)},
+			// Day 4: still pending.
+			{ReminderID: 1, Timestamp: at(4, 0), Shown: at(4, 0)},
+		}
+		snoozes = []SnoozeEvent{
+			{ReminderID: 1, Occurrence: at(1, 0), Timestamp: at(1, time.Minute), Until: at(1, 15*time.Minute+10*time.Second)},
+			{ReminderID: 1, Occurrence: at(1, 15*time.Minute), Timestamp: at(1, 16*time.Minute), Until: at(1, 30*time.Minute)},
+		}
+		items = BuildHistory(notes, snoozes)
+	)
+
+	if len(items) != 5 {
+		t.Fatalf("BuildHistory returned %d items, expected 5: %v",
+			len(items),
+			items)
+	}
+
+	for i, h := range items {
+		if !h.Due.Equal(at(i, 0)) {
+			t.Errorf("Item %d is due at %s, expected %s",
+				i,
+				h.Due,
+				at(i, 0))
+		}
+	}
+
+	if items[0].AckLatency() != 2*time.Minute || !items[0].OnTime(OnTimeWindow) {
+		t.Errorf("Item 0 should have been acknowledged on time after 2 minutes: %v",
+			items[0])
+	} else if items[1].Snoozes != 2 || items[1].DisplayLatency() != 30*time.Minute || items[1].OnTime(OnTimeWindow) {
+		t.Errorf("Item 1 should have been snoozed twice and displayed 30 minutes late: %v",
+			items[1])
+	} else if !items[2].Missed || !items[2].Acknowledged.IsZero() {
+		t.Errorf("Item 2 should have been missed: %v",
+			items[2])
+	} else if !items[3].Missed || !items[3].Shown.Equal(at(3, 0)) {
+		t.Errorf("Item 3 should have been displayed and missed: %v",
+			items[3])
+	} else if !items[4].Pending() {
+		t.Errorf("Item 4 should be pending: %v",
+			items[4])
+	}
+} // func TestBuildHistory(t *testing.T)
+
+func TestComputeStats(t *testing.T) {
+	type testCase struct {
+		acked  []bool
+		streak int
+		best   int
+	}
+
+	var (
+		day   = time.Date(2026, 10, 1, 20, 0, 0, 0, time.UTC)
+		habit = Reminder{ID: 1, Title: "Floss", Recur: Recurrence{Repeat: repeat.Daily}}
+		cases = []testCase{
+			{acked: []bool{true, true, false, true, true, true}, streak: 3, best: 3},
+			{acked: []bool{true, true, true, false, true}, streak: 1, best: 3},
+			{acked: []bool{true, true, false}, streak: 0, best: 2},
+			{acked: nil, streak: 0, best: 0},
+		}
+	)
+
+	for _, c := range cases {
+		var (
+			stats ReminderStats
+			items = make([]HistoryItem, len(c.acked))
+		)
+
+		for i, ok := range c.acked {
+			items[i].Due = day.AddDate(0, 0, i)
+			items[i].Shown = items[i].Due
+			if ok {
+				items[i].Acknowledged = items[i].Due.Add(time.Duration(i) * 10 * time.Minute)
+			} else {
+				items[i].Missed = true
+			}
+		}
+
+		stats = ComputeStats(&habit, items, OnTimeWindow)
+
+		if stats.Streak != c.streak || stats.BestStreak != c.best {
+			t.Errorf("Streaks for %v are %d/%d, expected %d/%d",
+				c.acked,
+				stats.Streak,
+				stats.BestStreak,
+				c.streak,
+				c.best)
+		}
+	}
+
+	var (
+		items = []HistoryItem{
+			{Due: day, Shown: day, Acknowledged: day.Add(5 * time.Minute)},
+			{Due: day.AddDate(0, 0, 1), Shown: day.AddDate(0, 0, 1), Acknowledged: day.AddDate(0, 0, 1).Add(time.Hour)},
+			{Due: day.AddDate(0, 0, 2), Missed: true},
+			{Due: day.AddDate(0, 0, 3), Shown: day.AddDate(0, 0, 3), Acknowledged: day.AddDate(0, 0, 3).Add(time.Minute), Snoozes: 1},
+			{Due: day.AddDate(0, 0, 4), Shown: day.AddDate(0, 0, 4)},
+		}
+		stats = ComputeStats(&habit, items, OnTimeWindow)
+	)
+
+	if stats.Occurrences != 5 || stats.Acknowledged != 3 || stats.Missed != 1 || stats.Snoozes != 1 || stats.OnTime != 1 {
+		t.Errorf("Unexpected statistics: %#v", stats)
+	} else if pct := stats.OnTimePercent(); pct != 25 {
+		t.Errorf("On-time percentage is %.1f, expected 25", pct)
+	} else if stats.AckLatency != 22*time.Minute {
+		t.Errorf("Average latency is %s, expected 22m", stats.AckLatency)
+	}
+} // func TestComputeStats(t *testing.T)
+
+func TestMostSnoozed(t *testing.T) {
+	var (
+		stats = []ReminderStats{
+			{Title: "a", Snoozes: 2},
+			{Title: "b", Snoozes: 0},
+			{Title: "c", Snoozes: 7},
+			{Title: "d", Snoozes: 2},
+		}
+		top = MostSnoozed(stats, 2)
+	)
+
+	if len(top) != 2 || top[0].Title != "c" || top[1].Title != "a" {
+		t.Errorf("Unexpected list of most-snoozed Reminders: %v", top)
+	} else if top = MostSnoozed(stats, 0); len(top) != 3 {
+		t.Errorf("MostSnoozed without a limit returned %d items, expected 3", len(top))
+	}
+} // func TestMostSnoozed(t *testing.T)
@@ -0,0 +1,50 @@
+// /home/krylon/go/src/github.com/blicero/theseus/objects/18_tombstone_test.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 17. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <>
+
+package objects
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTombstoneSupersedes(t *testing.T) {
+	var (
+		now  = time.Date(2026, 10, 22, 19, 0, 0, 0, time.UTC)
+		tomb = Tombstone{UUID: "a", Deleted: now}
+	)
+
+	if !tomb.Supersedes(&Reminder{UUID: "a", Changed: now.Add(-time.Minute)}) {
+		t.Error("Deletion should win over an older change")
+	} else if !tomb.Supersedes(&Reminder{UUID: "a", Changed: now}) {
+		t.Error("Deletion should win over a change at the same time")
+	} else if tomb.Supersedes(&Reminder{UUID: "a", Changed: now.Add(time.Second)}) {
+		t.Error("A change after the deletion should win")
+	}
+} // func TestTombstoneSupersedes(t *testing.T)
+
+func TestTombstoneCutoff(t *testing.T) {
+	var (
+		cutoff time.Time
+		ok     bool
+		now    = time.Date(2026, 10, 22, 19, 0, 0, 0, time.UTC)
+		peers  = []PeerState{
+			{Name: "a", Seen: now},
+			{Name: "b", Seen: now.Add(-time.Hour)},
+			{Name: "c", Seen: now.Add(time.Hour)},
+		}
+	)
+
+	if _, ok = TombstoneCutoff(nil); ok {
+		t.Error("Without any Peers, there should be no cutoff")
+	} else if cutoff, ok = TombstoneCutoff(peers); !ok {
+		t.Error("TombstoneCutoff found no cutoff")
+	} else if !cutoff.Equal(now.Add(-time.Hour)) {
+		t.Errorf("Cutoff is %s, expected %s",
+			cutoff,
+			now.Add(-time.Hour))
+	}
+} // func TestTombstoneCutoff(t *testing.T)
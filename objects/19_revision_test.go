@@ -0,0 +1,66 @@
+// /home/krylon/go/src/github.com/blicero/theseus/objects/19_revision_test.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 17. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <>
+
+package objects
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRevisionsBetween(t *testing.T) {
+	var (
+		err   error
+		revs  []Revision
+		state *Reminder
+		now   = time.Date(2026, 10, 23, 19, 0, 0, 0, time.UTC)
+		orig  = &Reminder{
+			Title:     "Water the plants",
+			Timestamp: now,
+			UUID:      "a",
+			Tags:      []string{"home"},
+			Alerts:    []int{300},
+		}
+		edit = orig.Clone()
+	)
+
+	edit.Title = "Water the flowers"
+	edit.Tags[0] = "garden"
+	edit.Finished = true
+	edit.Recur.Counter = 3
+
+	if orig.Tags[0] != "home" {
+		t.Fatal("Clone shares memory with the original")
+	} else if revs, err = RevisionsBetween(orig, edit); err != nil {
+		t.Fatalf("Cannot compute Revisions: %s", err.Error())
+	} else if len(revs) != 3 {
+		t.Fatalf("Expected 3 Revisions, got %d: %v", len(revs), revs)
+	} else if revs[0].Field != "Title" || revs[0].Old != `"Water the plants"` {
+		t.Errorf("Unexpected first Revision: %#v", revs[0])
+	} else if state, err = Rewind(edit, revs); err != nil {
+		t.Fatalf("Cannot rewind Revisions: %s", err.Error())
+	} else if state.Title != orig.Title || state.Tags[0] != "home" || state.Finished {
+		t.Errorf("Rewinding did not restore the original: %#v", state)
+	} else if state.Recur.Counter != 3 {
+		t.Errorf("Rewinding should leave the Counter alone, it is %d", state.Recur.Counter)
+	} else if edit.Title != "Water the flowers" {
+		t.Error("Rewind modified its argument")
+	}
+
+	if revs, err = RevisionsBetween(edit, nil); err != nil {
+		t.Fatalf("Cannot compute Revisions for deletion: %s", err.Error())
+	} else if len(revs) != 1 || revs[0].Field != FieldReminder || revs[0].New != "" {
+		t.Fatalf("Unexpected Revisions for deletion: %v", revs)
+	} else if state, err = Rewind(nil, revs); err != nil {
+		t.Fatalf("Cannot rewind deletion: %s", err.Error())
+	} else if state == nil || state.UUID != "a" || state.Title != edit.Title {
+		t.Errorf("Rewinding deletion did not restore the Reminder: %#v", state)
+	} else if _, err = Rewind(nil, []Revision{{Field: "Title", Old: `"x"`}}); err == nil {
+		t.Error("Rewinding a change to a Reminder that does not exist should fail")
+	} else if err = state.SetFieldValue("UUID", `"b"`); err == nil {
+		t.Error("Setting a field we do not keep track of should fail")
+	}
+} // func TestRevisionsBetween(t *testing.T)
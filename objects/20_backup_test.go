@@ -0,0 +1,82 @@
+// /home/krylon/go/src/github.com/blicero/theseus/objects/20_backup_test.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 17. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <>
+
+package objects
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseBackupInterval(t *testing.T) {
+	type testCase struct {
+		input  string
+		output time.Duration
+		err    bool
+	}
+
+	var cases = []testCase{
+		{input: "daily", output: 24 * time.Hour},
+		{input: "Weekly", output: 168 * time.Hour},
+		{input: "off"},
+		{input: "12h", output: 12 * time.Hour},
+		{input: "5m", err: true},
+		{input: "sometimes", err: true},
+	}
+
+	for _, c := range cases {
+		var (
+			err error
+			d   time.Duration
+		)
+
+		if d, err = ParseBackupInterval(c.input); err != nil {
+			if !c.err {
+				t.Errorf("Cannot parse %q: %s", c.input, err.Error())
+			}
+		} else if c.err {
+			t.Errorf("Parsing %q should have failed", c.input)
+		} else if d != c.output {
+			t.Errorf("Parsing %q returned %s, expected %s", c.input, d, c.output)
+		}
+	}
+} // func TestParseBackupInterval(t *testing.T)
+
+func TestBackupPolicy(t *testing.T) {
+	var (
+		now     = time.Date(2026, 10, 24, 16, 0, 0, 0, time.UTC)
+		policy  = BackupPolicy{Interval: 24 * time.Hour, Keep: 2}
+		expired []Backup
+		backups = []Backup{
+			{Name: "b", Stamp: now.Add(-48 * time.Hour)},
+			{Name: "d", Stamp: now.Add(-time.Hour)},
+			{Name: "a", Stamp: now.Add(-72 * time.Hour)},
+			{Name: "c", Stamp: now.Add(-24 * time.Hour)},
+		}
+	)
+
+	if !policy.Due(time.Time{}, now) {
+		t.Error("A backup should be due if there is none")
+	} else if policy.Due(now.Add(-time.Hour), now) {
+		t.Error("A backup should not be due an hour after the last one")
+	} else if !policy.Due(now.Add(-25*time.Hour), now) {
+		t.Error("A backup should be due a day after the last one")
+	} else if (&BackupPolicy{}).Due(time.Time{}, now) {
+		t.Error("No backup should be due if scheduled backups are off")
+	}
+
+	if expired = policy.Expired(backups); len(expired) != 2 {
+		t.Fatalf("Expected 2 expired backups, got %d", len(expired))
+	} else if expired[0].Name != "a" || expired[1].Name != "b" {
+		t.Errorf("Wrong backups expired: %v", expired)
+	} else if backups[0].Name != "b" {
+		t.Error("Expired modified its argument")
+	} else if expired = (&BackupPolicy{Interval: time.Hour}).Expired(backups); expired != nil {
+		t.Errorf("Nothing should expire if we keep all backups: %v", expired)
+	} else if s := policy.String(); s != "daily, keep 2" {
+		t.Errorf("Unexpected description of policy: %q", s)
+	}
+} // func TestBackupPolicy(t *testing.T)
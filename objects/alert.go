@@ -0,0 +1,222 @@
+// /home/krylon/go/src/github.com/blicero/theseus/objects/alert.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 17. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <>
+
+package objects
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// A Reminder can warn about an upcoming occurrence in advance, e.g. a day
+// and an hour before a meeting. We call those warnings pre-alerts. The
+// Alerts of a Reminder hold the number of seconds before the due time at
+// which a pre-alert is displayed.
+// Users write them as negative offsets, like "-1d", "-1h" or "-15m".
+
+// maxAlertLead is the longest advance warning we allow.
+const maxAlertLead = 28 * 86400
+
+var (
+	alertRe     = regexp.MustCompile(`^-?((?:\d+[wdhm])+)$`)
+	alertPartRe = regexp.MustCompile(`(\d+)([wdhm])`)
+)
+
+var alertUnits = []struct {
+	unit string
+	secs int
+}{
+	{"w", 7 * 86400},
+	{"d", 86400},
+	{"h", 3600},
+	{"m", 60},
+}
+
+// normalizeAlerts sorts the Alerts so the earliest warning comes first, and
+// removes duplicates.
+func normalizeAlerts(alerts []int) []int {
+	sort.Sort(sort.Reverse(sort.IntSlice(alerts)))
+
+	var n int
+	for i, a := range alerts {
+		if i == 0 || a != alerts[n-1] {
+			alerts[n] = a
+			n++
+		}
+	}
+
+	return alerts[:n]
+} // func normalizeAlerts(alerts []int) []int
+
+// AlertsString returns the Alerts as a comma-separated list of seconds,
+// which is how we store them in the database.
+func (r *Reminder) AlertsString() string {
+	var items = make([]string, len(r.Alerts))
+
+	for i, a := range r.Alerts {
+		items[i] = strconv.Itoa(a)
+	}
+
+	return strings.Join(items, ",")
+} // func (r *Reminder) AlertsString() string
+
+// SetAlerts sets the Alerts from a comma-separated list of seconds, as
+// returned by AlertsString.
+func (r *Reminder) SetAlerts(s string) error {
+	var alerts []int
+
+	for _, item := range strings.Split(s, ",") {
+		var (
+			err error
+			a   int
+		)
+
+		if item = strings.TrimSpace(item); item == "" {
+			continue
+		} else if a, err = strconv.Atoi(item); err != nil {
+			return fmt.Errorf("Invalid pre-alert %q: %s", item, err.Error())
+		} else if a <= 0 || a > maxAlertLead {
+			return fmt.Errorf("Pre-alert is out of range: %d", a)
+		}
+
+		alerts = append(alerts, a)
+	}
+
+	r.Alerts = normalizeAlerts(alerts)
+	return nil
+} // func (r *Reminder) SetAlerts(s string) error
+
+// ParseAlerts parses a list of offsets like "-1d, -1h, -15m" or "-1h30m".
+// The units are w(eeks), d(ays), h(ours) and m(inutes). The minus sign is
+// optional, pre-alerts always come before the due time.
+func ParseAlerts(s string) ([]int, error) {
+	var alerts []int
+
+	for _, item := range strings.FieldsFunc(s, func(c rune) bool { return c == ',' || c == ' ' }) {
+		var (
+			m    []string
+			secs int
+		)
+
+		if m = alertRe.FindStringSubmatch(strings.ToLower(item)); m == nil {
+			return nil, fmt.Errorf("Invalid pre-alert %q, expected something like -15m", item)
+		}
+
+		if secs = parseSpan(m[1]); secs <= 0 || secs > maxAlertLead {
+			return nil, fmt.Errorf("Pre-alert %q is out of range", item)
+		}
+
+		alerts = append(alerts, secs)
+	}
+
+	return normalizeAlerts(alerts), nil
+} // func ParseAlerts(s string) ([]int, error)
+
+// FormatAlerts is the inverse of ParseAlerts.
+func FormatAlerts(alerts []int) string {
+	var items = make([]string, len(alerts))
+
+	for i, a := range alerts {
+		items[i] = "-" + formatSpan(a)
+	}
+
+	return strings.Join(items, ", ")
+} // func FormatAlerts(alerts []int) string
+
+// parseSpan returns the number of seconds in a period of time written
+// like "1h30m", see alertUnits. The caller has to make sure the string is
+// well-formed.
+func parseSpan(s string) int {
+	var secs int
+
+	for _, part := range alertPartRe.FindAllStringSubmatch(s, -1) {
+		var n, _ = strconv.Atoi(part[1])
+
+		for _, u := range alertUnits {
+			if u.unit == part[2] {
+				secs += n * u.secs
+			}
+		}
+	}
+
+	return secs
+} // func parseSpan(s string) int
+
+// formatSpan is the inverse of parseSpan.
+func formatSpan(secs int) string {
+	var str string
+
+	for _, u := range alertUnits {
+		if secs >= u.secs {
+			str += fmt.Sprintf("%d%s", secs/u.secs, u.unit)
+			secs %= u.secs
+		}
+	}
+
+	return str
+} // func formatSpan(secs int) string
+
+// FormatLead returns a human-readable description of a period of time,
+// e.g. "1 hour 15 minutes", for telling the user how long it is until a
+// Reminder is due. Seconds are rounded up to the next minute.
+func FormatLead(secs int) string {
+	var (
+		parts []string
+		mins  = (secs + 59) / 60
+		units = []struct {
+			name string
+			mins int
+		}{
+			{"day", 1440},
+			{"hour", 60},
+			{"minute", 1},
+		}
+	)
+
+	if mins <= 0 {
+		return "now"
+	}
+
+	for _, u := range units {
+		if n := mins / u.mins; n > 0 {
+			if n == 1 {
+				parts = append(parts, fmt.Sprintf("1 %s", u.name))
+			} else {
+				parts = append(parts, fmt.Sprintf("%d %ss", n, u.name))
+			}
+			mins %= u.mins
+		}
+	}
+
+	return strings.Join(parts, " ")
+} // func FormatLead(secs int) string
+
+// AlertTimes returns the points in time at which the pre-alerts for an
+// occurrence of the Reminder due at the given time are displayed, earliest
+// first.
+func (r *Reminder) AlertTimes(due time.Time) []time.Time {
+	var times = make([]time.Time, len(r.Alerts))
+
+	for i, a := range r.Alerts {
+		times[i] = due.Add(time.Duration(-a) * time.Second)
+	}
+
+	return times
+} // func (r *Reminder) AlertTimes(due time.Time) []time.Time
+
+// MaxLead returns the longest advance warning of the Reminder, or 0 if it
+// has no pre-alerts.
+func (r *Reminder) MaxLead() time.Duration {
+	if len(r.Alerts) == 0 {
+		return 0
+	}
+
+	return time.Duration(r.Alerts[0]) * time.Second
+} // func (r *Reminder) MaxLead() time.Duration
@@ -0,0 +1,112 @@
+// /home/krylon/go/src/github.com/blicero/theseus/objects/backup.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 17. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <>
+
+package objects
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Backup is a copy of the database we made at Stamp.
+type Backup struct {
+	Name  string
+	Size  int64
+	Stamp time.Time
+}
+
+// BackupPolicy says how often we back up the database, and how many
+// backups we keep around. An Interval of 0 turns scheduled backups off,
+// a Keep of 0 keeps all of them.
+type BackupPolicy struct {
+	Interval time.Duration
+	Keep     int
+}
+
+// DefaultBackupPolicy is what we do unless the user has configured
+// something else.
+var DefaultBackupPolicy = BackupPolicy{
+	Interval: 24 * time.Hour,
+	Keep:     7,
+}
+
+// MinBackupInterval is the shortest interval between scheduled backups.
+const MinBackupInterval = time.Hour
+
+// ParseBackupInterval parses the interval between scheduled backups. It
+// understands "daily", "weekly" and "off", as well as any duration Go's
+// time package understands.
+func ParseBackupInterval(s string) (time.Duration, error) {
+	var (
+		err error
+		d   time.Duration
+	)
+
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "daily":
+		return 24 * time.Hour, nil
+	case "weekly":
+		return 7 * 24 * time.Hour, nil
+	case "off", "never", "0":
+		return 0, nil
+	}
+
+	if d, err = time.ParseDuration(strings.TrimSpace(s)); err != nil {
+		return 0, fmt.Errorf("Invalid backup interval %q: %s",
+			s,
+			err.Error())
+	} else if d < MinBackupInterval {
+		return 0, fmt.Errorf("Backup interval %s is shorter than %s",
+			d,
+			MinBackupInterval)
+	}
+
+	return d, nil
+} // func ParseBackupInterval(s string) (time.Duration, error)
+
+func (p BackupPolicy) String() string {
+	var every string
+
+	switch p.Interval {
+	case 0:
+		return "off"
+	case 24 * time.Hour:
+		every = "daily"
+	case 7 * 24 * time.Hour:
+		every = "weekly"
+	default:
+		every = "every " + p.Interval.String()
+	}
+
+	if p.Keep == 0 {
+		return every + ", keep all"
+	}
+
+	return fmt.Sprintf("%s, keep %d", every, p.Keep)
+} // func (p BackupPolicy) String() string
+
+// Due returns true if it is time for a scheduled backup, given the time
+// of the last one, which is zero if there is none.
+func (p *BackupPolicy) Due(last, now time.Time) bool {
+	return p.Interval > 0 && (last.IsZero() || now.Sub(last) >= p.Interval)
+} // func (p *BackupPolicy) Due(last, now time.Time) bool
+
+// Expired returns the backups that are beyond the number we keep, oldest
+// first.
+func (p *BackupPolicy) Expired(backups []Backup) []Backup {
+	if p.Keep <= 0 || len(backups) <= p.Keep {
+		return nil
+	}
+
+	var list = make([]Backup, len(backups))
+	copy(list, backups)
+
+	sort.Slice(list, func(i, j int) bool { return list[i].Stamp.Before(list[j].Stamp) })
+
+	return list[:len(list)-p.Keep]
+} // func (p *BackupPolicy) Expired(backups []Backup) []Backup
@@ -0,0 +1,152 @@
+// /home/krylon/go/src/github.com/blicero/theseus/objects/checklist.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 17. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <>
+
+package objects
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ChecklistItem is one step of a Reminder that is really a short
+// procedure, like "passport" in "leave for the airport".
+// The items of a Reminder are ordered, an item is identified by its
+// position in the list.
+type ChecklistItem struct {
+	Text string
+	Done bool
+}
+
+// Prefixes for checklist items in the text form, as used by
+// ParseChecklist and FormatChecklist.
+const (
+	checkDone = "[x] "
+	checkOpen = "[ ] "
+)
+
+// ParseChecklist parses a checklist with one item per line. Lines starting
+// with "[x]" are items that are done, all other non-empty lines are items
+// that are still open, with or without a leading "[ ]".
+func ParseChecklist(s string) ([]ChecklistItem, error) {
+	var items []ChecklistItem
+
+	for _, line := range strings.Split(s, "\n") {
+		var item ChecklistItem
+
+		if line = strings.TrimSpace(line); line == "" {
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(strings.ToLower(line), "[x]"):
+			item.Done = true
+			item.Text = strings.TrimSpace(line[3:])
+		case strings.HasPrefix(line, "[ ]"), strings.HasPrefix(line, "[]"):
+			item.Text = strings.TrimSpace(line[strings.Index(line, "]")+1:])
+		default:
+			item.Text = line
+		}
+
+		if item.Text == "" {
+			return nil, fmt.Errorf("Checklist item %q is empty", line)
+		}
+
+		items = append(items, item)
+	}
+
+	return items, nil
+} // func ParseChecklist(s string) ([]ChecklistItem, error)
+
+// FormatChecklist is the inverse of ParseChecklist.
+func FormatChecklist(items []ChecklistItem) string {
+	var lines = make([]string, len(items))
+
+	for i, item := range items {
+		if item.Done {
+			lines[i] = checkDone + item.Text
+		} else {
+			lines[i] = checkOpen + item.Text
+		}
+	}
+
+	return strings.Join(lines, "\n")
+} // func FormatChecklist(items []ChecklistItem) string
+
+// ChecklistString returns the Reminder's checklist as a string.
+func (r *Reminder) ChecklistString() string {
+	return FormatChecklist(r.Checklist)
+} // func (r *Reminder) ChecklistString() string
+
+// SetChecklist sets the Reminder's checklist from a string as returned by
+// ChecklistString.
+func (r *Reminder) SetChecklist(s string) error {
+	var (
+		err   error
+		items []ChecklistItem
+	)
+
+	if items, err = ParseChecklist(s); err != nil {
+		return err
+	}
+
+	r.Checklist = items
+	return nil
+} // func (r *Reminder) SetChecklist(s string) error
+
+// ChecklistProgress returns the number of checklist items that are done,
+// and the total number of items.
+func (r *Reminder) ChecklistProgress() (int, int) {
+	var done int
+
+	for _, item := range r.Checklist {
+		if item.Done {
+			done++
+		}
+	}
+
+	return done, len(r.Checklist)
+} // func (r *Reminder) ChecklistProgress() (int, int)
+
+// ChecklistComplete returns true if all checklist items are done. A
+// Reminder without a checklist is always complete.
+func (r *Reminder) ChecklistComplete() bool {
+	var done, total = r.ChecklistProgress()
+
+	return done == total
+} // func (r *Reminder) ChecklistComplete() bool
+
+// NextOpenItem returns the position of the first checklist item that is
+// not done, yet, or -1 if there is none.
+func (r *Reminder) NextOpenItem() int {
+	for i, item := range r.Checklist {
+		if !item.Done {
+			return i
+		}
+	}
+
+	return -1
+} // func (r *Reminder) NextOpenItem() int
+
+// checklistBody renders the checklist for the body of a Notification.
+func (r *Reminder) checklistBody() string {
+	var (
+		b           strings.Builder
+		done, total = r.ChecklistProgress()
+	)
+
+	fmt.Fprintf(&b, "%d/%d done", done, total)
+
+	for _, item := range r.Checklist {
+		if item.Done {
+			b.WriteString("\n☑ ")
+		} else {
+			b.WriteString("\n☐ ")
+		}
+		b.WriteString(item.Text)
+	}
+
+	return b.String()
+} // func (r *Reminder) checklistBody() string
@@ -0,0 +1,196 @@
+// /home/krylon/go/src/github.com/blicero/theseus/objects/dependency.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 17. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <>
+
+package objects
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// A Reminder can depend on other Reminders, its prerequisites. We refer to
+// the prerequisite by its UUID, so the link survives synchronization.
+// There are two kinds of dependency:
+//
+// A Reminder that comes *after* another one is held back until the other
+// one is finished. "Don't remind me to book the hotel before I have booked
+// the flight."
+//
+// A *follow-up* is held back until the user acknowledges a Notification for
+// the other Reminder. It is then scheduled to go off after the given delay.
+// "When I have submitted the expense report, remind me to check for the
+// reimbursement in 14 days." If the prerequisite is recurring, the
+// follow-up comes up again after each occurrence.
+
+// DepKind is the kind of a Dependency.
+type DepKind uint8
+
+// DepAfter and DepFollowUp are the kinds of Dependency, see above.
+const (
+	DepAfter DepKind = iota
+	DepFollowUp
+)
+
+func (k DepKind) String() string {
+	switch k {
+	case DepAfter:
+		return "after"
+	case DepFollowUp:
+		return "followup"
+	default:
+		return fmt.Sprintf("DepKind(%d)", k)
+	}
+} // func (k DepKind) String() string
+
+// ParseDepKind parses the name of a DepKind, as returned by its String
+// method.
+func ParseDepKind(s string) (DepKind, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "after":
+		return DepAfter, nil
+	case "followup", "follow-up":
+		return DepFollowUp, nil
+	default:
+		return 0, fmt.Errorf("Invalid kind of dependency %q", s)
+	}
+} // func ParseDepKind(s string) (DepKind, error)
+
+// Dependency links a Reminder to one of its prerequisites. Triggered is
+// the last time a follow-up was scheduled, it is zero for follow-ups that
+// are still waiting for their prerequisite.
+type Dependency struct {
+	Prereq    string
+	Kind      DepKind
+	Delay     int
+	Triggered time.Time
+}
+
+func (d *Dependency) String() string {
+	if d.Kind == DepFollowUp {
+		return fmt.Sprintf("%s %s +%s", d.Kind, d.Prereq, FormatDelay(d.Delay))
+	}
+
+	return fmt.Sprintf("%s %s", d.Kind, d.Prereq)
+} // func (d *Dependency) String() string
+
+// Validate checks if the Dependency makes sense for the given Reminder.
+func (d *Dependency) Validate(r *Reminder) error {
+	if d.Prereq == "" {
+		return fmt.Errorf("Dependency of %q lacks a prerequisite", r.Title)
+	} else if d.Prereq == r.UUID {
+		return fmt.Errorf("Reminder %q cannot depend on itself", r.Title)
+	} else if d.Kind > DepFollowUp {
+		return fmt.Errorf("Invalid kind of dependency %s", d.Kind)
+	} else if d.Delay < 0 {
+		return fmt.Errorf("Invalid delay %d for follow-up of %q", d.Delay, r.Title)
+	}
+
+	return nil
+} // func (d *Dependency) Validate(r *Reminder) error
+
+// Holds returns true if the Dependency keeps its Reminder from going off.
+// prereq is the prerequisite, or nil if we do not know about it (any
+// more), in which case it does not hold anything back.
+func (d *Dependency) Holds(prereq *Reminder) bool {
+	if d.Kind == DepFollowUp {
+		return d.Triggered.IsZero()
+	}
+
+	return prereq != nil && !prereq.Finished
+} // func (d *Dependency) Holds(prereq *Reminder) bool
+
+// ParseDelay parses the delay of a follow-up, a period of time like "14d"
+// or "1w2d".
+func ParseDelay(s string) (int, error) {
+	var m []string
+
+	if s = strings.TrimSpace(s); s == "" {
+		return 0, nil
+	} else if m = snoozeSpanRe.FindStringSubmatch(strings.ToLower(s)); m == nil {
+		return 0, fmt.Errorf("Invalid delay %q, expected something like 14d or 1w2d", s)
+	}
+
+	return parseSpan(m[1]), nil
+} // func ParseDelay(s string) (int, error)
+
+// FormatDelay is the inverse of ParseDelay.
+func FormatDelay(secs int) string {
+	if secs < 60 {
+		return "0m"
+	}
+
+	return formatSpan(secs)
+} // func FormatDelay(secs int) string
+
+// SameDepends returns true if both lists of Dependencies are the same.
+func SameDepends(a, b []Dependency) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i].Prereq != b[i].Prereq ||
+			a[i].Kind != b[i].Kind ||
+			a[i].Delay != b[i].Delay ||
+			a[i].Triggered.Unix() != b[i].Triggered.Unix() {
+			return false
+		}
+	}
+
+	return true
+} // func SameDepends(a, b []Dependency) bool
+
+// FindCycle checks if giving the Reminder with the given UUID the given
+// Dependencies would create a cycle among the Reminders. If so, it returns
+// the UUIDs of the Reminders along the cycle, starting and ending with
+// uuid. Otherwise, it returns nil.
+func FindCycle(reminders []Reminder, uuid string, deps []Dependency) []string {
+	var (
+		graph = make(map[string][]string, len(reminders)+1)
+		seen  = make(map[string]bool)
+		path  []string
+		visit func(string) bool
+	)
+
+	for i := range reminders {
+		for _, d := range reminders[i].Depends {
+			graph[reminders[i].UUID] = append(graph[reminders[i].UUID], d.Prereq)
+		}
+	}
+
+	graph[uuid] = nil
+	for _, d := range deps {
+		graph[uuid] = append(graph[uuid], d.Prereq)
+	}
+
+	// Since the rest of the graph is free of cycles, any cycle has to
+	// go through uuid, so a depth-first search from there will find it.
+	visit = func(node string) bool {
+		path = append(path, node)
+
+		for _, next := range graph[node] {
+			if next == uuid {
+				path = append(path, next)
+				return true
+			} else if !seen[next] {
+				seen[next] = true
+				if visit(next) {
+					return true
+				}
+			}
+		}
+
+		path = path[:len(path)-1]
+		return false
+	}
+
+	if visit(uuid) {
+		return path
+	}
+
+	return nil
+} // func FindCycle(reminders []Reminder, uuid string, deps []Dependency) []string
@@ -0,0 +1,161 @@
+// /home/krylon/go/src/github.com/blicero/theseus/objects/escalation.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 17. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <>
+
+package objects
+
+import (
+	"fmt"
+	"time"
+)
+
+// Urgency is the urgency level of a desktop notification, as defined by
+// the Desktop Notifications Specification.
+type Urgency uint8
+
+// The urgency levels, the values are the ones that go over the wire.
+const (
+	UrgencyLow Urgency = iota
+	UrgencyNormal
+	UrgencyCritical
+)
+
+func (u Urgency) String() string {
+	switch u {
+	case UrgencyLow:
+		return "Low"
+	case UrgencyNormal:
+		return "Normal"
+	case UrgencyCritical:
+		return "Critical"
+	default:
+		return fmt.Sprintf("Urgency(%d)", u)
+	}
+} // func (u Urgency) String() string
+
+// Channel is the way a Notification is delivered to the user.
+type Channel uint8
+
+// ChannelDesktop is a plain desktop notification, ChannelSound is a
+// desktop notification that also plays an alarm sound.
+const (
+	ChannelDesktop Channel = iota
+	ChannelSound
+)
+
+func (c Channel) String() string {
+	switch c {
+	case ChannelDesktop:
+		return "Desktop"
+	case ChannelSound:
+		return "Sound"
+	default:
+		return fmt.Sprintf("Channel(%d)", c)
+	}
+} // func (c Channel) String() string
+
+// minEscalationInterval is the shortest interval at which we re-notify the
+// user. We check for pending Notifications every 30 seconds anyway.
+const minEscalationInterval = 60
+
+// Escalation describes how insistent we get about a Notification the user
+// has not acknowledged. Every Interval seconds, the Notification is posted
+// again, with increasing urgency. Once it has been re-posted Threshold
+// times, we switch to the louder Channel.
+// An Interval of 0 means we do not escalate at all, a Threshold of 0 means
+// we never switch channels.
+type Escalation struct {
+	Interval  int
+	Threshold int
+	Channel   Channel
+}
+
+// EscalationStep records one re-notification of a Notification the user
+// did not react to.
+type EscalationStep struct {
+	ID             int64
+	NotificationID int64
+	Step           int
+	Timestamp      time.Time
+	Urgency        Urgency
+	Channel        Channel
+}
+
+func (e *Escalation) String() string {
+	if !e.Enabled() {
+		return "Escalation{ off }"
+	}
+
+	return fmt.Sprintf("Escalation{ Interval: %s, Threshold: %d, Channel: %s }",
+		time.Duration(e.Interval)*time.Second,
+		e.Threshold,
+		e.Channel)
+} // func (e *Escalation) String() string
+
+// Enabled returns true if unacknowledged Notifications are re-posted.
+func (e *Escalation) Enabled() bool {
+	return e.Interval > 0
+} // func (e *Escalation) Enabled() bool
+
+// Validate checks if the Escalation makes sense.
+func (e *Escalation) Validate() error {
+	if e.Interval < 0 || (e.Interval > 0 && e.Interval < minEscalationInterval) {
+		return fmt.Errorf("Escalation interval must be at least %d seconds, not %d",
+			minEscalationInterval,
+			e.Interval)
+	} else if e.Threshold < 0 {
+		return fmt.Errorf("Escalation threshold must not be negative: %d",
+			e.Threshold)
+	} else if e.Channel > ChannelSound {
+		return fmt.Errorf("Invalid notification channel %s", e.Channel)
+	}
+
+	return nil
+} // func (e *Escalation) Validate() error
+
+// Next returns the time at which a Notification that has last been
+// displayed at the given time is due to be posted again.
+// If escalation is disabled, the zero Time is returned.
+func (e *Escalation) Next(displayed time.Time) time.Time {
+	if !e.Enabled() {
+		return time.Time{}
+	}
+
+	return displayed.Add(time.Duration(e.Interval) * time.Second)
+} // func (e *Escalation) Next(displayed time.Time) time.Time
+
+// Urgency returns the urgency of the given step. The first display of a
+// Notification is step 0, the first re-notification is step 1, etc.
+func (e *Escalation) Urgency(step int) Urgency {
+	switch {
+	case step <= 0:
+		return UrgencyLow
+	case step == 1:
+		return UrgencyNormal
+	default:
+		return UrgencyCritical
+	}
+} // func (e *Escalation) Urgency(step int) Urgency
+
+// ChannelFor returns the delivery channel for the given step.
+func (e *Escalation) ChannelFor(step int) Channel {
+	if e.Threshold > 0 && step >= e.Threshold {
+		return e.Channel
+	}
+
+	return ChannelDesktop
+} // func (e *Escalation) ChannelFor(step int) Channel
+
+// Step returns the description of the given escalation step of a
+// Notification.
+func (e *Escalation) Step(n *Notification, step int, t time.Time) EscalationStep {
+	return EscalationStep{
+		NotificationID: n.ID,
+		Step:           step,
+		Timestamp:      t,
+		Urgency:        e.Urgency(step),
+		Channel:        e.ChannelFor(step),
+	}
+} // func (e *Escalation) Step(n *Notification, step int, t time.Time) EscalationStep
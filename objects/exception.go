@@ -0,0 +1,55 @@
+// /home/krylon/go/src/github.com/blicero/theseus/objects/exception.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 17. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <>
+
+package objects
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/blicero/theseus/common"
+)
+
+// Exception modifies a single occurrence of a recurring Reminder.
+// The occurrence is identified by the time it would have been due, and it
+// is either skipped or moved to a different point in time (Due).
+type Exception struct {
+	ID         int64
+	ReminderID int64
+	Occurrence time.Time
+	Due        time.Time
+	Changed    time.Time
+}
+
+// Skip returns true if the Exception cancels the occurrence.
+func (e *Exception) Skip() bool {
+	return e.Due.IsZero()
+} // func (e *Exception) Skip() bool
+
+func (e *Exception) String() string {
+	if e.Skip() {
+		return fmt.Sprintf("Exception{ Skip %s }",
+			e.Occurrence.Format(common.TimestampFormatMinute))
+	}
+
+	return fmt.Sprintf("Exception{ Move %s -> %s }",
+		e.Occurrence.Format(common.TimestampFormatMinute),
+		e.Due.Format(common.TimestampFormatMinute))
+} // func (e *Exception) String() string
+
+// Exception returns the Exception for the occurrence of the Reminder at the
+// given time, or nil if there is none.
+func (r *Reminder) Exception(t time.Time) *Exception {
+	t = t.Truncate(time.Minute)
+
+	for i := range r.Exceptions {
+		if r.Exceptions[i].Occurrence.Truncate(time.Minute).Equal(t) {
+			return &r.Exceptions[i]
+		}
+	}
+
+	return nil
+} // func (r *Reminder) Exception(t time.Time) *Exception
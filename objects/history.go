@@ -0,0 +1,282 @@
+// /home/krylon/go/src/github.com/blicero/theseus/objects/history.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 17. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <>
+
+package objects
+
+import (
+	"sort"
+	"time"
+
+	"github.com/blicero/theseus/objects/repeat"
+)
+
+// OnTimeWindow is how soon after its due time the user has to acknowledge
+// an occurrence for it to count as done on time.
+const OnTimeWindow = 15 * time.Minute
+
+// SnoozeEvent records that the user put off the Notification for the
+// occurrence at Occurrence until Until. When a Notification is snoozed
+// several times, the Until of one SnoozeEvent is the Occurrence of the
+// next.
+type SnoozeEvent struct {
+	ID         int64
+	ReminderID int64
+	Occurrence time.Time
+	Timestamp  time.Time
+	Until      time.Time
+}
+
+// HistoryItem is what became of a single occurrence of a Reminder. Due is
+// the time the occurrence was originally scheduled for, Timestamp the
+// time it finally went off after Snoozes snoozes. An occurrence is Missed
+// if it was never acknowledged, but a more recent one came up.
+type HistoryItem struct {
+	ReminderID   int64
+	Due          time.Time
+	Timestamp    time.Time
+	Shown        time.Time
+	Acknowledged time.Time
+	Snoozes      int
+	Missed       bool
+}
+
+// Pending returns true if the occurrence is neither acknowledged nor
+// missed, yet.
+func (h *HistoryItem) Pending() bool {
+	return h.Acknowledged.IsZero() && !h.Missed
+} // func (h *HistoryItem) Pending() bool
+
+// DisplayLatency returns how long after the occurrence was due its
+// Notification was displayed for the first time.
+func (h *HistoryItem) DisplayLatency() time.Duration {
+	if h.Shown.IsZero() {
+		return 0
+	}
+
+	return h.Shown.Sub(h.Due)
+} // func (h *HistoryItem) DisplayLatency() time.Duration
+
+// AckLatency returns how long it took the user to acknowledge the
+// Notification once it was displayed.
+func (h *HistoryItem) AckLatency() time.Duration {
+	if h.Shown.IsZero() || h.Acknowledged.IsZero() {
+		return 0
+	}
+
+	return h.Acknowledged.Sub(h.Shown)
+} // func (h *HistoryItem) AckLatency() time.Duration
+
+// OnTime returns true if the occurrence was acknowledged within the given
+// window after it was due, without being snoozed.
+func (h *HistoryItem) OnTime(window time.Duration) bool {
+	return !h.Acknowledged.IsZero() &&
+		h.Snoozes == 0 &&
+		h.Acknowledged.Sub(h.Due) <= window
+} // func (h *HistoryItem) OnTime(window time.Duration) bool
+
+// historyKey is what we match Notifications and SnoozeEvents by. Snoozing
+// does not care for seconds, so neither do we.
+func historyKey(t time.Time) int64 {
+	return t.Truncate(time.Minute).Unix()
+} // func historyKey(t time.Time) int64
+
+// BuildHistory reconstructs the occurrences of a Reminder from its
+// Notifications and the SnoozeEvents. Pre-alerts are ignored, and the
+// Notifications for a snoozed occurrence are folded into the one it was
+// snoozed to. The result is sorted by due time.
+func BuildHistory(notes []Notification, snoozes []SnoozeEvent) []HistoryItem {
+	var (
+		until   = make(map[int64]*SnoozeEvent, len(snoozes))
+		snoozed = make(map[int64]bool, len(snoozes))
+		items   = make([]HistoryItem, 0, len(notes))
+	)
+
+	for i := range snoozes {
+		var (
+			s      = &snoozes[i]
+			prev   = until[historyKey(s.Until)]
+			origin = historyKey(s.Occurrence)
+		)
+
+		if prev == nil || prev.Timestamp.Before(s.Timestamp) {
+			until[historyKey(s.Until)] = s
+		}
+		snoozed[origin] = true
+	}
+
+	for _, n := range notes {
+		if n.IsPreAlert() || snoozed[historyKey(n.Timestamp)] {
+			continue
+		}
+
+		var item = HistoryItem{
+			ReminderID: n.ReminderID,
+			Due:        n.Timestamp,
+			Timestamp:  n.Timestamp,
+			Shown:      n.Shown,
+			Missed:     n.Skipped,
+		}
+
+		if item.Shown.IsZero() {
+			item.Shown = n.Displayed
+		}
+		if !n.Skipped {
+			item.Acknowledged = n.Acknowledged
+		}
+
+		// Follow the chain of snoozes back to the original due time.
+		// The chain cannot be longer than the list of snoozes, so we
+		// do not get stuck on a loop.
+		for s := until[historyKey(item.Due)]; s != nil && item.Snoozes < len(snoozes); s = until[historyKey(item.Due)] {
+			item.Due = s.Occurrence
+			item.Snoozes++
+		}
+
+		items = append(items, item)
+	}
+
+	sort.SliceStable(items, func(i, j int) bool { return items[i].Due.Before(items[j].Due) })
+
+	// An occurrence nobody acknowledged before the next one came up is
+	// not going to be acknowledged any more.
+	for i := 0; i < len(items)-1; i++ {
+		if items[i].Acknowledged.IsZero() {
+			items[i].Missed = true
+		}
+	}
+
+	return items
+} // func BuildHistory(notes []Notification, snoozes []SnoozeEvent) []HistoryItem
+
+// ReminderStats sums up the history of a Reminder. The Streak is the
+// number of consecutive occurrences of a recurring Reminder the user has
+// acknowledged up to the most recent one, BestStreak is the longest such
+// run. AckLatency is the average time it took the user to acknowledge an
+// occurrence after it was displayed.
+type ReminderStats struct {
+	ReminderID   int64
+	Title        string
+	Occurrences  int
+	Acknowledged int
+	Missed       int
+	Snoozes      int
+	OnTime       int
+	Streak       int
+	BestStreak   int
+	AckLatency   time.Duration
+}
+
+// OnTimePercent returns the share of occurrences that were acknowledged on
+// time, out of those that are not pending any more.
+func (s *ReminderStats) OnTimePercent() float64 {
+	var done = s.Acknowledged + s.Missed
+
+	if done == 0 {
+		return 0
+	}
+
+	return float64(s.OnTime) * 100 / float64(done)
+} // func (s *ReminderStats) OnTimePercent() float64
+
+// ComputeStats sums up the history of the given Reminder. An occurrence
+// counts as on time if it was acknowledged within window after it was due.
+func ComputeStats(r *Reminder, items []HistoryItem, window time.Duration) ReminderStats {
+	var (
+		latency time.Duration
+		stats   = ReminderStats{
+			ReminderID:  r.ID,
+			Title:       r.Title,
+			Occurrences: len(items),
+		}
+	)
+
+	for i := range items {
+		var h = &items[i]
+
+		stats.Snoozes += h.Snoozes
+
+		switch {
+		case h.Missed:
+			stats.Missed++
+			stats.Streak = 0
+		case !h.Acknowledged.IsZero():
+			stats.Acknowledged++
+			stats.Streak++
+			latency += h.AckLatency()
+			if h.OnTime(window) {
+				stats.OnTime++
+			}
+		}
+
+		if stats.Streak > stats.BestStreak {
+			stats.BestStreak = stats.Streak
+		}
+	}
+
+	if stats.Acknowledged > 0 {
+		stats.AckLatency = latency / time.Duration(stats.Acknowledged)
+	}
+
+	// A streak is something that only habits have.
+	if r.Recur.Repeat == repeat.Once {
+		stats.Streak, stats.BestStreak = 0, 0
+	}
+
+	return stats
+} // func ComputeStats(r *Reminder, items []HistoryItem, window time.Duration) ReminderStats
+
+// History is the history of a single Reminder, along with its statistics.
+type History struct {
+	Stats ReminderStats
+	Items []HistoryItem
+}
+
+// HistorySummary holds the statistics of all Reminders since From, plus
+// the ones the user snoozed most often.
+type HistorySummary struct {
+	From        time.Time
+	Reminders   []ReminderStats
+	MostSnoozed []ReminderStats
+}
+
+// OnTimePercent returns the share of occurrences of all Reminders that
+// were acknowledged on time.
+func (s *HistorySummary) OnTimePercent() float64 {
+	var total = ReminderStats{}
+
+	for _, r := range s.Reminders {
+		total.Acknowledged += r.Acknowledged
+		total.Missed += r.Missed
+		total.OnTime += r.OnTime
+	}
+
+	return total.OnTimePercent()
+} // func (s *HistorySummary) OnTimePercent() float64
+
+// MostSnoozed returns up to max of the given statistics, those with the
+// most snoozes first. Reminders that were never snoozed are left out.
+func MostSnoozed(stats []ReminderStats, max int) []ReminderStats {
+	var res = make([]ReminderStats, 0, len(stats))
+
+	for _, s := range stats {
+		if s.Snoozes > 0 {
+			res = append(res, s)
+		}
+	}
+
+	sort.SliceStable(res, func(i, j int) bool {
+		if res[i].Snoozes != res[j].Snoozes {
+			return res[i].Snoozes > res[j].Snoozes
+		}
+		return res[i].Title < res[j].Title
+	})
+
+	if max > 0 && len(res) > max {
+		res = res[:max]
+	}
+
+	return res
+} // func MostSnoozed(stats []ReminderStats, max int) []ReminderStats
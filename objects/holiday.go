@@ -0,0 +1,412 @@
+// /home/krylon/go/src/github.com/blicero/theseus/objects/holiday.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 17. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <>
+
+package objects
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// HolidayRule determines what happens to an occurrence of a Reminder that
+// falls on a holiday.
+type HolidayRule uint8
+
+// HolidayIgnore means holidays make no difference, HolidaySkip drops
+// occurrences on holidays, and HolidayShift moves them to the next
+// business day, i.e. the next day that is neither a holiday nor on a
+// weekend.
+const (
+	HolidayIgnore HolidayRule = iota
+	HolidaySkip
+	HolidayShift
+)
+
+func (h HolidayRule) String() string {
+	switch h {
+	case HolidayIgnore:
+		return "Ignore"
+	case HolidaySkip:
+		return "Skip"
+	case HolidayShift:
+		return "Shift"
+	default:
+		return fmt.Sprintf("HolidayRule(%d)", h)
+	}
+} // func (h HolidayRule) String() string
+
+const (
+	dateFormat   = "2006-01-02"
+	annualFormat = "01-02"
+	icsDate      = "20060102"
+)
+
+// maxHolidayShift is the number of days an occurrence can be shifted at
+// most. That is plenty even for the time between Christmas and New Year.
+const maxHolidayShift = 14
+
+// Calendar is a list of holidays. Holidays are either tied to a particular
+// date, or they occur on the same day every year.
+type Calendar struct {
+	Name   string
+	dates  map[string]string
+	annual map[string]string
+}
+
+// NewCalendar creates an empty Calendar.
+func NewCalendar(name string) *Calendar {
+	return &Calendar{
+		Name:   name,
+		dates:  make(map[string]string),
+		annual: make(map[string]string),
+	}
+} // func NewCalendar(name string) *Calendar
+
+// Add adds a holiday on the given day to the Calendar. If annual is true,
+// the holiday occurs on the same day every year.
+func (c *Calendar) Add(day time.Time, name string, annual bool) {
+	if annual {
+		c.annual[day.Format(annualFormat)] = name
+	} else {
+		c.dates[day.Format(dateFormat)] = name
+	}
+} // func (c *Calendar) Add(day time.Time, name string, annual bool)
+
+// Len returns the number of holidays in the Calendar.
+func (c *Calendar) Len() int {
+	return len(c.dates) + len(c.annual)
+} // func (c *Calendar) Len() int
+
+// Holiday returns the name of the holiday on the day of t, if there is one.
+// The time of day is ignored.
+func (c *Calendar) Holiday(t time.Time) (string, bool) {
+	if name, ok := c.dates[t.Format(dateFormat)]; ok {
+		return name, true
+	}
+
+	var name, ok = c.annual[t.Format(annualFormat)]
+	return name, ok
+} // func (c *Calendar) Holiday(t time.Time) (string, bool)
+
+// BusinessDay returns true if the day of t is neither a holiday nor on a
+// weekend.
+func (c *Calendar) BusinessDay(t time.Time) bool {
+	if t.Weekday() == time.Saturday || t.Weekday() == time.Sunday {
+		return false
+	}
+
+	var _, holiday = c.Holiday(t)
+	return !holiday
+} // func (c *Calendar) BusinessDay(t time.Time) bool
+
+// ParseHolidayList reads a Calendar from a plain text file. Each line holds
+// a date and the name of the holiday, separated by whitespace. Dates in the
+// form YYYY-MM-DD denote a single day, dates in the form MM-DD denote a
+// holiday that occurs every year. Empty lines and lines starting with # are
+// ignored.
+func ParseHolidayList(name string, r io.Reader) (*Calendar, error) {
+	var (
+		cal  = NewCalendar(name)
+		scn  = bufio.NewScanner(r)
+		lnum int
+	)
+
+	for scn.Scan() {
+		var (
+			err    error
+			day    time.Time
+			fields []string
+			line   = strings.TrimSpace(scn.Text())
+			title  string
+		)
+
+		lnum++
+
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields = strings.Fields(line)
+		title = strings.Join(fields[1:], " ")
+
+		if day, err = time.Parse(dateFormat, fields[0]); err == nil {
+			cal.Add(day, title, false)
+		} else if day, err = time.Parse(annualFormat, fields[0]); err == nil {
+			cal.Add(day, title, true)
+		} else {
+			return nil, fmt.Errorf("Invalid date in line %d of %s: %q",
+				lnum,
+				name,
+				fields[0])
+		}
+	}
+
+	if err := scn.Err(); err != nil {
+		return nil, err
+	}
+
+	return cal, nil
+} // func ParseHolidayList(name string, r io.Reader) (*Calendar, error)
+
+var icsDateRe = regexp.MustCompile(`^(\d{8})`)
+
+// ParseICS reads a Calendar from an iCalendar file. Every event is treated
+// as a holiday that lasts from the day of its start to the day before its
+// end (or just one day, if it has no end). Events that repeat yearly are
+// treated as annual holidays, other recurrence rules are not supported.
+func ParseICS(name string, r io.Reader) (*Calendar, error) {
+	var (
+		err     error
+		cal     = NewCalendar(name)
+		lines   []string
+		scn     = bufio.NewScanner(r)
+		inEvent bool
+		annual  bool
+		start   time.Time
+		end     time.Time
+		summary string
+	)
+
+	// Long lines are folded, i.e. continued on the next line, which
+	// starts with a space or a tab.
+	for scn.Scan() {
+		var line = strings.TrimRight(scn.Text(), "\r")
+
+		if len(lines) > 0 && (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) {
+			lines[len(lines)-1] += line[1:]
+		} else {
+			lines = append(lines, line)
+		}
+	}
+
+	if err = scn.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, line := range lines {
+		var key, value string
+
+		if idx := strings.Index(line, ":"); idx < 0 {
+			continue
+		} else {
+			key = strings.ToUpper(line[:idx])
+			value = line[idx+1:]
+		}
+
+		// Strip parameters, such as DTSTART;VALUE=DATE
+		if idx := strings.Index(key, ";"); idx >= 0 {
+			key = key[:idx]
+		}
+
+		switch key {
+		case "BEGIN":
+			if strings.EqualFold(value, "VEVENT") {
+				inEvent = true
+				annual = false
+				start = time.Time{}
+				end = time.Time{}
+				summary = ""
+			}
+		case "DTSTART", "DTEND":
+			var (
+				day time.Time
+				m   = icsDateRe.FindStringSubmatch(value)
+			)
+
+			if !inEvent {
+				continue
+			} else if m == nil {
+				return nil, fmt.Errorf("Invalid date in %s: %q", name, value)
+			} else if day, err = time.Parse(icsDate, m[1]); err != nil {
+				return nil, fmt.Errorf("Invalid date in %s: %q", name, value)
+			} else if key == "DTSTART" {
+				start = day
+			} else {
+				end = day
+			}
+		case "SUMMARY":
+			summary = strings.ReplaceAll(value, "\\,", ",")
+		case "RRULE":
+			annual = strings.Contains(strings.ToUpper(value), "FREQ=YEARLY")
+		case "END":
+			if !inEvent || !strings.EqualFold(value, "VEVENT") {
+				continue
+			} else if start.IsZero() {
+				return nil, fmt.Errorf("Event %q in %s has no start date",
+					summary,
+					name)
+			}
+
+			inEvent = false
+			cal.Add(start, summary, annual)
+
+			for day := start.AddDate(0, 0, 1); day.Before(end); day = day.AddDate(0, 0, 1) {
+				cal.Add(day, summary, annual)
+			}
+		}
+	}
+
+	return cal, nil
+} // func ParseICS(name string, r io.Reader) (*Calendar, error)
+
+// LoadCalendar reads a Calendar from a file. Files ending in .ics are
+// parsed as iCalendar files, all others as lists of holidays.
+// The name of the Calendar is the name of the file without the suffix.
+func LoadCalendar(path string) (*Calendar, error) {
+	var (
+		err  error
+		fh   *os.File
+		ext  = filepath.Ext(path)
+		name = strings.TrimSuffix(filepath.Base(path), ext)
+	)
+
+	if fh, err = os.Open(path); err != nil {
+		return nil, err
+	}
+
+	defer fh.Close() // nolint: errcheck
+
+	if strings.EqualFold(ext, ".ics") {
+		return ParseICS(name, fh)
+	}
+
+	return ParseHolidayList(name, fh)
+} // func LoadCalendar(path string) (*Calendar, error)
+
+type calendarEntry struct {
+	cal   *Calendar
+	mtime time.Time
+}
+
+var (
+	calLock   sync.RWMutex
+	calendars = make(map[string]calendarEntry)
+)
+
+// RegisterCalendar makes a Calendar available to Reminders by its name.
+func RegisterCalendar(c *Calendar) {
+	calLock.Lock()
+	calendars[c.Name] = calendarEntry{cal: c}
+	calLock.Unlock()
+} // func RegisterCalendar(c *Calendar)
+
+// GetCalendar returns the Calendar with the given name, or nil if there is
+// no such Calendar.
+func GetCalendar(name string) *Calendar {
+	calLock.RLock()
+	defer calLock.RUnlock()
+
+	if e, ok := calendars[name]; ok {
+		return e.cal
+	}
+
+	return nil
+} // func GetCalendar(name string) *Calendar
+
+// CalendarNames returns the names of all known Calendars in alphabetical
+// order.
+func CalendarNames() []string {
+	calLock.RLock()
+	var names = make([]string, 0, len(calendars))
+	for name := range calendars {
+		names = append(names, name)
+	}
+	calLock.RUnlock()
+
+	sort.Strings(names)
+	return names
+} // func CalendarNames() []string
+
+// LoadCalendars loads all Calendars from the files in the given folder.
+// Files that have not changed since they were last loaded are skipped,
+// so it is cheap to call this regularly to pick up changes.
+// If a file cannot be parsed, the remaining files are loaded anyway, and
+// the first error is returned.
+func LoadCalendars(dir string) error {
+	var (
+		err, first error
+		entries    []os.DirEntry
+	)
+
+	if entries, err = os.ReadDir(dir); err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	for _, ent := range entries {
+		var (
+			info os.FileInfo
+			cal  *Calendar
+			path = filepath.Join(dir, ent.Name())
+			name = strings.TrimSuffix(ent.Name(), filepath.Ext(ent.Name()))
+		)
+
+		if ent.IsDir() || strings.HasPrefix(ent.Name(), ".") {
+			continue
+		} else if info, err = ent.Info(); err != nil {
+			if first == nil {
+				first = err
+			}
+			continue
+		}
+
+		calLock.RLock()
+		var e, ok = calendars[name]
+		calLock.RUnlock()
+
+		if ok && e.mtime.Equal(info.ModTime()) {
+			continue
+		} else if cal, err = LoadCalendar(path); err != nil {
+			if first == nil {
+				first = fmt.Errorf("Cannot load holidays from %s: %s",
+					path,
+					err.Error())
+			}
+			continue
+		}
+
+		calLock.Lock()
+		calendars[name] = calendarEntry{cal: cal, mtime: info.ModTime()}
+		calLock.Unlock()
+	}
+
+	return first
+} // func LoadCalendars(dir string) error
+
+// ImportCalendar copies a holiday file into the given folder, so that
+// LoadCalendars picks it up. The file is parsed first, to make sure it
+// is usable. It returns the name of the Calendar.
+func ImportCalendar(path, dir string) (string, error) {
+	var (
+		err  error
+		cal  *Calendar
+		data []byte
+	)
+
+	if cal, err = LoadCalendar(path); err != nil {
+		return "", err
+	} else if cal.Len() == 0 {
+		return "", fmt.Errorf("%s does not contain any holidays", path)
+	} else if data, err = os.ReadFile(path); err != nil {
+		return "", err
+	} else if err = os.MkdirAll(dir, 0700); err != nil {
+		return "", err
+	} else if err = os.WriteFile(filepath.Join(dir, filepath.Base(path)), data, 0600); err != nil {
+		return "", err
+	}
+
+	return cal.Name, nil
+} // func ImportCalendar(path, dir string) (string, error)
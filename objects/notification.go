@@ -12,10 +12,36 @@ import "time"
 // Notification represents one point in time when a Reminder is displayed.
 // We use it to keep track of whether the Notification has been acknowledged
 // yet, so we do not forget a Notification, but do not display it twice, either.
+// A Notification with a non-zero Lead is a pre-alert, it is displayed Lead
+// seconds before the occurrence it warns about.
+// Step counts how often the Notification has been re-posted because the
+// user did not acknowledge it.
+// Deferred is the time the Notification was held back because it came up
+// during quiet hours, it is the zero Time for Notifications that were not.
+// Shown is the time the Notification was displayed for the first time
+// (Displayed is updated each time it is posted again), and Skipped is true
+// if it was never acknowledged, but dropped because a more recent
+// occurrence came up. We only load those two for the history.
 type Notification struct {
 	ID           int64
 	ReminderID   int64
 	Timestamp    time.Time
 	Displayed    time.Time
 	Acknowledged time.Time
+	Lead         int
+	Step         int
+	Deferred     time.Time
+	Shown        time.Time
+	Skipped      bool
 }
+
+// IsPreAlert returns true if the Notification warns about an upcoming
+// occurrence rather than being the occurrence itself.
+func (n *Notification) IsPreAlert() bool {
+	return n.Lead > 0
+} // func (n *Notification) IsPreAlert() bool
+
+// Due returns the time of the occurrence the Notification is about.
+func (n *Notification) Due() time.Time {
+	return n.Timestamp.Add(time.Duration(n.Lead) * time.Second)
+} // func (n *Notification) Due() time.Time
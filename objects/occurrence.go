@@ -0,0 +1,148 @@
+// /home/krylon/go/src/github.com/blicero/theseus/objects/occurrence.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 17. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <>
+
+package objects
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/blicero/theseus/common"
+	"github.com/blicero/theseus/objects/repeat"
+)
+
+// States an Occurrence can be in. An Occurrence is Scheduled as long as no
+// Notification has been created for it.
+const (
+	OccurrenceScheduled    = "scheduled"
+	OccurrencePending      = "pending"
+	OccurrenceDisplayed    = "displayed"
+	OccurrenceAcknowledged = "acknowledged"
+)
+
+// maxOccurrences is the number of occurrences we generate per Reminder at
+// most, so a Reminder that goes off every minute cannot flood a client.
+const maxOccurrences = 4096
+
+// Occurrence is a single point in time at which a Reminder is due.
+type Occurrence struct {
+	ReminderID   int64
+	Title        string
+	Timestamp    time.Time
+	State        string
+	Notification *Notification
+}
+
+func (o *Occurrence) String() string {
+	return fmt.Sprintf("Occurrence{ ReminderID: %d, Title: %q, Timestamp: %s, State: %s }",
+		o.ReminderID,
+		o.Title,
+		o.Timestamp.Format(common.TimestampFormatMinute),
+		o.State)
+} // func (o *Occurrence) String() string
+
+// SetNotification attaches a Notification to the Occurrence and sets its
+// State accordingly.
+func (o *Occurrence) SetNotification(n *Notification) {
+	o.Notification = n
+
+	if n == nil {
+		o.State = OccurrenceScheduled
+	} else if !n.Acknowledged.IsZero() {
+		o.State = OccurrenceAcknowledged
+	} else if !n.Displayed.IsZero() {
+		o.State = OccurrenceDisplayed
+	} else {
+		o.State = OccurrencePending
+	}
+} // func (o *Occurrence) SetNotification(n *Notification)
+
+// Occurrences returns the points in time at which the Reminder is due in
+// the interval [from, to), in ascending order.
+// Skipped and moved occurrences are taken into account. A Limit only
+// applies to occurrences from now on, the ones before are assumed to
+// have happened already.
+func (r *Reminder) Occurrences(from, to time.Time) []time.Time {
+	var (
+		now   = time.Now()
+		items []time.Time
+	)
+
+	if !from.Before(to) {
+		return nil
+	} else if r.Recur.Repeat == repeat.Once {
+		var t = r.Timestamp.Truncate(time.Minute)
+		if !t.Before(from) && t.Before(to) {
+			items = append(items, t)
+		}
+		return items
+	}
+
+	// We count the remaining occurrences ourselves, so we look at the
+	// schedule without the Limit.
+	var (
+		rem       = *r
+		remaining = -1
+		cur       = from
+	)
+
+	rem.Recur.Limit = 0
+
+	if r.Finished || (r.Recur.Limit > 0 && r.Recur.Counter >= r.Recur.Limit) {
+		remaining = 0
+	} else if r.Recur.Limit > 0 {
+		remaining = r.Recur.Limit - r.Recur.Counter
+	}
+
+	for len(items) < maxOccurrences {
+		var t = rem.DueNext(&cur)
+
+		if t.IsZero() || !t.Before(to) || t.Before(cur.Truncate(time.Minute)) {
+			break
+		} else if !t.Before(now.Truncate(time.Minute)) {
+			if remaining == 0 {
+				break
+			} else if remaining > 0 {
+				remaining--
+			}
+		}
+
+		items = append(items, t)
+		cur = t.Add(time.Minute)
+	}
+
+	return items
+} // func (r *Reminder) Occurrences(from, to time.Time) []time.Time
+
+// Expand returns all occurrences of the given Reminders in the interval
+// [from, to), ordered by time. The Occurrences are in the Scheduled state,
+// it is up to the caller to attach Notifications.
+func Expand(items []Reminder, from, to time.Time) []Occurrence {
+	var occ = make([]Occurrence, 0, len(items))
+
+	for i := range items {
+		var r = &items[i]
+
+		for _, t := range r.Occurrences(from, to) {
+			occ = append(occ, Occurrence{
+				ReminderID: r.ID,
+				Title:      r.Title,
+				Timestamp:  t,
+				State:      OccurrenceScheduled,
+			})
+		}
+	}
+
+	sort.SliceStable(occ, func(i, j int) bool {
+		if occ[i].Timestamp.Equal(occ[j].Timestamp) {
+			return occ[i].ReminderID < occ[j].ReminderID
+		}
+		return occ[i].Timestamp.Before(occ[j].Timestamp)
+	})
+
+	return occ
+} // func Expand(items []Reminder, from, to time.Time) []Occurrence
@@ -0,0 +1,1474 @@
+// /home/krylon/go/src/github.com/blicero/theseus/objects/phrase.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 17. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <>
+
+package objects
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/blicero/theseus/objects/repeat"
+)
+
+// This file implements a parser for reminders entered in plain English or
+// German, like "call mom tomorrow at 9:00", "in 20 minutes tea is ready",
+// "every weekday at 8 standup" or "jeden ersten Montag im Monat um 14 Uhr
+// Teamrunde".
+//
+// The parser does not attempt to understand the phrase as a whole. It scans
+// the words from left to right, looking for the building blocks of a point
+// in time - a date, a weekday, a time of day, "every" and so on. Everything
+// it does not recognize becomes the title of the Reminder. Once the phrase
+// has been scanned, we look at the pieces we found and put them together.
+
+// defaultPhraseTime is the time of day we use when a phrase mentions a day,
+// but no time.
+const defaultPhraseTime = 9 * 3600
+
+// maxPhraseScan is the number of days we look ahead at most to find the
+// first day matching a phrase.
+const maxPhraseScan = 400
+
+type phraseUnit uint8
+
+const (
+	unitNone phraseUnit = iota
+	unitMinute
+	unitHour
+	unitDay
+	unitWeek
+	unitMonth
+	unitYear
+)
+
+var phraseUnits = map[string]phraseUnit{
+	"m":       unitMinute,
+	"min":     unitMinute,
+	"mins":    unitMinute,
+	"minute":  unitMinute,
+	"minutes": unitMinute,
+	"minuten": unitMinute,
+	"h":       unitHour,
+	"hr":      unitHour,
+	"hrs":     unitHour,
+	"hour":    unitHour,
+	"hours":   unitHour,
+	"std":     unitHour,
+	"stunde":  unitHour,
+	"stunden": unitHour,
+	"d":       unitDay,
+	"day":     unitDay,
+	"days":    unitDay,
+	"tag":     unitDay,
+	"tage":    unitDay,
+	"tagen":   unitDay,
+	"w":       unitWeek,
+	"week":    unitWeek,
+	"weeks":   unitWeek,
+	"woche":   unitWeek,
+	"wochen":  unitWeek,
+	"month":   unitMonth,
+	"months":  unitMonth,
+	"monat":   unitMonth,
+	"monate":  unitMonth,
+	"monaten": unitMonth,
+	"monats":  unitMonth,
+	"year":    unitYear,
+	"years":   unitYear,
+	"jahr":    unitYear,
+	"jahre":   unitYear,
+	"jahren":  unitYear,
+	"jahres":  unitYear,
+}
+
+var phraseNumbers = map[string]int{
+	"a":      1,
+	"an":     1,
+	"one":    1,
+	"ein":    1,
+	"eine":   1,
+	"einen":  1,
+	"einer":  1,
+	"einem":  1,
+	"two":    2,
+	"zwei":   2,
+	"three":  3,
+	"drei":   3,
+	"four":   4,
+	"vier":   4,
+	"five":   5,
+	"fuenf":  5,
+	"six":    6,
+	"sechs":  6,
+	"seven":  7,
+	"sieben": 7,
+	"eight":  8,
+	"acht":   8,
+	"nine":   9,
+	"neun":   9,
+	"ten":    10,
+	"zehn":   10,
+}
+
+// phraseWeekdays maps the full names of the weekdays to their index in
+// Weekdays. The plural forms ("mondays", "montags") mean every week.
+var phraseWeekdays = map[string]int{
+	"monday":     0,
+	"tuesday":    1,
+	"wednesday":  2,
+	"thursday":   3,
+	"friday":     4,
+	"saturday":   5,
+	"sunday":     6,
+	"montag":     0,
+	"dienstag":   1,
+	"mittwoch":   2,
+	"donnerstag": 3,
+	"freitag":    4,
+	"samstag":    5,
+	"sonnabend":  5,
+	"sonntag":    6,
+}
+
+// phraseWeekdayAbbr maps abbreviations of the weekdays to their index in
+// Weekdays. Some of them are words in their own right ("do", "so"), so we
+// only accept them after a word like "on" or "every", or in a list.
+// The German abbreviations are added from wDayStr in init.
+var phraseWeekdayAbbr = map[string]int{
+	"mon":   0,
+	"tue":   1,
+	"tues":  1,
+	"wed":   2,
+	"thu":   3,
+	"thur":  3,
+	"thurs": 3,
+	"fri":   4,
+	"sat":   5,
+	"sun":   6,
+}
+
+var phraseMonths = map[string]time.Month{
+	"january":   time.January,
+	"jan":       time.January,
+	"januar":    time.January,
+	"jaenner":   time.January,
+	"february":  time.February,
+	"feb":       time.February,
+	"februar":   time.February,
+	"march":     time.March,
+	"mar":       time.March,
+	"maerz":     time.March,
+	"april":     time.April,
+	"apr":       time.April,
+	"may":       time.May,
+	"mai":       time.May,
+	"june":      time.June,
+	"jun":       time.June,
+	"juni":      time.June,
+	"july":      time.July,
+	"jul":       time.July,
+	"juli":      time.July,
+	"august":    time.August,
+	"aug":       time.August,
+	"september": time.September,
+	"sep":       time.September,
+	"sept":      time.September,
+	"october":   time.October,
+	"oct":       time.October,
+	"oktober":   time.October,
+	"okt":       time.October,
+	"november":  time.November,
+	"nov":       time.November,
+	"december":  time.December,
+	"dec":       time.December,
+	"dezember":  time.December,
+	"dez":       time.December,
+}
+
+// phraseWeekOrdinals are used for "the first Monday of the month" and such.
+var phraseWeekOrdinals = map[string]int{
+	"first":    1,
+	"1st":      1,
+	"ersten":   1,
+	"erste":    1,
+	"erster":   1,
+	"second":   2,
+	"2nd":      2,
+	"zweiten":  2,
+	"zweite":   2,
+	"zweiter":  2,
+	"third":    3,
+	"3rd":      3,
+	"dritten":  3,
+	"dritte":   3,
+	"dritter":  3,
+	"fourth":   4,
+	"4th":      4,
+	"vierten":  4,
+	"vierte":   4,
+	"vierter":  4,
+	"fifth":    5,
+	"5th":      5,
+	"fuenften": 5,
+	"fuenfte":  5,
+	"fuenfter": 5,
+	"last":     LastWeek,
+	"letzten":  LastWeek,
+	"letzte":   LastWeek,
+	"letzter":  LastWeek,
+}
+
+// phraseDayTimes are vague times of day, which we use if no time is given
+// explicitly.
+var phraseDayTimes = map[string]int{
+	"morning":     9 * 3600,
+	"morgens":     9 * 3600,
+	"frueh":       8 * 3600,
+	"vormittag":   10 * 3600,
+	"vormittags":  10 * 3600,
+	"afternoon":   15 * 3600,
+	"nachmittag":  15 * 3600,
+	"nachmittags": 15 * 3600,
+	"evening":     19 * 3600,
+	"abend":       19 * 3600,
+	"abends":      19 * 3600,
+	"tonight":     20 * 3600,
+	"night":       22 * 3600,
+	"nacht":       22 * 3600,
+	"nachts":      22 * 3600,
+}
+
+var phraseEvery = map[string]bool{
+	"every": true,
+	"each":  true,
+	"jeden": true,
+	"jede":  true,
+	"jedes": true,
+	"jeder": true,
+	"alle":  true,
+}
+
+var (
+	workdays = Weekdays{true, true, true, true, true, false, false}
+	weekend  = Weekdays{false, false, false, false, false, true, true}
+)
+
+func init() {
+	for i, s := range wDayStr {
+		phraseWeekdayAbbr[strings.ToLower(s)] = i
+	}
+} // func init()
+
+var (
+	phraseClockRe   = regexp.MustCompile(`^(\d{1,2}):(\d{2})$`)
+	phraseDotTimeRe = regexp.MustCompile(`^(\d{1,2})\.(\d{2})$`)
+	phraseHourRe    = regexp.MustCompile(`^(\d{1,2})$`)
+	phraseISODateRe = regexp.MustCompile(`^(\d{4})-(\d{1,2})-(\d{1,2})$`)
+	phraseDotDateRe = regexp.MustCompile(`^(\d{1,2})\.(\d{1,2})\.(\d{2}|\d{4})?$`)
+	phraseOrdinalRe = regexp.MustCompile(`^(\d{1,2})(?:st|nd|rd|th|\.)$`)
+	phraseYearRe    = regexp.MustCompile(`^(\d{4})$`)
+	phraseGluedRe   = regexp.MustCompile(`^(\d{1,2}(?::\d{2})?)(am|pm|h|uhr|min|mins|std)$`)
+)
+
+var phraseFold = strings.NewReplacer(
+	"ä", "ae",
+	"ö", "oe",
+	"ü", "ue",
+	"ß", "ss",
+)
+
+type phraseToken struct {
+	raw   string // The word as it was entered
+	word  string // Lower case, umlauts replaced, trailing punctuation removed
+	comma bool   // Was the word followed by a comma?
+}
+
+type phraseParser struct {
+	toks  []phraseToken
+	now   time.Time
+	loc   *time.Location
+	title []string
+
+	// "in 2 hours", "in 3 days"
+	relative                       bool
+	relMinutes, relDays, relMonths int
+	shifted                        bool // today, tomorrow
+	shift                          int
+	dated                          bool // a calendar date
+	year, day                      int
+	month                          time.Month
+	mday                           int  // "on the 3rd"
+	week                           int  // "the first Monday"
+	monthRef                       bool // "of the month"
+	days                           Weekdays
+	next                           bool // "next Friday"
+	times                          []int
+	daytime                        int // "in the morning"
+	recurring                      bool
+	unit                           phraseUnit
+	count                          int
+	until                          time.Time
+	hasUntil                       bool
+}
+
+// ParseReminder creates a Reminder from a phrase in English or German, such
+// as "call mom tomorrow at 9:00", "next friday at 14:30 dentist", "every
+// weekday at 8 standup", "pay rent on the 3rd of every month" or "jeden
+// Montag um 10 Uhr Müll rausbringen". Relative times are computed from now,
+// and all times are taken to be in the time zone of now.
+// The words that do not describe the time make up the title of the
+// Reminder. It is an error if there are none, or if the phrase does not
+// contain a time at all.
+func ParseReminder(text string, now time.Time) (*Reminder, error) {
+	var p = &phraseParser{
+		now: now,
+		loc: now.Location(),
+	}
+
+	p.tokenize(text)
+	p.scan()
+
+	return p.reminder()
+} // func ParseReminder(text string, now time.Time) (*Reminder, error)
+
+func (p *phraseParser) tokenize(text string) {
+	for _, raw := range strings.Fields(text) {
+		var (
+			tok  = phraseToken{raw: raw}
+			word = phraseFold.Replace(strings.ToLower(raw))
+		)
+
+		if strings.HasSuffix(word, ",") {
+			tok.comma = true
+		}
+
+		tok.word = strings.TrimRight(word, ",;:!?")
+
+		// "8pm", "20min", "9uhr"
+		if m := phraseGluedRe.FindStringSubmatch(tok.word); m != nil {
+			var idx = len(m[1])
+			p.toks = append(p.toks,
+				phraseToken{raw: raw[:idx], word: m[1]},
+				phraseToken{raw: raw[idx:], word: m[2], comma: tok.comma})
+			continue
+		}
+
+		p.toks = append(p.toks, tok)
+	}
+} // func (p *phraseParser) tokenize(text string)
+
+// word returns the normalized word at index i, or an empty string if i is
+// out of range.
+func (p *phraseParser) word(i int) string {
+	if i < 0 || i >= len(p.toks) {
+		return ""
+	}
+
+	return p.toks[i].word
+} // func (p *phraseParser) word(i int) string
+
+// bare returns the word at index i without a trailing period.
+func (p *phraseParser) bare(i int) string {
+	return strings.TrimSuffix(p.word(i), ".")
+} // func (p *phraseParser) bare(i int) string
+
+// joined returns the number of words that join the item at index i-1 to the
+// next item in a list, i.e. 1 for "and" or "und", or 0 if the previous word
+// ended with a comma. If the list ends at i, it returns -1.
+func (p *phraseParser) joined(i int) int {
+	if i > 0 && i-1 < len(p.toks) && p.toks[i-1].comma {
+		return 0
+	} else if w := p.word(i); w == "and" || w == "und" || w == "&" {
+		return 1
+	}
+
+	return -1
+} // func (p *phraseParser) joined(i int) int
+
+func (p *phraseParser) scan() {
+	var matchers = []func(int) int{
+		p.matchRelative,
+		p.matchEvery,
+		p.matchAdverb,
+		p.matchUntil,
+		p.matchDayName,
+		p.matchDate,
+		p.matchOrdinal,
+		p.matchMonthRef,
+		p.matchWeekday,
+		p.matchTime,
+		p.matchDayTime,
+	}
+
+	for i := 0; i < len(p.toks); {
+		var n int
+
+		for _, m := range matchers {
+			if n = m(i); n > 0 {
+				break
+			}
+		}
+
+		if n == 0 {
+			p.title = append(p.title, p.toks[i].raw)
+			n = 1
+		}
+
+		i += n
+	}
+} // func (p *phraseParser) scan()
+
+// number returns the number at index i, either in digits or as a word.
+func (p *phraseParser) number(i int) (int, bool) {
+	var w = p.word(i)
+
+	if n, ok := phraseNumbers[w]; ok {
+		return n, true
+	} else if n, err := strconv.Atoi(w); err == nil && n > 0 {
+		return n, true
+	}
+
+	return 0, false
+} // func (p *phraseParser) number(i int) (int, bool)
+
+// matchRelative matches "in 20 minutes", "in 2 hours and 15 minutes",
+// "in half an hour", "in 3 Tagen", "in einer halben Stunde".
+func (p *phraseParser) matchRelative(i int) int {
+	if w := p.word(i); w != "in" || p.relative {
+		return 0
+	}
+
+	var (
+		j     = i + 1
+		found bool
+	)
+
+	for {
+		var (
+			cnt  int
+			unit phraseUnit
+			ok   bool
+			k    = j
+			half bool
+		)
+
+		if found {
+			if n := p.joined(k); n >= 0 {
+				k += n
+			}
+		}
+
+		if w := p.word(k); w == "half" && (p.word(k+1) == "an" || p.word(k+1) == "a") {
+			half = true
+			k += 2
+		} else if cnt, ok = p.number(k); !ok {
+			break
+		} else {
+			k++
+			if w = p.word(k); cnt == 1 && (w == "halben" || w == "halbe") {
+				half = true
+				k++
+			}
+		}
+
+		if unit, ok = phraseUnits[p.word(k)]; !ok {
+			break
+		} else if half && unit != unitHour {
+			break
+		}
+
+		switch {
+		case half:
+			p.relMinutes += 30
+		case unit == unitMinute:
+			p.relMinutes += cnt
+		case unit == unitHour:
+			p.relMinutes += cnt * 60
+		case unit == unitDay:
+			p.relDays += cnt
+		case unit == unitWeek:
+			p.relDays += cnt * 7
+		case unit == unitMonth:
+			p.relMonths += cnt
+		case unit == unitYear:
+			p.relMonths += cnt * 12
+		}
+
+		found = true
+		j = k + 1
+	}
+
+	if !found {
+		return 0
+	}
+
+	p.relative = true
+	return j - i
+} // func (p *phraseParser) matchRelative(i int) int
+
+// matchEvery matches "every day", "every 2 hours", "every other week",
+// "every weekday", "every Monday and Thursday", "every first Friday",
+// "jeden Tag", "alle 3 Tage", "jeden Werktag", ...
+func (p *phraseParser) matchEvery(i int) int {
+	if !phraseEvery[p.word(i)] {
+		return 0
+	}
+
+	var (
+		j   = i + 1
+		cnt = 1
+	)
+
+	if p.word(j) == "other" {
+		cnt = 2
+		j++
+	} else if n, ok := p.number(j); ok && phraseNumbers[p.word(j)] != 1 {
+		cnt = n
+		j++
+	}
+
+	var w = p.bare(j)
+
+	if unit, ok := phraseUnits[w]; ok {
+		p.unit = unit
+		j++
+	} else if w == "weekday" || w == "weekdays" || w == "workday" || w == "werktag" || w == "arbeitstag" {
+		p.unit = unitWeek
+		p.days = workdays
+		j++
+	} else if w == "weekend" || w == "wochenende" {
+		p.unit = unitWeek
+		p.days = weekend
+		j++
+	} else if n := p.matchWeekdayList(j, true); n > 0 {
+		p.unit = unitWeek
+		j += n
+	} else if n = p.matchWeekOrdinal(j); n > 0 && cnt == 1 {
+		// "every first Monday", "jeden letzten Freitag"
+		p.unit = unitMonth
+		j += n
+	} else if m := phraseOrdinalRe.FindStringSubmatch(p.word(j)); m != nil && cnt == 1 {
+		// "jeden 3. des Monats", what unit this refers to remains to be
+		// seen.
+		p.mday, _ = strconv.Atoi(m[1])
+		j++
+	} else {
+		return 0
+	}
+
+	p.recurring = true
+	p.count = cnt
+	return j - i
+} // func (p *phraseParser) matchEvery(i int) int
+
+// matchAdverb matches "daily", "weekly", "hourly", "weekdays", "mondays",
+// "täglich", "werktags", "montags und donnerstags", ...
+func (p *phraseParser) matchAdverb(i int) int {
+	var (
+		w    = p.bare(i)
+		unit = unitNone
+		cnt  = 1
+	)
+
+	switch w {
+	case "hourly", "stuendlich":
+		unit = unitHour
+	case "daily", "taeglich":
+		unit = unitDay
+	case "weekly", "woechentlich":
+		unit = unitWeek
+	case "biweekly", "fortnightly", "zweiwoechentlich":
+		unit = unitWeek
+		cnt = 2
+	case "monthly", "monatlich":
+		unit = unitMonth
+	case "yearly", "annually", "jaehrlich":
+		unit = unitYear
+	case "weekdays", "workdays", "werktags", "wochentags":
+		p.days = workdays
+		unit = unitWeek
+	case "weekends", "wochenends":
+		p.days = weekend
+		unit = unitWeek
+	}
+
+	if unit != unitNone {
+		p.recurring = true
+		p.unit = unit
+		p.count = cnt
+		return 1
+	}
+
+	// Plural forms of the weekdays, possibly a list of them
+	var (
+		days Weekdays
+		j    = i
+	)
+
+	for {
+		var (
+			k   = j
+			idx int
+			ok  bool
+		)
+
+		if j > i {
+			var n = p.joined(k)
+
+			if n < 0 {
+				break
+			}
+
+			k += n
+		}
+
+		if idx, ok = weekdayPlural(p.bare(k)); !ok {
+			break
+		}
+
+		days[idx] = true
+		j = k + 1
+	}
+
+	if j == i {
+		return 0
+	}
+
+	for idx, on := range days {
+		if on {
+			p.days[idx] = true
+		}
+	}
+
+	p.recurring = true
+	p.unit = unitWeek
+	p.count = 1
+	return j - i
+} // func (p *phraseParser) matchAdverb(i int) int
+
+// weekdayPlural returns the index of the weekday if w is the plural form of
+// its name, e.g. "mondays" or "montags".
+func weekdayPlural(w string) (int, bool) {
+	if !strings.HasSuffix(w, "s") {
+		return 0, false
+	}
+
+	var idx, ok = phraseWeekdays[strings.TrimSuffix(w, "s")]
+
+	return idx, ok
+} // func weekdayPlural(w string) (int, bool)
+
+// matchUntil matches "until 2026-12-31", "until December 24th" and "bis
+// 31.12.".
+func (p *phraseParser) matchUntil(i int) int {
+	if w := p.word(i); w != "until" && w != "bis" {
+		return 0
+	}
+
+	var j = i + 1
+
+	if w := p.word(j); w == "the" || w == "zum" {
+		j++
+	}
+
+	var year, month, day, n = p.date(j)
+
+	if n == 0 {
+		return 0
+	} else if year == 0 {
+		var today = civil(p.now.In(p.loc))
+
+		year = today.Year()
+		if time.Date(year, time.Month(month), day, 12, 0, 0, 0, time.UTC).Before(today) {
+			year++
+		}
+	}
+
+	p.until = wallClock(year, time.Month(month), day, 86399, p.loc)
+	p.hasUntil = true
+	return j - i + n
+} // func (p *phraseParser) matchUntil(i int) int
+
+// matchDayName matches "today", "tomorrow", "the day after tomorrow",
+// "heute", "morgen" and "übermorgen".
+func (p *phraseParser) matchDayName(i int) int {
+	var n = 1
+
+	switch p.bare(i) {
+	case "today", "heute":
+		p.shift = 0
+	case "tomorrow", "morgen":
+		p.shift = 1
+	case "uebermorgen":
+		p.shift = 2
+	case "day":
+		if p.word(i+1) != "after" || p.bare(i+2) != "tomorrow" {
+			return 0
+		}
+		p.shift = 2
+		n = 3
+	case "the":
+		if p.word(i+1) != "day" || p.word(i+2) != "after" || p.bare(i+3) != "tomorrow" {
+			return 0
+		}
+		p.shift = 2
+		n = 4
+	default:
+		return 0
+	}
+
+	p.shifted = true
+	return n
+} // func (p *phraseParser) matchDayName(i int) int
+
+// date parses a calendar date at index i: "2026-10-20", "20.10.",
+// "20.10.2026", "24th of December", "24. Dezember 2026", "Dec 24".
+// The year is zero if it was not given. It returns the number of words
+// it consumed, or 0 if there is no date at index i.
+func (p *phraseParser) date(i int) (year, month, day, n int) {
+	var (
+		w = p.word(i)
+		m []string
+	)
+
+	if m = phraseISODateRe.FindStringSubmatch(w); m != nil {
+		year, _ = strconv.Atoi(m[1])
+		month, _ = strconv.Atoi(m[2])
+		day, _ = strconv.Atoi(m[3])
+		n = 1
+	} else if m = phraseDotDateRe.FindStringSubmatch(w); m != nil {
+		day, _ = strconv.Atoi(m[1])
+		month, _ = strconv.Atoi(m[2])
+		if m[3] != "" {
+			year, _ = strconv.Atoi(m[3])
+			if year < 100 {
+				year += 2000
+			}
+		}
+		n = 1
+	} else if mon, ok := phraseMonths[p.bare(i)]; ok {
+		// "December 24th", "Dec 24"
+		var d = strings.TrimSuffix(p.word(i+1), ".")
+
+		if m = phraseOrdinalRe.FindStringSubmatch(p.word(i + 1)); m != nil {
+			d = m[1]
+		}
+
+		if day, _ = strconv.Atoi(d); day == 0 {
+			return 0, 0, 0, 0
+		}
+
+		month = int(mon)
+		n = 2
+	} else {
+		// "24th of December", "24. Dezember", "24 Dec"
+		var (
+			d = w
+			j = i + 1
+		)
+
+		if m = phraseOrdinalRe.FindStringSubmatch(w); m != nil {
+			d = m[1]
+		}
+
+		if day, _ = strconv.Atoi(d); day == 0 {
+			return 0, 0, 0, 0
+		} else if p.word(j) == "of" {
+			j++
+		}
+
+		if mon, ok := phraseMonths[p.bare(j)]; ok {
+			month = int(mon)
+			n = j - i + 1
+		} else {
+			return 0, 0, 0, 0
+		}
+	}
+
+	if n > 1 {
+		if m = phraseYearRe.FindStringSubmatch(p.bare(i + n)); m != nil {
+			year, _ = strconv.Atoi(m[1])
+			n++
+		}
+	}
+
+	// Reject dates like February 30th
+	var check = time.Date(2000, time.Month(month), day, 12, 0, 0, 0, time.UTC)
+
+	if year != 0 {
+		check = time.Date(year, time.Month(month), day, 12, 0, 0, 0, time.UTC)
+	}
+
+	if month < 1 || month > 12 || check.Day() != day {
+		return 0, 0, 0, 0
+	}
+
+	return year, month, day, n
+} // func (p *phraseParser) date(i int) (year, month, day, n int)
+
+// matchDate matches a calendar date, optionally preceded by "on" or "am".
+func (p *phraseParser) matchDate(i int) int {
+	var j = i
+
+	if w := p.word(j); w == "on" || w == "am" || w == "the" || w == "den" {
+		j++
+		if w == "on" && p.word(j) == "the" {
+			j++
+		}
+	}
+
+	var year, month, day, n = p.date(j)
+
+	if n == 0 || p.dated {
+		return 0
+	}
+
+	p.dated = true
+	p.year = year
+	p.month = time.Month(month)
+	p.day = day
+
+	return j - i + n
+} // func (p *phraseParser) matchDate(i int) int
+
+// matchOrdinal matches "on the 3rd", "am 3.", "the first Monday", "am
+// letzten Freitag", possibly followed by "of the month" or "of every month".
+func (p *phraseParser) matchOrdinal(i int) int {
+	var j = i
+
+	if w := p.word(j); w == "on" || w == "am" {
+		j++
+	}
+
+	if w := p.word(j); w == "the" || w == "den" {
+		j++
+	}
+
+	if n := p.matchWeekOrdinal(j); n > 0 {
+		j += n
+	} else if m := phraseOrdinalRe.FindStringSubmatch(p.word(j)); m != nil {
+		var mday, _ = strconv.Atoi(m[1])
+
+		if mday < 1 || mday > 31 {
+			return 0
+		}
+
+		p.mday = mday
+		j++
+	} else {
+		return 0
+	}
+
+	j += p.matchMonthRef(j)
+
+	return j - i
+} // func (p *phraseParser) matchOrdinal(i int) int
+
+// matchWeekOrdinal matches "first Monday", "last Friday", "zweiten Dienstag".
+func (p *phraseParser) matchWeekOrdinal(i int) int {
+	var week, ok = phraseWeekOrdinals[p.word(i)]
+
+	if !ok {
+		return 0
+	}
+
+	var n = p.matchWeekdayList(i+1, true)
+
+	if n == 0 {
+		return 0
+	}
+
+	p.week = week
+	return n + 1
+} // func (p *phraseParser) matchWeekOrdinal(i int) int
+
+// matchMonthRef matches "of the month", "of every month", "im Monat",
+// "des Monats", "eines jeden Monats".
+func (p *phraseParser) matchMonthRef(i int) int {
+	var (
+		j     = i
+		every bool
+	)
+
+	switch p.word(j) {
+	case "of", "im", "in", "des":
+		j++
+	case "eines":
+		every = true
+		j++
+	default:
+		return 0
+	}
+
+	if w := p.word(j); w == "the" {
+		j++
+	} else if phraseEvery[w] {
+		every = true
+		j++
+	}
+
+	if unit := phraseUnits[p.bare(j)]; unit != unitMonth {
+		return 0
+	}
+
+	p.monthRef = true
+
+	if every {
+		p.recurring = true
+		if p.unit == unitNone {
+			p.unit = unitMonth
+			p.count = 1
+		}
+	}
+
+	return j - i + 1
+} // func (p *phraseParser) matchMonthRef(i int) int
+
+// matchWeekdayList matches one or more weekdays, e.g. "Monday", "Mon, Wed and
+// Fri", "Montag und Donnerstag", and adds them to the parser's Weekdays.
+// Abbreviations are only accepted if abbr is true or after a full name.
+func (p *phraseParser) matchWeekdayList(i int, abbr bool) int {
+	var (
+		days Weekdays
+		j    = i
+	)
+
+	for {
+		var (
+			k   = j
+			idx int
+			ok  bool
+		)
+
+		if j > i {
+			var n = p.joined(k)
+
+			if n < 0 {
+				break
+			}
+
+			k += n
+		}
+
+		if idx, ok = phraseWeekdays[p.bare(k)]; ok {
+			abbr = true
+		} else if !abbr {
+			break
+		} else if idx, ok = phraseWeekdayAbbr[p.bare(k)]; !ok {
+			break
+		}
+
+		days[idx] = true
+		j = k + 1
+	}
+
+	for idx, on := range days {
+		if on {
+			p.days[idx] = true
+		}
+	}
+
+	return j - i
+} // func (p *phraseParser) matchWeekdayList(i int, abbr bool) int
+
+// matchWeekday matches "Friday", "on Friday", "next Friday", "am Freitag",
+// "nächsten Freitag", "on Mon and Thu".
+func (p *phraseParser) matchWeekday(i int) int {
+	var (
+		j      = i
+		prefix bool
+		next   bool
+	)
+
+	switch p.word(j) {
+	case "on", "am", "this", "diesen":
+		prefix = true
+		j++
+	case "next", "naechsten", "naechster", "naechste", "kommenden":
+		prefix = true
+		next = true
+		j++
+	}
+
+	var n = p.matchWeekdayList(j, prefix)
+
+	if n == 0 {
+		return 0
+	} else if next {
+		p.next = true
+	}
+
+	return j - i + n
+} // func (p *phraseParser) matchWeekday(i int) int
+
+// timeOfDay parses a time of day at index i: "14:30", "9:00", "noon",
+// "8 am", "14 Uhr", "14.30 Uhr". A plain number is only accepted as an hour
+// if explicit is true, i.e. if it is preceded by "at" or "um", or if it is
+// followed by "am", "pm", "Uhr" or "o'clock". German times ("um 8") never
+// take "am" or "pm", because "am" means "on" in German.
+func (p *phraseParser) timeOfDay(i int, explicit, german bool) (int, int) {
+	var (
+		w            = p.word(i)
+		hour, minute int
+		n            = 1
+		m            []string
+	)
+
+	switch p.bare(i) {
+	case "noon", "mittag", "mittags":
+		return 12 * 3600, 1
+	case "midnight", "mitternacht":
+		return 0, 1
+	}
+
+	var suffix = p.word(i + 1)
+
+	if m = phraseClockRe.FindStringSubmatch(w); m == nil {
+		if m = phraseDotTimeRe.FindStringSubmatch(p.bare(i)); m != nil {
+			if !explicit && suffix != "uhr" {
+				return 0, 0
+			}
+		} else if m = phraseHourRe.FindStringSubmatch(p.bare(i)); m != nil {
+			switch suffix {
+			case "am", "pm", "uhr", "o'clock", "oclock", "h":
+			default:
+				if !explicit {
+					return 0, 0
+				}
+			}
+			m = append(m, "0")
+		} else {
+			return 0, 0
+		}
+	}
+
+	hour, _ = strconv.Atoi(m[1])
+	minute, _ = strconv.Atoi(m[2])
+
+	switch suffix {
+	case "am", "pm":
+		if german || hour < 1 || hour > 12 {
+			break
+		} else if suffix == "pm" && hour < 12 {
+			hour += 12
+		} else if suffix == "am" && hour == 12 {
+			hour = 0
+		}
+		n++
+	case "uhr", "o'clock", "oclock", "h":
+		n++
+	}
+
+	if hour > 23 || minute > 59 {
+		return 0, 0
+	}
+
+	return hour*3600 + minute*60, n
+} // func (p *phraseParser) timeOfDay(i int, explicit, german bool) (int, int)
+
+// matchTime matches one or more times of day, "at 8", "um 14:30 Uhr", "at
+// 8:00, 12:00 and 18:00".
+func (p *phraseParser) matchTime(i int) int {
+	var (
+		j        = i
+		explicit bool
+		german   bool
+	)
+
+	switch p.word(j) {
+	case "at", "@":
+		explicit = true
+		j++
+	case "um":
+		explicit = true
+		german = true
+		j++
+	}
+
+	var off, n = p.timeOfDay(j, explicit, german)
+
+	if n == 0 {
+		return 0
+	}
+
+	p.times = append(p.times, off)
+	j += n
+
+	for {
+		var k = j
+
+		if n = p.joined(k); n < 0 {
+			break
+		}
+
+		k += n
+
+		if w := p.word(k); w == "at" || w == "um" || w == "@" {
+			k++
+		}
+
+		if off, n = p.timeOfDay(k, true, german); n == 0 {
+			break
+		}
+
+		p.times = append(p.times, off)
+		j = k + n
+	}
+
+	return j - i
+} // func (p *phraseParser) matchTime(i int) int
+
+// matchDayTime matches vague times of day, like "in the morning", "this
+// evening", "tonight", "abends", "am Nachmittag".
+func (p *phraseParser) matchDayTime(i int) int {
+	var j = i
+
+	switch p.word(j) {
+	case "in":
+		if p.word(j+1) != "the" {
+			return 0
+		}
+		j += 2
+	case "this", "am", "at":
+		j++
+	}
+
+	var off, ok = phraseDayTimes[p.bare(j)]
+
+	if !ok {
+		return 0
+	} else if p.bare(j) == "tonight" && !p.shifted {
+		p.shifted = true
+		p.shift = 0
+	}
+
+	p.daytime = off
+	return j - i + 1
+} // func (p *phraseParser) matchDayTime(i int) int
+
+// found returns true if the parser found anything that describes a time.
+func (p *phraseParser) found() bool {
+	return p.relative ||
+		p.shifted ||
+		p.dated ||
+		p.mday > 0 ||
+		p.week != 0 ||
+		p.days.Count() > 0 ||
+		len(p.times) > 0 ||
+		p.daytime > 0 ||
+		p.recurring
+} // func (p *phraseParser) found() bool
+
+// hasTime returns true if the phrase mentions a time of day.
+func (p *phraseParser) hasTime() bool {
+	return len(p.times) > 0 || p.daytime > 0
+} // func (p *phraseParser) hasTime() bool
+
+// tod returns the (first) time of day the phrase mentions.
+func (p *phraseParser) tod() int {
+	if len(p.times) > 0 {
+		return p.times[0]
+	} else if p.daytime > 0 {
+		return p.daytime
+	}
+
+	return defaultPhraseTime
+} // func (p *phraseParser) tod() int
+
+// civil returns the day of t as a Time at noon UTC, which is what
+// Recurrence.OnDay expects.
+func civil(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 12, 0, 0, 0, time.UTC)
+} // func civil(t time.Time) time.Time
+
+// first returns the first day, starting at start, that rec goes off on
+// at the given time of day, and that has not passed, yet.
+func (p *phraseParser) first(rec *Recurrence, start time.Time, tod int) (time.Time, bool) {
+	for i := 0; i < maxPhraseScan; i++ {
+		var day = start.AddDate(0, 0, i)
+
+		if !rec.OnDay(day) {
+			continue
+		} else if t := wallClock(day.Year(), day.Month(), day.Day(), tod, p.loc); t.After(p.now) {
+			return day, true
+		}
+	}
+
+	return time.Time{}, false
+} // func (p *phraseParser) first(rec *Recurrence, start time.Time, tod int) (time.Time, bool)
+
+// start returns the day the phrase refers to, or today if it does not
+// refer to a particular day.
+func (p *phraseParser) start() time.Time {
+	var today = civil(p.now.In(p.loc))
+
+	if p.dated {
+		var year = p.year
+
+		if year == 0 {
+			// If the date has passed this year, we mean next year.
+			// February 29th may be a few years away, though.
+			for year = today.Year(); year < today.Year()+8; year++ {
+				var d = time.Date(year, p.month, p.day, 12, 0, 0, 0, time.UTC)
+
+				if d.Day() == p.day && !d.Before(today) {
+					break
+				}
+			}
+		}
+
+		return time.Date(year, p.month, p.day, 12, 0, 0, 0, time.UTC)
+	} else if p.shifted {
+		return today.AddDate(0, 0, p.shift)
+	} else if p.next {
+		return today.AddDate(0, 0, 1)
+	}
+
+	return today
+} // func (p *phraseParser) start() time.Time
+
+func (p *phraseParser) reminder() (*Reminder, error) {
+	var (
+		err error
+		r   = &Reminder{Title: strings.Join(p.title, " ")}
+	)
+
+	if p.loc != time.Local {
+		r.TimeZone = p.loc.String()
+	}
+
+	if !p.found() {
+		return nil, errors.New("Could not find a date or time in the phrase")
+	} else if r.Title == "" {
+		return nil, errors.New("The phrase does not contain a title")
+	} else if p.recurring {
+		err = p.recurrence(r)
+	} else {
+		err = p.once(r)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return r, nil
+} // func (p *phraseParser) reminder() (*Reminder, error)
+
+// once sets the Timestamp of a Reminder that goes off only once.
+func (p *phraseParser) once(r *Reminder) error {
+	var (
+		now = p.now.In(p.loc)
+		tod = p.tod()
+		day time.Time
+		ok  bool
+		rec Recurrence
+	)
+
+	r.Recur.Repeat = repeat.Once
+
+	if len(p.times) > 1 {
+		return errors.New("Several times of day only work for recurring reminders")
+	} else if p.hasUntil {
+		return errors.New("Only recurring reminders can have an end date")
+	} else if p.relative {
+		var t = now.Add(time.Duration(p.relMinutes)*time.Minute).AddDate(0, p.relMonths, p.relDays)
+
+		if p.hasTime() && (p.relDays > 0 || p.relMonths > 0) {
+			t = wallClock(t.Year(), t.Month(), t.Day(), tod, p.loc)
+		}
+
+		r.Timestamp = t
+		return nil
+	}
+
+	switch {
+	case p.dated || p.shifted:
+		day = p.start()
+		ok = true
+	case p.week != 0:
+		rec = Recurrence{Repeat: repeat.MonthlyWeekday, Week: p.week, Days: p.days, Fallback: FallbackSkip}
+		day, ok = p.first(&rec, p.start(), tod)
+	case p.mday > 0:
+		rec = Recurrence{Repeat: repeat.Monthly, Day: p.mday, Fallback: FallbackSkip}
+		day, ok = p.first(&rec, p.start(), tod)
+	case p.days.Count() > 0:
+		rec = Recurrence{Repeat: repeat.Custom, Days: p.days}
+		day, ok = p.first(&rec, p.start(), tod)
+	default:
+		rec = Recurrence{Repeat: repeat.Daily}
+		day, ok = p.first(&rec, p.start(), tod)
+	}
+
+	if !ok {
+		return errors.New("Could not find a day matching the phrase")
+	}
+
+	r.Timestamp = wallClock(day.Year(), day.Month(), day.Day(), tod, p.loc)
+	return nil
+} // func (p *phraseParser) once(r *Reminder) error
+
+// recurrence sets the Recurrence of a recurring Reminder.
+func (p *phraseParser) recurrence(r *Reminder) error {
+	var (
+		err   error
+		ok    bool
+		day   time.Time
+		now   = p.now.In(p.loc)
+		tod   = p.tod()
+		unit  = p.unit
+		cnt   = p.count
+		start = p.start()
+		rec   = Recurrence{Offset: tod, Until: p.until}
+	)
+
+	if cnt < 1 {
+		cnt = 1
+	}
+
+	if unit == unitNone {
+		switch {
+		case p.week != 0 || p.mday > 0 || p.monthRef:
+			unit = unitMonth
+		case p.days.Count() > 0:
+			unit = unitWeek
+		case p.dated:
+			unit = unitYear
+		default:
+			return errors.New("Could not tell how often the reminder should repeat")
+		}
+	}
+
+	if len(p.times) > 1 {
+		for _, t := range p.times[1:] {
+			if t != tod {
+				rec.Times = append(rec.Times, t)
+			}
+		}
+	}
+
+	switch unit {
+	case unitMinute, unitHour:
+		var (
+			period = cnt * 60
+			anchor time.Time
+		)
+
+		if unit == unitHour {
+			period *= 60
+		}
+
+		if p.hasTime() || p.dated || p.shifted {
+			var daily = Recurrence{Repeat: repeat.Daily}
+			if day, ok = p.first(&daily, start, tod); !ok {
+				return errors.New("Could not find a day matching the phrase")
+			}
+			anchor = wallClock(day.Year(), day.Month(), day.Day(), tod, p.loc)
+		} else {
+			anchor = now.Truncate(time.Minute).Add(time.Duration(period) * time.Second)
+		}
+
+		r.Timestamp = anchor
+		r.Recur = Recurrence{
+			Repeat: repeat.Interval,
+			Offset: int(anchor.Unix()),
+			Period: period,
+			Until:  p.until,
+		}
+		return nil
+	case unitDay:
+		rec.Repeat = repeat.Daily
+	case unitWeek:
+		if rec.Days = p.days; rec.Days.Count() == 0 {
+			rec.Days[(start.Weekday()+6)%7] = true
+		}
+		rec.Repeat = repeat.Custom
+	case unitMonth:
+		if p.week != 0 {
+			if p.days.Count() == 0 {
+				return errors.New("Missing the weekday for a monthly reminder")
+			}
+			rec.Repeat = repeat.MonthlyWeekday
+			rec.Week = p.week
+			rec.Days = p.days
+		} else {
+			rec.Repeat = repeat.Monthly
+			switch {
+			case p.mday > 0:
+				rec.Day = p.mday
+			case p.dated:
+				rec.Day = p.day
+			default:
+				rec.Day = start.Day()
+			}
+		}
+	case unitYear:
+		rec.Repeat = repeat.Yearly
+		rec.Month = start.Month()
+		rec.Day = start.Day()
+		if p.mday > 0 && !p.dated {
+			rec.Day = p.mday
+		}
+	}
+
+	r.Timestamp = time.Unix(int64(tod), 0).In(time.UTC)
+	r.Recur = rec
+
+	if cnt == 1 {
+		return nil
+	}
+
+	// Things like "every 3 days" or "every other Monday" cannot be
+	// expressed with the simple repeat modes, so we turn them into a
+	// recurrence rule, starting on the first day that matches.
+	if day, ok = p.first(&rec, start, tod); !ok {
+		return errors.New("Could not find a day matching the phrase")
+	}
+
+	var rr = RRule{
+		Interval:  cnt,
+		WeekStart: time.Monday,
+	}
+
+	switch rec.Repeat {
+	case repeat.Daily:
+		rr.Freq = FreqDaily
+	case repeat.Custom:
+		rr.Freq = FreqWeekly
+		for idx, on := range rec.Days {
+			if on {
+				rr.ByDay = append(rr.ByDay, WeekdayNum{Day: time.Weekday((idx + 1) % 7)})
+			}
+		}
+	case repeat.MonthlyWeekday:
+		rr.Freq = FreqMonthly
+		for idx, on := range rec.Days {
+			if on {
+				rr.ByDay = append(rr.ByDay, WeekdayNum{N: rec.Week, Day: time.Weekday((idx + 1) % 7)})
+			}
+		}
+	case repeat.Monthly:
+		rr.Freq = FreqMonthly
+		rr.ByMonthDay = []int{rec.Day}
+	case repeat.Yearly:
+		rr.Freq = FreqYearly
+		rr.ByMonth = []time.Month{rec.Month}
+		rr.ByMonthDay = []int{rec.Day}
+	}
+
+	r.Timestamp = wallClock(day.Year(), day.Month(), day.Day(), tod, p.loc)
+
+	if err = r.SetRRule(rr.String()); err != nil {
+		return fmt.Errorf("Cannot create recurrence rule %q: %s",
+			rr.String(),
+			err.Error())
+	}
+
+	return nil
+} // func (p *phraseParser) recurrence(r *Reminder) error
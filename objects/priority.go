@@ -0,0 +1,116 @@
+// /home/krylon/go/src/github.com/blicero/theseus/objects/priority.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 17. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <>
+
+package objects
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Priority indicates how important a Reminder is. The zero value is
+// PriorityNormal, so Reminders that were created before we had priorities,
+// or by peers that do not know about them, are treated as normal.
+// Priorities are ordered, a higher value means more important.
+type Priority int8
+
+// The priorities a Reminder can have.
+const (
+	PriorityLow      Priority = -1
+	PriorityNormal   Priority = 0
+	PriorityHigh     Priority = 1
+	PriorityCritical Priority = 2
+)
+
+// Priorities lists all priorities, from the lowest to the highest.
+var Priorities = []Priority{
+	PriorityLow,
+	PriorityNormal,
+	PriorityHigh,
+	PriorityCritical,
+}
+
+func (p Priority) String() string {
+	switch p {
+	case PriorityLow:
+		return "Low"
+	case PriorityNormal:
+		return "Normal"
+	case PriorityHigh:
+		return "High"
+	case PriorityCritical:
+		return "Critical"
+	default:
+		return fmt.Sprintf("Priority(%d)", p)
+	}
+} // func (p Priority) String() string
+
+// ParsePriority returns the Priority with the given name, regardless of
+// case.
+func ParsePriority(s string) (Priority, error) {
+	for _, p := range Priorities {
+		if strings.EqualFold(s, p.String()) {
+			return p, nil
+		}
+	}
+
+	return PriorityNormal, fmt.Errorf("Invalid priority %q", s)
+} // func ParsePriority(s string) (Priority, error)
+
+// Valid returns true if p is one of the defined priorities.
+func (p Priority) Valid() bool {
+	return p >= PriorityLow && p <= PriorityCritical
+} // func (p Priority) Valid() bool
+
+// Urgency returns the urgency of desktop notifications for Reminders with
+// the given Priority.
+func (p Priority) Urgency() Urgency {
+	switch {
+	case p <= PriorityLow:
+		return UrgencyLow
+	case p >= PriorityCritical:
+		return UrgencyCritical
+	default:
+		return UrgencyNormal
+	}
+} // func (p Priority) Urgency() Urgency
+
+// Icon returns the name of the icon to display along with notifications
+// for Reminders with the given Priority, from the freedesktop.org icon
+// naming specification.
+func (p Priority) Icon() string {
+	switch {
+	case p <= PriorityLow:
+		return "appointment-soon"
+	case p == PriorityNormal:
+		return "appointment-new"
+	case p == PriorityHigh:
+		return "dialog-warning"
+	default:
+		return "dialog-error"
+	}
+} // func (p Priority) Icon() string
+
+// Timeout returns the number of milliseconds after which a notification
+// for a Reminder with the given Priority expires. -1 leaves it to the
+// notification server, 0 means the notification never expires.
+func (p Priority) Timeout() int32 {
+	switch {
+	case p <= PriorityLow:
+		return 10000
+	case p == PriorityNormal:
+		return -1
+	default:
+		return 0
+	}
+} // func (p Priority) Timeout() int32
+
+// Resident returns true if notifications for Reminders with the given
+// Priority should stay around in the notification server after the user
+// has clicked an action, until they are explicitly dismissed.
+func (p Priority) Resident() bool {
+	return p >= PriorityHigh
+} // func (p Priority) Resident() bool
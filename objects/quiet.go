@@ -0,0 +1,224 @@
+// /home/krylon/go/src/github.com/blicero/theseus/objects/quiet.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 17. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <>
+
+package objects
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// During quiet hours, we hold back Notifications for all but critical
+// Reminders, and tell the user about them in one go once the quiet hours
+// are over. Quiet hours can follow a weekly schedule, or be switched on
+// and off by hand ("do not disturb").
+
+// QuietWindow is a period of time on certain weekdays. Start and End are
+// the number of seconds after midnight. If End is not after Start, the
+// window extends past midnight into the following day, so a window from
+// 22:00 to 07:00 on Fridays ends on Saturday morning.
+type QuietWindow struct {
+	Days  Weekdays
+	Start int
+	End   int
+}
+
+// QuietHours is a weekly schedule of quiet hours.
+type QuietHours []QuietWindow
+
+// QuietStatus describes the state of the quiet hours, for clients.
+type QuietStatus struct {
+	Active   bool
+	Manual   bool
+	Schedule string
+	Deferred int
+}
+
+var quietTimeRe = regexp.MustCompile(`^(\d{1,2}):(\d{2})-(\d{1,2}):(\d{2})$`)
+
+// quietDayNames are the names we use when formatting a QuietWindow.
+var quietDayNames = []string{"mon", "tue", "wed", "thu", "fri", "sat", "sun"}
+
+// parseQuietDays parses a list of weekdays like "mon,wed,fri" or ranges
+// like "mon-fri". "daily", "weekdays" and "weekend" are understood, too.
+func parseQuietDays(s string) (Weekdays, error) {
+	var days Weekdays
+
+	switch s {
+	case "daily", "*":
+		return Weekdays{true, true, true, true, true, true, true}, nil
+	case "weekdays", "workdays":
+		return workdays, nil
+	case "weekend":
+		return weekend, nil
+	}
+
+	for _, item := range strings.Split(s, ",") {
+		var (
+			ok       bool
+			from, to int
+			bounds   = strings.SplitN(item, "-", 2)
+		)
+
+		if from, ok = quietDay(bounds[0]); !ok {
+			return days, fmt.Errorf("Invalid weekday %q", bounds[0])
+		} else if to = from; len(bounds) == 2 {
+			if to, ok = quietDay(bounds[1]); !ok {
+				return days, fmt.Errorf("Invalid weekday %q", bounds[1])
+			}
+		}
+
+		// Ranges may wrap around the end of the week, e.g. "fri-mon".
+		for i := from; ; i = (i + 1) % 7 {
+			days[i] = true
+			if i == to {
+				break
+			}
+		}
+	}
+
+	return days, nil
+} // func parseQuietDays(s string) (Weekdays, error)
+
+func quietDay(s string) (int, bool) {
+	var (
+		idx int
+		ok  bool
+	)
+
+	if idx, ok = phraseWeekdayAbbr[s]; !ok {
+		idx, ok = phraseWeekdays[s]
+	}
+
+	return idx, ok
+} // func quietDay(s string) (int, bool)
+
+// ParseQuietHours parses a schedule of quiet hours, a list of windows
+// separated by semicolons, like "mon-fri 22:00-07:00; sat,sun 23:00-09:00".
+// An empty string is an empty schedule.
+func ParseQuietHours(s string) (QuietHours, error) {
+	var q QuietHours
+
+	for _, str := range strings.Split(s, ";") {
+		var (
+			err    error
+			w      QuietWindow
+			m      []string
+			fields = strings.Fields(strings.ToLower(str))
+		)
+
+		if len(fields) == 0 {
+			continue
+		} else if len(fields) != 2 {
+			return nil, fmt.Errorf("Invalid quiet hours %q, expected something like \"mon-fri 22:00-07:00\"",
+				strings.TrimSpace(str))
+		} else if w.Days, err = parseQuietDays(fields[0]); err != nil {
+			return nil, err
+		} else if m = quietTimeRe.FindStringSubmatch(fields[1]); m == nil {
+			return nil, fmt.Errorf("Invalid time span %q, expected something like 22:00-07:00",
+				fields[1])
+		}
+
+		var (
+			h1, _ = strconv.Atoi(m[1])
+			m1, _ = strconv.Atoi(m[2])
+			h2, _ = strconv.Atoi(m[3])
+			m2, _ = strconv.Atoi(m[4])
+		)
+
+		if h1 > 23 || h2 > 23 || m1 > 59 || m2 > 59 {
+			return nil, fmt.Errorf("Invalid time span %q", fields[1])
+		} else if w.Start, w.End = h1*3600+m1*60, h2*3600+m2*60; w.Start == w.End {
+			return nil, fmt.Errorf("Time span %q is empty", fields[1])
+		}
+
+		q = append(q, w)
+	}
+
+	return q, nil
+} // func ParseQuietHours(s string) (QuietHours, error)
+
+func (w *QuietWindow) String() string {
+	var (
+		days  []string
+		start = -1
+	)
+
+	if w.Days.Count() == 7 {
+		days = []string{"daily"}
+	} else {
+		// Runs of three or more days are written as a range.
+		for i := 0; i <= 7; i++ {
+			if i < 7 && w.Days[i] {
+				if start < 0 {
+					start = i
+				}
+				continue
+			} else if start < 0 {
+				continue
+			}
+
+			switch i - start {
+			case 1:
+				days = append(days, quietDayNames[start])
+			case 2:
+				days = append(days, quietDayNames[start], quietDayNames[i-1])
+			default:
+				days = append(days, quietDayNames[start]+"-"+quietDayNames[i-1])
+			}
+			start = -1
+		}
+	}
+
+	return fmt.Sprintf("%s %02d:%02d-%02d:%02d",
+		strings.Join(days, ","),
+		w.Start/3600,
+		w.Start%3600/60,
+		w.End/3600,
+		w.End%3600/60)
+} // func (w *QuietWindow) String() string
+
+// String returns the schedule in the form ParseQuietHours understands.
+func (q QuietHours) String() string {
+	var items = make([]string, len(q))
+
+	for i := range q {
+		items[i] = q[i].String()
+	}
+
+	return strings.Join(items, "; ")
+} // func (q QuietHours) String() string
+
+// Active returns true if the given point in time falls within the
+// window. The time of day is taken in the location of t.
+func (w *QuietWindow) Active(t time.Time) bool {
+	var (
+		secs = t.Hour()*3600 + t.Minute()*60 + t.Second()
+		wday = t.Weekday()
+	)
+
+	if w.Start < w.End {
+		return w.Days.On(wday) && secs >= w.Start && secs < w.End
+	}
+
+	return (w.Days.On(wday) && secs >= w.Start) ||
+		(w.Days.On((wday+6)%7) && secs < w.End)
+} // func (w *QuietWindow) Active(t time.Time) bool
+
+// Active returns true if the given point in time falls within the quiet
+// hours.
+func (q QuietHours) Active(t time.Time) bool {
+	for i := range q {
+		if q[i].Active(t) {
+			return true
+		}
+	}
+
+	return false
+} // func (q QuietHours) Active(t time.Time) bool
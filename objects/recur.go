@@ -10,6 +10,8 @@ package objects
 
 import (
 	"fmt"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -66,17 +68,77 @@ func (w *Weekdays) On(d time.Weekday) bool {
 	return w[(d+6)%7]
 } // func (w *Weekdays) On(d time.Weekday) bool
 
+// Fallback determines what happens to monthly and yearly Recurrences in
+// months where the requested day does not exist, e.g. the 31st in April,
+// the fifth Friday in a month that only has four of them, or February 29th
+// outside of leap years.
+type Fallback uint8
+
+// FallbackClamp moves the Reminder to the last matching day of the month,
+// FallbackSkip skips the month (or year) entirely. So a birthday on February
+// 29th goes off on February 28th in regular years with FallbackClamp, and
+// only in leap years with FallbackSkip.
+const (
+	FallbackClamp Fallback = iota
+	FallbackSkip
+)
+
+func (f Fallback) String() string {
+	switch f {
+	case FallbackClamp:
+		return "Clamp"
+	case FallbackSkip:
+		return "Skip"
+	default:
+		return fmt.Sprintf("Fallback(%d)", f)
+	}
+} // func (f Fallback) String() string
+
+// LastWeek is used as the value of Recurrence.Week to denote the last
+// occurrence of a weekday within a month.
+const LastWeek = -1
+
 // Recurrence specifies a potentially recurring point in time
 // as an offset into the day (in seconds) and a Recurrence to
 // specify how the event will repeat.
+//
+// For Monthly Recurrences, Day is the day of the month (1-31).
+// For MonthlyWeekday Recurrences, Week is the ordinal (1-5 or LastWeek) of
+// the weekday(s) selected in Days.
+// For Yearly Recurrences, Month and Day specify the date.
+// For Interval Recurrences, Offset is the Unix timestamp of the first
+// occurrence (the anchor), and Period the number of seconds between two
+// occurrences.
+// For RRule Recurrences, Rule holds the recurrence rule as it was entered,
+// and Offset is the Unix timestamp of the start of the recurrence.
+//
+// A recurring Reminder stops going off once Counter (the number of
+// occurrences that have been acknowledged) reaches Limit, or after Until.
+// A Limit of 0 or a zero Until mean there is no such restriction.
+//
+// Reminders that go off on certain days (Daily, Custom, Monthly,
+// MonthlyWeekday and Yearly) may go off several times on each of those
+// days. Times holds the times of day (in seconds past midnight) in
+// addition to Offset. Those Reminders can also take the holidays from the
+// Calendar of the given name into account, as specified by Holidays.
 type Recurrence struct {
-	ID      int64
-	Offset  int
-	Repeat  repeat.Repeat
-	Days    Weekdays
-	Limit   int
-	Counter int
-	UUID    string
+	ID       int64
+	Offset   int
+	Times    []int
+	Repeat   repeat.Repeat
+	Days     Weekdays
+	Day      int
+	Week     int
+	Month    time.Month
+	Period   int
+	Rule     string
+	Fallback Fallback
+	Limit    int
+	Counter  int
+	Until    time.Time
+	Calendar string
+	Holidays HolidayRule
+	UUID     string
 }
 
 // Go's time package has a type Weekday, too, can I use that somehow?
@@ -90,6 +152,160 @@ func (a *Recurrence) Weekdays() uint8 {
 	return a.Days.Bitfield()
 } // func (a *Alarmclock) Weekdays() uint8
 
+// Equal returns true if both Recurrences describe the same schedule.
+// Unlike the == operator, it compares Until as a point in time, and it
+// ignores the Counter, which tracks the progress along the schedule.
+func (a *Recurrence) Equal(b *Recurrence) bool {
+	if len(a.Times) != len(b.Times) {
+		return false
+	}
+
+	for i := range a.Times {
+		if a.Times[i] != b.Times[i] {
+			return false
+		}
+	}
+
+	return a.ID == b.ID &&
+		a.Offset == b.Offset &&
+		a.Repeat == b.Repeat &&
+		a.Days == b.Days &&
+		a.Day == b.Day &&
+		a.Week == b.Week &&
+		a.Month == b.Month &&
+		a.Period == b.Period &&
+		a.Rule == b.Rule &&
+		a.Fallback == b.Fallback &&
+		a.Limit == b.Limit &&
+		a.Until.Equal(b.Until) &&
+		a.Calendar == b.Calendar &&
+		a.Holidays == b.Holidays &&
+		a.UUID == b.UUID
+} // func (a *Recurrence) Equal(b *Recurrence) bool
+
+// UntilStamp returns Until as a Unix timestamp, or 0 if Until is not set.
+func (a *Recurrence) UntilStamp() int64 {
+	if a.Until.IsZero() {
+		return 0
+	}
+
+	return a.Until.Unix()
+} // func (a *Recurrence) UntilStamp() int64
+
+// DailyTimes returns true if the Recurrence can go off several times a day.
+func (a *Recurrence) DailyTimes() bool {
+	switch a.Repeat {
+	case repeat.Daily, repeat.Custom, repeat.Monthly, repeat.MonthlyWeekday, repeat.Yearly:
+		return true
+	default:
+		return false
+	}
+} // func (a *Recurrence) DailyTimes() bool
+
+// TimesString returns the additional times of day as a comma-separated list
+// of seconds past midnight, which is how we store them in the database.
+func (a *Recurrence) TimesString() string {
+	var items = make([]string, len(a.Times))
+
+	for i, t := range a.Times {
+		items[i] = strconv.Itoa(t)
+	}
+
+	return strings.Join(items, ",")
+} // func (a *Recurrence) TimesString() string
+
+// SetTimes sets the additional times of day from a comma-separated list of
+// seconds past midnight, as returned by TimesString.
+func (a *Recurrence) SetTimes(s string) error {
+	var times []int
+
+	for _, item := range strings.Split(s, ",") {
+		var (
+			err error
+			t   int
+		)
+
+		if item = strings.TrimSpace(item); item == "" {
+			continue
+		} else if t, err = strconv.Atoi(item); err != nil {
+			return fmt.Errorf("Invalid time of day %q: %s", item, err.Error())
+		} else if t < 0 || t >= 86400 {
+			return fmt.Errorf("Time of day is out of range: %d", t)
+		}
+
+		times = append(times, t)
+	}
+
+	sort.Ints(times)
+	a.Times = times
+	return nil
+} // func (a *Recurrence) SetTimes(s string) error
+
+// OnDay returns true if the Recurrence goes off on the day of the given
+// time. The time of day is ignored.
+func (a *Recurrence) OnDay(t time.Time) bool {
+	switch a.Repeat {
+	case repeat.Daily:
+		return true
+	case repeat.Custom:
+		return a.Days.On(t.Weekday())
+	case repeat.Yearly:
+		if t.Month() != a.Month {
+			return false
+		}
+		fallthrough
+	case repeat.Monthly:
+		var last = daysInMonth(t.Year(), t.Month())
+
+		if a.Day <= last {
+			return t.Day() == a.Day
+		}
+
+		return a.Fallback == FallbackClamp && t.Day() == last
+	case repeat.MonthlyWeekday:
+		if !a.Days.On(t.Weekday()) {
+			return false
+		}
+
+		var (
+			nth    = (t.Day()-1)/7 + 1
+			isLast = t.Day()+7 > daysInMonth(t.Year(), t.Month())
+		)
+
+		switch {
+		case a.Week == LastWeek:
+			return isLast
+		case a.Week == nth:
+			return true
+		case a.Week > nth && isLast:
+			return a.Fallback == FallbackClamp
+		}
+	}
+
+	return false
+} // func (a *Recurrence) OnDay(t time.Time) bool
+
+// Validate checks that the day a Monthly or Yearly Recurrence goes off on
+// exists, at least in some months or years. A Yearly Recurrence on
+// February 29th is fine, it goes off in leap years, but there never is a
+// February 30th or an April 31st.
+func (a *Recurrence) Validate() error {
+	switch a.Repeat {
+	case repeat.Monthly:
+		if a.Day < 1 || a.Day > 31 {
+			return fmt.Errorf("Invalid day of month: %d", a.Day)
+		}
+	case repeat.Yearly:
+		if a.Month < time.January || a.Month > time.December {
+			return fmt.Errorf("Invalid month: %d", a.Month)
+		} else if last := daysInMonth(2000, a.Month); a.Day < 1 || a.Day > last {
+			return fmt.Errorf("%s has no day %d", a.Month, a.Day)
+		}
+	}
+
+	return nil
+} // func (a *Recurrence) Validate() error
+
 var wDayStr = []string{
 	"Mo",
 	"Di",
@@ -111,6 +327,12 @@ func (a *Recurrence) String() string {
 
 	offset = fmtOffset(a.Offset)
 
+	if a.DailyTimes() {
+		for _, t := range a.Times {
+			offset += "," + fmtOffset(t)
+		}
+	}
+
 	switch a.Repeat {
 	case repeat.Once:
 		fallthrough
@@ -130,6 +352,40 @@ func (a *Recurrence) String() string {
 		str = fmt.Sprintf("%s(%s)",
 			a.Repeat,
 			strings.Join(days, ","))
+	case repeat.Monthly:
+		str = fmt.Sprintf("%s(%d., %s)",
+			a.Repeat,
+			a.Day,
+			offset)
+	case repeat.Yearly:
+		str = fmt.Sprintf("%s(%02d.%02d., %s)",
+			a.Repeat,
+			a.Day,
+			a.Month,
+			offset)
+	case repeat.Interval:
+		str = fmt.Sprintf("%s(%s, %s)",
+			a.Repeat,
+			time.Duration(a.Period)*time.Second,
+			offset)
+	case repeat.RRule:
+		str = fmt.Sprintf("%s(%s)",
+			a.Repeat,
+			a.Rule)
+	case repeat.MonthlyWeekday:
+		var nth string
+
+		if a.Week == LastWeek {
+			nth = "last"
+		} else {
+			nth = fmt.Sprintf("%d.", a.Week)
+		}
+
+		str = fmt.Sprintf("%s(%s %s, %s)",
+			a.Repeat,
+			nth,
+			a.Days,
+			offset)
 	default:
 		str = fmt.Sprintf("InvalidRecurrence(%d)", a.Repeat)
 	}
@@ -160,6 +416,10 @@ func fmtOffset(off int) string {
 		h, m, s)
 } // func fmtOffset(off int) string
 
+func daysInMonth(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+} // func daysInMonth(year int, month time.Month) int
+
 func b2i(b bool) uint8 {
 	if b {
 		return 1
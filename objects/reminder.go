@@ -8,9 +8,10 @@ package objects
 
 import (
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
-	"github.com/blicero/theseus/common"
 	"github.com/blicero/theseus/objects/repeat"
 )
 
@@ -26,17 +27,72 @@ type Reminder struct {
 	Finished    bool
 	UUID        string
 	Changed     time.Time
+	TimeZone    string
+	Exceptions  []Exception
+	Alerts      []int
+	Escalation  Escalation
+	Priority    Priority
+	Snooze      []Snooze
+	Tags        []string
+	Checklist   []ChecklistItem
+	Depends     []Dependency
 }
 
-const tod = "15:04:05" // tod == Time Of Day
-
 // DueNext returns the Reminder's due time.
 // If ref is non-nil, it is used as the reference point from which
 // to compute the next due time for recurring Reminders, otherwise the
 // current time is used.
-func (r *Reminder) DueNext(ref *time.Time) (d time.Time) {
+// Recurring Reminders are evaluated in the Reminder's time zone, so one
+// that is set to go off at 08:00 does so at 08:00 local time, summer or
+// winter. If there are no more occurrences, e.g. because the Reminder
+// has reached its Limit or its Until date, the zero Time is returned.
+// Occurrences that have been skipped or moved are taken into account.
+func (r *Reminder) DueNext(ref *time.Time) time.Time {
+	if r.Recur.Repeat == repeat.Once || len(r.Exceptions) == 0 {
+		return r.dueNext(ref)
+	} else if r.Recur.Limit > 0 && r.Recur.Counter >= r.Recur.Limit {
+		return time.Time{}
+	}
+
+	var (
+		now = time.Now()
+		t   time.Time
+	)
+
+	if ref != nil {
+		now = *ref
+	}
+
+	t = r.dueNext(&now)
+
+	// Each Exception can knock out one occurrence at most, so we need to
+	// look at no more than len(r.Exceptions) + 1 occurrences.
+	for i := 0; !t.IsZero() && r.Exception(t) != nil; i++ {
+		if i == len(r.Exceptions) {
+			t = time.Time{}
+			break
+		}
+
+		var after = t.Add(time.Minute)
+		t = r.dueNext(&after)
+	}
+
+	for _, e := range r.Exceptions {
+		if e.Skip() || e.Due.Before(now.Truncate(time.Minute)) {
+			continue
+		} else if t.IsZero() || e.Due.Before(t) {
+			t = e.Due.Truncate(time.Minute)
+		}
+	}
+
+	return t
+} // func (r *Reminder) DueNext(ref *time.Time) time.Time
+
+// dueNext computes the next due time from the Reminder's schedule alone.
+func (r *Reminder) dueNext(ref *time.Time) (d time.Time) {
 	var (
 		now, t1 time.Time
+		loc     = r.Location()
 	)
 
 	defer func() {
@@ -44,62 +100,92 @@ func (r *Reminder) DueNext(ref *time.Time) (d time.Time) {
 	}()
 
 	if ref == nil {
-		now = time.Now().In(time.UTC)
+		now = time.Now().In(loc)
 	} else {
-		now = *ref
+		now = ref.In(loc)
 	}
 
 	switch r.Recur.Repeat {
 	case repeat.Once:
 		t1 = r.Timestamp
-	case repeat.Daily:
-		var stamp = r.Timestamp.Unix()
-
-		if stamp < (now.Unix() % 86400) {
-			t1 = now.Truncate(time.Second * 86400).Add(time.Second * 86400).Add(time.Second * time.Duration(stamp))
-		} else {
-			t1 = now.Truncate(time.Second * 86400).Add(time.Second * time.Duration(stamp))
+	case repeat.Daily, repeat.Custom, repeat.Monthly, repeat.MonthlyWeekday, repeat.Yearly:
+		t1 = r.scanDays(now, 1)
+	case repeat.Interval:
+		t1 = r.interval(now, 1)
+	case repeat.RRule:
+		var rr, err = r.RRule()
+
+		if err != nil {
+			// The database logs broken rules when it loads them, and
+			// SetRRule refuses them, so we do not do it again here.
+			return time.Time{}
 		}
-	case repeat.Custom:
-		var (
-			offset = r.Timestamp.Unix()
-			due    = now.Truncate(time.Hour * 24).Add(time.Duration(offset) * time.Second)
-		)
 
-		fmt.Printf("DueNext -- Reference time is %s\n",
-			now.Format(common.TimestampFormatSubSecond))
-		fmt.Printf("DueNext -- Offset: %06d | Due: %s\n",
-			offset,
-			due.Format(common.TimestampFormat))
-
-		if due.Before(now) {
-			fmt.Printf("DueNext -- The time of day (%s) is past the time stamp (%s)\n",
-				now.Format(tod),
-				due.Format(tod))
-			due = due.Add(time.Hour * 24)
-		}
+		t1 = rr.Next(r.Timestamp.In(loc), now)
+	default:
+		panic(fmt.Errorf("Invalid Recurrence type %d", r.Recur.Repeat))
+	}
 
-		for !r.Recur.Days.On(due.Weekday()) {
-			fmt.Printf("DueNext -- Reminder is not due on %s, skipping one day ahead.\n",
-				due.Weekday())
-			due = due.Add(time.Hour * 24)
+	if r.Recur.Repeat != repeat.Once {
+		if r.Recur.Limit > 0 && r.Recur.Counter >= r.Recur.Limit {
+			return time.Time{}
+		} else if !r.Recur.Until.IsZero() && t1.After(r.Recur.Until) {
+			return time.Time{}
 		}
+	}
 
-		fmt.Printf("DueNext -- Reminder IS due on %s at %s\n",
-			due.Weekday(),
-			due.Format(common.TimestampFormatTime))
+	return t1.Truncate(time.Minute)
+} // func (r *Reminder) dueNext(ref *time.Time) time.Time
+
+// DuePrev returns the most recent due time of the Reminder that is not after
+// the reference time (or the current time, if ref is nil). If there is none,
+// it returns the first due time, just like it does for one-time Reminders.
+// Occurrences that have been skipped or moved are taken into account.
+func (r *Reminder) DuePrev(ref *time.Time) time.Time {
+	if r.Recur.Repeat == repeat.Once || len(r.Exceptions) == 0 {
+		return r.duePrev(ref)
+	}
 
-		t1 = due
-	default:
-		panic(fmt.Errorf("Invalid Recurrence type %d", r.Recur.Repeat))
+	var (
+		now = time.Now()
+		t   time.Time
+	)
+
+	if ref != nil {
+		now = *ref
 	}
 
-	return t1.Truncate(time.Minute)
-} // func (r *Reminder) DueNext() time.Time
+	t = r.duePrev(&now)
+
+	for i := 0; !t.IsZero() && r.Exception(t) != nil; i++ {
+		var before = t.Add(-time.Minute)
 
-func (r *Reminder) DuePrev(ref *time.Time) (d time.Time) {
+		if i == len(r.Exceptions) {
+			t = time.Time{}
+			break
+		} else if t = r.duePrev(&before); t.After(before) {
+			// duePrev falls back to the first occurrence if there
+			// is no earlier one.
+			t = time.Time{}
+		}
+	}
+
+	for _, e := range r.Exceptions {
+		if e.Skip() || e.Due.After(now) {
+			continue
+		} else if t.IsZero() || e.Due.After(t) {
+			t = e.Due.Truncate(time.Minute)
+		}
+	}
+
+	return t
+} // func (r *Reminder) DuePrev(ref *time.Time) time.Time
+
+// duePrev computes the previous due time from the Reminder's schedule alone.
+func (r *Reminder) duePrev(ref *time.Time) (d time.Time) {
 	var (
 		now, t1 time.Time
+		loc     = r.Location()
 	)
 
 	defer func() {
@@ -107,67 +193,254 @@ func (r *Reminder) DuePrev(ref *time.Time) (d time.Time) {
 	}()
 
 	if ref == nil {
-		now = time.Now().In(time.UTC)
+		now = time.Now().In(loc)
 	} else {
-		now = *ref
+		now = ref.In(loc)
+	}
+
+	// Nothing happens after the Until date, so the most recent occurrence
+	// cannot be later than that.
+	if r.Recur.Repeat != repeat.Once &&
+		!r.Recur.Until.IsZero() &&
+		now.After(r.Recur.Until) {
+		now = r.Recur.Until.In(loc)
 	}
 
 	switch r.Recur.Repeat {
 	case repeat.Once:
 		t1 = r.Timestamp
-	case repeat.Daily:
-		var stamp = r.Timestamp.Unix()
+	case repeat.Daily, repeat.Custom, repeat.Monthly, repeat.MonthlyWeekday, repeat.Yearly:
+		t1 = r.scanDays(now, -1)
+	case repeat.Interval:
+		t1 = r.interval(now, -1)
+	case repeat.RRule:
+		var rr, err = r.RRule()
+
+		if err != nil {
+			// See dueNext
+			return time.Time{}
+		}
 
-		if stamp > (now.Unix() % 86400) {
-			t1 = now.Truncate(time.Hour * 24).Add(time.Second * -86400).Add(time.Second * time.Duration(stamp))
-		} else {
-			t1 = now.Truncate(time.Second * 86400).Add(time.Second * time.Duration(stamp))
+		t1 = rr.Prev(r.Timestamp.In(loc), now)
+	default:
+		panic(fmt.Errorf("Invalid Recurrence type %d", r.Recur.Repeat))
+	}
+
+	return t1.Truncate(time.Minute)
+} // func (r *Reminder) duePrev(ref *time.Time) time.Time
+
+// Exhausted returns true if a recurring Reminder will not go off again after
+// the reference time (or the current time, if ref is nil), because it has
+// reached its Limit or its Until date, or because its recurrence rule has
+// run out. One-time Reminders are never exhausted, they are finished.
+func (r *Reminder) Exhausted(ref *time.Time) bool {
+	return r.Recur.Repeat != repeat.Once && r.DueNext(ref).IsZero()
+} // func (r *Reminder) Exhausted(ref *time.Time) bool
+
+// Offsets returns the times of day (in seconds past midnight) the Reminder
+// goes off at, in ascending order. For Reminders that go off at most once
+// a day, this is just the time of day of the Timestamp.
+func (r *Reminder) Offsets() []int {
+	var (
+		first   = int(r.Timestamp.Unix())
+		offsets = []int{first}
+	)
+
+	if !r.Recur.DailyTimes() {
+		return offsets
+	}
+
+	for _, t := range r.Recur.Times {
+		if t != first {
+			offsets = append(offsets, t)
+		}
+	}
+
+	sort.Ints(offsets)
+
+	// Remove duplicates
+	var n = 1
+	for i := 1; i < len(offsets); i++ {
+		if offsets[i] != offsets[n-1] {
+			offsets[n] = offsets[i]
+			n++
 		}
-	case repeat.Custom:
+	}
+
+	return offsets[:n]
+} // func (r *Reminder) Offsets() []int
+
+// HolidayCalendar returns the Calendar the Reminder consults for holidays,
+// or nil if it does not care about holidays. Only Reminders that go off on
+// certain days can do so. If the Calendar is not known on this system,
+// holidays are ignored, too.
+func (r *Reminder) HolidayCalendar() *Calendar {
+	if r.Recur.Holidays == HolidayIgnore ||
+		r.Recur.Calendar == "" ||
+		!r.Recur.DailyTimes() {
+		return nil
+	}
+
+	return GetCalendar(r.Recur.Calendar)
+} // func (r *Reminder) HolidayCalendar() *Calendar
+
+// maxDayScan is the number of days scanDays looks ahead or back at most.
+// A Reminder on February 29th that skips regular years may have to wait
+// up to eight years (e.g. from 2096 to 2104).
+const maxDayScan = 366 * 8
+
+// scanDays walks from the day of the reference time one day at a time in
+// the direction given by step (1 or -1) and returns the first point in time
+// on a day the Recurrence goes off that is not before (or, going backwards,
+// not after) the reference time. The time of day is taken as wall clock
+// time in the location of the reference time.
+// If no such day is found within maxDayScan days, the zero Time is returned.
+func (r *Reminder) scanDays(now time.Time, step int) time.Time {
+	var (
+		offsets = r.Offsets()
+		cal     = r.HolidayCalendar()
+		first   = 0
+	)
+
+	if step < 0 {
+		for i, j := 0, len(offsets)-1; i < j; i, j = i+1, j-1 {
+			offsets[i], offsets[j] = offsets[j], offsets[i]
+		}
+	} else if cal != nil && r.Recur.Holidays == HolidayShift {
+		// An occurrence on a day before the reference time may have
+		// been shifted past it.
+		first = -maxHolidayShift
+	}
+
+	for i := first; i <= maxDayScan; i++ {
+		var day = time.Date(now.Year(), now.Month(), now.Day()+i*step, 12, 0, 0, 0, time.UTC)
+
+		if !r.Recur.OnDay(day) {
+			continue
+		} else if cal != nil {
+			if _, holiday := cal.Holiday(day); holiday {
+				if r.Recur.Holidays == HolidaySkip {
+					continue
+				}
+
+				for j := 0; j < maxHolidayShift && !cal.BusinessDay(day); j++ {
+					day = day.AddDate(0, 0, 1)
+				}
+			}
+		}
+
+		for _, offset := range offsets {
+			var due = wallClock(day.Year(), day.Month(), day.Day(), offset, now.Location())
+
+			if (step > 0 && !due.Before(now)) || (step < 0 && !due.After(now)) {
+				return due
+			}
+		}
+	}
+
+	return time.Time{}
+} // func (r *Reminder) scanDays(now time.Time, step int) time.Time
+
+// interval returns the next (dir > 0) or previous (dir < 0) occurrence of
+// an Interval Reminder relative to now. Periods that are a whole number of
+// days are counted in calendar days, so the time of day does not change
+// when DST begins or ends.
+func (r *Reminder) interval(now time.Time, dir int) time.Time {
+	var (
+		period = int64(r.Recur.Period)
+		anchor = r.Timestamp.In(now.Location())
+		delta  = now.Unix() - anchor.Unix()
+		k      int64
+	)
+
+	if delta <= 0 || period <= 0 {
+		return anchor
+	} else if period%86400 == 0 {
 		var (
-			offset = r.Timestamp.Unix()
-			due    = now.Truncate(time.Hour * 24).Add(time.Duration(offset) * time.Second)
+			days = period / 86400
+			off  = secondsOfDay(anchor)
+			due  time.Time
 		)
 
-		fmt.Printf("DuePrev -- Reference time is %s\n",
-			now.Format(common.TimestampFormatSubSecond))
-		fmt.Printf("DuePrev -- Offset: %06d | Due: %s\n",
-			offset,
-			due.Format(common.TimestampFormat))
-
-		if due.After(now) {
-			fmt.Printf("DuePrev -- The time of day (%s) is past the time stamp (%s)\n",
-				now.Format(tod),
-				due.Format(tod))
-			due = due.Add(time.Hour * -24)
-		}
+		k = int64(civilDays(anchor, now)) / days
+		due = wallClock(anchor.Year(), anchor.Month(), anchor.Day()+int(k*days), off, now.Location())
 
-		for !r.Recur.Days.On(due.Weekday()) {
-			fmt.Printf("DuePrev -- Reminder is not due on %s, stepping back one day.\n",
-				due.Weekday())
-			due = due.Add(time.Hour * -24)
+		if dir > 0 && due.Before(now) {
+			k++
+		} else if dir < 0 && due.After(now) && k > 0 {
+			k--
 		}
 
-		fmt.Printf("DuePrev -- Reminder IS due on %s at %s\n",
-			due.Weekday(),
-			due.Format(common.TimestampFormatTime))
+		return wallClock(anchor.Year(), anchor.Month(), anchor.Day()+int(k*days), off, now.Location())
+	} else if dir > 0 {
+		k = (delta + period - 1) / period
+	} else {
+		k = delta / period
+	}
 
-		t1 = due
-	default:
-		panic(fmt.Errorf("Invalid Recurrence type %d", r.Recur.Repeat))
+	return anchor.Add(time.Duration(k*period) * time.Second)
+} // func (r *Reminder) interval(now time.Time, dir int) time.Time
+
+// SetRRule sets the Reminder's Recurrence from an iCalendar recurrence rule,
+// using the Reminder's Timestamp as the start of the recurrence.
+// If the rule can be expressed using one of the other repeat modes, that
+// mode is used, otherwise the rule is stored verbatim.
+// The repeat modes that go off on certain days only keep the time of day,
+// so they cannot tell when the recurrence starts, and DTSTART is the first
+// occurrence even if it does not match the rule. So we only use them if
+// DTSTART has passed and is a regular occurrence of the rule.
+func (r *Reminder) SetRRule(rule string) error {
+	var (
+		err     error
+		ok      bool
+		rr      *RRule
+		rec     Recurrence
+		dtstart = r.Timestamp.In(r.Location())
+	)
+
+	if rr, err = ParseRRuleIn(rule, r.Location()); err != nil {
+		return err
+	} else if rec, ok = rr.recurrence(dtstart); ok && rec.Repeat != repeat.Interval {
+		ok = !dtstart.After(time.Now()) && rr.matches(dtstart, dtstart)
 	}
 
-	return t1.Truncate(time.Minute)
-} // func (r *Reminder) DuePrev(ref *time.Time) time.Time
+	if !ok {
+		rec = Recurrence{
+			Repeat: repeat.RRule,
+			Offset: int(r.Timestamp.Unix()),
+			Rule:   strings.TrimSpace(rule),
+		}
+	} else if rec.Repeat != repeat.Interval {
+		r.Timestamp = time.Unix(int64(rec.Offset), 0).In(time.UTC)
+	}
+
+	rec.ID = r.Recur.ID
+	rec.Limit = r.Recur.Limit
+	rec.Counter = r.Recur.Counter
+	rec.Until = r.Recur.Until
+	rec.Times = r.Recur.Times
+	rec.UUID = r.Recur.UUID
+	r.Recur = rec
+
+	return nil
+} // func (r *Reminder) SetRRule(rule string) error
 
 // IsDue returns true if the Reminder's due time has passed.
 func (r *Reminder) IsDue() bool {
 	return r.Timestamp.Before(time.Now())
 } // func (r *Reminder) IsDue() bool
 
-// Payload returns the Reminder's Title and Description.
+// Payload returns the Reminder's Title and Description. If the Reminder
+// has a checklist, it is appended to the Description, along with the
+// progress.
 func (r *Reminder) Payload() (string, string) {
-	return r.Title, r.Description
+	if len(r.Checklist) == 0 {
+		return r.Title, r.Description
+	} else if r.Description == "" {
+		return r.Title, r.checklistBody()
+	}
+
+	return r.Title, r.Description + "\n\n" + r.checklistBody()
 } // func (r *Reminder) Payload() (string, string)
 
 // UniqueID returns an identifier that is unique across instances.
@@ -18,8 +18,21 @@ type Repeat uint8
 // Daily means it is repeated every day.
 // Custom means that the user specifies on which weekdays the Reminder should
 // go off (e.g. "only on workdays", or "only on weekends")
+// Monthly means the Reminder goes off on a fixed day of the month.
+// MonthlyWeekday means the Reminder goes off on the nth (or last) occurrence
+// of a weekday within the month, e.g. "every second Thursday".
+// Yearly means the Reminder goes off once a year on a fixed date.
+// Interval means the Reminder goes off every N seconds, starting from
+// a fixed point in time.
+// RRule means the Reminder is driven by an iCalendar recurrence rule
+// (RFC 5545) that cannot be expressed by any of the other modes.
 const (
 	Once Repeat = iota
 	Daily
 	Custom
+	Monthly
+	MonthlyWeekday
+	Yearly
+	Interval
+	RRule
 )
@@ -0,0 +1,245 @@
+// /home/krylon/go/src/github.com/blicero/theseus/objects/revision.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 17. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <>
+
+package objects
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// Revision records the change of a single field of a Reminder. All the
+// Revisions made by one edit belong to the same operation, Op. Peer is the
+// name of the Peer the change came from, or empty if it was made locally.
+// If the operation reverted an earlier one, Undoes is the ID of the latter.
+//
+// The values are stored as JSON. The field FieldReminder stands for the
+// Reminder as a whole, it is used when a Reminder is deleted or restored,
+// the value is empty if the Reminder does not exist.
+type Revision struct {
+	ID     int64
+	Op     int64
+	UUID   string
+	Peer   string
+	Stamp  time.Time
+	Undoes int64
+	Field  string
+	Old    string
+	New    string
+}
+
+// FieldReminder is the field of Revisions that delete or restore a
+// Reminder.
+const FieldReminder = "Reminder"
+
+// RevisionFields are the fields of a Reminder we keep track of. The
+// Recurrence comes before the Timestamp, because the two have to agree with
+// each other when we change them.
+var RevisionFields = []string{
+	"Title",
+	"Description",
+	"TimeZone",
+	"Recur",
+	"Timestamp",
+	"Alerts",
+	"Escalation",
+	"Priority",
+	"Snooze",
+	"Tags",
+	"Checklist",
+	"Depends",
+	"Finished",
+}
+
+// Who returns a description of where the change came from.
+func (r *Revision) Who() string {
+	if r.Peer == "" {
+		return "local"
+	}
+
+	return r.Peer
+} // func (r *Revision) Who() string
+
+func (r *Revision) String() string {
+	return fmt.Sprintf("Revision{ ID: %d, Op: %d, UUID: %s, Who: %s, Field: %s }",
+		r.ID,
+		r.Op,
+		r.UUID,
+		r.Who(),
+		r.Field)
+} // func (r *Revision) String() string
+
+func revisionField(r *Reminder, name string) (reflect.Value, error) {
+	for _, f := range RevisionFields {
+		if f == name {
+			return reflect.ValueOf(r).Elem().FieldByName(name), nil
+		}
+	}
+
+	return reflect.Value{}, fmt.Errorf("Unknown field %q", name)
+} // func revisionField(r *Reminder, name string) (reflect.Value, error)
+
+// FieldValue returns the value of the given field in the form used by
+// Revisions. The Counter of the Recurrence tracks the progress along the
+// schedule rather than being edited, and its Offset mirrors the Timestamp,
+// so both are left out.
+func (r *Reminder) FieldValue(name string) (string, error) {
+	var (
+		err error
+		f   reflect.Value
+		buf []byte
+	)
+
+	if f, err = revisionField(r, name); err != nil {
+		return "", err
+	} else if name == "Recur" {
+		var rec = r.Recur
+		rec.Counter = 0
+		rec.Offset = 0
+		buf, err = json.Marshal(&rec)
+	} else {
+		buf, err = json.Marshal(f.Interface())
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	return string(buf), nil
+} // func (r *Reminder) FieldValue(name string) (string, error)
+
+// SetFieldValue sets the given field from a value as returned by
+// FieldValue.
+func (r *Reminder) SetFieldValue(name, value string) error {
+	var (
+		err error
+		f   reflect.Value
+		v   reflect.Value
+		cnt = r.Recur.Counter
+		off = r.Recur.Offset
+	)
+
+	if f, err = revisionField(r, name); err != nil {
+		return err
+	}
+
+	v = reflect.New(f.Type())
+
+	if err = json.Unmarshal([]byte(value), v.Interface()); err != nil {
+		return fmt.Errorf("Cannot parse value of %s: %s",
+			name,
+			err.Error())
+	}
+
+	f.Set(v.Elem())
+	r.Recur.Counter = cnt
+	r.Recur.Offset = off
+	return nil
+} // func (r *Reminder) SetFieldValue(name, value string) error
+
+// Clone returns a copy of the Reminder that shares no memory with it.
+func (r *Reminder) Clone() *Reminder {
+	var c = *r
+
+	c.Recur.Times = append([]int(nil), r.Recur.Times...)
+	c.Exceptions = append([]Exception(nil), r.Exceptions...)
+	c.Alerts = append([]int(nil), r.Alerts...)
+	c.Snooze = append([]Snooze(nil), r.Snooze...)
+	c.Tags = append([]string(nil), r.Tags...)
+	c.Checklist = append([]ChecklistItem(nil), r.Checklist...)
+	c.Depends = append([]Dependency(nil), r.Depends...)
+
+	return &c
+} // func (r *Reminder) Clone() *Reminder
+
+// RevisionsBetween returns the Revisions that turn before into after. Both
+// must be versions of the same Reminder, nil stands for a Reminder that
+// does not exist. Op, Peer and Stamp are left for the caller to fill in.
+func RevisionsBetween(before, after *Reminder) ([]Revision, error) {
+	var (
+		err  error
+		buf  []byte
+		revs []Revision
+	)
+
+	if before == nil && after == nil {
+		return nil, nil
+	} else if before == nil {
+		if buf, err = json.Marshal(after); err != nil {
+			return nil, err
+		}
+
+		return []Revision{{UUID: after.UUID, Field: FieldReminder, New: string(buf)}}, nil
+	} else if after == nil {
+		if buf, err = json.Marshal(before); err != nil {
+			return nil, err
+		}
+
+		return []Revision{{UUID: before.UUID, Field: FieldReminder, Old: string(buf)}}, nil
+	}
+
+	for _, name := range RevisionFields {
+		var oldVal, newVal string
+
+		if oldVal, err = before.FieldValue(name); err != nil {
+			return nil, err
+		} else if newVal, err = after.FieldValue(name); err != nil {
+			return nil, err
+		} else if oldVal != newVal {
+			revs = append(revs, Revision{
+				UUID:  before.UUID,
+				Field: name,
+				Old:   oldVal,
+				New:   newVal,
+			})
+		}
+	}
+
+	return revs, nil
+} // func RevisionsBetween(before, after *Reminder) ([]Revision, error)
+
+// Rewind takes back the given Revisions, starting with the last one, and
+// returns the Reminder as it was before them. cur is the current state of
+// the Reminder, or nil if it does not exist, it is not modified.
+func Rewind(cur *Reminder, revs []Revision) (*Reminder, error) {
+	var (
+		err   error
+		state *Reminder
+	)
+
+	if cur != nil {
+		state = cur.Clone()
+	}
+
+	for i := len(revs) - 1; i >= 0; i-- {
+		var rev = &revs[i]
+
+		if rev.Field == FieldReminder {
+			if rev.Old == "" {
+				state = nil
+				continue
+			}
+
+			state = new(Reminder)
+
+			if err = json.Unmarshal([]byte(rev.Old), state); err != nil {
+				return nil, fmt.Errorf("Cannot parse Reminder from Revision %d: %s",
+					rev.ID,
+					err.Error())
+			}
+		} else if state == nil {
+			return nil, fmt.Errorf("Revision %d changes Reminder %s, which does not exist at that point",
+				rev.ID,
+				rev.UUID)
+		} else if err = state.SetFieldValue(rev.Field, rev.Old); err != nil {
+			return nil, err
+		}
+	}
+
+	return state, nil
+} // func Rewind(cur *Reminder, revs []Revision) (*Reminder, error)
@@ -0,0 +1,725 @@
+// /home/krylon/go/src/github.com/blicero/theseus/objects/rrule.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 17. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <>
+
+package objects
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/blicero/theseus/objects/repeat"
+)
+
+// Frequency is the FREQ part of a recurrence rule.
+type Frequency uint8
+
+// These are the frequencies we support. RFC 5545 also has SECONDLY,
+// but for a reminder application, that seems a little excessive.
+const (
+	FreqMinutely Frequency = iota
+	FreqHourly
+	FreqDaily
+	FreqWeekly
+	FreqMonthly
+	FreqYearly
+)
+
+var freqNames = []string{
+	"MINUTELY",
+	"HOURLY",
+	"DAILY",
+	"WEEKLY",
+	"MONTHLY",
+	"YEARLY",
+}
+
+func (f Frequency) String() string {
+	if int(f) < len(freqNames) {
+		return freqNames[f]
+	}
+
+	return fmt.Sprintf("Frequency(%d)", f)
+} // func (f Frequency) String() string
+
+// rruleDays contains the abbreviations RFC 5545 uses for weekdays, indexed
+// by time.Weekday.
+var rruleDays = [7]string{
+	"SU",
+	"MO",
+	"TU",
+	"WE",
+	"TH",
+	"FR",
+	"SA",
+}
+
+func parseWeekday(s string) (time.Weekday, error) {
+	for i, d := range rruleDays {
+		if d == s {
+			return time.Weekday(i), nil
+		}
+	}
+
+	return 0, fmt.Errorf("Invalid weekday %q", s)
+} // func parseWeekday(s string) (time.Weekday, error)
+
+// WeekdayNum is one entry in the BYDAY part of a recurrence rule,
+// e.g. "TH", "2TH", or "-1FR". N is zero if there is no ordinal.
+type WeekdayNum struct {
+	N   int
+	Day time.Weekday
+}
+
+func (w WeekdayNum) String() string {
+	if w.N == 0 {
+		return rruleDays[w.Day]
+	}
+
+	return fmt.Sprintf("%d%s", w.N, rruleDays[w.Day])
+} // func (w WeekdayNum) String() string
+
+// RRule is a recurrence rule as described in RFC 5545, section 3.3.10.
+// We support FREQ (MINUTELY through YEARLY), INTERVAL, BYDAY, BYMONTHDAY,
+// BYMONTH, WKST, COUNT and UNTIL. The start of the recurrence (DTSTART)
+// is not part of the rule, it is passed to the methods evaluating it.
+type RRule struct {
+	Freq       Frequency
+	Interval   int
+	ByDay      []WeekdayNum
+	ByMonthDay []int
+	ByMonth    []time.Month
+	WeekStart  time.Weekday
+	Count      int
+	Until      time.Time
+}
+
+const (
+	untilFormatUTC   = "20060102T150405Z"
+	untilFormatLocal = "20060102T150405"
+	untilFormatDate  = "20060102"
+)
+
+// parseUntil parses the UNTIL part of a recurrence rule. Floating times and
+// dates are in the time zone of DTSTART, which is passed as loc.
+func parseUntil(s string, loc *time.Location) (time.Time, error) {
+	var (
+		err error
+		t   time.Time
+	)
+
+	if t, err = time.Parse(untilFormatUTC, s); err == nil {
+		return t, nil
+	} else if t, err = time.ParseInLocation(untilFormatLocal, s, loc); err == nil {
+		return t, nil
+	} else if t, err = time.ParseInLocation(untilFormatDate, s, loc); err == nil {
+		// UNTIL is inclusive, so a date means "until the end of that day"
+		return wallClock(t.Year(), t.Month(), t.Day()+1, 0, loc).Add(-time.Second), nil
+	}
+
+	return t, fmt.Errorf("Invalid UNTIL value %q", s)
+} // func parseUntil(s string, loc *time.Location) (time.Time, error)
+
+// ParseRRule parses a recurrence rule, e.g. "FREQ=MONTHLY;BYDAY=-1FR".
+// The "RRULE:" prefix is optional. A floating UNTIL is taken to be in UTC,
+// use ParseRRuleIn if the time zone of DTSTART is known.
+func ParseRRule(s string) (*RRule, error) {
+	return ParseRRuleIn(s, time.UTC)
+} // func ParseRRule(s string) (*RRule, error)
+
+// ParseRRuleIn parses a recurrence rule like ParseRRule. A floating UNTIL,
+// i.e. a date or a time without the trailing Z, is interpreted in the
+// given time zone, which should be that of DTSTART.
+func ParseRRuleIn(s string, loc *time.Location) (*RRule, error) {
+	var (
+		err     error
+		hasFreq bool
+		rr      = &RRule{
+			Interval:  1,
+			WeekStart: time.Monday,
+		}
+	)
+
+	s = strings.ToUpper(strings.TrimSpace(s))
+	s = strings.TrimPrefix(s, "RRULE:")
+
+	if s == "" {
+		return nil, errors.New("Recurrence rule is empty")
+	}
+
+	for _, part := range strings.Split(s, ";") {
+		if part == "" {
+			continue
+		}
+
+		var kv = strings.SplitN(part, "=", 2)
+
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("Invalid part %q in recurrence rule", part)
+		}
+
+		var key, val = strings.TrimSpace(kv[0]), strings.TrimSpace(kv[1])
+
+		switch key {
+		case "FREQ":
+			hasFreq = false
+			for i, f := range freqNames {
+				if f == val {
+					rr.Freq = Frequency(i)
+					hasFreq = true
+					break
+				}
+			}
+
+			if !hasFreq {
+				return nil, fmt.Errorf("Unsupported frequency %q", val)
+			}
+		case "INTERVAL":
+			if rr.Interval, err = strconv.Atoi(val); err != nil || rr.Interval < 1 {
+				return nil, fmt.Errorf("Invalid INTERVAL %q", val)
+			}
+		case "COUNT":
+			if rr.Count, err = strconv.Atoi(val); err != nil || rr.Count < 1 {
+				return nil, fmt.Errorf("Invalid COUNT %q", val)
+			}
+		case "UNTIL":
+			if rr.Until, err = parseUntil(val, loc); err != nil {
+				return nil, err
+			}
+		case "WKST":
+			if rr.WeekStart, err = parseWeekday(val); err != nil {
+				return nil, err
+			}
+		case "BYDAY":
+			for _, d := range strings.Split(val, ",") {
+				var (
+					wd  WeekdayNum
+					idx = len(d) - 2
+				)
+
+				if idx < 0 {
+					return nil, fmt.Errorf("Invalid BYDAY entry %q", d)
+				} else if wd.Day, err = parseWeekday(d[idx:]); err != nil {
+					return nil, err
+				} else if idx > 0 {
+					if wd.N, err = strconv.Atoi(strings.TrimPrefix(d[:idx], "+")); err != nil ||
+						wd.N == 0 || wd.N < -53 || wd.N > 53 {
+						return nil, fmt.Errorf("Invalid BYDAY entry %q", d)
+					}
+				}
+
+				rr.ByDay = append(rr.ByDay, wd)
+			}
+		case "BYMONTHDAY":
+			for _, d := range strings.Split(val, ",") {
+				var n int
+
+				if n, err = strconv.Atoi(d); err != nil || n == 0 || n < -31 || n > 31 {
+					return nil, fmt.Errorf("Invalid BYMONTHDAY entry %q", d)
+				}
+
+				rr.ByMonthDay = append(rr.ByMonthDay, n)
+			}
+		case "BYMONTH":
+			for _, m := range strings.Split(val, ",") {
+				var n int
+
+				if n, err = strconv.Atoi(m); err != nil || n < 1 || n > 12 {
+					return nil, fmt.Errorf("Invalid BYMONTH entry %q", m)
+				}
+
+				rr.ByMonth = append(rr.ByMonth, time.Month(n))
+			}
+		default:
+			return nil, fmt.Errorf("Unsupported part %s in recurrence rule", key)
+		}
+	}
+
+	if !hasFreq {
+		return nil, errors.New("Recurrence rule lacks FREQ")
+	} else if rr.Count > 0 && !rr.Until.IsZero() {
+		return nil, errors.New("Recurrence rule must not have both COUNT and UNTIL")
+	}
+
+	for _, wd := range rr.ByDay {
+		if wd.N != 0 && rr.Freq != FreqMonthly && rr.Freq != FreqYearly {
+			return nil, fmt.Errorf("BYDAY entry %s only allowed with FREQ=MONTHLY or FREQ=YEARLY",
+				wd)
+		}
+	}
+
+	return rr, nil
+} // func ParseRRuleIn(s string, loc *time.Location) (*RRule, error)
+
+// rruleCache keeps the recurrence rules we have parsed already, so we do
+// not have to parse a rule every time we compute a due time. Floating
+// UNTIL values depend on the time zone, so that is part of the key.
+var rruleCache sync.Map
+
+type rruleKey struct {
+	rule string
+	zone string
+}
+
+type rruleEntry struct {
+	rr  *RRule
+	err error
+}
+
+// RRule returns the parsed recurrence rule of a Reminder that uses one,
+// and nil for all other Reminders. If the rule cannot be parsed, the error
+// is returned every time, so the caller can report it.
+func (r *Reminder) RRule() (*RRule, error) {
+	if r.Recur.Repeat != repeat.RRule {
+		return nil, nil
+	}
+
+	var (
+		loc = r.Location()
+		key = rruleKey{rule: r.Recur.Rule, zone: loc.String()}
+		e   rruleEntry
+	)
+
+	if v, ok := rruleCache.Load(key); ok {
+		e = v.(rruleEntry)
+		return e.rr, e.err
+	}
+
+	e.rr, e.err = ParseRRuleIn(r.Recur.Rule, loc)
+	rruleCache.Store(key, e)
+	return e.rr, e.err
+} // func (r *Reminder) RRule() (*RRule, error)
+
+func (rr *RRule) String() string {
+	var parts = []string{"FREQ=" + rr.Freq.String()}
+
+	if rr.Interval > 1 {
+		parts = append(parts, fmt.Sprintf("INTERVAL=%d", rr.Interval))
+	}
+
+	if len(rr.ByDay) > 0 {
+		var days = make([]string, len(rr.ByDay))
+		for i, d := range rr.ByDay {
+			days[i] = d.String()
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+
+	if len(rr.ByMonthDay) > 0 {
+		var days = make([]string, len(rr.ByMonthDay))
+		for i, d := range rr.ByMonthDay {
+			days[i] = strconv.Itoa(d)
+		}
+		parts = append(parts, "BYMONTHDAY="+strings.Join(days, ","))
+	}
+
+	if len(rr.ByMonth) > 0 {
+		var months = make([]string, len(rr.ByMonth))
+		for i, m := range rr.ByMonth {
+			months[i] = strconv.Itoa(int(m))
+		}
+		parts = append(parts, "BYMONTH="+strings.Join(months, ","))
+	}
+
+	if rr.WeekStart != time.Monday {
+		parts = append(parts, "WKST="+rruleDays[rr.WeekStart])
+	}
+
+	if rr.Count > 0 {
+		parts = append(parts, fmt.Sprintf("COUNT=%d", rr.Count))
+	} else if !rr.Until.IsZero() {
+		parts = append(parts, "UNTIL="+rr.Until.UTC().Format(untilFormatUTC))
+	}
+
+	return strings.Join(parts, ";")
+} // func (rr *RRule) String() string
+
+// step returns the distance between two candidates for the sub-daily
+// frequencies, and zero for the others, which step one calendar day at a
+// time.
+func (rr *RRule) step() time.Duration {
+	switch rr.Freq {
+	case FreqMinutely:
+		return time.Minute * time.Duration(rr.Interval)
+	case FreqHourly:
+		return time.Hour * time.Duration(rr.Interval)
+	default:
+		return 0
+	}
+} // func (rr *RRule) step() time.Duration
+
+// candidate returns the i-th point in time after dtstart that might be an
+// occurrence of the rule.
+func (rr *RRule) candidate(dtstart time.Time, i int) time.Time {
+	if s := rr.step(); s != 0 {
+		return dtstart.Add(time.Duration(i) * s)
+	}
+
+	return wallClock(dtstart.Year(),
+		dtstart.Month(),
+		dtstart.Day()+i,
+		secondsOfDay(dtstart),
+		dtstart.Location())
+} // func (rr *RRule) candidate(dtstart time.Time, i int) time.Time
+
+// index returns the index of the last candidate not after t, or -1 if t
+// is before dtstart.
+func (rr *RRule) index(dtstart, t time.Time) int {
+	if t.Before(dtstart) {
+		return -1
+	} else if s := rr.step(); s != 0 {
+		return int(t.Sub(dtstart) / s)
+	}
+
+	var i = civilDays(dtstart, t.In(dtstart.Location()))
+
+	if rr.candidate(dtstart, i).After(t) {
+		i--
+	}
+
+	return i
+} // func (rr *RRule) index(dtstart, t time.Time) int
+
+// scanLimit is the number of consecutive candidates we look at before we
+// conclude there are no more occurrences.
+func (rr *RRule) scanLimit() int {
+	if s := rr.step(); s != 0 {
+		return int(time.Hour * 24 * maxDayScan / s)
+	}
+
+	return maxDayScan * rr.Interval
+} // func (rr *RRule) scanLimit() int
+
+// matches returns true if the candidate t is an occurrence of the rule.
+func (rr *RRule) matches(dtstart, t time.Time) bool {
+	switch rr.Freq {
+	case FreqMinutely, FreqHourly:
+		// Candidates are spaced by the interval already.
+	case FreqDaily:
+		if civilDays(dtstart, t)%rr.Interval != 0 {
+			return false
+		}
+	case FreqWeekly:
+		var (
+			d      = civilDays(dtstart, t)
+			shiftA = (int(dtstart.Weekday()) - int(rr.WeekStart) + 7) % 7
+			shiftB = (int(t.Weekday()) - int(rr.WeekStart) + 7) % 7
+		)
+
+		if ((d-shiftB+shiftA)/7)%rr.Interval != 0 {
+			return false
+		} else if len(rr.ByDay) == 0 && t.Weekday() != dtstart.Weekday() {
+			return false
+		}
+	case FreqMonthly:
+		var months = (t.Year()-dtstart.Year())*12 + int(t.Month()-dtstart.Month())
+
+		if months%rr.Interval != 0 {
+			return false
+		} else if len(rr.ByDay) == 0 && len(rr.ByMonthDay) == 0 && t.Day() != dtstart.Day() {
+			return false
+		}
+	case FreqYearly:
+		if (t.Year()-dtstart.Year())%rr.Interval != 0 {
+			return false
+		} else if len(rr.ByDay) == 0 && len(rr.ByMonthDay) == 0 {
+			if t.Day() != dtstart.Day() {
+				return false
+			} else if len(rr.ByMonth) == 0 && t.Month() != dtstart.Month() {
+				return false
+			}
+		}
+	}
+
+	if len(rr.ByMonth) > 0 {
+		var found bool
+
+		for _, m := range rr.ByMonth {
+			if m == t.Month() {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	if len(rr.ByMonthDay) > 0 {
+		var (
+			found bool
+			dim   = daysInMonth(t.Year(), t.Month())
+		)
+
+		for _, d := range rr.ByMonthDay {
+			if (d > 0 && d == t.Day()) || (d < 0 && dim+d+1 == t.Day()) {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	if len(rr.ByDay) > 0 {
+		return rr.matchWeekday(t)
+	}
+
+	return true
+} // func (rr *RRule) matches(dtstart, t time.Time) bool
+
+func (rr *RRule) matchWeekday(t time.Time) bool {
+	for _, wd := range rr.ByDay {
+		if wd.Day != t.Weekday() {
+			continue
+		} else if wd.N == 0 {
+			return true
+		}
+
+		var nth, last int
+
+		if rr.Freq == FreqYearly && len(rr.ByMonth) == 0 {
+			var ylen = time.Date(t.Year(), time.December, 31, 0, 0, 0, 0, time.UTC).YearDay()
+
+			nth = (t.YearDay()-1)/7 + 1
+			last = (ylen-t.YearDay())/7 + 1
+		} else {
+			var dim = daysInMonth(t.Year(), t.Month())
+
+			nth = (t.Day()-1)/7 + 1
+			last = (dim-t.Day())/7 + 1
+		}
+
+		if (wd.N > 0 && wd.N == nth) || (wd.N < 0 && -wd.N == last) {
+			return true
+		}
+	}
+
+	return false
+} // func (rr *RRule) matchWeekday(t time.Time) bool
+
+// each calls fn for every occurrence of the rule, in chronological order,
+// until fn returns false or the rule is exhausted. As RFC 5545 has it,
+// DTSTART is the first occurrence and counts towards COUNT, even if it does
+// not match the rule.
+func (rr *RRule) each(dtstart time.Time, fn func(t time.Time) bool) {
+	var (
+		cnt, miss int
+		limit     = rr.scanLimit()
+	)
+
+	for i := 0; miss <= limit; i++ {
+		var t = rr.candidate(dtstart, i)
+
+		if !rr.Until.IsZero() && t.After(rr.Until) {
+			return
+		} else if i > 0 && !rr.matches(dtstart, t) {
+			miss++
+			continue
+		}
+
+		miss = 0
+		cnt++
+
+		if !fn(t) || (rr.Count > 0 && cnt >= rr.Count) {
+			return
+		}
+	}
+} // func (rr *RRule) each(dtstart time.Time, fn func(t time.Time) bool)
+
+// Next returns the first occurrence of the rule starting at dtstart that is
+// not before ref. DTSTART itself is always the first occurrence, see each.
+// If the rule is exhausted, the zero Time is returned.
+func (rr *RRule) Next(dtstart, ref time.Time) time.Time {
+	var res time.Time
+
+	if rr.Count > 0 {
+		// We have to count the occurrences from the very beginning.
+		rr.each(dtstart, func(t time.Time) bool {
+			if !t.Before(ref) {
+				res = t
+				return false
+			}
+			return true
+		})
+
+		return res
+	}
+
+	var start = rr.index(dtstart, ref)
+
+	if start < 0 {
+		start = 0
+	}
+
+	for i, limit := start, start+rr.scanLimit(); i <= limit; i++ {
+		var t = rr.candidate(dtstart, i)
+
+		if !rr.Until.IsZero() && t.After(rr.Until) {
+			break
+		} else if !t.Before(ref) && (i == 0 || rr.matches(dtstart, t)) {
+			return t
+		}
+	}
+
+	return res
+} // func (rr *RRule) Next(dtstart, ref time.Time) time.Time
+
+// Prev returns the last occurrence of the rule starting at dtstart that is
+// not after ref. If there is none, the first occurrence is returned, which
+// is what Reminder.DuePrev does for one-time Reminders, too.
+func (rr *RRule) Prev(dtstart, ref time.Time) time.Time {
+	var res time.Time
+
+	if rr.Count > 0 {
+		rr.each(dtstart, func(t time.Time) bool {
+			if t.After(ref) {
+				if res.IsZero() {
+					res = t
+				}
+				return false
+			}
+
+			res = t
+			return true
+		})
+
+		return res
+	}
+
+	var end = ref
+
+	if !rr.Until.IsZero() && rr.Until.Before(end) {
+		end = rr.Until
+	}
+
+	for i, limit := rr.index(dtstart, end), rr.scanLimit(); i >= 0 && limit >= 0; i, limit = i-1, limit-1 {
+		var t = rr.candidate(dtstart, i)
+
+		if i == 0 || rr.matches(dtstart, t) {
+			return t
+		}
+	}
+
+	return rr.Next(dtstart, dtstart)
+} // func (rr *RRule) Prev(dtstart, ref time.Time) time.Time
+
+// recurrence returns a Recurrence using one of the other repeat modes that
+// is equivalent to the rule, if there is one.
+func (rr *RRule) recurrence(dtstart time.Time) (Recurrence, bool) {
+	var rec Recurrence
+
+	if rr.Count > 0 || !rr.Until.IsZero() {
+		return rec, false
+	}
+
+	if s := rr.step(); s != 0 {
+		if len(rr.ByDay) > 0 || len(rr.ByMonthDay) > 0 || len(rr.ByMonth) > 0 {
+			return rec, false
+		}
+
+		rec.Repeat = repeat.Interval
+		rec.Offset = int(dtstart.Unix())
+		rec.Period = int(s / time.Second)
+		return rec, true
+	}
+
+	if rr.Interval != 1 {
+		return rec, false
+	}
+
+	// The other repeat modes store the time of day as wall clock time in
+	// the Reminder's time zone.
+	rec.Offset = secondsOfDay(dtstart)
+	rec.Fallback = FallbackSkip
+
+	switch rr.Freq {
+	case FreqDaily, FreqWeekly:
+		if len(rr.ByMonthDay) > 0 || len(rr.ByMonth) > 0 {
+			return rec, false
+		} else if rr.Freq == FreqDaily && len(rr.ByDay) == 0 {
+			rec.Repeat = repeat.Daily
+			rec.Fallback = FallbackClamp
+			return rec, true
+		} else if len(rr.ByDay) == 0 {
+			rec.Days[(dtstart.Weekday()+6)%7] = true
+		}
+
+		for _, wd := range rr.ByDay {
+			rec.Days[(wd.Day+6)%7] = true
+		}
+
+		rec.Repeat = repeat.Custom
+		rec.Fallback = FallbackClamp
+		return rec, true
+	case FreqMonthly:
+		if len(rr.ByMonth) > 0 || len(rr.ByMonthDay) > 1 ||
+			(len(rr.ByMonthDay) > 0 && len(rr.ByDay) > 0) {
+			return rec, false
+		} else if len(rr.ByMonthDay) == 1 {
+			if rr.ByMonthDay[0] < 0 {
+				return rec, false
+			}
+
+			rec.Repeat = repeat.Monthly
+			rec.Day = rr.ByMonthDay[0]
+			return rec, true
+		} else if len(rr.ByDay) == 0 {
+			rec.Repeat = repeat.Monthly
+			rec.Day = dtstart.Day()
+			return rec, true
+		}
+
+		rec.Week = rr.ByDay[0].N
+
+		for _, wd := range rr.ByDay {
+			if wd.N != rec.Week || wd.N < LastWeek || wd.N == 0 || wd.N > 5 {
+				return rec, false
+			}
+
+			rec.Days[(wd.Day+6)%7] = true
+		}
+
+		rec.Repeat = repeat.MonthlyWeekday
+		return rec, true
+	case FreqYearly:
+		if len(rr.ByDay) > 0 || len(rr.ByMonth) > 1 || len(rr.ByMonthDay) > 1 ||
+			(len(rr.ByMonthDay) == 1 && (len(rr.ByMonth) == 0 || rr.ByMonthDay[0] < 0)) {
+			return rec, false
+		}
+
+		rec.Repeat = repeat.Yearly
+		rec.Month = dtstart.Month()
+		rec.Day = dtstart.Day()
+
+		if len(rr.ByMonth) == 1 {
+			rec.Month = rr.ByMonth[0]
+		}
+
+		if len(rr.ByMonthDay) == 1 {
+			rec.Day = rr.ByMonthDay[0]
+		}
+
+		return rec, true
+	}
+
+	return rec, false
+} // func (rr *RRule) recurrence(dtstart time.Time) (Recurrence, bool)
+
+func civilDays(a, b time.Time) int {
+	var (
+		da = time.Date(a.Year(), a.Month(), a.Day(), 0, 0, 0, 0, time.UTC)
+		db = time.Date(b.Year(), b.Month(), b.Day(), 0, 0, 0, 0, time.UTC)
+	)
+
+	return int(db.Sub(da) / (time.Hour * 24))
+} // func civilDays(a, b time.Time) int
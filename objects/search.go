@@ -0,0 +1,32 @@
+// /home/krylon/go/src/github.com/blicero/theseus/objects/search.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 17. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <>
+
+package objects
+
+import "strings"
+
+// MatchStart and MatchEnd surround the parts of the title and the snippet
+// of a SearchResult that match the query.
+const (
+	MatchStart = "<mark>"
+	MatchEnd   = "</mark>"
+)
+
+// SearchResult is a Reminder that matches a search query. Title is the
+// title of the Reminder and Snippet the part of its description that best
+// matches the query, with the matching terms highlighted. Results with a
+// lower Rank are better matches.
+type SearchResult struct {
+	Reminder Reminder
+	Rank     float64
+	Title    string
+	Snippet  string
+}
+
+// StripMatches removes the highlighting from a title or snippet.
+func StripMatches(s string) string {
+	return strings.NewReplacer(MatchStart, "", MatchEnd, "").Replace(s)
+} // func StripMatches(s string) string
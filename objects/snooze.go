@@ -0,0 +1,194 @@
+// /home/krylon/go/src/github.com/blicero/theseus/objects/snooze.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 17. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <>
+
+package objects
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxSnoozeDelay is the longest a Notification can be put off in one go.
+const maxSnoozeDelay = 7 * 86400
+
+// Snooze is one of the choices the user has for putting off a
+// Notification: Either by a fixed number of seconds, or until a given time
+// of day on the following day, e.g. "tomorrow morning".
+type Snooze struct {
+	Delay    int
+	Tomorrow bool
+	At       int // Seconds after midnight, if Tomorrow is set.
+}
+
+// DefaultSnoozes are the choices we offer unless the user has configured
+// something else.
+var DefaultSnoozes = []Snooze{
+	{Delay: 300},
+	{Delay: 900},
+	{Delay: 3600},
+	{Tomorrow: true, At: 8 * 3600},
+}
+
+var (
+	snoozeSpanRe     = regexp.MustCompile(`^\+?((?:\d+[wdhm])+)$`)
+	snoozeTomorrowRe = regexp.MustCompile(`^tomorrow(?:\s+(?:(\w+)|(\d{1,2}):(\d{2})))?$`)
+)
+
+// snoozeDayTimes are the times of day we understand by name.
+var snoozeDayTimes = []struct {
+	name string
+	secs int
+}{
+	{"morning", 8 * 3600},
+	{"noon", 12 * 3600},
+	{"afternoon", 15 * 3600},
+	{"evening", 18 * 3600},
+}
+
+// ParseSnooze parses a single snooze choice, either a period of time like
+// "15m" or "1h30m", or "tomorrow", optionally followed by a time of day
+// like "tomorrow 07:30" or "tomorrow evening". Plain "tomorrow" means
+// tomorrow morning.
+func ParseSnooze(s string) (Snooze, error) {
+	var (
+		m     []string
+		item  = strings.ToLower(strings.TrimSpace(s))
+		delay int
+	)
+
+	if m = snoozeSpanRe.FindStringSubmatch(item); m != nil {
+		if delay = parseSpan(m[1]); delay <= 0 || delay > maxSnoozeDelay {
+			return Snooze{}, fmt.Errorf("Snooze %q is out of range", s)
+		}
+
+		return Snooze{Delay: delay}, nil
+	} else if m = snoozeTomorrowRe.FindStringSubmatch(item); m == nil {
+		return Snooze{}, fmt.Errorf("Invalid snooze %q, expected something like 15m or \"tomorrow morning\"", s)
+	}
+
+	switch {
+	case m[1] != "":
+		for _, t := range snoozeDayTimes {
+			if t.name == m[1] {
+				return Snooze{Tomorrow: true, At: t.secs}, nil
+			}
+		}
+
+		return Snooze{}, fmt.Errorf("Unknown time of day %q", m[1])
+	case m[2] != "":
+		var (
+			hour, _   = strconv.Atoi(m[2])
+			minute, _ = strconv.Atoi(m[3])
+		)
+
+		if hour > 23 || minute > 59 {
+			return Snooze{}, fmt.Errorf("Invalid time of day in snooze %q", s)
+		}
+
+		return Snooze{Tomorrow: true, At: hour*3600 + minute*60}, nil
+	default:
+		return Snooze{Tomorrow: true, At: snoozeDayTimes[0].secs}, nil
+	}
+} // func ParseSnooze(s string) (Snooze, error)
+
+// ParseSnoozes parses a comma-separated list of snooze choices, like
+// "5m, 15m, 1h, tomorrow morning".
+func ParseSnoozes(s string) ([]Snooze, error) {
+	var items []Snooze
+
+	for _, str := range strings.Split(s, ",") {
+		if str = strings.TrimSpace(str); str == "" {
+			continue
+		}
+
+		var (
+			err error
+			z   Snooze
+		)
+
+		if z, err = ParseSnooze(str); err != nil {
+			return nil, err
+		}
+
+		items = append(items, z)
+	}
+
+	return items, nil
+} // func ParseSnoozes(s string) ([]Snooze, error)
+
+// FormatSnoozes is the inverse of ParseSnoozes.
+func FormatSnoozes(items []Snooze) string {
+	var strs = make([]string, len(items))
+
+	for i, z := range items {
+		strs[i] = z.String()
+	}
+
+	return strings.Join(strs, ", ")
+} // func FormatSnoozes(items []Snooze) string
+
+// String returns the Snooze in the form ParseSnooze understands.
+func (z Snooze) String() string {
+	if !z.Tomorrow {
+		return formatSpan(z.Delay)
+	}
+
+	for _, t := range snoozeDayTimes {
+		if t.secs == z.At {
+			return "tomorrow " + t.name
+		}
+	}
+
+	return fmt.Sprintf("tomorrow %02d:%02d", z.At/3600, z.At%3600/60)
+} // func (z Snooze) String() string
+
+// Label returns a description of the Snooze suitable for a button.
+func (z Snooze) Label() string {
+	if z.Tomorrow {
+		return "T" + z.String()[1:]
+	}
+
+	return FormatLead(z.Delay)
+} // func (z Snooze) Label() string
+
+// Until returns the point in time a Notification snoozed at the given time
+// is due again. For "tomorrow", the day is determined by the location of
+// now.
+func (z Snooze) Until(now time.Time) time.Time {
+	if !z.Tomorrow {
+		return now.Add(time.Duration(z.Delay) * time.Second)
+	}
+
+	var y, m, d = now.Date()
+
+	return time.Date(y, m, d+1, z.At/3600, z.At%3600/60, 0, 0, now.Location())
+} // func (z Snooze) Until(now time.Time) time.Time
+
+// SnoozeString returns the Reminder's own snooze choices in the form we
+// store them in the database. An empty string means the Reminder uses the
+// global defaults.
+func (r *Reminder) SnoozeString() string {
+	return FormatSnoozes(r.Snooze)
+} // func (r *Reminder) SnoozeString() string
+
+// SetSnooze sets the Reminder's snooze choices from a string as returned by
+// SnoozeString.
+func (r *Reminder) SetSnooze(s string) error {
+	var (
+		err   error
+		items []Snooze
+	)
+
+	if items, err = ParseSnoozes(s); err != nil {
+		return err
+	}
+
+	r.Snooze = items
+	return nil
+} // func (r *Reminder) SetSnooze(s string) error
@@ -0,0 +1,143 @@
+// /home/krylon/go/src/github.com/blicero/theseus/objects/tag.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 17. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <>
+
+package objects
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Tag is a label that can be attached to any number of Reminders, for
+// grouping and filtering them. A Tag also carries defaults for the
+// Reminders it is attached to: They get at least the Tag's Priority, its
+// Channel is used from the first Notification on, and if the Tag is
+// exempt from quiet hours, so are the Reminders.
+type Tag struct {
+	ID          int64
+	Name        string
+	Priority    Priority
+	Channel     Channel
+	QuietExempt bool
+}
+
+// TagDefaults are the settings a Reminder inherits from its Tags.
+type TagDefaults struct {
+	Priority    Priority
+	Channel     Channel
+	QuietExempt bool
+}
+
+var tagRe = regexp.MustCompile(`^[\pL\pN][\pL\pN_./-]*$`)
+
+// ParseTags parses a list of Tag names, separated by commas and/or
+// whitespace. Names are case-insensitive, the result is in lower case,
+// sorted, and free of duplicates.
+func ParseTags(s string) ([]string, error) {
+	var (
+		tags []string
+		seen = make(map[string]bool)
+	)
+
+	for _, name := range strings.FieldsFunc(strings.ToLower(s), isTagSep) {
+		if !tagRe.MatchString(name) {
+			return nil, fmt.Errorf("Invalid tag %q", name)
+		} else if !seen[name] {
+			seen[name] = true
+			tags = append(tags, name)
+		}
+	}
+
+	sort.Strings(tags)
+
+	return tags, nil
+} // func ParseTags(s string) ([]string, error)
+
+func isTagSep(c rune) bool {
+	return c == ',' || c == ' ' || c == '\t' || c == '\n'
+} // func isTagSep(c rune) bool
+
+// FormatTags is the inverse of ParseTags.
+func FormatTags(tags []string) string {
+	return strings.Join(tags, ", ")
+} // func FormatTags(tags []string) string
+
+// TagsString returns the Reminder's Tags as a string.
+func (r *Reminder) TagsString() string {
+	return FormatTags(r.Tags)
+} // func (r *Reminder) TagsString() string
+
+// SetTags sets the Reminder's Tags from a string as returned by TagsString.
+func (r *Reminder) SetTags(s string) error {
+	var (
+		err  error
+		tags []string
+	)
+
+	if tags, err = ParseTags(s); err != nil {
+		return err
+	}
+
+	r.Tags = tags
+	return nil
+} // func (r *Reminder) SetTags(s string) error
+
+// HasTag returns true if the Reminder has the Tag with the given name.
+func (r *Reminder) HasTag(name string) bool {
+	name = strings.ToLower(name)
+
+	for _, t := range r.Tags {
+		if t == name {
+			return true
+		}
+	}
+
+	return false
+} // func (r *Reminder) HasTag(name string) bool
+
+// TagDefaults combines the defaults of the Reminder's Tags, looked up in
+// the given map. The highest Priority and the loudest Channel win.
+func (r *Reminder) TagDefaults(tags map[string]Tag) TagDefaults {
+	var def TagDefaults
+
+	for _, name := range r.Tags {
+		var (
+			t  Tag
+			ok bool
+		)
+
+		if t, ok = tags[name]; !ok {
+			continue
+		}
+
+		if t.Priority > def.Priority {
+			def.Priority = t.Priority
+		}
+
+		if t.Channel > def.Channel {
+			def.Channel = t.Channel
+		}
+
+		def.QuietExempt = def.QuietExempt || t.QuietExempt
+	}
+
+	return def
+} // func (r *Reminder) TagDefaults(tags map[string]Tag) TagDefaults
+
+// Validate checks if the Tag makes sense.
+func (t *Tag) Validate() error {
+	if !tagRe.MatchString(t.Name) || strings.ToLower(t.Name) != t.Name {
+		return fmt.Errorf("Invalid tag %q", t.Name)
+	} else if !t.Priority.Valid() {
+		return fmt.Errorf("Invalid priority %s for tag %q", t.Priority, t.Name)
+	} else if t.Channel > ChannelSound {
+		return fmt.Errorf("Invalid notification channel %s for tag %q", t.Channel, t.Name)
+	}
+
+	return nil
+} // func (t *Tag) Validate() error
@@ -0,0 +1,69 @@
+// /home/krylon/go/src/github.com/blicero/theseus/objects/tombstone.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 17. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <>
+
+package objects
+
+import (
+	"fmt"
+	"time"
+)
+
+// Tombstone records that the Reminder with the given UUID was deleted, so
+// the deletion can be passed on to Peers, rather than the Reminder coming
+// back from them. Deleted is the time the Reminder was deleted, Recorded
+// the time we learned about it.
+type Tombstone struct {
+	ID       int64
+	UUID     string
+	Deleted  time.Time
+	Recorded time.Time
+}
+
+func (t *Tombstone) String() string {
+	return fmt.Sprintf("Tombstone{ UUID: %s, Deleted: %s }",
+		t.UUID,
+		t.Deleted.Format(time.RFC3339))
+} // func (t *Tombstone) String() string
+
+// Supersedes returns true if the deletion happened after the last change
+// to the given Reminder. As with two versions of a Reminder, the more
+// recent change wins, but if in doubt, the deletion does.
+func (t *Tombstone) Supersedes(r *Reminder) bool {
+	return !r.Changed.After(t.Deleted)
+} // func (t *Tombstone) Supersedes(r *Reminder) bool
+
+// PeerState is what we remember about a Peer we synchronized with: Seen is
+// the time up to which it has received our Tombstones.
+type PeerState struct {
+	ID   int64
+	Name string
+	Seen time.Time
+}
+
+// TombstoneCutoff returns the time before which all known Peers have seen
+// our Tombstones, so Tombstones recorded before then can be discarded. If
+// there are no Peers, there is no such time, and ok is false.
+func TombstoneCutoff(peers []PeerState) (cutoff time.Time, ok bool) {
+	for i, p := range peers {
+		if i == 0 || p.Seen.Before(cutoff) {
+			cutoff = p.Seen
+		}
+	}
+
+	return cutoff, len(peers) > 0
+} // func TombstoneCutoff(peers []PeerState) (time.Time, bool)
+
+// SyncData is what two Peers exchange when they synchronize. Peer is the
+// name of the sender, Stamp the time it collected the data. When pushing
+// its changes, a Peer acknowledges the data it pulled before by sending
+// back its Stamp in Ack.
+type SyncData struct {
+	Peer       string
+	Stamp      time.Time
+	Ack        time.Time
+	Reminders  []Reminder
+	Tombstones []Tombstone
+}
@@ -0,0 +1,76 @@
+// /home/krylon/go/src/github.com/blicero/theseus/objects/zone.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 17. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <>
+
+package objects
+
+import (
+	"sync"
+	"time"
+)
+
+// zoneCache keeps the time zones we have loaded already, so we do not have
+// to hit the file system every time we compute a due time.
+var zoneCache sync.Map
+
+// Location returns the time zone the Reminder is evaluated in.
+// If the Reminder has no time zone, or if its time zone is not known
+// on this system, the system's local time zone is used.
+func (r *Reminder) Location() *time.Location {
+	if r.TimeZone == "" {
+		return time.Local
+	} else if loc, ok := zoneCache.Load(r.TimeZone); ok {
+		return loc.(*time.Location)
+	}
+
+	var (
+		err error
+		loc *time.Location
+	)
+
+	if loc, err = time.LoadLocation(r.TimeZone); err != nil {
+		return time.Local
+	}
+
+	zoneCache.Store(r.TimeZone, loc)
+	return loc
+} // func (r *Reminder) Location() *time.Location
+
+// secondsOfDay returns the wall clock time of t as seconds since midnight.
+func secondsOfDay(t time.Time) int {
+	return t.Hour()*3600 + t.Minute()*60 + t.Second()
+} // func secondsOfDay(t time.Time) int
+
+// wallClock returns the point in time when the wall clock in loc shows
+// offset seconds past midnight on the given day.
+// If the clock skips that time (when DST begins), we return the time it
+// would have been without the gap, i.e. 02:30 becomes 03:30. If the clock
+// shows that time twice (when DST ends), we return the earlier one.
+func wallClock(year int, month time.Month, day, offset int, loc *time.Location) time.Time {
+	var (
+		naive     = time.Date(year, month, day, 0, 0, offset, 0, time.UTC).Unix()
+		_, offA   = time.Unix(naive-86400, 0).In(loc).Zone()
+		_, offB   = time.Unix(naive+86400, 0).In(loc).Zone()
+		candA     = naive - int64(offA)
+		candB     = naive - int64(offB)
+		_, checkA = time.Unix(candA, 0).In(loc).Zone()
+		_, checkB = time.Unix(candB, 0).In(loc).Zone()
+		validA    = checkA == offA
+		validB    = checkB == offB
+		stamp     int64
+	)
+
+	if validA && validB && candB < candA {
+		stamp = candB
+	} else if validA {
+		stamp = candA
+	} else if validB {
+		stamp = candB
+	} else {
+		stamp = candA
+	}
+
+	return time.Unix(stamp, 0).In(loc)
+} // func wallClock(year int, month time.Month, day, offset int, loc *time.Location) time.Time
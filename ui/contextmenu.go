@@ -170,6 +170,14 @@ func (g *GUI) mkReminderContextMenu(path *gtk.TreePath, r *objects.Reminder) (*g
 	menu.Append(delItem)
 	menu.Append(toggleItem)
 
+	if r.Recur.Repeat != repeat.Once && !r.Finished {
+		if err = g.mkExceptionMenu(menu, r); err != nil {
+			msg = fmt.Sprintf("Cannot create menu items for Exceptions: %s",
+				err.Error())
+			goto ERROR
+		}
+	}
+
 	return menu, nil
 ERROR:
 	g.log.Printf("[ERROR] %s\n", msg)
@@ -362,11 +370,13 @@ func (g *GUI) handleReminderClickEdit() {
 
 BEGIN:
 	if r.Recur.Repeat == repeat.Once {
-		cal.SelectMonth(uint(r.Timestamp.Month())-1, uint(r.Timestamp.Year()))
-		cal.SelectDay(uint(r.Timestamp.Day()))
+		var stamp = r.Timestamp.In(r.Location())
+
+		cal.SelectMonth(uint(stamp.Month())-1, uint(stamp.Year()))
+		cal.SelectDay(uint(stamp.Day()))
 
-		hourInput.SetValue(float64(r.Timestamp.Hour()))
-		minuteInput.SetValue(float64(r.Timestamp.Minute()) + 10)
+		hourInput.SetValue(float64(stamp.Hour()))
+		minuteInput.SetValue(float64(stamp.Minute()) + 10)
 	} else {
 		var min, hour int
 
@@ -417,7 +427,7 @@ BEGIN:
 			min,
 			0,
 			0,
-			time.Local)
+			r.Location())
 	} else {
 		r.Recur = recEdit.GetRecurrence()
 		r.Timestamp = time.Unix(int64(r.Recur.Offset), 0).In(time.UTC)
@@ -514,7 +524,7 @@ BEGIN:
 
 		g.store.Set( // nolint: errcheck
 			iter,
-			[]int{0, 1, 2, 3, 5, 6},
+			[]int{0, 1, 2, 3, 5, 6, 7},
 			[]any{
 				r.ID,
 				r.Title,
@@ -522,6 +532,7 @@ BEGIN:
 				r.Recur.String(),
 				r.Finished,
 				cstr,
+				calendarLabel(&r),
 			},
 		)
 	} else {
@@ -744,3 +755,340 @@ func (g *GUI) handleReminderClickToggleActive() {
 		g.displayMsg(response.Message)
 	}
 } // func (g *GUI) handleReminderClickToggleActive()
+
+// mkExceptionMenu adds the menu items to skip or move the next occurrence
+// of a recurring Reminder, and to undo that.
+func (g *GUI) mkExceptionMenu(menu *gtk.Menu, r *objects.Reminder) error {
+	var (
+		err                error
+		sep                *gtk.SeparatorMenuItem
+		skipItem, moveItem *gtk.MenuItem
+		rem                = *r
+		next               = r.DueNext(nil)
+	)
+
+	if next.IsZero() {
+		return nil
+	} else if sep, err = gtk.SeparatorMenuItemNew(); err != nil {
+		return err
+	} else if skipItem, err = gtk.MenuItemNewWithMnemonic("_Skip next occurrence"); err != nil {
+		return err
+	} else if moveItem, err = gtk.MenuItemNewWithMnemonic("_Move next occurrence..."); err != nil {
+		return err
+	}
+
+	// If the next occurrence has been moved already, we modify the
+	// Exception rather than adding a new one.
+	var occ = next
+
+	for _, e := range rem.Exceptions {
+		if !e.Skip() && e.Due.Truncate(time.Minute).Equal(next) {
+			occ = e.Occurrence
+			break
+		}
+	}
+
+	skipItem.Connect("activate", func() {
+		var exc = objects.Exception{Occurrence: occ}
+
+		g.reminderExceptionAdd(&rem, &exc)
+	})
+
+	moveItem.Connect("activate", func() {
+		var exc = objects.Exception{
+			Occurrence: occ,
+			Due:        next,
+		}
+
+		if g.reminderExceptionEdit(&rem, &exc) {
+			g.reminderExceptionAdd(&rem, &exc)
+		}
+	})
+
+	menu.Append(sep)
+	menu.Append(skipItem)
+	menu.Append(moveItem)
+
+	if len(rem.Exceptions) == 0 {
+		return nil
+	}
+
+	var (
+		sub      *gtk.Menu
+		restItem *gtk.MenuItem
+	)
+
+	if sub, err = gtk.MenuNew(); err != nil {
+		return err
+	} else if restItem, err = gtk.MenuItemNewWithMnemonic("_Restore occurrence"); err != nil {
+		return err
+	}
+
+	for _, e := range rem.Exceptions {
+		var (
+			item  *gtk.MenuItem
+			exc   = e
+			label string
+		)
+
+		if exc.Skip() {
+			label = fmt.Sprintf("%s (skipped)",
+				exc.Occurrence.In(time.Local).Format(common.TimestampFormatMinute))
+		} else {
+			label = fmt.Sprintf("%s (moved to %s)",
+				exc.Occurrence.In(time.Local).Format(common.TimestampFormatMinute),
+				exc.Due.In(time.Local).Format(common.TimestampFormatMinute))
+		}
+
+		if item, err = gtk.MenuItemNewWithLabel(label); err != nil {
+			return err
+		}
+
+		item.Connect("activate", func() {
+			g.reminderExceptionDelete(&rem, &exc)
+		})
+
+		sub.Append(item)
+	}
+
+	restItem.SetSubmenu(sub)
+	menu.Append(restItem)
+
+	return nil
+} // func (g *GUI) mkExceptionMenu(menu *gtk.Menu, r *objects.Reminder) error
+
+// reminderExceptionEdit asks the user when an occurrence should be due
+// instead. It returns false if the user cancelled the dialog.
+func (g *GUI) reminderExceptionEdit(r *objects.Reminder, e *objects.Exception) bool {
+	var (
+		err                    error
+		dlg                    *gtk.Dialog
+		dbox                   *gtk.Box
+		grid                   *gtk.Grid
+		cal                    *gtk.Calendar
+		hourInput, minuteInput *gtk.SpinButton
+		timeLbl, sepLbl        *gtk.Label
+		loc                    = r.Location()
+		stamp                  = e.Due.In(loc)
+	)
+
+	if dlg, err = gtk.DialogNewWithButtons(
+		fmt.Sprintf("Move %q on %s",
+			r.Title,
+			e.Occurrence.In(loc).Format(common.TimestampFormatMinute)),
+		g.win,
+		gtk.DIALOG_MODAL,
+		[]any{
+			"_Cancel",
+			gtk.RESPONSE_CANCEL,
+			"_OK",
+			gtk.RESPONSE_OK,
+		},
+	); err != nil {
+		g.log.Printf("[ERROR] Failed to create Dialog: %s\n",
+			err.Error())
+		return false
+	}
+
+	defer dlg.Close()
+
+	if grid, err = gtk.GridNew(); err != nil {
+		g.log.Printf("[ERROR] Cannot create gtk.Grid: %s\n",
+			err.Error())
+		return false
+	} else if cal, err = gtk.CalendarNew(); err != nil {
+		g.log.Printf("[ERROR] Cannot create gtk.Calendar: %s\n",
+			err.Error())
+		return false
+	} else if hourInput, err = gtk.SpinButtonNewWithRange(0, 23, 1); err != nil {
+		g.log.Printf("[ERROR] Cannot create hourInput: %s\n",
+			err.Error())
+		return false
+	} else if minuteInput, err = gtk.SpinButtonNewWithRange(0, 59, 1); err != nil {
+		g.log.Printf("[ERROR] Cannot create minuteInput: %s\n",
+			err.Error())
+		return false
+	} else if timeLbl, err = gtk.LabelNew("Time"); err != nil {
+		g.log.Printf("[ERROR] Cannot create time label: %s\n",
+			err.Error())
+		return false
+	} else if sepLbl, err = gtk.LabelNew(":"); err != nil {
+		g.log.Printf("[ERROR] Cannot create separator label: %s\n",
+			err.Error())
+		return false
+	} else if dbox, err = dlg.GetContentArea(); err != nil {
+		g.log.Printf("[ERROR] Cannot get ContentArea of Dialog: %s\n",
+			err.Error())
+		return false
+	}
+
+	grid.InsertColumn(0)
+	grid.InsertColumn(1)
+	grid.InsertColumn(2)
+	grid.InsertColumn(3)
+	grid.InsertRow(0)
+	grid.InsertRow(1)
+
+	grid.Attach(cal, 0, 0, 4, 1)
+	grid.Attach(timeLbl, 0, 1, 1, 1)
+	grid.Attach(hourInput, 1, 1, 1, 1)
+	grid.Attach(sepLbl, 2, 1, 1, 1)
+	grid.Attach(minuteInput, 3, 1, 1, 1)
+
+	cal.SelectMonth(uint(stamp.Month())-1, uint(stamp.Year()))
+	cal.SelectDay(uint(stamp.Day()))
+	hourInput.SetValue(float64(stamp.Hour()))
+	minuteInput.SetValue(float64(stamp.Minute()))
+
+	dbox.PackStart(grid, true, true, 0)
+	dlg.ShowAll()
+
+BEGIN:
+	if res := dlg.Run(); res != gtk.RESPONSE_OK {
+		g.log.Printf("[DEBUG] User cancelled moving occurrence of Reminder %q: %d\n",
+			r.Title,
+			res)
+		return false
+	}
+
+	var year, month, day = cal.GetDate()
+
+	e.Due = time.Date(
+		int(year),
+		time.Month(month+1),
+		int(day),
+		hourInput.GetValueAsInt(),
+		minuteInput.GetValueAsInt(),
+		0,
+		0,
+		loc)
+
+	if e.Due.Before(time.Now()) {
+		var msg = fmt.Sprintf("The time you selected is in the past: %s",
+			e.Due.Format(common.TimestampFormat))
+		g.displayMsg(msg)
+		g.log.Printf("[ERROR] %s\n", msg)
+		goto BEGIN
+	}
+
+	return true
+} // func (g *GUI) reminderExceptionEdit(r *objects.Reminder, e *objects.Exception) bool
+
+// reminderExceptionAdd submits an Exception for a Reminder to the backend.
+func (g *GUI) reminderExceptionAdd(r *objects.Reminder, e *objects.Exception) {
+	var (
+		err      error
+		reply    *http.Response
+		response objects.Response
+		rcvBuf   bytes.Buffer
+		sndBuf   []byte
+		addr     = fmt.Sprintf("http://%s%s",
+			g.srv,
+			fmt.Sprintf(uriExceptionAdd, r.ID))
+		payload = make(url.Values)
+	)
+
+	if sndBuf, err = ffjson.Marshal(e); err != nil {
+		g.log.Printf("[ERROR] Cannot serialize Exception: %s\n",
+			err.Error())
+		return
+	}
+
+	payload["exception"] = []string{string(sndBuf)}
+
+	if reply, err = g.web.PostForm(addr, payload); err != nil {
+		g.log.Printf("[ERROR] Failed to submit Exception to Backend: %s\n",
+			err.Error())
+		return
+	} else if reply.StatusCode != 200 {
+		g.log.Printf("[ERROR] Backend responds with status %s\n",
+			reply.Status)
+		return
+	}
+
+	defer reply.Body.Close() // nolint: errcheck
+
+	if _, err = io.Copy(&rcvBuf, reply.Body); err != nil {
+		g.log.Printf("[ERROR] Cannot read HTTP reply from backend: %s\n",
+			err.Error())
+		return
+	} else if err = ffjson.Unmarshal(rcvBuf.Bytes(), &response); err != nil {
+		g.log.Printf("[ERROR] Cannot de-serialize Response from JSON: %s\n",
+			err.Error())
+		return
+	}
+
+	g.log.Printf("[DEBUG] Got response from backend: %#v\n",
+		response)
+
+	if response.Status {
+		g.pushMsg(response.Message)
+		g.refreshReminders()
+	} else {
+		g.log.Printf("[ERROR] Failed to add %s to Reminder %q in backend: %s\n",
+			e,
+			r.Title,
+			response.Message)
+		g.pushMsg(response.Message)
+		g.displayMsg(response.Message)
+	}
+} // func (g *GUI) reminderExceptionAdd(r *objects.Reminder, e *objects.Exception)
+
+// reminderExceptionDelete asks the backend to remove an Exception from
+// a Reminder.
+func (g *GUI) reminderExceptionDelete(r *objects.Reminder, e *objects.Exception) {
+	var (
+		err      error
+		msg      string
+		reply    *http.Response
+		response objects.Response
+		buf      bytes.Buffer
+		addr     = fmt.Sprintf("http://%s%s",
+			g.srv,
+			fmt.Sprintf(uriExceptionDelete, r.ID, e.ID))
+	)
+
+	g.log.Printf("[TRACE] GET %s\n", addr)
+
+	if reply, err = g.web.Get(addr); err != nil {
+		msg = fmt.Sprintf("Failed to GET %s: %s",
+			addr,
+			err.Error())
+		g.log.Printf("[ERROR] %s\n", msg)
+		g.pushMsg(msg)
+		return
+	} else if reply.StatusCode != 200 {
+		msg = fmt.Sprintf("Unexpected HTTP status from server: %s",
+			reply.Status)
+		g.log.Printf("[ERROR] %s\n", msg)
+		g.pushMsg(msg)
+		return
+	}
+
+	defer reply.Body.Close() // nolint: errcheck
+
+	if _, err = io.Copy(&buf, reply.Body); err != nil {
+		g.log.Printf("[ERROR] Cannot read HTTP reply from backend: %s\n",
+			err.Error())
+		return
+	} else if err = ffjson.Unmarshal(buf.Bytes(), &response); err != nil {
+		g.log.Printf("[ERROR] Cannot de-serialize Response from JSON: %s\n",
+			err.Error())
+		return
+	}
+
+	g.log.Printf("[DEBUG] Got response from backend: %#v\n",
+		response)
+
+	if response.Status {
+		g.pushMsg(response.Message)
+		g.refreshReminders()
+	} else {
+		g.log.Printf("[ERROR] Failed to remove %s from Reminder %q in backend: %s\n",
+			e,
+			r.Title,
+			response.Message)
+		g.pushMsg(response.Message)
+		g.displayMsg(response.Message)
+	}
+} // func (g *GUI) reminderExceptionDelete(r *objects.Reminder, e *objects.Exception)
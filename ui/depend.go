@@ -0,0 +1,163 @@
+// /home/krylon/go/src/github.com/blicero/theseus/ui/depend.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 17. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <>
+
+package ui
+
+import (
+	"fmt"
+	"log"
+	"sort"
+
+	"github.com/blicero/theseus/objects"
+	"github.com/gotk3/gotk3/gtk"
+)
+
+// depNone is the entry in the list of prerequisites that means the row
+// does not hold a Dependency.
+const depNone = "(None)"
+
+// depRow holds the widgets for editing a single Dependency.
+type depRow struct {
+	prereqCombo, kindCombo *gtk.ComboBoxText
+	delayEntry             *gtk.Entry
+}
+
+// DepEditor lets the user edit the Dependencies of a Reminder. It shows
+// one row per Dependency, plus an empty one for adding a new Dependency.
+type DepEditor struct {
+	log  *log.Logger
+	deps []objects.Dependency
+	grid *gtk.Grid
+	rows []depRow
+}
+
+// NewDepEditor creates an editor for the Dependencies of the given
+// Reminder. The user can pick the prerequisites among the given Reminders.
+func NewDepEditor(r *objects.Reminder, reminders map[int64]objects.Reminder, l *log.Logger) (*DepEditor, error) {
+	var (
+		err   error
+		known = make(map[string]bool, len(reminders))
+		cands = make([]objects.Reminder, 0, len(reminders))
+		e     = &DepEditor{
+			log:  l,
+			deps: r.Depends,
+		}
+	)
+
+	for _, c := range reminders {
+		if c.UUID != r.UUID {
+			cands = append(cands, c)
+			known[c.UUID] = true
+		}
+	}
+
+	sort.Slice(cands, func(i, j int) bool { return cands[i].Title < cands[j].Title })
+
+	if e.grid, err = gtk.GridNew(); err != nil {
+		e.log.Printf("[ERROR] Cannot create gtk.Grid: %s\n",
+			err.Error())
+		return nil, err
+	}
+
+	e.grid.SetColumnSpacing(2)
+
+	for i := 0; i <= len(r.Depends); i++ {
+		var row depRow
+
+		if row.prereqCombo, err = gtk.ComboBoxTextNew(); err != nil {
+			e.log.Printf("[ERROR] Cannot create gtk.ComboBoxText: %s\n",
+				err.Error())
+			return nil, err
+		} else if row.kindCombo, err = gtk.ComboBoxTextNew(); err != nil {
+			e.log.Printf("[ERROR] Cannot create gtk.ComboBoxText: %s\n",
+				err.Error())
+			return nil, err
+		} else if row.delayEntry, err = gtk.EntryNew(); err != nil {
+			e.log.Printf("[ERROR] Cannot create gtk.Entry: %s\n",
+				err.Error())
+			return nil, err
+		}
+
+		row.prereqCombo.Append("", depNone)
+		for _, c := range cands {
+			row.prereqCombo.Append(c.UUID, c.Title)
+		}
+
+		row.kindCombo.Append(objects.DepAfter.String(), "After it is finished")
+		row.kindCombo.Append(objects.DepFollowUp.String(), "Follow-up, delay:")
+		row.delayEntry.SetPlaceholderText("e.g. 14d")
+		row.delayEntry.SetWidthChars(8)
+
+		if i < len(r.Depends) {
+			var dep = &r.Depends[i]
+
+			// The prerequisite may have been deleted, or not
+			// have been synchronized, yet.
+			if !known[dep.Prereq] {
+				row.prereqCombo.Append(dep.Prereq, fmt.Sprintf("(Unknown: %s)", dep.Prereq))
+			}
+
+			row.prereqCombo.SetActiveID(dep.Prereq)
+			row.kindCombo.SetActiveID(dep.Kind.String())
+			if dep.Kind == objects.DepFollowUp {
+				row.delayEntry.SetText(objects.FormatDelay(dep.Delay))
+			}
+		} else {
+			row.prereqCombo.SetActiveID("")
+			row.kindCombo.SetActiveID(objects.DepAfter.String())
+		}
+
+		var delay = row.delayEntry
+		delay.SetSensitive(row.kindCombo.GetActiveID() == objects.DepFollowUp.String())
+		row.kindCombo.Connect("changed", func(c *gtk.ComboBoxText) {
+			delay.SetSensitive(c.GetActiveID() == objects.DepFollowUp.String())
+		})
+
+		e.grid.Attach(row.prereqCombo, 0, i, 1, 1)
+		e.grid.Attach(row.kindCombo, 1, i, 1, 1)
+		e.grid.Attach(row.delayEntry, 2, i, 1, 1)
+		e.rows = append(e.rows, row)
+	}
+
+	return e, nil
+} // func NewDepEditor(r *objects.Reminder, reminders map[int64]objects.Reminder, l *log.Logger) (*DepEditor, error)
+
+// GetDependencies returns the Dependencies the user has entered.
+func (e *DepEditor) GetDependencies() ([]objects.Dependency, error) {
+	var deps []objects.Dependency
+
+	for _, row := range e.rows {
+		var (
+			err   error
+			txt   string
+			dep   objects.Dependency
+			delay = row.delayEntry
+		)
+
+		if dep.Prereq = row.prereqCombo.GetActiveID(); dep.Prereq == "" {
+			continue
+		} else if dep.Kind, err = objects.ParseDepKind(row.kindCombo.GetActiveID()); err != nil {
+			return nil, err
+		} else if dep.Kind == objects.DepFollowUp {
+			txt, _ = delay.GetText()
+			if dep.Delay, err = objects.ParseDelay(txt); err != nil {
+				return nil, err
+			}
+		}
+
+		// A follow-up that has come up before keeps its state, unless
+		// the user changed what it follows up on.
+		for _, old := range e.deps {
+			if old.Prereq == dep.Prereq && old.Kind == dep.Kind {
+				dep.Triggered = old.Triggered
+			}
+		}
+
+		deps = append(deps, dep)
+	}
+
+	return deps, nil
+} // func (e *DepEditor) GetDependencies() ([]objects.Dependency, error)
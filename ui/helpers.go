@@ -7,11 +7,17 @@
 package ui
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
+	"io"
+	"net/http"
+	"net/url"
 
+	"github.com/blicero/theseus/objects"
 	"github.com/gotk3/gotk3/glib"
 	"github.com/gotk3/gotk3/gtk"
+	"github.com/pquerna/ffjson/ffjson"
 )
 
 ////////////////////////////////////////////////////////////////////////////////
@@ -130,6 +136,87 @@ func (g *GUI) yesOrNo(title, question string) (bool, error) {
 	return answer, nil
 } // func (g *GUI) yesOrNo(question string) (bool, error)
 
+// callBackend sends a request to the backend and returns its Response. If
+// payload is nil, it sends a GET request, otherwise it POSTs the payload.
+func (g *GUI) callBackend(uri string, payload url.Values) (*objects.Response, error) {
+	var (
+		err      error
+		reply    *http.Response
+		buf      bytes.Buffer
+		response objects.Response
+		addr     = fmt.Sprintf("http://%s%s", g.srv, uri)
+	)
+
+	if payload == nil {
+		reply, err = g.web.Get(addr)
+	} else {
+		reply, err = g.web.PostForm(addr, payload)
+	}
+
+	if err != nil {
+		g.log.Printf("[ERROR] Failed to send request to backend for %s: %s\n",
+			addr,
+			err.Error())
+		return nil, err
+	}
+
+	defer reply.Body.Close() // nolint: errcheck
+
+	if reply.StatusCode != 200 {
+		err = fmt.Errorf("Unexpected HTTP status from backend: %s",
+			reply.Status)
+		g.log.Printf("[ERROR] %s\n", err.Error())
+		return nil, err
+	} else if _, err = io.Copy(&buf, reply.Body); err != nil {
+		g.log.Printf("[ERROR] Cannot read HTTP reply from backend: %s\n",
+			err.Error())
+		return nil, err
+	} else if err = ffjson.Unmarshal(buf.Bytes(), &response); err != nil {
+		g.log.Printf("[ERROR] Cannot de-serialize Response from JSON: %s\n",
+			err.Error())
+		return nil, err
+	}
+
+	return &response, nil
+} // func (g *GUI) callBackend(uri string, payload url.Values) (*objects.Response, error)
+
+// getJSON fetches a JSON document from the backend and de-serializes it
+// into v.
+func (g *GUI) getJSON(uri string, v any) error {
+	var (
+		err   error
+		reply *http.Response
+		buf   bytes.Buffer
+		addr  = fmt.Sprintf("http://%s%s", g.srv, uri)
+	)
+
+	if reply, err = g.web.Get(addr); err != nil {
+		g.log.Printf("[ERROR] Failed to send request to backend for %s: %s\n",
+			addr,
+			err.Error())
+		return err
+	}
+
+	defer reply.Body.Close() // nolint: errcheck
+
+	if reply.StatusCode != 200 {
+		err = fmt.Errorf("Unexpected HTTP status from backend: %s",
+			reply.Status)
+		g.log.Printf("[ERROR] %s\n", err.Error())
+		return err
+	} else if _, err = io.Copy(&buf, reply.Body); err != nil {
+		g.log.Printf("[ERROR] Cannot read HTTP reply from backend: %s\n",
+			err.Error())
+		return err
+	} else if err = ffjson.Unmarshal(buf.Bytes(), v); err != nil {
+		g.log.Printf("[ERROR] Cannot de-serialize reply from JSON: %s\n",
+			err.Error())
+		return err
+	}
+
+	return nil
+} // func (g *GUI) getJSON(uri string, v any) error
+
 func (g *GUI) pushMsg(msg string) {
 	g.statusbar.Push(msgID, msg)
 } // func (g *GUI) pushMsg(msg string)
@@ -219,3 +306,35 @@ func responseTypeStr(t gtk.ResponseType) string {
 			t)
 	}
 } // func responseTypeStr(t gtk.ResponseType) string
+
+// getTextViewText returns the full text of a TextView.
+func getTextViewText(v *gtk.TextView) (string, error) {
+	var (
+		err        error
+		buf        *gtk.TextBuffer
+		start, end *gtk.TextIter
+	)
+
+	if buf, err = v.GetBuffer(); err != nil {
+		return "", err
+	}
+
+	start, end = buf.GetBounds()
+
+	return buf.GetText(start, end, false)
+} // func getTextViewText(v *gtk.TextView) (string, error)
+
+// setTextViewText replaces the text of a TextView.
+func setTextViewText(v *gtk.TextView, s string) error {
+	var (
+		err error
+		buf *gtk.TextBuffer
+	)
+
+	if buf, err = v.GetBuffer(); err != nil {
+		return err
+	}
+
+	buf.SetText(s)
+	return nil
+} // func setTextViewText(v *gtk.TextView, s string) error
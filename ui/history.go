@@ -0,0 +1,305 @@
+// /home/krylon/go/src/github.com/blicero/theseus/ui/history.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 17. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <>
+
+package ui
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/blicero/theseus/common"
+	"github.com/blicero/theseus/objects"
+	"github.com/gotk3/gotk3/glib"
+	"github.com/gotk3/gotk3/gtk"
+)
+
+// The columns of the statistics and the history in the history pane.
+// The first column of the statistics holds the ID of the Reminder and is
+// not displayed.
+var (
+	statCols = []string{
+		"ID",
+		"Title",
+		"Occurrences",
+		"Acknowledged",
+		"Missed",
+		"Snoozes",
+		"On time",
+		"Streak",
+		"Best streak",
+		"Avg. latency",
+	}
+	histCols = []string{
+		"Due",
+		"Shown",
+		"Acknowledged",
+		"Display delay",
+		"Ack delay",
+		"Snoozes",
+		"State",
+	}
+)
+
+// fmtLatency formats a delay for display in the history pane.
+func fmtLatency(d time.Duration) string {
+	if d <= 0 {
+		return ""
+	}
+
+	return objects.FormatDelay(int(d.Seconds()))
+} // func fmtLatency(d time.Duration) string
+
+// fmtStamp formats a timestamp for display in the history pane.
+func fmtStamp(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+
+	return t.Format(common.TimestampFormatMinute)
+} // func fmtStamp(t time.Time) string
+
+// histState describes the state of an occurrence for the history pane.
+func histState(h *objects.HistoryItem) string {
+	switch {
+	case h.Missed:
+		return "missed"
+	case h.Pending():
+		return "pending"
+	case h.OnTime(objects.OnTimeWindow):
+		return "on time"
+	default:
+		return "late"
+	}
+} // func histState(h *objects.HistoryItem) string
+
+// mkHistoryView creates a TreeView to display the given columns, all but
+// the first one of type string. If hideFirst is true, the first column is
+// not displayed.
+func mkHistoryView(titles []string, first glib.Type, hideFirst bool) (*gtk.ListStore, *gtk.TreeView, error) {
+	var (
+		err   error
+		store *gtk.ListStore
+		view  *gtk.TreeView
+		types = make([]glib.Type, len(titles))
+	)
+
+	types[0] = first
+	for i := 1; i < len(types); i++ {
+		types[i] = glib.TYPE_STRING
+	}
+
+	if store, err = gtk.ListStoreNew(types...); err != nil {
+		return nil, nil, err
+	} else if view, err = gtk.TreeViewNewWithModel(store); err != nil {
+		return nil, nil, err
+	}
+
+	for i, title := range titles {
+		var col *gtk.TreeViewColumn
+
+		if i == 0 && hideFirst {
+			continue
+		} else if col, _, err = createCol(title, i); err != nil {
+			return nil, nil, err
+		}
+
+		view.AppendColumn(col)
+	}
+
+	return store, view, nil
+} // func mkHistoryView(titles []string, first glib.Type, hideFirst bool) (*gtk.ListStore, *gtk.TreeView, error)
+
+// showHistory displays the statistics of all Reminders, the ones the user
+// snoozed most often, and the history of the Reminder selected in the
+// list of statistics.
+func (g *GUI) showHistory() {
+	var (
+		err                    error
+		msg                    string
+		dlg                    *gtk.Dialog
+		dbox                   *gtk.Box
+		pane                   *gtk.Paned
+		sumLbl                 *gtk.Label
+		statScr, histScr       *gtk.ScrolledWindow
+		statStore, histStore   *gtk.ListStore
+		statView, histView     *gtk.TreeView
+		sel                    *gtk.TreeSelection
+		summary                objects.HistorySummary
+		snoozed                []string
+		statValues, histValues = make([]any, len(statCols)), make([]any, len(histCols))
+		statIdx, histIdx       = make([]int, len(statCols)), make([]int, len(histCols))
+	)
+
+	if err = g.getJSON(uriHistoryStats, &summary); err != nil {
+		msg = fmt.Sprintf("Cannot load statistics: %s", err.Error())
+		g.pushMsg(msg)
+		g.displayMsg(msg)
+		return
+	}
+
+	if dlg, err = gtk.DialogNewWithButtons(
+		"History",
+		g.win,
+		gtk.DIALOG_MODAL,
+		[]any{
+			"_Close",
+			gtk.RESPONSE_CLOSE,
+		},
+	); err != nil {
+		g.log.Printf("[ERROR] Failed to create Dialog: %s\n",
+			err.Error())
+		return
+	}
+
+	defer dlg.Close()
+
+	if pane, err = gtk.PanedNew(gtk.ORIENTATION_VERTICAL); err != nil {
+		g.log.Printf("[ERROR] Cannot create gtk.Paned: %s\n",
+			err.Error())
+		return
+	} else if sumLbl, err = gtk.LabelNew(""); err != nil {
+		g.log.Printf("[ERROR] Cannot create summary Label: %s\n",
+			err.Error())
+		return
+	} else if statScr, err = gtk.ScrolledWindowNew(nil, nil); err != nil {
+		g.log.Printf("[ERROR] Cannot create ScrolledWindow: %s\n",
+			err.Error())
+		return
+	} else if histScr, err = gtk.ScrolledWindowNew(nil, nil); err != nil {
+		g.log.Printf("[ERROR] Cannot create ScrolledWindow: %s\n",
+			err.Error())
+		return
+	} else if statStore, statView, err = mkHistoryView(statCols, glib.TYPE_INT, true); err != nil {
+		g.log.Printf("[ERROR] Cannot create TreeView for statistics: %s\n",
+			err.Error())
+		return
+	} else if histStore, histView, err = mkHistoryView(histCols, glib.TYPE_STRING, false); err != nil {
+		g.log.Printf("[ERROR] Cannot create TreeView for history: %s\n",
+			err.Error())
+		return
+	} else if sel, err = statView.GetSelection(); err != nil {
+		g.log.Printf("[ERROR] Cannot get TreeSelection: %s\n",
+			err.Error())
+		return
+	} else if dbox, err = dlg.GetContentArea(); err != nil {
+		g.log.Printf("[ERROR] Cannot get ContentArea of Dialog: %s\n",
+			err.Error())
+		return
+	}
+
+	for i := range statIdx {
+		statIdx[i] = i
+	}
+	for i := range histIdx {
+		histIdx[i] = i
+	}
+
+	for _, s := range summary.Reminders {
+		var streak string
+
+		if s.BestStreak > 0 {
+			streak = strconv.Itoa(s.Streak)
+		}
+
+		statValues[0] = s.ReminderID
+		statValues[1] = s.Title
+		statValues[2] = strconv.Itoa(s.Occurrences)
+		statValues[3] = strconv.Itoa(s.Acknowledged)
+		statValues[4] = strconv.Itoa(s.Missed)
+		statValues[5] = strconv.Itoa(s.Snoozes)
+		statValues[6] = fmt.Sprintf("%.0f%%", s.OnTimePercent())
+		statValues[7] = streak
+		statValues[8] = strconv.Itoa(s.BestStreak)
+		statValues[9] = fmtLatency(s.AckLatency)
+
+		if err = statStore.Set(statStore.Append(), statIdx, statValues); err != nil {
+			g.log.Printf("[ERROR] Cannot add statistics of Reminder %d: %s\n",
+				s.ReminderID,
+				err.Error())
+			return
+		}
+	}
+
+	for _, s := range summary.MostSnoozed {
+		snoozed = append(snoozed, fmt.Sprintf("%s (%d)", s.Title, s.Snoozes))
+	}
+
+	msg = fmt.Sprintf("Since %s: %.0f%% on time",
+		summary.From.Format(common.TimestampFormatDate),
+		summary.OnTimePercent())
+	if len(snoozed) > 0 {
+		msg += fmt.Sprintf(" -- Most snoozed: %s", strings.Join(snoozed, ", "))
+	}
+
+	sumLbl.SetText(msg)
+	sumLbl.SetLineWrap(true)
+
+	sel.SetMode(gtk.SELECTION_SINGLE)
+	sel.Connect("changed", func(s *gtk.TreeSelection) {
+		var (
+			err   error
+			model gtk.ITreeModel
+			iter  *gtk.TreeIter
+			ok    bool
+			gval  *glib.Value
+			rval  any
+			hist  objects.History
+		)
+
+		histStore.Clear()
+
+		if model, iter, ok = s.GetSelected(); !ok || iter == nil {
+			return
+		} else if gval, err = model.ToTreeModel().GetValue(iter, 0); err != nil {
+			g.log.Printf("[ERROR] Error getting Column ID: %s\n",
+				err.Error())
+			return
+		} else if rval, err = gval.GoValue(); err != nil {
+			g.log.Printf("[ERROR] Error getting GoValue from glib.Value: %s\n",
+				err.Error())
+			return
+		} else if err = g.getJSON(fmt.Sprintf(uriReminderHistory, rval.(int), url.QueryEscape(summary.From.Format(time.RFC3339))), &hist); err != nil {
+			g.pushMsg(fmt.Sprintf("Cannot load history of Reminder %d: %s",
+				rval.(int),
+				err.Error()))
+			return
+		}
+
+		// Most recent occurrences first.
+		for i := len(hist.Items) - 1; i >= 0; i-- {
+			var h = &hist.Items[i]
+
+			histValues[0] = fmtStamp(h.Due)
+			histValues[1] = fmtStamp(h.Shown)
+			histValues[2] = fmtStamp(h.Acknowledged)
+			histValues[3] = fmtLatency(h.DisplayLatency())
+			histValues[4] = fmtLatency(h.AckLatency())
+			histValues[5] = strconv.Itoa(h.Snoozes)
+			histValues[6] = histState(h)
+
+			if err = histStore.Set(histStore.Append(), histIdx, histValues); err != nil {
+				g.log.Printf("[ERROR] Cannot add occurrence of Reminder %d: %s\n",
+					h.ReminderID,
+					err.Error())
+				return
+			}
+		}
+	})
+
+	statScr.Add(statView)
+	histScr.Add(histView)
+	pane.Pack1(statScr, true, false)
+	pane.Pack2(histScr, true, false)
+
+	dbox.PackStart(sumLbl, false, false, 1)
+	dbox.PackStart(pane, true, true, 1)
+	dlg.SetSizeRequest(800, 600)
+	dlg.ShowAll()
+	dlg.Run()
+} // func (g *GUI) showHistory()
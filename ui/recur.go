@@ -10,12 +10,41 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/blicero/theseus/objects"
 	"github.com/blicero/theseus/objects/repeat"
 	"github.com/gotk3/gotk3/gtk"
 )
 
+// weekName contains the labels for the ordinals a MonthlyWeekday Recurrence
+// can use. The last entry corresponds to objects.LastWeek.
+var weekName = []string{
+	"1.",
+	"2.",
+	"3.",
+	"4.",
+	"5.",
+	"Last",
+}
+
+// periodUnit contains the units the period of an Interval Recurrence can be
+// entered in, along with their length in seconds.
+var periodUnit = []struct {
+	name string
+	secs int
+}{
+	{"Minutes", 60},
+	{"Hours", 3600},
+	{"Days", 86400},
+}
+
+// untilFormat is the format in which the end date of a Recurrence is
+// entered. A Recurrence ends at the end of that day.
+const untilFormat = "2006-01-02"
+
 var dayName = [7]string{
 	"Mo",
 	"Di",
@@ -26,19 +55,84 @@ var dayName = [7]string{
 	"So",
 }
 
+// calNone is the entry in the list of holiday calendars that means the
+// Recurrence does not use one.
+const calNone = "(No holidays)"
+
+// holidayRules contains the choices for dealing with holidays, in the order
+// of their numeric values.
+var holidayRules = []struct {
+	label string
+	rule  objects.HolidayRule
+}{
+	{"Ignore holidays", objects.HolidayIgnore},
+	{"Skip holidays", objects.HolidaySkip},
+	{"Shift to next business day", objects.HolidayShift},
+}
+
+// timeFormat is the format in which additional times of day are entered.
+const timeFormat = "15:04"
+
+// fmtTimes formats a list of times of day (in seconds past midnight) for
+// display.
+func fmtTimes(times []int) string {
+	var items = make([]string, len(times))
+
+	for i, t := range times {
+		items[i] = fmt.Sprintf("%02d:%02d", t/3600, (t%3600)/60)
+	}
+
+	return strings.Join(items, ", ")
+} // func fmtTimes(times []int) string
+
+// parseTimes parses a comma-separated list of times of day, as formatted
+// by fmtTimes.
+func parseTimes(s string) ([]int, error) {
+	var times []int
+
+	for _, item := range strings.Split(s, ",") {
+		var (
+			err error
+			t   time.Time
+		)
+
+		if item = strings.TrimSpace(item); item == "" {
+			continue
+		} else if t, err = time.Parse(timeFormat, item); err != nil {
+			return nil, err
+		}
+
+		times = append(times, t.Hour()*3600+t.Minute()*60)
+	}
+
+	sort.Ints(times)
+	return times, nil
+} // func parseTimes(s string) ([]int, error)
+
+// hasStartTime returns true if Reminders using the given repeat mode have a
+// start date and time (as opposed to just a time of day).
+func hasStartTime(r repeat.Repeat) bool {
+	return r == repeat.Once || r == repeat.Interval || r == repeat.RRule
+} // func hasStartTime(r repeat.Repeat) bool
+
 // RecurEditor is a sorta-kinda custom widget for editing Recurrences
 // of Reminders.
 // I don't think I can create "real" custom widgets in Go, but I'll
 // try to create something reusable.
 type RecurEditor struct {
-	log                        *log.Logger
-	rec                        *objects.Recurrence
-	box                        *gtk.Box
-	oBox, tBox, cntBox, dayBox *gtk.Box
-	rtCombo                    *gtk.ComboBoxText
-	offMin, offHour            *gtk.SpinButton
-	cntEdit                    *gtk.SpinButton
-	weekdays                   [7]*gtk.CheckButton
+	log                                *log.Logger
+	rec                                *objects.Recurrence
+	box                                *gtk.Box
+	oBox, tBox, cntBox, dayBox, monBox *gtk.Box
+	periodBox, ruleBox, calBox         *gtk.Box
+	rtCombo, weekCombo, monthCombo     *gtk.ComboBoxText
+	unitCombo, calCombo, holCombo      *gtk.ComboBoxText
+	offMin, offHour                    *gtk.SpinButton
+	cntEdit, mdayEdit, periodEdit      *gtk.SpinButton
+	fallbackCB, untilCB                *gtk.CheckButton
+	ruleEntry, untilEntry, timesEntry  *gtk.Entry
+	cntLbl                             *gtk.Label
+	weekdays                           [7]*gtk.CheckButton
 }
 
 // NewRecurEditor creates and returns a fresh Editor for Recurrences.
@@ -77,6 +171,22 @@ func NewRecurEditor(r *objects.Recurrence, l *log.Logger) (*RecurEditor, error)
 		e.log.Printf("[ERROR] Cannot create gtk.Box: %s\n",
 			err.Error())
 		return nil, err
+	} else if e.monBox, err = gtk.BoxNew(gtk.ORIENTATION_HORIZONTAL, 1); err != nil {
+		e.log.Printf("[ERROR] Cannot create gtk.Box: %s\n",
+			err.Error())
+		return nil, err
+	} else if e.periodBox, err = gtk.BoxNew(gtk.ORIENTATION_HORIZONTAL, 1); err != nil {
+		e.log.Printf("[ERROR] Cannot create gtk.Box: %s\n",
+			err.Error())
+		return nil, err
+	} else if e.ruleBox, err = gtk.BoxNew(gtk.ORIENTATION_HORIZONTAL, 1); err != nil {
+		e.log.Printf("[ERROR] Cannot create gtk.Box: %s\n",
+			err.Error())
+		return nil, err
+	} else if e.ruleEntry, err = gtk.EntryNew(); err != nil {
+		e.log.Printf("[ERROR] Cannot create gtk.Entry: %s\n",
+			err.Error())
+		return nil, err
 	} else if e.rtCombo, err = gtk.ComboBoxTextNew(); err != nil {
 		e.log.Printf("[ERROR] Cannot create gtk.ComboBoxText: %s\n",
 			err.Error())
@@ -93,11 +203,108 @@ func NewRecurEditor(r *objects.Recurrence, l *log.Logger) (*RecurEditor, error)
 		e.log.Printf("[ERROR] Cannot create gtk.SpinButton: %s\n",
 			err.Error())
 		return nil, err
+	} else if e.mdayEdit, err = gtk.SpinButtonNewWithRange(1, 31, 1); err != nil {
+		e.log.Printf("[ERROR] Cannot create gtk.SpinButton: %s\n",
+			err.Error())
+		return nil, err
+	} else if e.weekCombo, err = gtk.ComboBoxTextNew(); err != nil {
+		e.log.Printf("[ERROR] Cannot create gtk.ComboBoxText: %s\n",
+			err.Error())
+		return nil, err
+	} else if e.monthCombo, err = gtk.ComboBoxTextNew(); err != nil {
+		e.log.Printf("[ERROR] Cannot create gtk.ComboBoxText: %s\n",
+			err.Error())
+		return nil, err
+	} else if e.periodEdit, err = gtk.SpinButtonNewWithRange(1, 9999, 1); err != nil {
+		e.log.Printf("[ERROR] Cannot create gtk.SpinButton: %s\n",
+			err.Error())
+		return nil, err
+	} else if e.unitCombo, err = gtk.ComboBoxTextNew(); err != nil {
+		e.log.Printf("[ERROR] Cannot create gtk.ComboBoxText: %s\n",
+			err.Error())
+		return nil, err
+	} else if e.fallbackCB, err = gtk.CheckButtonNewWithLabel("Skip if the day does not exist"); err != nil {
+		e.log.Printf("[ERROR] Cannot create gtk.CheckButton: %s\n",
+			err.Error())
+		return nil, err
+	} else if e.cntLbl, err = gtk.LabelNew("Times (0 = unlimited)"); err != nil {
+		e.log.Printf("[ERROR] Cannot create gtk.Label: %s\n",
+			err.Error())
+		return nil, err
+	} else if e.untilCB, err = gtk.CheckButtonNewWithLabel("Until"); err != nil {
+		e.log.Printf("[ERROR] Cannot create gtk.CheckButton: %s\n",
+			err.Error())
+		return nil, err
+	} else if e.untilEntry, err = gtk.EntryNew(); err != nil {
+		e.log.Printf("[ERROR] Cannot create gtk.Entry: %s\n",
+			err.Error())
+		return nil, err
+	} else if e.timesEntry, err = gtk.EntryNew(); err != nil {
+		e.log.Printf("[ERROR] Cannot create gtk.Entry: %s\n",
+			err.Error())
+		return nil, err
+	} else if e.calBox, err = gtk.BoxNew(gtk.ORIENTATION_HORIZONTAL, 1); err != nil {
+		e.log.Printf("[ERROR] Cannot create gtk.Box: %s\n",
+			err.Error())
+		return nil, err
+	} else if e.calCombo, err = gtk.ComboBoxTextNew(); err != nil {
+		e.log.Printf("[ERROR] Cannot create gtk.ComboBoxText: %s\n",
+			err.Error())
+		return nil, err
+	} else if e.holCombo, err = gtk.ComboBoxTextNew(); err != nil {
+		e.log.Printf("[ERROR] Cannot create gtk.ComboBoxText: %s\n",
+			err.Error())
+		return nil, err
 	}
 
 	e.rtCombo.AppendText(repeat.Once.String())
 	e.rtCombo.AppendText(repeat.Daily.String())
 	e.rtCombo.AppendText(repeat.Custom.String())
+	e.rtCombo.AppendText(repeat.Monthly.String())
+	e.rtCombo.AppendText(repeat.MonthlyWeekday.String())
+	e.rtCombo.AppendText(repeat.Yearly.String())
+	e.rtCombo.AppendText(repeat.Interval.String())
+	e.rtCombo.AppendText(repeat.RRule.String())
+
+	for _, w := range weekName {
+		e.weekCombo.AppendText(w)
+	}
+	e.weekCombo.SetActive(0)
+
+	for m := time.January; m <= time.December; m++ {
+		e.monthCombo.AppendText(m.String())
+	}
+	e.monthCombo.SetActive(0)
+
+	for _, u := range periodUnit {
+		e.unitCombo.AppendText(u.name)
+	}
+	e.unitCombo.SetActive(0)
+
+	// The first entry means "no calendar". If the Reminder uses a
+	// calendar we do not know (e.g. one that only exists on a peer),
+	// we add it anyway, so editing the Reminder does not lose it.
+	var calNames = append([]string{calNone}, objects.CalendarNames()...)
+
+	if e.rec.Calendar != "" && objects.GetCalendar(e.rec.Calendar) == nil {
+		calNames = append(calNames, e.rec.Calendar)
+	}
+
+	for i, name := range calNames {
+		e.calCombo.AppendText(name)
+		if name == e.rec.Calendar {
+			e.calCombo.SetActive(i)
+		}
+	}
+
+	if e.rec.Calendar == "" {
+		e.calCombo.SetActive(0)
+	}
+
+	for _, h := range holidayRules {
+		e.holCombo.AppendText(h.label)
+	}
+	e.holCombo.SetActive(int(e.rec.Holidays))
 
 	for i := range e.weekdays {
 		if e.weekdays[i], err = gtk.CheckButtonNewWithLabel(dayName[i]); err != nil {
@@ -112,24 +319,92 @@ func NewRecurEditor(r *objects.Recurrence, l *log.Logger) (*RecurEditor, error)
 	e.box.PackStart(e.tBox, true, true, 0)
 	e.box.PackStart(e.cntBox, true, true, 0)
 	e.box.PackStart(e.dayBox, true, true, 0)
+	e.box.PackStart(e.monBox, true, true, 0)
+	e.box.PackStart(e.periodBox, true, true, 0)
+	e.box.PackStart(e.ruleBox, true, true, 0)
+	e.box.PackStart(e.calBox, true, true, 0)
 
 	e.oBox.PackStart(e.offHour, true, true, 0)
 	e.oBox.PackStart(e.offMin, true, true, 0)
+	e.oBox.PackStart(e.timesEntry, true, true, 0)
+	e.timesEntry.SetPlaceholderText("More times, e.g. 12:00, 18:30")
 
 	e.rtCombo.SetActive(0)
 	e.rtCombo.Connect("changed", e.handleModeChange)
 
 	e.tBox.PackStart(e.rtCombo, true, true, 0)
 	e.cntBox.PackStart(e.cntEdit, true, true, 0)
+	e.cntBox.PackStart(e.cntLbl, true, true, 0)
+	e.cntBox.PackStart(e.untilCB, true, true, 0)
+	e.cntBox.PackStart(e.untilEntry, true, true, 0)
+	e.untilEntry.SetPlaceholderText(untilFormat)
+	e.untilCB.Connect("toggled", func() {
+		e.untilEntry.SetSensitive(e.untilCB.GetActive())
+	})
+
+	e.cntEdit.SetValue(float64(e.rec.Limit))
+	if e.rec.Counter > 0 {
+		e.cntLbl.SetText(fmt.Sprintf("Times (0 = unlimited, %d done)",
+			e.rec.Counter))
+	}
+
+	if !e.rec.Until.IsZero() {
+		e.untilCB.SetActive(true)
+		e.untilEntry.SetText(e.rec.Until.Local().Format(untilFormat))
+	} else {
+		e.untilEntry.SetSensitive(false)
+	}
 	for _, v := range e.weekdays {
 		e.dayBox.PackStart(v, true, true, 0)
 	}
+	e.monBox.PackStart(e.mdayEdit, true, true, 0)
+	e.monBox.PackStart(e.monthCombo, true, true, 0)
+	e.monBox.PackStart(e.weekCombo, true, true, 0)
+	e.monBox.PackStart(e.fallbackCB, true, true, 0)
+	e.periodBox.PackStart(e.periodEdit, true, true, 0)
+	e.periodBox.PackStart(e.unitCombo, true, true, 0)
+	e.ruleBox.PackStart(e.ruleEntry, true, true, 0)
+	e.calBox.PackStart(e.calCombo, true, true, 0)
+	e.calBox.PackStart(e.holCombo, true, true, 0)
+	e.ruleEntry.SetPlaceholderText("FREQ=MONTHLY;BYDAY=-1FR")
 
 	var min, hour int
 
 	switch e.rec.Repeat {
 	case repeat.Once:
 		// Nothing to do here, move along
+	case repeat.RRule:
+		e.ruleEntry.SetText(e.rec.Rule)
+	case repeat.Interval:
+		// The anchor is edited in the dialog, just like the due time of
+		// a one-time Reminder, so we only care for the period.
+		var idx = 0
+
+		for i, u := range periodUnit {
+			if e.rec.Period%u.secs == 0 {
+				idx = i
+			}
+		}
+
+		e.unitCombo.SetActive(idx)
+		e.periodEdit.SetValue(float64(e.rec.Period / periodUnit[idx].secs))
+	case repeat.Monthly, repeat.MonthlyWeekday, repeat.Yearly:
+		if e.rec.Day > 0 {
+			e.mdayEdit.SetValue(float64(e.rec.Day))
+		}
+
+		if e.rec.Month >= time.January {
+			e.monthCombo.SetActive(int(e.rec.Month - time.January))
+		}
+
+		if e.rec.Week == objects.LastWeek {
+			e.weekCombo.SetActive(len(weekName) - 1)
+		} else if e.rec.Week > 0 {
+			e.weekCombo.SetActive(e.rec.Week - 1)
+		}
+
+		e.fallbackCB.SetActive(e.rec.Fallback == objects.FallbackSkip)
+		fallthrough
 	case repeat.Custom:
 		for i, f := range e.rec.Days {
 			e.weekdays[i].SetActive(f)
@@ -141,6 +416,7 @@ func NewRecurEditor(r *objects.Recurrence, l *log.Logger) (*RecurEditor, error)
 
 		e.offHour.SetValue(float64(hour))
 		e.offMin.SetValue(float64(min))
+		e.timesEntry.SetText(fmtTimes(e.rec.Times))
 	default:
 		e.log.Printf("[CANTHAPPEN] Invalid recurrence type: %d\n",
 			r.Repeat)
@@ -154,18 +430,88 @@ func (e *RecurEditor) handleModeChange() {
 	case repeat.Once.String():
 		e.cntBox.Hide()
 		e.dayBox.Hide()
+		e.monBox.Hide()
+		e.periodBox.Hide()
+		e.ruleBox.Hide()
 		e.offMin.SetSensitive(false)
 		e.offHour.SetSensitive(false)
+		e.timesEntry.SetSensitive(false)
+		e.calBox.SetSensitive(false)
+	case repeat.RRule.String():
+		e.cntBox.ShowAll()
+		e.dayBox.Hide()
+		e.monBox.Hide()
+		e.periodBox.Hide()
+		e.ruleBox.ShowAll()
+		e.offMin.SetSensitive(false)
+		e.offHour.SetSensitive(false)
+		e.timesEntry.SetSensitive(false)
+		e.calBox.SetSensitive(false)
+	case repeat.Interval.String():
+		e.cntBox.ShowAll()
+		e.dayBox.Hide()
+		e.monBox.Hide()
+		e.periodBox.ShowAll()
+		e.ruleBox.Hide()
+		e.offMin.SetSensitive(false)
+		e.offHour.SetSensitive(false)
+		e.timesEntry.SetSensitive(false)
+		e.calBox.SetSensitive(false)
 	case repeat.Daily.String():
 		e.cntBox.ShowAll()
 		e.dayBox.Hide()
+		e.monBox.Hide()
+		e.periodBox.Hide()
+		e.ruleBox.Hide()
 		e.offMin.SetSensitive(true)
 		e.offHour.SetSensitive(true)
+		e.timesEntry.SetSensitive(true)
+		e.calBox.SetSensitive(true)
 	case repeat.Custom.String():
 		e.cntBox.ShowAll()
 		e.dayBox.ShowAll()
+		e.monBox.Hide()
+		e.periodBox.Hide()
+		e.ruleBox.Hide()
 		e.offMin.SetSensitive(true)
 		e.offHour.SetSensitive(true)
+		e.timesEntry.SetSensitive(true)
+		e.calBox.SetSensitive(true)
+	case repeat.Monthly.String():
+		e.cntBox.ShowAll()
+		e.dayBox.Hide()
+		e.monBox.ShowAll()
+		e.periodBox.Hide()
+		e.ruleBox.Hide()
+		e.weekCombo.Hide()
+		e.monthCombo.Hide()
+		e.offMin.SetSensitive(true)
+		e.offHour.SetSensitive(true)
+		e.timesEntry.SetSensitive(true)
+		e.calBox.SetSensitive(true)
+	case repeat.MonthlyWeekday.String():
+		e.cntBox.ShowAll()
+		e.dayBox.ShowAll()
+		e.monBox.ShowAll()
+		e.periodBox.Hide()
+		e.ruleBox.Hide()
+		e.mdayEdit.Hide()
+		e.monthCombo.Hide()
+		e.offMin.SetSensitive(true)
+		e.offHour.SetSensitive(true)
+		e.timesEntry.SetSensitive(true)
+		e.calBox.SetSensitive(true)
+	case repeat.Yearly.String():
+		e.cntBox.ShowAll()
+		e.dayBox.Hide()
+		e.monBox.ShowAll()
+		e.periodBox.Hide()
+		e.ruleBox.Hide()
+		e.weekCombo.Hide()
+		e.offMin.SetSensitive(true)
+		e.offHour.SetSensitive(true)
+		e.timesEntry.SetSensitive(true)
+		e.calBox.SetSensitive(true)
 	default:
 		e.log.Printf("[CANTHAPPEN] %q is not a valid recurrence type!\n",
 			txt)
@@ -180,6 +526,16 @@ func (e *RecurEditor) GetRecurrence() objects.Recurrence {
 		e.rec.Repeat = repeat.Daily
 	case repeat.Custom.String():
 		e.rec.Repeat = repeat.Custom
+	case repeat.Monthly.String():
+		e.rec.Repeat = repeat.Monthly
+	case repeat.MonthlyWeekday.String():
+		e.rec.Repeat = repeat.MonthlyWeekday
+	case repeat.Yearly.String():
+		e.rec.Repeat = repeat.Yearly
+	case repeat.Interval.String():
+		e.rec.Repeat = repeat.Interval
+	case repeat.RRule.String():
+		e.rec.Repeat = repeat.RRule
 	default:
 		var msg = fmt.Sprintf("%q is not a valid recurrence type!",
 			txt)
@@ -193,5 +549,91 @@ func (e *RecurEditor) GetRecurrence() objects.Recurrence {
 		e.rec.Days[i] = b.GetActive()
 	}
 
+	e.rec.Day = 0
+	e.rec.Week = 0
+	e.rec.Month = 0
+	e.rec.Period = 0
+	e.rec.Rule = ""
+	e.rec.Fallback = objects.FallbackClamp
+
+	switch e.rec.Repeat {
+	case repeat.Monthly:
+		e.rec.Day = e.mdayEdit.GetValueAsInt()
+	case repeat.Interval:
+		e.rec.Period = e.periodEdit.GetValueAsInt() *
+			periodUnit[e.unitCombo.GetActive()].secs
+	case repeat.RRule:
+		e.rec.Rule, _ = e.ruleEntry.GetText()
+	case repeat.Yearly:
+		e.rec.Day = e.mdayEdit.GetValueAsInt()
+		e.rec.Month = time.Month(e.monthCombo.GetActive()) + time.January
+	case repeat.MonthlyWeekday:
+		if idx := e.weekCombo.GetActive(); idx == len(weekName)-1 {
+			e.rec.Week = objects.LastWeek
+		} else {
+			e.rec.Week = idx + 1
+		}
+	}
+
+	e.rec.Times = nil
+
+	if e.rec.DailyTimes() {
+		var (
+			err   error
+			text  string
+			times []int
+		)
+
+		text, _ = e.timesEntry.GetText()
+
+		if times, err = parseTimes(text); err != nil {
+			e.log.Printf("[ERROR] Cannot parse times of day %q: %s\n",
+				text,
+				err.Error())
+		} else {
+			e.rec.Times = times
+		}
+	}
+
+	e.rec.Calendar = ""
+	e.rec.Holidays = objects.HolidayIgnore
+
+	if e.rec.DailyTimes() && e.calCombo.GetActive() > 0 {
+		e.rec.Calendar = e.calCombo.GetActiveText()
+		e.rec.Holidays = holidayRules[e.holCombo.GetActive()].rule
+	}
+
+	e.rec.Limit = 0
+	e.rec.Until = time.Time{}
+
+	if e.rec.Repeat != repeat.Once {
+		e.rec.Limit = e.cntEdit.GetValueAsInt()
+
+		if e.untilCB.GetActive() {
+			var (
+				err  error
+				day  time.Time
+				text string
+			)
+
+			text, _ = e.untilEntry.GetText()
+
+			if day, err = time.ParseInLocation(untilFormat, text, time.Local); err != nil {
+				e.log.Printf("[ERROR] Cannot parse end date %q: %s\n",
+					text,
+					err.Error())
+			} else {
+				e.rec.Until = day.AddDate(0, 0, 1).Add(-time.Second)
+			}
+		}
+	}
+
+	if e.fallbackCB.GetActive() &&
+		(e.rec.Repeat == repeat.Monthly ||
+			e.rec.Repeat == repeat.MonthlyWeekday ||
+			e.rec.Repeat == repeat.Yearly) {
+		e.rec.Fallback = objects.FallbackSkip
+	}
+
 	return *e.rec
 } // func (e *RecurEditor) GetRecurrence() objects.Alarmclock
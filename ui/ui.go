@@ -9,6 +9,7 @@ package ui
 import (
 	"bytes"
 	_ "embed" // for the icon
+	"encoding/json"
 	"fmt"
 	"io"
 	"log"
@@ -16,6 +17,8 @@ import (
 	"net/url"
 	"os"
 	"os/signal"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"syscall"
@@ -42,11 +45,25 @@ const (
 	maxSpawnAttempts       = 3
 	uriGetAll              = "/reminder/all"
 	uriReminderAdd         = "/reminder/add"
+	uriReminderQuickAdd    = "/reminder/quickadd"
 	uriReminderDelete      = "/reminder/%d/delete"
 	uriReminderEdit        = "/reminder/%d/update"
 	uriReminderReactivate  = "/reminder/%d/reactivate"
 	uriReminderSetFinished = "/reminder/%d/set_finished/%t"
+	uriExceptionAdd        = "/reminder/%d/exception"
+	uriExceptionDelete     = "/reminder/%d/exception/%d/delete"
 	uriPeerListGet         = "/peer/all"
+	uriSettingsSnooze      = "/settings/snooze"
+	uriDNDStatus           = "/dnd/status"
+	uriDNDSchedule         = "/dnd/schedule"
+	uriDNDSet              = "/dnd/%t"
+	uriTagAll              = "/tag/all"
+	uriTagSet              = "/tag/set"
+	uriTagDelete           = "/tag/%s/delete"
+	uriReminderHistory     = "/reminder/%d/history?from=%s"
+	uriHistoryStats        = "/history/stats"
+	uriReminderSearch      = "/reminder/search?q=%s"
+	uriRevisionUndo        = "/revision/undo"
 )
 
 type column struct {
@@ -99,8 +116,63 @@ var cols = []column{
 		display: true,
 		edit:    false,
 	},
+	column{
+		colType: glib.TYPE_STRING,
+		title:   "Calendar",
+		display: true,
+		edit:    false,
+	},
+	column{
+		colType: glib.TYPE_STRING,
+		title:   "Priority",
+		display: true,
+		edit:    false,
+	},
+	column{
+		colType: glib.TYPE_STRING,
+		title:   "Tags",
+		display: true,
+		edit:    false,
+	},
 }
 
+// Indices of the columns we need to refer to.
+const (
+	colTime     = 2
+	colPriority = 8
+)
+
+// calendarLabel describes how a Reminder deals with holidays, for display.
+func calendarLabel(r *objects.Reminder) string {
+	if r.Recur.Calendar == "" || r.Recur.Holidays == objects.HolidayIgnore {
+		return ""
+	} else if objects.GetCalendar(r.Recur.Calendar) == nil {
+		return fmt.Sprintf("%s (%s, not found)",
+			r.Recur.Calendar,
+			r.Recur.Holidays)
+	}
+
+	return fmt.Sprintf("%s (%s)",
+		r.Recur.Calendar,
+		r.Recur.Holidays)
+} // func calendarLabel(r *objects.Reminder) string
+
+// getEscalation reads the escalation policy from the inputs in the edit
+// dialogs. The interval is entered in minutes. Since there is only one
+// louder channel, a non-zero threshold means we play a sound.
+func getEscalation(interval, threshold *gtk.SpinButton) objects.Escalation {
+	var e = objects.Escalation{
+		Interval:  interval.GetValueAsInt() * 60,
+		Threshold: threshold.GetValueAsInt(),
+	}
+
+	if e.Threshold > 0 {
+		e.Channel = objects.ChannelSound
+	}
+
+	return e
+} // func getEscalation(interval, threshold *gtk.SpinButton) objects.Escalation
+
 func createCol(title string, id int) (*gtk.TreeViewColumn, *gtk.CellRendererText, error) {
 	renderer, err := gtk.CellRendererTextNew()
 	if err != nil {
@@ -133,12 +205,17 @@ type GUI struct {
 	view         *gtk.TreeView
 	scr          *gtk.ScrolledWindow
 	menuBar      *gtk.MenuBar
+	quickEntry   *gtk.Entry
+	searchEntry  *gtk.SearchEntry
 	statusbar    *gtk.Statusbar
 	fMenu        *gtk.Menu // nolint: unused,structcheck
 	web          http.Client
 	reminders    map[int64]objects.Reminder
 	peers        map[string]objects.Peer
 	hideFinished bool
+	minPriority  objects.Priority
+	tagFilter    []string
+	searchHits   map[int64]bool
 }
 
 // Create creates a new GUI instance ready to be used. Call the Run() method
@@ -148,9 +225,10 @@ func Create(srv string) (*GUI, error) {
 		err    error
 		pixbuf *gdk.Pixbuf
 		win    = &GUI{
-			srv:       srv,
-			reminders: make(map[int64]objects.Reminder),
-			peers:     make(map[string]objects.Peer),
+			srv:         srv,
+			reminders:   make(map[int64]objects.Reminder),
+			peers:       make(map[string]objects.Peer),
+			minPriority: objects.PriorityLow,
 		}
 	)
 
@@ -182,6 +260,19 @@ func Create(srv string) (*GUI, error) {
 		win.log.Printf("[ERROR] Cannot create Status bar: %s\n",
 			err.Error())
 		return nil, err
+	} else if win.quickEntry, err = gtk.EntryNew(); err != nil {
+		win.log.Printf("[ERROR] Cannot create Entry for quick add: %s\n",
+			err.Error())
+		return nil, err
+	} else if win.searchEntry, err = gtk.SearchEntryNew(); err != nil {
+		win.log.Printf("[ERROR] Cannot create SearchEntry: %s\n",
+			err.Error())
+		return nil, err
+	}
+
+	if err = objects.LoadCalendars(common.HolidayDir); err != nil {
+		win.log.Printf("[ERROR] Cannot load holiday calendars: %s\n",
+			err.Error())
 	}
 
 	// Initialize data store and TreeView
@@ -204,7 +295,8 @@ func Create(srv string) (*GUI, error) {
 	win.store.SetDefaultSortFunc(win.reminderCmpFunc)
 	win.store.SetSortFunc(2, win.reminderCmpFunc)
 	win.store.SetSortFunc(3, win.reminderCmpFunc)
-	win.store.SetSortColumnId(2, gtk.SORT_ASCENDING)
+	win.store.SetSortFunc(colPriority, win.reminderCmpPriority)
+	win.store.SetSortColumnId(colTime, gtk.SORT_ASCENDING)
 	//win.view.SetReorderable(true)
 
 	for i, c := range cols {
@@ -233,10 +325,16 @@ func Create(srv string) (*GUI, error) {
 	win.win.Add(win.mainBox)
 	win.scr.Add(win.view)
 	win.mainBox.PackStart(win.menuBar, false, false, 1)
+	win.mainBox.PackStart(win.quickEntry, false, false, 1)
+	win.mainBox.PackStart(win.searchEntry, false, false, 1)
 	win.mainBox.PackStart(win.scr, true, true, 1)
 	win.mainBox.PackStart(win.statusbar, false, false, 1)
 
 	win.win.Connect("destroy", gtk.MainQuit)
+	win.quickEntry.SetPlaceholderText("Quick add, e.g. \"call mom tomorrow at 9\" or \"jeden Montag um 7 Uhr Müll\"")
+	win.quickEntry.Connect("activate", win.reminderQuickAdd)
+	win.searchEntry.SetPlaceholderText("Search, e.g. dent or \"call the dentist\"")
+	win.searchEntry.Connect("search-changed", win.reminderSearch)
 	// win.win.Connect("key-press-event", win.handleKeyPressEvent)
 
 	if err = win.initTree(); err != nil {
@@ -281,8 +379,11 @@ func (g *GUI) initMenu() error {
 		fMenu, rMenu                         *gtk.Menu
 		srvItem, quitItem, addItem, editItem *gtk.MenuItem
 		fItem, rItem, rrItem, delItem        *gtk.MenuItem
-		hideFinItem                          *gtk.CheckMenuItem
-		syncItem, refreshItem                *gtk.MenuItem
+		hideFinItem, sortPrioItem            *gtk.CheckMenuItem
+		syncItem, refreshItem, prioItem      *gtk.MenuItem
+		snoozeItem, quietItem, tagItem       *gtk.MenuItem
+		tagFilterItem, histItem, undoItem    *gtk.MenuItem
+		prioMenu                             *gtk.Menu
 	)
 
 	if fMenu, err = gtk.MenuNew(); err != nil {
@@ -297,6 +398,18 @@ func (g *GUI) initMenu() error {
 		g.log.Printf("[ERROR] Cannot create menu item SRV: %s\n",
 			err.Error())
 		return err
+	} else if snoozeItem, err = gtk.MenuItemNewWithMnemonic("S_nooze Choices"); err != nil {
+		g.log.Printf("[ERROR] Cannot create menu item SNOOZE: %s\n",
+			err.Error())
+		return err
+	} else if quietItem, err = gtk.MenuItemNewWithMnemonic("Quiet _Hours"); err != nil {
+		g.log.Printf("[ERROR] Cannot create menu item QUIET: %s\n",
+			err.Error())
+		return err
+	} else if tagItem, err = gtk.MenuItemNewWithMnemonic("_Tags"); err != nil {
+		g.log.Printf("[ERROR] Cannot create menu item TAGS: %s\n",
+			err.Error())
+		return err
 	} else if quitItem, err = gtk.MenuItemNewWithMnemonic("_Quit"); err != nil {
 		g.log.Printf("[ERROR] Cannot create menu item QUIT: %s\n",
 			err.Error())
@@ -337,27 +450,85 @@ func (g *GUI) initMenu() error {
 		g.log.Printf("[ERROR] Cannot create menu item REFRESH: %s\n",
 			err.Error())
 		return err
+	} else if sortPrioItem, err = gtk.CheckMenuItemNewWithMnemonic("Sort by _Priority"); err != nil {
+		g.log.Printf("[ERROR] Cannot create menu item SORT_PRIORITY: %s\n",
+			err.Error())
+		return err
+	} else if prioItem, err = gtk.MenuItemNewWithMnemonic("Minimum Priorit_y"); err != nil {
+		g.log.Printf("[ERROR] Cannot create menu item MIN_PRIORITY: %s\n",
+			err.Error())
+		return err
+	} else if tagFilterItem, err = gtk.MenuItemNewWithMnemonic("Filter by _Tags"); err != nil {
+		g.log.Printf("[ERROR] Cannot create menu item TAG_FILTER: %s\n",
+			err.Error())
+		return err
+	} else if histItem, err = gtk.MenuItemNewWithMnemonic("_History"); err != nil {
+		g.log.Printf("[ERROR] Cannot create menu item HISTORY: %s\n",
+			err.Error())
+		return err
+	} else if undoItem, err = gtk.MenuItemNewWithMnemonic("_Undo"); err != nil {
+		g.log.Printf("[ERROR] Cannot create menu item UNDO: %s\n",
+			err.Error())
+		return err
+	} else if prioMenu, err = gtk.MenuNew(); err != nil {
+		g.log.Printf("[ERROR] Cannot create Menu Priority: %s\n",
+			err.Error())
+		return err
+	}
+
+	for _, p := range objects.Priorities {
+		var (
+			item *gtk.MenuItem
+			prio = p
+		)
+
+		if item, err = gtk.MenuItemNewWithLabel(prio.String()); err != nil {
+			g.log.Printf("[ERROR] Cannot create menu item for priority %s: %s\n",
+				prio,
+				err.Error())
+			return err
+		}
+
+		item.Connect("activate", func() { g.reminderFilterPriority(prio) })
+		prioMenu.Append(item)
 	}
 
 	quitItem.Connect("activate", gtk.MainQuit)
 	srvItem.Connect("activate", g.setServer)
+	snoozeItem.Connect("activate", g.setSnooze)
+	quietItem.Connect("activate", g.setQuietHours)
+	tagItem.Connect("activate", g.editTags)
+	tagFilterItem.Connect("activate", g.reminderFilterTags)
 	addItem.Connect("activate", g.reminderAdd)
 	editItem.Connect("activate", g.reminderEdit)
 	refreshItem.Connect("activate", g.refreshReminders)
 	rrItem.Connect("activate", g.reminderReactivate)
 	delItem.Connect("activate", g.reminderDelete)
 	hideFinItem.Connect("activate", g.reminderHideFinished)
+	sortPrioItem.Connect("toggled", func() { g.reminderSortPriority(sortPrioItem.GetActive()) })
 	syncItem.Connect("activate", g.synchronize)
+	histItem.Connect("activate", g.showHistory)
+	undoItem.Connect("activate", g.reminderUndo)
 
 	fMenu.Append(srvItem)
+	fMenu.Append(snoozeItem)
+	fMenu.Append(quietItem)
+	fMenu.Append(tagItem)
 	fMenu.Append(quitItem)
+	rMenu.Append(undoItem)
 	rMenu.Append(addItem)
 	rMenu.Append(editItem)
 	rMenu.Append(refreshItem)
 	rMenu.Append(rrItem)
 	rMenu.Append(delItem)
 	rMenu.Append(syncItem)
+	rMenu.Append(histItem)
 	rMenu.Append(hideFinItem)
+	rMenu.Append(sortPrioItem)
+	rMenu.Append(prioItem)
+	rMenu.Append(tagFilterItem)
+
+	prioItem.SetSubmenu(prioMenu)
 
 	g.menuBar.Append(fItem)
 	g.menuBar.Append(rItem)
@@ -460,6 +631,11 @@ func (g *GUI) fetchReminders() (repeat bool) {
 		return true
 	}
 
+	if err = objects.LoadCalendars(common.HolidayDir); err != nil {
+		g.log.Printf("[ERROR] Cannot load holiday calendars: %s\n",
+			err.Error())
+	}
+
 	var list = make([]objects.Reminder, 0, 64)
 
 	if err = ffjson.Unmarshal(body[:rsize], &list); err != nil {
@@ -480,9 +656,12 @@ func (g *GUI) fetchReminders() (repeat bool) {
 		var (
 			ok   bool
 			iter *gtk.TreeIter
-			tstr = r.DueNext(nil).Format(common.TimestampFormat)
+			tstr = r.DueNext(nil).Local().Format(common.TimestampFormat)
 			cstr = r.Changed.Format(common.TimestampFormat)
 			rstr = r.Recur.String()
+			hstr = calendarLabel(&r)
+			pstr = r.Priority.String()
+			gstr = r.TagsString()
 		)
 
 		idList[r.ID] = true
@@ -493,18 +672,21 @@ func (g *GUI) fetchReminders() (repeat bool) {
 
 			g.store.Set( // nolint: errcheck
 				iter,
-				[]int{0, 1, 2, 3, 4, 5, 6},
-				[]any{r.ID, r.Title, tstr, rstr, r.Finished, r.UUID, cstr},
+				[]int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9},
+				[]any{r.ID, r.Title, tstr, rstr, r.Finished, r.UUID, cstr, hstr, pstr, gstr},
 			)
 		} else if iter, err = g.getIter(r.ID); err != nil || iter == nil {
 			g.log.Printf("{ERROR] Could not get TreeIter for Reminder #%d\n",
 				r.ID)
 			continue
 		} else {
+			// The sort and filter functions look at the Reminders
+			// in the map, so we need to keep them up to date.
+			g.reminders[r.ID] = r
 			g.store.Set( // nolint: errcheck
 				iter,
-				[]int{0, 1, 2, 3, 4, 5, 6},
-				[]any{r.ID, r.Title, tstr, rstr, r.Finished, r.UUID, cstr},
+				[]int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9},
+				[]any{r.ID, r.Title, tstr, rstr, r.Finished, r.UUID, cstr, hstr, pstr, gstr},
 			)
 		}
 	}
@@ -712,23 +894,33 @@ func (g *GUI) setServer() {
 	g.srv = srv
 } // func (g *GUI) setServer()
 
-func (g *GUI) reminderAdd() {
+// setSnooze lets the user edit the snooze choices offered for Reminders
+// that do not have their own.
+func (g *GUI) setSnooze() {
 	var (
-		err                                error
-		msg                                string
-		dlg                                *gtk.Dialog
-		dbox                               *gtk.Box
-		grid                               *gtk.Grid
-		cal                                *gtk.Calendar
-		titleEntry, bodyEntry              *gtk.Entry
-		hourInput, minuteInput             *gtk.SpinButton
-		timeLbl, sepLbl, titleLbl, bodyLbl *gtk.Label
-		recEdit                            *RecurEditor
-		now                                time.Time
+		err   error
+		msg   string
+		dlg   *gtk.Dialog
+		entry *gtk.Entry
+		lbl   *gtk.Label
+		dbox  *gtk.Box
+		grid  *gtk.Grid
+		res   *objects.Response
 	)
 
+	if res, err = g.callBackend(uriSettingsSnooze, nil); err != nil {
+		msg = fmt.Sprintf("Cannot load snooze choices: %s", err.Error())
+		g.pushMsg(msg)
+		g.displayMsg(msg)
+		return
+	} else if !res.Status {
+		g.pushMsg(res.Message)
+		g.displayMsg(res.Message)
+		return
+	}
+
 	if dlg, err = gtk.DialogNewWithButtons(
-		"Choose Server",
+		"Snooze Choices",
 		g.win,
 		gtk.DIALOG_MODAL,
 		[]any{
@@ -745,51 +937,151 @@ func (g *GUI) reminderAdd() {
 
 	defer dlg.Close()
 
-	if _, err = dlg.AddButton("_OK", gtk.RESPONSE_OK); err != nil {
-		g.log.Printf("[ERROR] Cannot add OK button to dialog: %s\n",
-			err.Error())
-		return
-	} else if grid, err = gtk.GridNew(); err != nil {
+	if grid, err = gtk.GridNew(); err != nil {
 		g.log.Printf("[ERROR] Cannot create gtk.Grid: %s\n",
 			err.Error())
 		return
-	} else if cal, err = gtk.CalendarNew(); err != nil {
-		g.log.Printf("[ERROR] Cannot create gtk.Calendar: %s\n",
-			err.Error())
-	} else if hourInput, err = gtk.SpinButtonNewWithRange(0, 23, 1); err != nil {
-		g.log.Printf("[ERROR] Cannot greate hourInput: %s\n",
+	} else if lbl, err = gtk.LabelNew("Snooze choices:"); err != nil {
+		g.log.Printf("[ERROR] Cannot create gtk.Label: %s\n",
 			err.Error())
 		return
-	} else if minuteInput, err = gtk.SpinButtonNewWithRange(0, 59, 1); err != nil {
-		g.log.Printf("[ERROR] Cannot greate minuteInput: %s\n",
+	} else if entry, err = gtk.EntryNew(); err != nil {
+		g.log.Printf("[ERROR] Cannot create gtk.Entry: %s\n",
 			err.Error())
 		return
-	} else if timeLbl, err = gtk.LabelNew("Time"); err != nil {
-		g.log.Printf("[ERROR] Cannot create time label: %s\n",
+	} else if dbox, err = dlg.GetContentArea(); err != nil {
+		g.log.Printf("[ERROR] Cannot get ContentArea of Dialog: %s\n",
 			err.Error())
 		return
-	} else if sepLbl, err = gtk.LabelNew(":"); err != nil {
-		g.log.Printf("[ERROR] Cannot create separator label: %s\n",
+	}
+
+	grid.InsertColumn(0)
+	grid.InsertColumn(1)
+	grid.InsertRow(0)
+
+	grid.Attach(lbl, 0, 0, 1, 1)
+	grid.Attach(entry, 1, 0, 1, 1)
+
+	entry.SetText(res.Message)
+	entry.SetPlaceholderText(objects.FormatSnoozes(objects.DefaultSnoozes))
+
+	dbox.PackStart(grid, true, true, 0)
+	dlg.ShowAll()
+
+BEGIN:
+	var rtype = dlg.Run()
+
+	switch rtype {
+	case gtk.RESPONSE_NONE:
+		fallthrough
+	case gtk.RESPONSE_DELETE_EVENT:
+		fallthrough
+	case gtk.RESPONSE_CLOSE:
+		fallthrough
+	case gtk.RESPONSE_CANCEL:
+		g.log.Println("[DEBUG] User changed their mind about the snooze choices. Fine with me.")
+		return
+	case gtk.RESPONSE_OK:
+		// 's ist los, Hund?
+	default:
+		g.log.Printf("[CANTHAPPEN] Well, I did NOT see this coming: %d\n",
+			rtype)
+		return
+	}
+
+	var (
+		items   []objects.Snooze
+		txt, _  = entry.GetText()
+		payload = make(url.Values)
+	)
+
+	if items, err = objects.ParseSnoozes(txt); err != nil {
+		msg = err.Error()
+		g.displayMsg(msg)
+		g.log.Printf("[ERROR] %s\n", msg)
+		goto BEGIN
+	}
+
+	payload["snooze"] = []string{objects.FormatSnoozes(items)}
+
+	if res, err = g.callBackend(uriSettingsSnooze, payload); err != nil {
+		msg = fmt.Sprintf("Cannot save snooze choices: %s", err.Error())
+	} else if !res.Status {
+		msg = res.Message
+	} else {
+		msg = fmt.Sprintf("Snooze choices are now %s", res.Message)
+	}
+
+	g.pushMsg(msg)
+	g.log.Printf("[INFO] %s\n", msg)
+} // func (g *GUI) setSnooze()
+
+// setQuietHours lets the user switch do-not-disturb on or off and edit the
+// schedule of quiet hours.
+func (g *GUI) setQuietHours() {
+	var (
+		err                 error
+		msg                 string
+		dlg                 *gtk.Dialog
+		entry               *gtk.Entry
+		schedLbl, statusLbl *gtk.Label
+		dndCB               *gtk.CheckButton
+		dbox                *gtk.Box
+		grid                *gtk.Grid
+		status              objects.QuietStatus
+		res                 *objects.Response
+	)
+
+	if err = g.getJSON(uriDNDStatus, &status); err != nil {
+		msg = fmt.Sprintf("Cannot load status of quiet hours: %s", err.Error())
+		g.pushMsg(msg)
+		g.displayMsg(msg)
+		return
+	}
+
+	if dlg, err = gtk.DialogNewWithButtons(
+		"Quiet Hours",
+		g.win,
+		gtk.DIALOG_MODAL,
+		[]any{
+			"_Cancel",
+			gtk.RESPONSE_CANCEL,
+			"_OK",
+			gtk.RESPONSE_OK,
+		},
+	); err != nil {
+		g.log.Printf("[ERROR] Failed to create Dialog: %s\n",
 			err.Error())
 		return
-	} else if titleLbl, err = gtk.LabelNew("Title:"); err != nil {
-		g.log.Printf("[ERROR] Cannot create Title label: %s\n",
+	}
+
+	defer dlg.Close()
+
+	if status.Active {
+		msg = fmt.Sprintf("Quiet hours are in effect, %d Notifications are held back",
+			status.Deferred)
+	} else {
+		msg = "Quiet hours are not in effect"
+	}
+
+	if grid, err = gtk.GridNew(); err != nil {
+		g.log.Printf("[ERROR] Cannot create gtk.Grid: %s\n",
 			err.Error())
 		return
-	} else if bodyLbl, err = gtk.LabelNew("Description:"); err != nil {
-		g.log.Printf("[ERROR] Cannot create Body Label: %s\n",
+	} else if statusLbl, err = gtk.LabelNew(msg); err != nil {
+		g.log.Printf("[ERROR] Cannot create status Label: %s\n",
 			err.Error())
 		return
-	} else if titleEntry, err = gtk.EntryNew(); err != nil {
-		g.log.Printf("[ERROR] Cannot create Entry for title: %s\n",
+	} else if dndCB, err = gtk.CheckButtonNewWithLabel("Do not disturb"); err != nil {
+		g.log.Printf("[ERROR] Cannot create CheckButton: %s\n",
 			err.Error())
 		return
-	} else if bodyEntry, err = gtk.EntryNew(); err != nil {
-		g.log.Printf("[ERROR] Cannot create Entry for Body: %s\n",
+	} else if schedLbl, err = gtk.LabelNew("Schedule:"); err != nil {
+		g.log.Printf("[ERROR] Cannot create schedule Label: %s\n",
 			err.Error())
 		return
-	} else if recEdit, err = NewRecurEditor(nil, g.log); err != nil {
-		g.log.Printf("[ERROR] Cannot create Recurrence Editor: %s\n",
+	} else if entry, err = gtk.EntryNew(); err != nil {
+		g.log.Printf("[ERROR] Cannot create gtk.Entry: %s\n",
 			err.Error())
 		return
 	} else if dbox, err = dlg.GetContentArea(); err != nil {
@@ -798,58 +1090,28 @@ func (g *GUI) reminderAdd() {
 		return
 	}
 
-	recEdit.rtCombo.Connect("changed",
-		func() {
-			switch txt := recEdit.rtCombo.GetActiveText(); txt {
-			case repeat.Once.String():
-				cal.SetSensitive(true)
-				hourInput.SetSensitive(true)
-				minuteInput.SetSensitive(true)
-			case repeat.Daily.String():
-				fallthrough
-			case repeat.Custom.String():
-				cal.SetSensitive(false)
-				hourInput.SetSensitive(false)
-				minuteInput.SetSensitive(false)
-			}
-		})
-
 	grid.InsertColumn(0)
 	grid.InsertColumn(1)
-	grid.InsertColumn(2)
-	grid.InsertColumn(3)
 	grid.InsertRow(0)
 	grid.InsertRow(1)
 	grid.InsertRow(2)
-	grid.InsertRow(3)
-	grid.InsertRow(4)
 
-	grid.Attach(cal, 0, 0, 4, 1)
-	grid.Attach(timeLbl, 0, 1, 1, 1)
-	grid.Attach(hourInput, 1, 1, 1, 1)
-	grid.Attach(sepLbl, 2, 1, 1, 1)
-	grid.Attach(minuteInput, 3, 1, 1, 1)
-	grid.Attach(titleLbl, 0, 2, 1, 1)
-	grid.Attach(titleEntry, 1, 2, 3, 1)
-	grid.Attach(bodyLbl, 0, 3, 1, 1)
-	grid.Attach(bodyEntry, 1, 3, 3, 1)
-	grid.Attach(recEdit.box, 0, 4, 4, 1)
+	grid.Attach(statusLbl, 0, 0, 2, 1)
+	grid.Attach(dndCB, 0, 1, 2, 1)
+	grid.Attach(schedLbl, 0, 2, 1, 1)
+	grid.Attach(entry, 1, 2, 1, 1)
+
+	dndCB.SetActive(status.Manual)
+	entry.SetText(status.Schedule)
+	entry.SetPlaceholderText("e.g. mon-fri 22:00-07:00; sat,sun 23:00-09:00")
 
 	dbox.PackStart(grid, true, true, 0)
 	dlg.ShowAll()
 
 BEGIN:
-	now = time.Now()
-
-	cal.SelectMonth(uint(now.Month())-1, uint(now.Year()))
-	cal.SelectDay(uint(now.Day()))
-
-	hourInput.SetValue(float64(now.Hour()))
-	minuteInput.SetValue(float64(now.Minute()) + 10)
-
-	var res = dlg.Run()
+	var rtype = dlg.Run()
 
-	switch res {
+	switch rtype {
 	case gtk.RESPONSE_NONE:
 		fallthrough
 	case gtk.RESPONSE_DELETE_EVENT:
@@ -857,38 +1119,605 @@ BEGIN:
 	case gtk.RESPONSE_CLOSE:
 		fallthrough
 	case gtk.RESPONSE_CANCEL:
-		g.log.Println("[DEBUG] User changed their mind about adding a Program. Fine with me.")
+		g.log.Println("[DEBUG] User changed their mind about the quiet hours. Fine with me.")
 		return
 	case gtk.RESPONSE_OK:
 		// 's ist los, Hund?
 	default:
 		g.log.Printf("[CANTHAPPEN] Well, I did NOT see this coming: %d\n",
-			res)
+			rtype)
 		return
 	}
 
-	var r objects.Reminder
+	var (
+		q       objects.QuietHours
+		txt, _  = entry.GetText()
+		dnd     = dndCB.GetActive()
+		payload = make(url.Values)
+	)
 
-	r.Recur = recEdit.GetRecurrence()
+	if q, err = objects.ParseQuietHours(txt); err != nil {
+		msg = err.Error()
+		g.displayMsg(msg)
+		g.log.Printf("[ERROR] %s\n", msg)
+		goto BEGIN
+	}
 
-	if r.Recur.Repeat == repeat.Once {
-		var (
-			year, month, day uint
-			hour, min        int
-		)
+	payload["schedule"] = []string{q.String()}
 
-		year, month, day = cal.GetDate()
-		hour = hourInput.GetValueAsInt()
-		min = minuteInput.GetValueAsInt()
-		r.Timestamp = time.Date(
-			int(year),
-			time.Month(month+1),
-			int(day),
-			hour,
-			min,
+	if res, err = g.callBackend(uriDNDSchedule, payload); err != nil {
+		msg = fmt.Sprintf("Cannot save quiet hours: %s", err.Error())
+	} else if !res.Status {
+		msg = res.Message
+	} else if dnd != status.Manual {
+		if res, err = g.callBackend(fmt.Sprintf(uriDNDSet, dnd), url.Values{}); err != nil {
+			msg = fmt.Sprintf("Cannot switch do-not-disturb: %s", err.Error())
+		} else {
+			msg = res.Message
+		}
+	} else {
+		msg = fmt.Sprintf("Quiet hours are now %q", res.Message)
+	}
+
+	g.pushMsg(msg)
+	g.log.Printf("[INFO] %s\n", msg)
+} // func (g *GUI) setQuietHours()
+
+// editTags lets the user change the defaults a Tag passes on to its
+// Reminders, or delete a Tag.
+func (g *GUI) editTags() {
+	const respDelete = gtk.RESPONSE_REJECT
+	var (
+		err                  error
+		msg                  string
+		dlg                  *gtk.Dialog
+		nameLbl, prioLbl     *gtk.Label
+		nameCombo, prioCombo *gtk.ComboBoxText
+		soundCB, quietCB     *gtk.CheckButton
+		dbox                 *gtk.Box
+		grid                 *gtk.Grid
+		tags                 []objects.Tag
+		res                  *objects.Response
+		tagMap               = make(map[string]objects.Tag)
+	)
+
+	if err = g.getJSON(uriTagAll, &tags); err != nil {
+		msg = fmt.Sprintf("Cannot load Tags: %s", err.Error())
+		g.pushMsg(msg)
+		g.displayMsg(msg)
+		return
+	}
+
+	if dlg, err = gtk.DialogNewWithButtons(
+		"Tags",
+		g.win,
+		gtk.DIALOG_MODAL,
+		[]any{
+			"_Cancel",
+			gtk.RESPONSE_CANCEL,
+			"_Delete",
+			respDelete,
+			"_OK",
+			gtk.RESPONSE_OK,
+		},
+	); err != nil {
+		g.log.Printf("[ERROR] Failed to create Dialog: %s\n",
+			err.Error())
+		return
+	}
+
+	defer dlg.Close()
+
+	if grid, err = gtk.GridNew(); err != nil {
+		g.log.Printf("[ERROR] Cannot create gtk.Grid: %s\n",
+			err.Error())
+		return
+	} else if nameLbl, err = gtk.LabelNew("Tag:"); err != nil {
+		g.log.Printf("[ERROR] Cannot create name Label: %s\n",
+			err.Error())
+		return
+	} else if nameCombo, err = gtk.ComboBoxTextNewWithEntry(); err != nil {
+		g.log.Printf("[ERROR] Cannot create name ComboBox: %s\n",
+			err.Error())
+		return
+	} else if prioLbl, err = gtk.LabelNew("Minimum priority:"); err != nil {
+		g.log.Printf("[ERROR] Cannot create priority Label: %s\n",
+			err.Error())
+		return
+	} else if prioCombo, err = gtk.ComboBoxTextNew(); err != nil {
+		g.log.Printf("[ERROR] Cannot create priority ComboBox: %s\n",
+			err.Error())
+		return
+	} else if soundCB, err = gtk.CheckButtonNewWithLabel("Play alarm sound"); err != nil {
+		g.log.Printf("[ERROR] Cannot create CheckButton: %s\n",
+			err.Error())
+		return
+	} else if quietCB, err = gtk.CheckButtonNewWithLabel("Ignore quiet hours"); err != nil {
+		g.log.Printf("[ERROR] Cannot create CheckButton: %s\n",
+			err.Error())
+		return
+	} else if dbox, err = dlg.GetContentArea(); err != nil {
+		g.log.Printf("[ERROR] Cannot get ContentArea of Dialog: %s\n",
+			err.Error())
+		return
+	}
+
+	grid.InsertColumn(0)
+	grid.InsertColumn(1)
+	grid.InsertRow(0)
+	grid.InsertRow(1)
+	grid.InsertRow(2)
+	grid.InsertRow(3)
+
+	grid.Attach(nameLbl, 0, 0, 1, 1)
+	grid.Attach(nameCombo, 1, 0, 1, 1)
+	grid.Attach(prioLbl, 0, 1, 1, 1)
+	grid.Attach(prioCombo, 1, 1, 1, 1)
+	grid.Attach(soundCB, 0, 2, 2, 1)
+	grid.Attach(quietCB, 0, 3, 2, 1)
+
+	for _, t := range tags {
+		tagMap[t.Name] = t
+		nameCombo.Append(t.Name, t.Name)
+	}
+
+	for _, p := range objects.Priorities {
+		prioCombo.Append(p.String(), p.String())
+	}
+	prioCombo.SetActiveID(objects.PriorityNormal.String())
+
+	nameCombo.Connect("changed", func() {
+		var t, ok = tagMap[strings.ToLower(nameCombo.GetActiveText())]
+
+		if !ok {
+			t = objects.Tag{}
+		}
+
+		prioCombo.SetActiveID(t.Priority.String())
+		soundCB.SetActive(t.Channel == objects.ChannelSound)
+		quietCB.SetActive(t.QuietExempt)
+	})
+
+	if len(tags) > 0 {
+		nameCombo.SetActive(0)
+	}
+
+	dbox.PackStart(grid, true, true, 0)
+	dlg.ShowAll()
+
+BEGIN:
+	var rtype = dlg.Run()
+
+	switch rtype {
+	case gtk.RESPONSE_NONE:
+		fallthrough
+	case gtk.RESPONSE_DELETE_EVENT:
+		fallthrough
+	case gtk.RESPONSE_CLOSE:
+		fallthrough
+	case gtk.RESPONSE_CANCEL:
+		g.log.Println("[DEBUG] User changed their mind about the tags. Fine with me.")
+		return
+	case gtk.RESPONSE_OK, respDelete:
+		// 's ist los, Hund?
+	default:
+		g.log.Printf("[CANTHAPPEN] Well, I did NOT see this coming: %d\n",
+			rtype)
+		return
+	}
+
+	var names []string
+
+	if names, err = objects.ParseTags(nameCombo.GetActiveText()); err != nil {
+		msg = err.Error()
+		g.displayMsg(msg)
+		g.log.Printf("[ERROR] %s\n", msg)
+		goto BEGIN
+	} else if len(names) != 1 {
+		msg = "Please enter exactly one tag"
+		g.displayMsg(msg)
+		goto BEGIN
+	}
+
+	if rtype == respDelete {
+		var ok bool
+
+		if ok, err = g.yesOrNo("Delete Tag", fmt.Sprintf("Remove the tag %q from all Reminders?", names[0])); err != nil || !ok {
+			goto BEGIN
+		}
+
+		res, err = g.callBackend(fmt.Sprintf(uriTagDelete, url.PathEscape(names[0])), nil)
+	} else {
+		var (
+			channel = objects.ChannelDesktop
+			payload = make(url.Values)
+		)
+
+		if soundCB.GetActive() {
+			channel = objects.ChannelSound
+		}
+
+		payload["name"] = []string{names[0]}
+		payload["priority"] = []string{prioCombo.GetActiveID()}
+		payload["channel"] = []string{strings.ToLower(channel.String())}
+		payload["quiet_exempt"] = []string{strconv.FormatBool(quietCB.GetActive())}
+
+		res, err = g.callBackend(uriTagSet, payload)
+	}
+
+	if err != nil {
+		msg = fmt.Sprintf("Cannot save tag %q: %s", names[0], err.Error())
+	} else if !res.Status {
+		msg = res.Message
+	} else if rtype == respDelete {
+		msg = res.Message
+		g.fetchReminders()
+	} else {
+		msg = fmt.Sprintf("Saved tag %q", res.Message)
+	}
+
+	g.pushMsg(msg)
+	g.log.Printf("[INFO] %s\n", msg)
+} // func (g *GUI) editTags()
+
+func (g *GUI) reminderAdd() {
+	var (
+		err                                error
+		msg                                string
+		dlg                                *gtk.Dialog
+		dbox                               *gtk.Box
+		grid                               *gtk.Grid
+		cal                                *gtk.Calendar
+		titleEntry, bodyEntry, zoneEntry   *gtk.Entry
+		alertEntry, snoozeEntry, tagEntry  *gtk.Entry
+		hourInput, minuteInput             *gtk.SpinButton
+		escInput, alarmInput               *gtk.SpinButton
+		timeLbl, sepLbl, titleLbl, bodyLbl *gtk.Label
+		zoneLbl, alertLbl, snoozeLbl       *gtk.Label
+		tagLbl, checkLbl                   *gtk.Label
+		checkView                          *gtk.TextView
+		checkScroll                        *gtk.ScrolledWindow
+		depLbl                             *gtk.Label
+		depEdit                            *DepEditor
+		escLbl, alarmLbl, prioLbl          *gtk.Label
+		prioCombo                          *gtk.ComboBoxText
+		recEdit                            *RecurEditor
+		now                                time.Time
+	)
+
+	if dlg, err = gtk.DialogNewWithButtons(
+		"Choose Server",
+		g.win,
+		gtk.DIALOG_MODAL,
+		[]any{
+			"_Cancel",
+			gtk.RESPONSE_CANCEL,
+			"_OK",
+			gtk.RESPONSE_OK,
+		},
+	); err != nil {
+		g.log.Printf("[ERROR] Failed to create Dialog: %s\n",
+			err.Error())
+		return
+	}
+
+	defer dlg.Close()
+
+	if _, err = dlg.AddButton("_OK", gtk.RESPONSE_OK); err != nil {
+		g.log.Printf("[ERROR] Cannot add OK button to dialog: %s\n",
+			err.Error())
+		return
+	} else if grid, err = gtk.GridNew(); err != nil {
+		g.log.Printf("[ERROR] Cannot create gtk.Grid: %s\n",
+			err.Error())
+		return
+	} else if cal, err = gtk.CalendarNew(); err != nil {
+		g.log.Printf("[ERROR] Cannot create gtk.Calendar: %s\n",
+			err.Error())
+	} else if hourInput, err = gtk.SpinButtonNewWithRange(0, 23, 1); err != nil {
+		g.log.Printf("[ERROR] Cannot greate hourInput: %s\n",
+			err.Error())
+		return
+	} else if minuteInput, err = gtk.SpinButtonNewWithRange(0, 59, 1); err != nil {
+		g.log.Printf("[ERROR] Cannot greate minuteInput: %s\n",
+			err.Error())
+		return
+	} else if timeLbl, err = gtk.LabelNew("Time"); err != nil {
+		g.log.Printf("[ERROR] Cannot create time label: %s\n",
+			err.Error())
+		return
+	} else if sepLbl, err = gtk.LabelNew(":"); err != nil {
+		g.log.Printf("[ERROR] Cannot create separator label: %s\n",
+			err.Error())
+		return
+	} else if titleLbl, err = gtk.LabelNew("Title:"); err != nil {
+		g.log.Printf("[ERROR] Cannot create Title label: %s\n",
+			err.Error())
+		return
+	} else if bodyLbl, err = gtk.LabelNew("Description:"); err != nil {
+		g.log.Printf("[ERROR] Cannot create Body Label: %s\n",
+			err.Error())
+		return
+	} else if titleEntry, err = gtk.EntryNew(); err != nil {
+		g.log.Printf("[ERROR] Cannot create Entry for title: %s\n",
+			err.Error())
+		return
+	} else if bodyEntry, err = gtk.EntryNew(); err != nil {
+		g.log.Printf("[ERROR] Cannot create Entry for Body: %s\n",
+			err.Error())
+		return
+	} else if zoneLbl, err = gtk.LabelNew("Time zone:"); err != nil {
+		g.log.Printf("[ERROR] Cannot create time zone Label: %s\n",
+			err.Error())
+		return
+	} else if zoneEntry, err = gtk.EntryNew(); err != nil {
+		g.log.Printf("[ERROR] Cannot create Entry for time zone: %s\n",
+			err.Error())
+		return
+	} else if alertLbl, err = gtk.LabelNew("Warn ahead:"); err != nil {
+		g.log.Printf("[ERROR] Cannot create pre-alert Label: %s\n",
+			err.Error())
+		return
+	} else if alertEntry, err = gtk.EntryNew(); err != nil {
+		g.log.Printf("[ERROR] Cannot create Entry for pre-alerts: %s\n",
+			err.Error())
+		return
+	} else if escLbl, err = gtk.LabelNew("Nag every (min):"); err != nil {
+		g.log.Printf("[ERROR] Cannot create escalation Label: %s\n",
+			err.Error())
+		return
+	} else if escInput, err = gtk.SpinButtonNewWithRange(0, 1440, 1); err != nil {
+		g.log.Printf("[ERROR] Cannot create escalation input: %s\n",
+			err.Error())
+		return
+	} else if alarmLbl, err = gtk.LabelNew("Sound after:"); err != nil {
+		g.log.Printf("[ERROR] Cannot create alarm Label: %s\n",
+			err.Error())
+		return
+	} else if alarmInput, err = gtk.SpinButtonNewWithRange(0, 100, 1); err != nil {
+		g.log.Printf("[ERROR] Cannot create alarm input: %s\n",
+			err.Error())
+		return
+	} else if prioLbl, err = gtk.LabelNew("Priority:"); err != nil {
+		g.log.Printf("[ERROR] Cannot create priority Label: %s\n",
+			err.Error())
+		return
+	} else if prioCombo, err = gtk.ComboBoxTextNew(); err != nil {
+		g.log.Printf("[ERROR] Cannot create priority ComboBox: %s\n",
+			err.Error())
+		return
+	} else if snoozeLbl, err = gtk.LabelNew("Snooze choices:"); err != nil {
+		g.log.Printf("[ERROR] Cannot create snooze Label: %s\n",
+			err.Error())
+		return
+	} else if snoozeEntry, err = gtk.EntryNew(); err != nil {
+		g.log.Printf("[ERROR] Cannot create Entry for snooze choices: %s\n",
+			err.Error())
+		return
+	} else if tagLbl, err = gtk.LabelNew("Tags:"); err != nil {
+		g.log.Printf("[ERROR] Cannot create tag Label: %s\n",
+			err.Error())
+		return
+	} else if tagEntry, err = gtk.EntryNew(); err != nil {
+		g.log.Printf("[ERROR] Cannot create Entry for tags: %s\n",
+			err.Error())
+		return
+	} else if checkLbl, err = gtk.LabelNew("Checklist:"); err != nil {
+		g.log.Printf("[ERROR] Cannot create checklist Label: %s\n",
+			err.Error())
+		return
+	} else if checkView, err = gtk.TextViewNew(); err != nil {
+		g.log.Printf("[ERROR] Cannot create TextView for checklist: %s\n",
+			err.Error())
+		return
+	} else if checkScroll, err = gtk.ScrolledWindowNew(nil, nil); err != nil {
+		g.log.Printf("[ERROR] Cannot create ScrolledWindow for checklist: %s\n",
+			err.Error())
+		return
+	} else if depLbl, err = gtk.LabelNew("Depends on:"); err != nil {
+		g.log.Printf("[ERROR] Cannot create dependency Label: %s\n",
+			err.Error())
+		return
+	} else if depEdit, err = NewDepEditor(new(objects.Reminder), g.reminders, g.log); err != nil {
+		g.log.Printf("[ERROR] Cannot create Dependency Editor: %s\n",
+			err.Error())
+		return
+	} else if recEdit, err = NewRecurEditor(nil, g.log); err != nil {
+		g.log.Printf("[ERROR] Cannot create Recurrence Editor: %s\n",
+			err.Error())
+		return
+	} else if dbox, err = dlg.GetContentArea(); err != nil {
+		g.log.Printf("[ERROR] Cannot get ContentArea of Dialog: %s\n",
+			err.Error())
+		return
+	}
+
+	recEdit.rtCombo.Connect("changed",
+		func() {
+			switch txt := recEdit.rtCombo.GetActiveText(); txt {
+			case repeat.Once.String(), repeat.Interval.String(), repeat.RRule.String():
+				cal.SetSensitive(true)
+				hourInput.SetSensitive(true)
+				minuteInput.SetSensitive(true)
+			default:
+				cal.SetSensitive(false)
+				hourInput.SetSensitive(false)
+				minuteInput.SetSensitive(false)
+			}
+		})
+
+	grid.InsertColumn(0)
+	grid.InsertColumn(1)
+	grid.InsertColumn(2)
+	grid.InsertColumn(3)
+	grid.InsertRow(0)
+	grid.InsertRow(1)
+	grid.InsertRow(2)
+	grid.InsertRow(3)
+	grid.InsertRow(4)
+	grid.InsertRow(5)
+	grid.InsertRow(6)
+	grid.InsertRow(7)
+	grid.InsertRow(8)
+	grid.InsertRow(9)
+	grid.InsertRow(10)
+
+	grid.Attach(cal, 0, 0, 4, 1)
+	grid.Attach(timeLbl, 0, 1, 1, 1)
+	grid.Attach(hourInput, 1, 1, 1, 1)
+	grid.Attach(sepLbl, 2, 1, 1, 1)
+	grid.Attach(minuteInput, 3, 1, 1, 1)
+	grid.Attach(titleLbl, 0, 2, 1, 1)
+	grid.Attach(titleEntry, 1, 2, 3, 1)
+	grid.Attach(bodyLbl, 0, 3, 1, 1)
+	grid.Attach(bodyEntry, 1, 3, 3, 1)
+	grid.Attach(recEdit.box, 0, 4, 4, 1)
+	grid.Attach(zoneLbl, 0, 5, 1, 1)
+	grid.Attach(zoneEntry, 1, 5, 3, 1)
+	grid.Attach(alertLbl, 0, 6, 1, 1)
+	grid.Attach(alertEntry, 1, 6, 3, 1)
+	grid.Attach(escLbl, 0, 7, 1, 1)
+	grid.Attach(escInput, 1, 7, 1, 1)
+	grid.Attach(alarmLbl, 2, 7, 1, 1)
+	grid.Attach(alarmInput, 3, 7, 1, 1)
+	grid.Attach(prioLbl, 0, 8, 1, 1)
+	grid.Attach(prioCombo, 1, 8, 3, 1)
+	grid.Attach(snoozeLbl, 0, 9, 1, 1)
+	grid.Attach(snoozeEntry, 1, 9, 3, 1)
+	grid.Attach(tagLbl, 0, 10, 1, 1)
+	grid.Attach(tagEntry, 1, 10, 3, 1)
+	grid.Attach(checkLbl, 0, 11, 1, 1)
+	grid.Attach(checkScroll, 1, 11, 3, 1)
+	grid.Attach(depLbl, 0, 12, 1, 1)
+	grid.Attach(depEdit.grid, 1, 12, 3, 1)
+
+	checkScroll.Add(checkView)
+	checkScroll.SetSizeRequest(-1, 80)
+	checkView.SetTooltipText("One item per line, \"[x]\" marks items that are done")
+
+	for _, p := range objects.Priorities {
+		prioCombo.Append(p.String(), p.String())
+	}
+	prioCombo.SetActiveID(objects.PriorityNormal.String())
+
+	zoneEntry.SetText(common.LocalZoneName())
+	zoneEntry.SetPlaceholderText("Europe/Berlin")
+	alertEntry.SetPlaceholderText("e.g. -1d, -1h, -15m")
+	snoozeEntry.SetPlaceholderText("Default")
+	tagEntry.SetPlaceholderText("e.g. work, family")
+
+	dbox.PackStart(grid, true, true, 0)
+	dlg.ShowAll()
+
+BEGIN:
+	now = time.Now()
+
+	cal.SelectMonth(uint(now.Month())-1, uint(now.Year()))
+	cal.SelectDay(uint(now.Day()))
+
+	hourInput.SetValue(float64(now.Hour()))
+	minuteInput.SetValue(float64(now.Minute()) + 10)
+
+	var res = dlg.Run()
+
+	switch res {
+	case gtk.RESPONSE_NONE:
+		fallthrough
+	case gtk.RESPONSE_DELETE_EVENT:
+		fallthrough
+	case gtk.RESPONSE_CLOSE:
+		fallthrough
+	case gtk.RESPONSE_CANCEL:
+		g.log.Println("[DEBUG] User changed their mind about adding a Program. Fine with me.")
+		return
+	case gtk.RESPONSE_OK:
+		// 's ist los, Hund?
+	default:
+		g.log.Printf("[CANTHAPPEN] Well, I did NOT see this coming: %d\n",
+			res)
+		return
+	}
+
+	var r objects.Reminder
+
+	r.TimeZone, _ = zoneEntry.GetText()
+	r.TimeZone = strings.TrimSpace(r.TimeZone)
+
+	if r.TimeZone != "" {
+		if _, err = time.LoadLocation(r.TimeZone); err != nil {
+			msg = fmt.Sprintf("Unknown time zone %q: %s",
+				r.TimeZone,
+				err.Error())
+			g.displayMsg(msg)
+			g.log.Printf("[ERROR] %s\n", msg)
+			goto BEGIN
+		}
+	}
+
+	var alerts, _ = alertEntry.GetText()
+
+	if r.Alerts, err = objects.ParseAlerts(alerts); err != nil {
+		msg = err.Error()
+		g.displayMsg(msg)
+		g.log.Printf("[ERROR] %s\n", msg)
+		goto BEGIN
+	}
+
+	r.Escalation = getEscalation(escInput, alarmInput)
+	r.Priority, _ = objects.ParsePriority(prioCombo.GetActiveID())
+
+	var snooze, _ = snoozeEntry.GetText()
+
+	if r.Snooze, err = objects.ParseSnoozes(snooze); err != nil {
+		msg = err.Error()
+		g.displayMsg(msg)
+		g.log.Printf("[ERROR] %s\n", msg)
+		goto BEGIN
+	}
+
+	var tags, _ = tagEntry.GetText()
+
+	if r.Tags, err = objects.ParseTags(tags); err != nil {
+		msg = err.Error()
+		g.displayMsg(msg)
+		g.log.Printf("[ERROR] %s\n", msg)
+		goto BEGIN
+	}
+
+	var checklist, _ = getTextViewText(checkView)
+
+	if r.Checklist, err = objects.ParseChecklist(checklist); err != nil {
+		msg = err.Error()
+		g.displayMsg(msg)
+		g.log.Printf("[ERROR] %s\n", msg)
+		goto BEGIN
+	} else if r.Depends, err = depEdit.GetDependencies(); err != nil {
+		msg = err.Error()
+		g.displayMsg(msg)
+		g.log.Printf("[ERROR] %s\n", msg)
+		goto BEGIN
+	}
+
+	r.Recur = recEdit.GetRecurrence()
+
+	if hasStartTime(r.Recur.Repeat) {
+		var (
+			year, month, day uint
+			hour, min        int
+		)
+
+		year, month, day = cal.GetDate()
+		hour = hourInput.GetValueAsInt()
+		min = minuteInput.GetValueAsInt()
+		r.Timestamp = time.Date(
+			int(year),
+			time.Month(month+1),
+			int(day),
+			hour,
+			min,
 			0,
 			0,
-			time.Local)
+			r.Location())
 	} else {
 		r.Timestamp = time.Unix(int64(r.Recur.Offset), 0).In(time.UTC)
 	}
@@ -910,6 +1739,13 @@ BEGIN:
 		g.displayMsg(msg)
 		g.log.Printf("[ERROR] %s\n", msg)
 		goto BEGIN
+	} else if r.Recur.Repeat == repeat.RRule {
+		if err = r.SetRRule(r.Recur.Rule); err != nil {
+			msg = fmt.Sprintf("Invalid recurrence rule: %s", err.Error())
+			g.displayMsg(msg)
+			g.log.Printf("[ERROR] %s\n", msg)
+			goto BEGIN
+		}
 	}
 
 	var (
@@ -963,12 +1799,78 @@ BEGIN:
 			return false
 		})
 	} else {
-		g.log.Printf("[ERROR] Failed to add Reminder to Database: %s\n",
+		g.log.Printf("[ERROR] Failed to add Reminder to Database: %s\n",
+			response.Message)
+		g.pushMsg(response.Message)
+		g.displayMsg(response.Message)
+	}
+} // func (g *GUI) reminderAdd()
+
+// reminderQuickAdd sends the phrase in the quick add Entry to the backend,
+// which creates a Reminder from it.
+func (g *GUI) reminderQuickAdd() {
+	var (
+		err      error
+		msg      string
+		text     string
+		reply    *http.Response
+		response objects.Response
+		buf      bytes.Buffer
+		addr     = fmt.Sprintf("http://%s%s",
+			g.srv,
+			uriReminderQuickAdd)
+		payload = make(url.Values)
+	)
+
+	if text, err = g.quickEntry.GetText(); err != nil {
+		g.log.Printf("[ERROR] Cannot get Text from Entry: %s\n",
+			err.Error())
+		return
+	} else if text = strings.TrimSpace(text); text == "" {
+		return
+	}
+
+	payload["text"] = []string{text}
+	payload["tz"] = []string{common.LocalZoneName()}
+
+	if reply, err = g.web.PostForm(addr, payload); err != nil {
+		msg = fmt.Sprintf("Failed to submit %q to Backend: %s",
+			text,
+			err.Error())
+		g.log.Printf("[ERROR] %s\n", msg)
+		g.pushMsg(msg)
+		return
+	} else if reply.StatusCode != 200 {
+		msg = fmt.Sprintf("Backend responds with status %s",
+			reply.Status)
+		g.log.Printf("[ERROR] %s\n", msg)
+		g.pushMsg(msg)
+		return
+	}
+
+	defer reply.Body.Close() // nolint: errcheck
+
+	if _, err = io.Copy(&buf, reply.Body); err != nil {
+		g.log.Printf("[ERROR] Cannot read HTTP reply from backend: %s\n",
+			err.Error())
+		return
+	} else if err = ffjson.Unmarshal(buf.Bytes(), &response); err != nil {
+		g.log.Printf("[ERROR] Cannot de-serialize Response from JSON: %s\n",
+			err.Error())
+		return
+	}
+
+	if response.Status {
+		g.quickEntry.SetText("")
+		g.pushMsg(response.Message)
+		g.refreshReminders()
+	} else {
+		g.log.Printf("[ERROR] Failed to add Reminder: %s\n",
 			response.Message)
 		g.pushMsg(response.Message)
 		g.displayMsg(response.Message)
 	}
-} // func (g *GUI) reminderAdd()
+} // func (g *GUI) reminderQuickAdd()
 
 func (g *GUI) reminderEdit() {
 	var (
@@ -984,9 +1886,19 @@ func (g *GUI) reminderEdit() {
 		dbox                               *gtk.Box
 		grid                               *gtk.Grid
 		cal                                *gtk.Calendar
-		titleEntry, bodyEntry              *gtk.Entry
+		titleEntry, bodyEntry, zoneEntry   *gtk.Entry
+		alertEntry, snoozeEntry, tagEntry  *gtk.Entry
 		hourInput, minuteInput             *gtk.SpinButton
+		escInput, alarmInput               *gtk.SpinButton
 		timeLbl, sepLbl, titleLbl, bodyLbl *gtk.Label
+		zoneLbl, alertLbl, snoozeLbl       *gtk.Label
+		tagLbl, checkLbl                   *gtk.Label
+		checkView                          *gtk.TextView
+		checkScroll                        *gtk.ScrolledWindow
+		depLbl                             *gtk.Label
+		depEdit                            *DepEditor
+		escLbl, alarmLbl, prioLbl          *gtk.Label
+		prioCombo                          *gtk.ComboBoxText
 		finishedCB                         *gtk.CheckButton
 		recEdit                            *RecurEditor
 		id                                 int64
@@ -1090,6 +2002,82 @@ func (g *GUI) reminderEdit() {
 		g.log.Printf("[ERROR] Cannot create Entry for Body: %s\n",
 			err.Error())
 		return
+	} else if zoneLbl, err = gtk.LabelNew("Time zone:"); err != nil {
+		g.log.Printf("[ERROR] Cannot create time zone Label: %s\n",
+			err.Error())
+		return
+	} else if zoneEntry, err = gtk.EntryNew(); err != nil {
+		g.log.Printf("[ERROR] Cannot create Entry for time zone: %s\n",
+			err.Error())
+		return
+	} else if alertLbl, err = gtk.LabelNew("Warn ahead:"); err != nil {
+		g.log.Printf("[ERROR] Cannot create pre-alert Label: %s\n",
+			err.Error())
+		return
+	} else if alertEntry, err = gtk.EntryNew(); err != nil {
+		g.log.Printf("[ERROR] Cannot create Entry for pre-alerts: %s\n",
+			err.Error())
+		return
+	} else if escLbl, err = gtk.LabelNew("Nag every (min):"); err != nil {
+		g.log.Printf("[ERROR] Cannot create escalation Label: %s\n",
+			err.Error())
+		return
+	} else if escInput, err = gtk.SpinButtonNewWithRange(0, 1440, 1); err != nil {
+		g.log.Printf("[ERROR] Cannot create escalation input: %s\n",
+			err.Error())
+		return
+	} else if alarmLbl, err = gtk.LabelNew("Sound after:"); err != nil {
+		g.log.Printf("[ERROR] Cannot create alarm Label: %s\n",
+			err.Error())
+		return
+	} else if alarmInput, err = gtk.SpinButtonNewWithRange(0, 100, 1); err != nil {
+		g.log.Printf("[ERROR] Cannot create alarm input: %s\n",
+			err.Error())
+		return
+	} else if prioLbl, err = gtk.LabelNew("Priority:"); err != nil {
+		g.log.Printf("[ERROR] Cannot create priority Label: %s\n",
+			err.Error())
+		return
+	} else if prioCombo, err = gtk.ComboBoxTextNew(); err != nil {
+		g.log.Printf("[ERROR] Cannot create priority ComboBox: %s\n",
+			err.Error())
+		return
+	} else if snoozeLbl, err = gtk.LabelNew("Snooze choices:"); err != nil {
+		g.log.Printf("[ERROR] Cannot create snooze Label: %s\n",
+			err.Error())
+		return
+	} else if snoozeEntry, err = gtk.EntryNew(); err != nil {
+		g.log.Printf("[ERROR] Cannot create Entry for snooze choices: %s\n",
+			err.Error())
+		return
+	} else if tagLbl, err = gtk.LabelNew("Tags:"); err != nil {
+		g.log.Printf("[ERROR] Cannot create tag Label: %s\n",
+			err.Error())
+		return
+	} else if tagEntry, err = gtk.EntryNew(); err != nil {
+		g.log.Printf("[ERROR] Cannot create Entry for tags: %s\n",
+			err.Error())
+		return
+	} else if checkLbl, err = gtk.LabelNew("Checklist:"); err != nil {
+		g.log.Printf("[ERROR] Cannot create checklist Label: %s\n",
+			err.Error())
+		return
+	} else if checkView, err = gtk.TextViewNew(); err != nil {
+		g.log.Printf("[ERROR] Cannot create TextView for checklist: %s\n",
+			err.Error())
+		return
+	} else if checkScroll, err = gtk.ScrolledWindowNew(nil, nil); err != nil {
+		g.log.Printf("[ERROR] Cannot create ScrolledWindow for checklist: %s\n",
+			err.Error())
+		return
+	} else if depLbl, err = gtk.LabelNew("Depends on:"); err != nil {
+		g.log.Printf("[ERROR] Cannot create dependency Label: %s\n",
+			err.Error())
+		return
+	} else if depEdit, err = NewDepEditor(&r, g.reminders, g.log); err != nil {
+		g.log.Printf("[ERROR] Cannot create Dependency Editor: %s\n",
+			err.Error())
+		return
 	} else if finishedCB, err = gtk.CheckButtonNewWithLabel("Finished?"); err != nil {
 		g.log.Printf("[ERROR] Cannot create CheckButton: %s\n",
 			err.Error())
@@ -1114,6 +2102,12 @@ func (g *GUI) reminderEdit() {
 	grid.InsertRow(3)
 	grid.InsertRow(4)
 	grid.InsertRow(5)
+	grid.InsertRow(6)
+	grid.InsertRow(7)
+	grid.InsertRow(8)
+	grid.InsertRow(9)
+	grid.InsertRow(10)
+	grid.InsertRow(11)
 
 	grid.Attach(cal, 0, 0, 4, 1)
 	grid.Attach(timeLbl, 0, 1, 1, 1)
@@ -1126,6 +2120,50 @@ func (g *GUI) reminderEdit() {
 	grid.Attach(bodyEntry, 1, 3, 3, 1)
 	grid.Attach(finishedCB, 0, 4, 3, 1)
 	grid.Attach(recEdit.box, 0, 5, 3, 1)
+	grid.Attach(zoneLbl, 0, 6, 1, 1)
+	grid.Attach(zoneEntry, 1, 6, 3, 1)
+	grid.Attach(alertLbl, 0, 7, 1, 1)
+	grid.Attach(alertEntry, 1, 7, 3, 1)
+	grid.Attach(escLbl, 0, 8, 1, 1)
+	grid.Attach(escInput, 1, 8, 1, 1)
+	grid.Attach(alarmLbl, 2, 8, 1, 1)
+	grid.Attach(alarmInput, 3, 8, 1, 1)
+	grid.Attach(prioLbl, 0, 9, 1, 1)
+	grid.Attach(prioCombo, 1, 9, 3, 1)
+	grid.Attach(snoozeLbl, 0, 10, 1, 1)
+	grid.Attach(snoozeEntry, 1, 10, 3, 1)
+	grid.Attach(tagLbl, 0, 11, 1, 1)
+	grid.Attach(tagEntry, 1, 11, 3, 1)
+	grid.Attach(checkLbl, 0, 12, 1, 1)
+	grid.Attach(checkScroll, 1, 12, 3, 1)
+	grid.Attach(depLbl, 0, 13, 1, 1)
+	grid.Attach(depEdit.grid, 1, 13, 3, 1)
+
+	checkScroll.Add(checkView)
+	checkScroll.SetSizeRequest(-1, 80)
+	checkView.SetTooltipText("One item per line, \"[x]\" marks items that are done")
+
+	if err = setTextViewText(checkView, r.ChecklistString()); err != nil {
+		g.log.Printf("[ERROR] Cannot display checklist: %s\n",
+			err.Error())
+		return
+	}
+
+	for _, p := range objects.Priorities {
+		prioCombo.Append(p.String(), p.String())
+	}
+	prioCombo.SetActiveID(r.Priority.String())
+
+	zoneEntry.SetText(r.TimeZone)
+	zoneEntry.SetPlaceholderText("Local time")
+	alertEntry.SetText(objects.FormatAlerts(r.Alerts))
+	alertEntry.SetPlaceholderText("e.g. -1d, -1h, -15m")
+	snoozeEntry.SetText(r.SnoozeString())
+	snoozeEntry.SetPlaceholderText("Default")
+	tagEntry.SetText(r.TagsString())
+	tagEntry.SetPlaceholderText("e.g. work, family")
+	escInput.SetValue(float64(r.Escalation.Interval / 60))
+	alarmInput.SetValue(float64(r.Escalation.Threshold))
 
 	// Does it make any sense, like, at all, to edit a finished
 	// Reminder and save it as "finished"?
@@ -1135,13 +2173,11 @@ func (g *GUI) reminderEdit() {
 	recEdit.rtCombo.Connect("changed",
 		func() {
 			switch txt := recEdit.rtCombo.GetActiveText(); txt {
-			case repeat.Once.String():
+			case repeat.Once.String(), repeat.Interval.String(), repeat.RRule.String():
 				cal.SetSensitive(true)
 				hourInput.SetSensitive(true)
 				minuteInput.SetSensitive(true)
-			case repeat.Daily.String():
-				fallthrough
-			case repeat.Custom.String():
+			default:
 				cal.SetSensitive(false)
 				hourInput.SetSensitive(false)
 				minuteInput.SetSensitive(false)
@@ -1153,12 +2189,14 @@ func (g *GUI) reminderEdit() {
 	dlg.ShowAll()
 
 BEGIN:
-	if r.Recur.Repeat == repeat.Once {
-		cal.SelectMonth(uint(r.Timestamp.Month())-1, uint(r.Timestamp.Year()))
-		cal.SelectDay(uint(r.Timestamp.Day()))
+	if hasStartTime(r.Recur.Repeat) {
+		var stamp = r.Timestamp.In(r.Location())
+
+		cal.SelectMonth(uint(stamp.Month())-1, uint(stamp.Year()))
+		cal.SelectDay(uint(stamp.Day()))
 
-		hourInput.SetValue(float64(r.Timestamp.Hour()))
-		minuteInput.SetValue(float64(r.Timestamp.Minute()) + 10)
+		hourInput.SetValue(float64(stamp.Hour()))
+		minuteInput.SetValue(float64(stamp.Minute()))
 	} else {
 		var min, hour int
 
@@ -1200,7 +2238,67 @@ BEGIN:
 	hour = hourInput.GetValueAsInt()
 	min = minuteInput.GetValueAsInt()
 
-	if r.Recur.Repeat == repeat.Once {
+	r.TimeZone, _ = zoneEntry.GetText()
+	r.TimeZone = strings.TrimSpace(r.TimeZone)
+
+	if r.TimeZone != "" {
+		if _, err = time.LoadLocation(r.TimeZone); err != nil {
+			msg = fmt.Sprintf("Unknown time zone %q: %s",
+				r.TimeZone,
+				err.Error())
+			g.displayMsg(msg)
+			g.log.Printf("[ERROR] %s\n", msg)
+			goto BEGIN
+		}
+	}
+
+	var alerts, _ = alertEntry.GetText()
+
+	if r.Alerts, err = objects.ParseAlerts(alerts); err != nil {
+		msg = err.Error()
+		g.displayMsg(msg)
+		g.log.Printf("[ERROR] %s\n", msg)
+		goto BEGIN
+	}
+
+	r.Escalation = getEscalation(escInput, alarmInput)
+	r.Priority, _ = objects.ParsePriority(prioCombo.GetActiveID())
+
+	var snooze, _ = snoozeEntry.GetText()
+
+	if r.Snooze, err = objects.ParseSnoozes(snooze); err != nil {
+		msg = err.Error()
+		g.displayMsg(msg)
+		g.log.Printf("[ERROR] %s\n", msg)
+		goto BEGIN
+	}
+
+	var tags, _ = tagEntry.GetText()
+
+	if r.Tags, err = objects.ParseTags(tags); err != nil {
+		msg = err.Error()
+		g.displayMsg(msg)
+		g.log.Printf("[ERROR] %s\n", msg)
+		goto BEGIN
+	}
+
+	var checklist, _ = getTextViewText(checkView)
+
+	if r.Checklist, err = objects.ParseChecklist(checklist); err != nil {
+		msg = err.Error()
+		g.displayMsg(msg)
+		g.log.Printf("[ERROR] %s\n", msg)
+		goto BEGIN
+	} else if r.Depends, err = depEdit.GetDependencies(); err != nil {
+		msg = err.Error()
+		g.displayMsg(msg)
+		g.log.Printf("[ERROR] %s\n", msg)
+		goto BEGIN
+	}
+
+	r.Recur = recEdit.GetRecurrence()
+
+	if hasStartTime(r.Recur.Repeat) {
 		r.Timestamp = time.Date(
 			int(year),
 			time.Month(month+1),
@@ -1209,9 +2307,8 @@ BEGIN:
 			min,
 			0,
 			0,
-			time.Local)
+			r.Location())
 	} else {
-		r.Recur = recEdit.GetRecurrence()
 		r.Timestamp = time.Unix(int64(r.Recur.Offset), 0).In(time.UTC)
 	}
 
@@ -1233,6 +2330,13 @@ BEGIN:
 		g.displayMsg(msg)
 		g.log.Printf("[ERROR] %s\n", msg)
 		goto BEGIN
+	} else if r.Recur.Repeat == repeat.RRule {
+		if err = r.SetRRule(r.Recur.Rule); err != nil {
+			var msg = fmt.Sprintf("Invalid recurrence rule: %s", err.Error())
+			g.displayMsg(msg)
+			g.log.Printf("[ERROR] %s\n", msg)
+			goto BEGIN
+		}
 	}
 
 	var (
@@ -1306,7 +2410,7 @@ BEGIN:
 
 		g.store.Set( // nolint: errcheck
 			iter,
-			[]int{0, 1, 2, 3, 5, 6},
+			[]int{0, 1, 2, 3, 5, 6, 7},
 			[]any{
 				r.ID,
 				r.Title,
@@ -1314,6 +2418,7 @@ BEGIN:
 				r.Recur.String(),
 				r.Finished,
 				cstr,
+				calendarLabel(&r),
 			},
 		)
 	} else {
@@ -1440,6 +2545,56 @@ func (g *GUI) reminderReactivate() {
 	}
 } // func (g *GUI) reminderReactivate()
 
+// reminderUndo takes back the last change made to the Reminders.
+func (g *GUI) reminderUndo() {
+	var (
+		err      error
+		msg      string
+		reply    *http.Response
+		response objects.Response
+		buf      bytes.Buffer
+		addr     = fmt.Sprintf("http://%s%s",
+			g.srv,
+			uriRevisionUndo)
+	)
+
+	if reply, err = g.web.Get(addr); err != nil {
+		msg = fmt.Sprintf("Failed to GET %s: %s",
+			addr,
+			err.Error())
+		g.log.Printf("[ERROR] %s\n", msg)
+		g.pushMsg(msg)
+		return
+	}
+
+	defer reply.Body.Close() // nolint: errcheck
+
+	if reply.StatusCode != 200 {
+		msg = fmt.Sprintf("Unexpected HTTP status from server: %s",
+			reply.Status)
+		g.log.Printf("[ERROR] %s\n", msg)
+		g.pushMsg(msg)
+		return
+	} else if _, err = io.Copy(&buf, reply.Body); err != nil {
+		g.log.Printf("[ERROR] Cannot read HTTP reply from backend: %s\n",
+			err.Error())
+		return
+	} else if err = ffjson.Unmarshal(buf.Bytes(), &response); err != nil {
+		g.log.Printf("[ERROR] Cannot de-serialize Response from JSON: %s\n",
+			err.Error())
+		return
+	} else if !response.Status {
+		msg = fmt.Sprintf("Cannot undo last change: %s",
+			response.Message)
+		g.log.Printf("[ERROR] %s\n", msg)
+		g.displayMsg(msg)
+		return
+	}
+
+	g.pushMsg(response.Message)
+	g.refreshReminders()
+} // func (g *GUI) reminderUndo()
+
 func (g *GUI) reminderDelete() {
 	var (
 		err  error
@@ -1566,19 +2721,199 @@ func (g *GUI) reminderHideFinished() {
 	g.filter.Refilter()
 } // func (g *GUI) reminderHideFinished()
 
+// reminderFilterPriority hides all Reminders with a Priority below the
+// given one.
+func (g *GUI) reminderFilterPriority(p objects.Priority) {
+	g.minPriority = p
+	g.filter.Refilter()
+	g.pushMsg(fmt.Sprintf("Showing Reminders with priority %s or higher", p))
+} // func (g *GUI) reminderFilterPriority(p objects.Priority)
+
+// reminderFilterTags asks the user for a list of Tags and hides all
+// Reminders that do not have all of them. An empty list shows all
+// Reminders again.
+func (g *GUI) reminderFilterTags() {
+	var (
+		err   error
+		msg   string
+		dlg   *gtk.Dialog
+		entry *gtk.Entry
+		lbl   *gtk.Label
+		dbox  *gtk.Box
+		known = make(map[string]bool)
+		names []string
+	)
+
+	for _, r := range g.reminders {
+		for _, t := range r.Tags {
+			if !known[t] {
+				known[t] = true
+				names = append(names, t)
+			}
+		}
+	}
+
+	sort.Strings(names)
+
+	if len(names) == 0 {
+		msg = "Show only Reminders with these tags:"
+	} else {
+		msg = fmt.Sprintf("Show only Reminders with these tags\n(known tags: %s):",
+			objects.FormatTags(names))
+	}
+
+	if dlg, err = gtk.DialogNewWithButtons(
+		"Filter by Tags",
+		g.win,
+		gtk.DIALOG_MODAL,
+		[]any{
+			"_Cancel",
+			gtk.RESPONSE_CANCEL,
+			"_OK",
+			gtk.RESPONSE_OK,
+		},
+	); err != nil {
+		g.log.Printf("[ERROR] Failed to create Dialog: %s\n",
+			err.Error())
+		return
+	}
+
+	defer dlg.Close()
+
+	if lbl, err = gtk.LabelNew(msg); err != nil {
+		g.log.Printf("[ERROR] Cannot create gtk.Label: %s\n",
+			err.Error())
+		return
+	} else if entry, err = gtk.EntryNew(); err != nil {
+		g.log.Printf("[ERROR] Cannot create gtk.Entry: %s\n",
+			err.Error())
+		return
+	} else if dbox, err = dlg.GetContentArea(); err != nil {
+		g.log.Printf("[ERROR] Cannot get ContentArea of Dialog: %s\n",
+			err.Error())
+		return
+	}
+
+	entry.SetText(objects.FormatTags(g.tagFilter))
+	entry.SetPlaceholderText("All Reminders")
+
+	dbox.PackStart(lbl, true, true, 0)
+	dbox.PackStart(entry, true, true, 0)
+	dlg.ShowAll()
+
+BEGIN:
+	if rtype := dlg.Run(); rtype != gtk.RESPONSE_OK {
+		return
+	}
+
+	var (
+		tags   []string
+		txt, _ = entry.GetText()
+	)
+
+	if tags, err = objects.ParseTags(txt); err != nil {
+		msg = err.Error()
+		g.displayMsg(msg)
+		g.log.Printf("[ERROR] %s\n", msg)
+		goto BEGIN
+	}
+
+	g.tagFilter = tags
+	g.filter.Refilter()
+
+	if len(tags) == 0 {
+		g.pushMsg("Showing Reminders regardless of tags")
+	} else {
+		g.pushMsg(fmt.Sprintf("Showing Reminders tagged %s", objects.FormatTags(tags)))
+	}
+} // func (g *GUI) reminderFilterTags()
+
+// reminderSearch asks the backend for the Reminders that match the text in
+// the search entry and hides all others. An empty search shows all
+// Reminders again.
+func (g *GUI) reminderSearch() {
+	var (
+		err     error
+		txt     string
+		raw     json.RawMessage
+		res     objects.Response
+		results []objects.SearchResult
+	)
+
+	if txt, err = g.searchEntry.GetText(); err != nil {
+		g.log.Printf("[ERROR] Cannot get Text from SearchEntry: %s\n",
+			err.Error())
+		return
+	} else if txt = strings.TrimSpace(txt); txt == "" {
+		g.searchHits = nil
+		g.filter.Refilter()
+		g.pushMsg("Showing all Reminders")
+		return
+	} else if err = g.getJSON(fmt.Sprintf(uriReminderSearch, url.QueryEscape(txt)), &raw); err != nil {
+		g.pushMsg(fmt.Sprintf("Cannot search for %q: %s", txt, err.Error()))
+		return
+	} else if bytes.HasPrefix(bytes.TrimSpace(raw), []byte("{")) {
+		// The backend only sends a Response if something went wrong.
+		if err = ffjson.Unmarshal(raw, &res); err != nil {
+			g.log.Printf("[ERROR] Cannot de-serialize Response: %s\n",
+				err.Error())
+		}
+		g.pushMsg(fmt.Sprintf("Cannot search for %q: %s", txt, res.Message))
+		return
+	} else if err = ffjson.Unmarshal(raw, &results); err != nil {
+		g.log.Printf("[ERROR] Cannot de-serialize search results: %s\n",
+			err.Error())
+		g.pushMsg(fmt.Sprintf("Cannot search for %q: %s", txt, err.Error()))
+		return
+	}
+
+	g.searchHits = make(map[int64]bool, len(results))
+	for _, r := range results {
+		g.searchHits[r.Reminder.ID] = true
+	}
+
+	g.filter.Refilter()
+
+	switch len(results) {
+	case 0:
+		g.pushMsg(fmt.Sprintf("No Reminders match %q", txt))
+	case 1:
+		g.pushMsg(fmt.Sprintf("One Reminder matches %q: %s",
+			txt,
+			objects.StripMatches(results[0].Title)))
+	default:
+		g.pushMsg(fmt.Sprintf("%d Reminders match %q, best match: %s",
+			len(results),
+			txt,
+			objects.StripMatches(results[0].Title)))
+	}
+} // func (g *GUI) reminderSearch()
+
+// reminderSortPriority sorts the list of Reminders by Priority, most
+// important first, or by due time.
+func (g *GUI) reminderSortPriority(byPriority bool) {
+	if byPriority {
+		g.store.SetSortColumnId(colPriority, gtk.SORT_ASCENDING)
+	} else {
+		g.store.SetSortColumnId(colTime, gtk.SORT_ASCENDING)
+	}
+} // func (g *GUI) reminderSortPriority(byPriority bool)
+
 func (g *GUI) reminderFilterFn(model *gtk.TreeModel, iter *gtk.TreeIter) bool {
-	if !g.hideFinished {
+	if !g.hideFinished && g.minPriority == objects.PriorityLow && len(g.tagFilter) == 0 && g.searchHits == nil {
 		return true
 	}
 
 	var (
-		err          error
-		val          *glib.Value
-		gval         any
-		finished, ok bool
+		err  error
+		val  *glib.Value
+		gval any
+		id   int
+		ok   bool
+		r    objects.Reminder
 	)
 
-	if val, err = model.GetValue(iter, 3); err != nil {
+	if val, err = model.GetValue(iter, 0); err != nil {
 		g.log.Printf("[ERROR] Cannot get Value from Model: %s\n",
 			err.Error())
 		return true
@@ -1586,13 +2921,29 @@ func (g *GUI) reminderFilterFn(model *gtk.TreeModel, iter *gtk.TreeIter) bool {
 		g.log.Printf("[ERROR] Cannot get GoValue from glib.Value: %s\n",
 			err.Error())
 		return true
-	} else if finished, ok = gval.(bool); !ok {
-		g.log.Printf("[ERROR] Cannot get bool from GoValue %T\n",
+	} else if id, ok = gval.(int); !ok {
+		g.log.Printf("[ERROR] Cannot get int from GoValue %T\n",
 			gval)
 		return true
+	} else if r, ok = g.reminders[int64(id)]; !ok {
+		// The row is being added right now, so the Reminder is
+		// not in the map, yet.
+		return true
+	}
+
+	if g.hideFinished && r.Finished {
+		return false
+	} else if g.searchHits != nil && !g.searchHits[r.ID] {
+		return false
+	}
+
+	for _, t := range g.tagFilter {
+		if !r.HasTag(t) {
+			return false
+		}
 	}
 
-	return !finished
+	return r.Priority >= g.minPriority
 } // func (g *GUI) reminderFilterFn(model *gtk.TreeModel, iter *gtk.TreeIter) bool
 
 // nolint: unused
@@ -1655,6 +3006,55 @@ func (g *GUI) reminderCmpFunc(model *gtk.TreeModel, a, b *gtk.TreeIter) int {
 	return strings.Compare(r1.Title, r2.Title)
 } // func (g *GUI) reminderCmpFunc(a, b *gtk.TreeIter) int
 
+// reminderCmpPriority sorts Reminders by Priority, the most important ones
+// first. Reminders with the same Priority are sorted the same way as by
+// reminderCmpFunc.
+func (g *GUI) reminderCmpPriority(model *gtk.TreeModel, a, b *gtk.TreeIter) int {
+	var (
+		err      error
+		id1, id2 int
+		val      *glib.Value
+		gval     any
+	)
+
+	if val, err = model.GetValue(a, 0); err != nil {
+		g.log.Printf("[ERROR] Cannot get glib.Value from TreeIter a: %s\n",
+			err.Error())
+		return 0
+	} else if gval, err = val.GoValue(); err != nil {
+		g.log.Printf("[ERROR] Cannot get GoValue from glib.Value a: %s\n",
+			err.Error())
+		return 0
+	}
+
+	id1, _ = gval.(int)
+
+	if val, err = model.GetValue(b, 0); err != nil {
+		g.log.Printf("[ERROR] Cannot get glib.Value from TreeIter b: %s\n",
+			err.Error())
+		return 0
+	} else if gval, err = val.GoValue(); err != nil {
+		g.log.Printf("[ERROR] Cannot get GoValue from glib.Value b: %s\n",
+			err.Error())
+		return 0
+	}
+
+	id2, _ = gval.(int)
+
+	var (
+		p1 = g.reminders[int64(id1)].Priority
+		p2 = g.reminders[int64(id2)].Priority
+	)
+
+	if p1 > p2 {
+		return -1
+	} else if p1 < p2 {
+		return 1
+	}
+
+	return g.reminderCmpFunc(model, a, b)
+} // func (g *GUI) reminderCmpPriority(model *gtk.TreeModel, a, b *gtk.TreeIter) int
+
 func (g *GUI) synchronize() {
 	var (
 		err   error